@@ -0,0 +1,169 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harrison/conductor/internal/claude"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// Backend invokes an agent runtime for a single task (v3.6+).
+//
+// Implementations must return InvocationResult.Output in the claude CLI JSON
+// envelope ({"content": "..."}, {"result": "..."} or {"structured_output": {...}})
+// so task execution and QC parsing stay independent of the runtime.
+type Backend interface {
+	Invoke(ctx context.Context, task models.Task) (*InvocationResult, error)
+}
+
+// BackendRouter selects a Backend for each task and delegates the invocation.
+// Selection order: task.Backend, then the backend assigned to task.Agent, then the default.
+// BackendRouter satisfies the executor's InvokerInterface.
+type BackendRouter struct {
+	defaultName   string
+	backends      map[string]Backend
+	agentBackends map[string]string
+}
+
+// NewBackendRouter creates a BackendRouter that falls back to the named default backend.
+// The default backend is registered under defaultName.
+func NewBackendRouter(defaultName string, defaultBackend Backend) *BackendRouter {
+	r := &BackendRouter{
+		defaultName:   defaultName,
+		backends:      make(map[string]Backend),
+		agentBackends: make(map[string]string),
+	}
+	r.Register(defaultName, defaultBackend)
+	return r
+}
+
+// Register adds or replaces a named backend.
+func (r *BackendRouter) Register(name string, backend Backend) {
+	r.backends[name] = backend
+}
+
+// SetDefault changes the backend used when neither the task nor its agent selects one.
+func (r *BackendRouter) SetDefault(backendName string) error {
+	if _, ok := r.backends[backendName]; !ok {
+		return fmt.Errorf("default backend %q is not defined", backendName)
+	}
+	r.defaultName = backendName
+	return nil
+}
+
+// AssignAgent routes every invocation for agentName to the named backend.
+func (r *BackendRouter) AssignAgent(agentName, backendName string) error {
+	if _, ok := r.backends[backendName]; !ok {
+		return fmt.Errorf("agent %q: backend %q is not defined", agentName, backendName)
+	}
+	r.agentBackends[agentName] = backendName
+	return nil
+}
+
+// BackendName returns the backend name selected for a task.
+func (r *BackendRouter) BackendName(task models.Task) string {
+	if task.Backend != "" {
+		return task.Backend
+	}
+	if name, ok := r.agentBackends[task.Agent]; ok && task.Agent != "" {
+		return name
+	}
+	return r.defaultName
+}
+
+// Names returns the registered backend names in sorted order.
+func (r *BackendRouter) Names() []string {
+	names := make([]string, 0, len(r.backends))
+	for name := range r.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateTasks checks that every task-level backend override refers to a registered backend.
+func (r *BackendRouter) ValidateTasks(tasks []models.Task) []error {
+	var errs []error
+	for _, task := range tasks {
+		if task.Backend == "" {
+			continue
+		}
+		if _, ok := r.backends[task.Backend]; !ok {
+			errs = append(errs, fmt.Errorf("task %s: backend %q is not defined (available: %s)",
+				task.Number, task.Backend, strings.Join(r.Names(), ", ")))
+		}
+	}
+	return errs
+}
+
+// Invoke runs the task on its selected backend.
+func (r *BackendRouter) Invoke(ctx context.Context, task models.Task) (*InvocationResult, error) {
+	name := r.BackendName(task)
+	backend, ok := r.backends[name]
+	if !ok || backend == nil {
+		return nil, fmt.Errorf("task %s: backend %q is not defined", task.Number, name)
+	}
+	return backend.Invoke(ctx, task)
+}
+
+// isQCReviewTask reports whether the task is a QC review built by the QualityController.
+// QC review prompts come pre-formatted and expect a QC response instead of an AgentResponse.
+func isQCReviewTask(task models.Task) bool {
+	return strings.Contains(task.Name, "QC Review:")
+}
+
+// HelperTaskType marks tasks that carry a helper Claude request, such as QC
+// agent selection or estimation, instead of plan work (v3.6+). Their prompt is
+// sent as is and the response follows the task's JSONSchema.
+const HelperTaskType = "helper"
+
+// isHelperTask reports whether the task carries a helper Claude request.
+func isHelperTask(task models.Task) bool {
+	return task.Type == HelperTaskType
+}
+
+// HelperBackend runs the requests of a claude.Invoker on an agent Backend (v3.6+),
+// so helper calls follow the backends configuration like task agents.
+// Requests become HelperTaskType tasks without an agent, which a BackendRouter
+// sends to its default backend.
+type HelperBackend struct {
+	Backend Backend
+}
+
+// NewHelperBackend creates a HelperBackend running requests on backend.
+func NewHelperBackend(backend Backend) *HelperBackend {
+	return &HelperBackend{Backend: backend}
+}
+
+// InvokeRequest runs a helper request and returns the backend output in the claude CLI envelope.
+func (h *HelperBackend) InvokeRequest(ctx context.Context, req claude.Request) (*claude.Response, error) {
+	if req.Prompt == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+	task := models.Task{
+		Number:     "helper",
+		Name:       "Helper request",
+		Type:       HelperTaskType,
+		Prompt:     req.Prompt,
+		JSONSchema: req.Schema,
+		Model:      req.Model,
+	}
+	result, err := h.Backend.Invoke(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("helper invocation failed: %w", result.Error)
+	}
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("helper invocation failed: exit code %d (output: %s)", result.ExitCode, result.Output)
+	}
+	return &claude.Response{
+		RawOutput: []byte(result.Output),
+		SessionID: result.SessionID,
+		Usage:     result.Usage,
+	}, nil
+}
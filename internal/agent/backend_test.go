@@ -0,0 +1,266 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/claude"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// namedBackend records which backend handled an invocation.
+type namedBackend struct {
+	name  string
+	calls []models.Task
+}
+
+func (b *namedBackend) Invoke(ctx context.Context, task models.Task) (*InvocationResult, error) {
+	b.calls = append(b.calls, task)
+	return &InvocationResult{Output: b.name}, nil
+}
+
+func TestBackendRouter_SelectionOrder(t *testing.T) {
+	def := &namedBackend{name: "default"}
+	perAgent := &namedBackend{name: "agent"}
+	perTask := &namedBackend{name: "task"}
+
+	r := NewBackendRouter("default", def)
+	r.Register("agent", perAgent)
+	r.Register("task", perTask)
+	if err := r.AssignAgent("golang-pro", "agent"); err != nil {
+		t.Fatalf("AssignAgent returned error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		task models.Task
+		want string
+	}{
+		{"no overrides uses default", models.Task{Number: "1"}, "default"},
+		{"agent mapping", models.Task{Number: "2", Agent: "golang-pro"}, "agent"},
+		{"unmapped agent uses default", models.Task{Number: "3", Agent: "python-pro"}, "default"},
+		{"task override wins over agent", models.Task{Number: "4", Agent: "golang-pro", Backend: "task"}, "task"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := r.Invoke(context.Background(), tt.task)
+			if err != nil {
+				t.Fatalf("Invoke returned error: %v", err)
+			}
+			if result.Output != tt.want {
+				t.Errorf("routed to %q, want %q", result.Output, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendRouter_UnknownBackend(t *testing.T) {
+	r := NewBackendRouter("default", &namedBackend{name: "default"})
+
+	if err := r.AssignAgent("golang-pro", "missing"); err == nil {
+		t.Error("expected error assigning agent to unknown backend")
+	}
+	if _, err := r.Invoke(context.Background(), models.Task{Number: "1", Backend: "missing"}); err == nil {
+		t.Error("expected error invoking unknown task backend")
+	}
+
+	errs := r.ValidateTasks([]models.Task{
+		{Number: "1"},
+		{Number: "2", Backend: "default"},
+		{Number: "3", Backend: "missing"},
+	})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "task 3") {
+		t.Errorf("expected one validation error for task 3, got %v", errs)
+	}
+}
+
+func TestBackendRouter_SetDefault(t *testing.T) {
+	r := NewBackendRouter("claude", &namedBackend{name: "claude"})
+	r.Register("fake", &namedBackend{name: "fake"})
+
+	if err := r.SetDefault("fake"); err != nil {
+		t.Fatalf("SetDefault returned error: %v", err)
+	}
+	if got := r.BackendName(models.Task{Number: "1"}); got != "fake" {
+		t.Errorf("BackendName() = %q, want fake", got)
+	}
+	if err := r.SetDefault("missing"); err == nil {
+		t.Error("expected error for unknown default backend")
+	}
+	if got := strings.Join(r.Names(), ","); got != "claude,fake" {
+		t.Errorf("Names() = %q, want claude,fake", got)
+	}
+}
+
+func TestFakeBackend_Responses(t *testing.T) {
+	fake := NewFakeBackend()
+
+	result, err := fake.Invoke(context.Background(), models.Task{Number: "1", Name: "Build"})
+	if err != nil {
+		t.Fatalf("Invoke returned error: %v", err)
+	}
+	if result.AgentResponse == nil || result.AgentResponse.Status != "success" {
+		t.Fatalf("expected successful AgentResponse, got %+v", result.AgentResponse)
+	}
+	parsed, err := ParseClaudeOutput(result.Output)
+	if err != nil {
+		t.Fatalf("ParseClaudeOutput returned error: %v", err)
+	}
+	if _, err := parseAgentJSON(parsed.Content); err != nil {
+		t.Errorf("fake output should contain a valid AgentResponse: %v", err)
+	}
+
+	fake.Verdict = models.StatusRed
+	result, err = fake.Invoke(context.Background(), models.Task{Number: "1", Name: "QC Review: Build"})
+	if err != nil {
+		t.Fatalf("Invoke returned error: %v", err)
+	}
+	parsed, _ = ParseClaudeOutput(result.Output)
+	if !strings.Contains(parsed.Content, `"verdict":"RED"`) {
+		t.Errorf("expected RED QC verdict, got %s", parsed.Content)
+	}
+
+	if calls := fake.Calls(); len(calls) != 2 {
+		t.Errorf("expected 2 recorded calls, got %d", len(calls))
+	}
+}
+
+// writeBackendScript writes an executable shell script used as a command backend.
+func writeBackendScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "backend.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatalf("failed to write backend script: %v", err)
+	}
+	return path
+}
+
+func TestCommandBackend_InvokeAgentTask(t *testing.T) {
+	dir := t.TempDir()
+	script := writeBackendScript(t, `
+prompt=$(cat)
+case "$prompt" in
+  *"Implement the parser"*) ;;
+  *) echo "prompt missing" >&2; exit 3 ;;
+esac
+echo "log line before json"
+printf '{"status":"success","summary":"%s %s %s","output":"ok","errors":[],"files_modified":["%s"]}\n' "$1" "$CONDUCTOR_TASK_NUMBER" "$CONDUCTOR_RESPONSE_TYPE" "$(pwd)"
+`)
+
+	b := &CommandBackend{Name: "script", Command: script, Args: []string{"--agent={{.Agent}}"}}
+	task := models.Task{Number: "7", Name: "Parser", Agent: "golang-pro", Prompt: "Implement the parser", WorkDir: dir}
+
+	result, err := b.Invoke(context.Background(), task)
+	if err != nil {
+		t.Fatalf("Invoke returned error: %v", err)
+	}
+	if result.Error != nil {
+		t.Fatalf("unexpected result error: %v", result.Error)
+	}
+	if result.AgentResponse == nil {
+		t.Fatal("expected parsed AgentResponse")
+	}
+	if result.AgentResponse.Summary != "--agent=golang-pro 7 agent" {
+		t.Errorf("Summary = %q, want args and env expanded", result.AgentResponse.Summary)
+	}
+	realDir, _ := filepath.EvalSymlinks(dir)
+	if len(result.AgentResponse.Files) != 1 || (result.AgentResponse.Files[0] != dir && result.AgentResponse.Files[0] != realDir) {
+		t.Errorf("expected command to run in task WorkDir %s, got %v", dir, result.AgentResponse.Files)
+	}
+
+	parsed, _ := ParseClaudeOutput(result.Output)
+	if !strings.Contains(parsed.Content, `"status":"success"`) {
+		t.Errorf("expected output wrapped in claude envelope, got %q", result.Output)
+	}
+}
+
+func TestCommandBackend_InvokeQCTask(t *testing.T) {
+	script := writeBackendScript(t, `cat >/dev/null
+echo '{"verdict":"YELLOW","feedback":"minor issues","should_retry":false}'
+`)
+	b := &CommandBackend{Name: "script", Command: script}
+
+	result, err := b.Invoke(context.Background(), models.Task{Number: "1", Name: "QC Review: Parser", Prompt: "review"})
+	if err != nil {
+		t.Fatalf("Invoke returned error: %v", err)
+	}
+	if result.Error != nil {
+		t.Fatalf("unexpected result error: %v", result.Error)
+	}
+	if result.AgentResponse != nil {
+		t.Error("QC reviews should not be parsed as AgentResponse")
+	}
+	parsed, _ := ParseClaudeOutput(result.Output)
+	if !strings.Contains(parsed.Content, `"verdict":"YELLOW"`) {
+		t.Errorf("expected QC response in envelope, got %q", parsed.Content)
+	}
+}
+
+func TestHelperBackend_CommandBackend(t *testing.T) {
+	script := writeBackendScript(t, `prompt=$(cat)
+if [ "$prompt" != "Pick an agent" ] || [ "$CONDUCTOR_RESPONSE_TYPE" != "helper" ] || [ "$CONDUCTOR_JSON_SCHEMA" != '{"type":"object"}' ]; then
+  echo "unexpected helper request" >&2; exit 3
+fi
+echo '{"agent":"golang-pro"}'
+`)
+	helper := NewHelperBackend(NewBackendRouter("script", &CommandBackend{Name: "script", Command: script}))
+
+	resp, err := helper.InvokeRequest(context.Background(), claude.Request{Prompt: "Pick an agent", Schema: `{"type":"object"}`})
+	if err != nil {
+		t.Fatalf("InvokeRequest returned error: %v", err)
+	}
+	content, _, err := claude.ParseResponse(resp.RawOutput)
+	if err != nil || content != `{"agent":"golang-pro"}` {
+		t.Errorf("content = %q (err=%v), want the helper response", content, err)
+	}
+
+	failing := NewHelperBackend(&CommandBackend{Name: "script", Command: writeBackendScript(t, "exit 4\n")})
+	if _, err := failing.InvokeRequest(context.Background(), claude.Request{Prompt: "Pick an agent"}); err == nil || !strings.Contains(err.Error(), "exit code 4") {
+		t.Errorf("expected the exit code in the error, got %v", err)
+	}
+}
+
+func TestCommandBackend_Failures(t *testing.T) {
+	t.Run("non-zero exit", func(t *testing.T) {
+		b := &CommandBackend{Name: "script", Command: writeBackendScript(t, "exit 4\n")}
+		result, err := b.Invoke(context.Background(), models.Task{Number: "1", Prompt: "x"})
+		if err != nil {
+			t.Fatalf("Invoke returned error: %v", err)
+		}
+		if result.ExitCode != 4 {
+			t.Errorf("ExitCode = %d, want 4", result.ExitCode)
+		}
+	})
+
+	t.Run("no json", func(t *testing.T) {
+		b := &CommandBackend{Name: "script", Command: writeBackendScript(t, "echo plain text\n")}
+		result, err := b.Invoke(context.Background(), models.Task{Number: "1", Prompt: "x"})
+		if err != nil {
+			t.Fatalf("Invoke returned error: %v", err)
+		}
+		if result.Error == nil {
+			t.Error("expected error for output without JSON")
+		}
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		b := &CommandBackend{Name: "script", Command: "true", Args: []string{"{{.Missing}}"}}
+		if _, err := b.Invoke(context.Background(), models.Task{Number: "1", Prompt: "x"}); err == nil {
+			t.Error("expected error for unknown template field")
+		}
+	})
+
+	t.Run("missing command", func(t *testing.T) {
+		b := &CommandBackend{Name: "script", Command: filepath.Join(t.TempDir(), "nope")}
+		result, err := b.Invoke(context.Background(), models.Task{Number: "1", Prompt: "x"})
+		if err != nil {
+			t.Fatalf("Invoke returned error: %v", err)
+		}
+		if result.Error == nil {
+			t.Error("expected exec error for missing command")
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// CommandBackend runs an arbitrary command as the agent runtime (v3.6+).
+//
+// The prepared prompt is written to the command's stdin and the command must
+// print a JSON object on stdout: an AgentResponse for regular tasks or a QC
+// response for QC reviews. Args support Go text/template placeholders:
+// {{.Agent}}, {{.Model}}, {{.TaskNumber}}, {{.TaskName}} and {{.WorkDir}}.
+//
+// The command also receives CONDUCTOR_TASK_NUMBER, CONDUCTOR_AGENT,
+// CONDUCTOR_RESPONSE_TYPE ("agent", "qc" or "helper") and CONDUCTOR_JSON_SCHEMA
+// in its environment. Helper requests must print an object matching the schema.
+type CommandBackend struct {
+	Name     string
+	Command  string
+	Args     []string
+	Env      map[string]string
+	Registry *Registry
+	Subtasks bool // Offer agents the subtasks response field (v3.6+)
+}
+
+// commandTemplateData holds the values available to CommandBackend arg templates.
+type commandTemplateData struct {
+	Agent      string
+	Model      string
+	TaskNumber string
+	TaskName   string
+	WorkDir    string
+}
+
+// BuildArgs expands the argument templates for a task.
+func (b *CommandBackend) BuildArgs(task models.Task) ([]string, error) {
+	data := commandTemplateData{
+		Agent:      task.Agent,
+		Model:      task.Model,
+		TaskNumber: task.Number,
+		TaskName:   task.Name,
+		WorkDir:    task.WorkDir,
+	}
+	if data.Model == "" && task.Agent != "" && b.Registry != nil {
+		if agent, exists := b.Registry.Get(task.Agent); exists {
+			data.Model = agent.Model
+		}
+	}
+
+	args := make([]string, 0, len(b.Args))
+	for i, raw := range b.Args {
+		tmpl, err := template.New(fmt.Sprintf("arg%d", i)).Option("missingkey=error").Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("backend %q: invalid arg template %q: %w", b.Name, raw, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("backend %q: failed to expand arg %q: %w", b.Name, raw, err)
+		}
+		args = append(args, buf.String())
+	}
+	return args, nil
+}
+
+// BuildPrompt returns the stdin payload for a task.
+// Regular tasks get the agent definition prompt (when registered) and JSON response
+// instructions; QC reviews and helper requests are sent as they are.
+func (b *CommandBackend) BuildPrompt(task models.Task) string {
+	if isQCReviewTask(task) || isHelperTask(task) {
+		return task.Prompt
+	}
+
+	prompt := PrepareAgentPrompt(task.Prompt)
+	if task.Agent != "" && b.Registry != nil {
+		if agent, exists := b.Registry.Get(task.Agent); exists && agent.Prompt != "" {
+			prompt = XMLSection("agent_instructions", agent.Prompt) + "\n\n" + prompt
+		}
+	}
+	return prompt
+}
+
+// Invoke runs the command for a task and normalizes its stdout into the claude CLI envelope.
+func (b *CommandBackend) Invoke(ctx context.Context, task models.Task) (*InvocationResult, error) {
+	startTime := time.Now()
+
+	args, err := b.BuildArgs(task)
+	if err != nil {
+		return nil, err
+	}
+
+	responseType := "agent"
+	schema := task.JSONSchema
+	if isQCReviewTask(task) {
+		responseType = "qc"
+	} else if isHelperTask(task) {
+		responseType = "helper"
+	} else if schema == "" {
+		schema = models.AgentResponseSchemaWithOptions(b.Subtasks)
+	}
+
+	cmd := exec.CommandContext(ctx, b.Command, args...)
+	if task.WorkDir != "" {
+		cmd.Dir = task.WorkDir
+	}
+	cmd.Env = append(os.Environ(),
+		"CONDUCTOR_TASK_NUMBER="+task.Number,
+		"CONDUCTOR_AGENT="+task.Agent,
+		"CONDUCTOR_RESPONSE_TYPE="+responseType,
+		"CONDUCTOR_JSON_SCHEMA="+schema,
+	)
+	for key, value := range b.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = strings.NewReader(b.BuildPrompt(task))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+
+	if stderrStr := strings.TrimSpace(stderr.String()); stderrStr != "" {
+		fmt.Fprintf(os.Stderr, "\n[%s stderr]\n%s\n", b.Name, stderrStr)
+	}
+
+	result := &InvocationResult{
+		Output:   stdout.String(),
+		Duration: time.Since(startTime),
+	}
+
+	if runErr != nil {
+		if exitErr, ok := runErr.(*exec.ExitError); ok {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			result.Error = runErr
+		}
+		return result, nil
+	}
+
+	content, err := extractJSONObject(stdout.String())
+	if err != nil {
+		result.Error = fmt.Errorf("backend %q: %w", b.Name, err)
+		return result, nil
+	}
+
+	if responseType == "agent" {
+		agentResp, parseErr := parseAgentJSON(content)
+		if parseErr != nil {
+			result.Error = fmt.Errorf("failed to parse agent response: %w", parseErr)
+		} else {
+			result.AgentResponse = agentResp
+		}
+	}
+
+	envelope, err := wrapClaudeEnvelope(content)
+	if err != nil {
+		result.Error = err
+		return result, nil
+	}
+	result.Output = envelope
+
+	return result, nil
+}
+
+// extractJSONObject returns the outermost JSON object in output.
+func extractJSONObject(output string) (string, error) {
+	jsonStart := strings.Index(output, "{")
+	jsonEnd := strings.LastIndex(output, "}")
+	if jsonStart < 0 || jsonEnd <= jsonStart {
+		return "", fmt.Errorf("no JSON object found in command output")
+	}
+	return output[jsonStart : jsonEnd+1], nil
+}
+
+// wrapClaudeEnvelope wraps response content in the claude CLI output envelope
+// understood by ParseClaudeOutput.
+func wrapClaudeEnvelope(content string) (string, error) {
+	data, err := json.Marshal(ClaudeOutput{Content: content})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode backend output: %w", err)
+	}
+	return string(data), nil
+}
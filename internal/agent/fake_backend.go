@@ -0,0 +1,88 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// FakeBackend returns canned successful responses without running any agent (v3.6+).
+// It makes end-to-end runs possible without the claude CLI: regular tasks report
+// success, QC reviews return Verdict and helper requests get an empty object.
+// Invocations are recorded for inspection.
+type FakeBackend struct {
+	// Verdict is returned for QC reviews (default: GREEN)
+	Verdict string
+
+	mu    sync.Mutex
+	calls []models.Task
+}
+
+// NewFakeBackend creates a FakeBackend that approves every task.
+func NewFakeBackend() *FakeBackend {
+	return &FakeBackend{Verdict: models.StatusGreen}
+}
+
+// Invoke records the task and returns a canned response in the claude CLI envelope.
+func (f *FakeBackend) Invoke(ctx context.Context, task models.Task) (*InvocationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, task)
+	verdict := f.Verdict
+	f.mu.Unlock()
+
+	if verdict == "" {
+		verdict = models.StatusGreen
+	}
+
+	result := &InvocationResult{}
+
+	var payload interface{}
+	if isHelperTask(task) {
+		// Helper schemas vary; callers fall back to their defaults on an empty object
+		payload = map[string]interface{}{}
+	} else if isQCReviewTask(task) {
+		payload = models.QCResponse{
+			Verdict:  verdict,
+			Feedback: fmt.Sprintf("Fake backend review for task %s", task.Number),
+		}
+	} else {
+		resp := &models.AgentResponse{
+			Status:   "success",
+			Summary:  fmt.Sprintf("Fake backend completed task %s", task.Number),
+			Output:   fmt.Sprintf("Task %s (%s) completed by fake backend", task.Number, task.Name),
+			Errors:   []string{},
+			Files:    []string{},
+			Metadata: map[string]interface{}{},
+		}
+		result.AgentResponse = resp
+		payload = resp
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("fake backend: %w", err)
+	}
+	envelope, err := wrapClaudeEnvelope(string(content))
+	if err != nil {
+		return nil, err
+	}
+	result.Output = envelope
+
+	return result, nil
+}
+
+// Calls returns the tasks invoked so far.
+func (f *FakeBackend) Calls() []models.Task {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := make([]models.Task, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/harrison/conductor/internal/budget"
 	"github.com/harrison/conductor/internal/claude"
 	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/prompts"
 	"github.com/mattn/go-runewidth"
 	"golang.org/x/term"
 )
@@ -30,6 +32,14 @@ func (e *ErrRateLimit) Error() string {
 type Invoker struct {
 	ClaudePath string
 	Registry   *Registry
+
+	// Streaming switches to --output-format stream-json (v3.6+). Events are
+	// forwarded to the ActivityHandler registered on the invocation context.
+	Streaming bool
+
+	// Subtasks offers agents the subtasks response field (v3.6+). Set it only
+	// when proposed subtasks are accepted.
+	Subtasks bool
 }
 
 // InvocationResult captures the result of invoking the claude CLI
@@ -40,6 +50,7 @@ type InvocationResult struct {
 	Error         error
 	AgentResponse *models.AgentResponse
 	SessionID     string
+	Usage         models.Usage // Tokens and cost reported by the CLI (v3.6+)
 }
 
 // ClaudeOutput represents the JSON output structure from claude CLI
@@ -100,50 +111,23 @@ func serializeAgentToJSON(agent *Agent) (string, error) {
 	return string(jsonBytes), nil
 }
 
+// SystemPrompt replaces the default system prompt for every agent and QC invocation.
+const SystemPrompt = "You are a developer assistant. Your ONLY output must be valid JSON matching the provided schema. No markdown, no code fences, no XML tags, no prose, no explanations. Output raw JSON only."
+
 // PrepareAgentPrompt adds formatting instructions to agent prompts for consistent output
 // Includes Claude 4 enhancements and XML-formatted response instructions for guaranteed valid JSON output
 // Note: --json-schema is not enforced with --agents flag, so explicit format instruction is critical
+// Rendered from the agent_prompt prompt template (v3.6+)
 func PrepareAgentPrompt(prompt string) string {
-	// Add Claude 4-specific enhancements
-	enhanced := EnhancePromptForClaude4(prompt)
-
-	// XML-formatted response instructions
-	responseFormat := XMLSection("response_format",
-		`CRITICAL: Respond with ONLY valid JSON matching the provided schema.
-No markdown, no code fences, no XML tags in output, no prose, no explanations.
-Output raw JSON only.
-
-Required JSON structure:
-{"status":"success","summary":"...","output":"...","errors":[],"files_modified":[]}`)
-
-	return enhanced + "\n\n" + responseFormat
+	return prompts.Render(prompts.AgentPrompt, prompts.PromptData{Prompt: prompt})
 }
 
 // PrepareQCPrompt adds formatting instructions to QC review prompts
 // Includes Claude 4 enhancements and XML-formatted response instructions for guaranteed valid QC response
 // Note: --json-schema is not enforced with --agents flag, so explicit format instruction is critical
+// Rendered from the qc_prompt prompt template (v3.6+)
 func PrepareQCPrompt(prompt string) string {
-	// Add Claude 4-specific enhancements
-	enhanced := EnhancePromptForClaude4(prompt)
-
-	// Build XML-formatted instructions
-	var sb strings.Builder
-
-	sb.WriteString(XMLSection("response_instructions", `
-<consistency_rule>
-CRITICAL: Your feedback text MUST be consistent with criteria_results:
-- If ANY criterion has "passed": false, feedback MUST mention which criterion failed and why
-- NEVER say "successfully completed" or similar if any criterion failed
-- If verdict is RED, feedback MUST describe what needs to be fixed
-</consistency_rule>
-
-<response_format>
-Respond with ONLY valid JSON matching the QC schema. No prose, no markdown.
-Required structure:
-{"verdict":"GREEN|YELLOW|RED","feedback":"...","criteria_results":[{"index":0,"criterion":"...","passed":true,"evidence":"..."}],"should_retry":false}
-</response_format>`))
-
-	return enhanced + "\n\n" + sb.String()
+	return prompts.Render(prompts.QCPrompt, prompts.PromptData{Prompt: prompt})
 }
 
 // parseAgentJSON parses JSON response from agent
@@ -180,12 +164,13 @@ func parseAgentJSON(output string) (*models.AgentResponse, error) {
 //
 // Argument order:
 //  1. --agents (if agent specified and found in registry)
-//  2. --json-schema (enforces response structure via JSON schema - can be custom or default)
-//  3. --append-system-prompt (for QC tasks, enforces JSON-only output at system level)
-//  4. -p (prompt with explicit JSON format instructions appended)
-//  5. --permission-mode bypassPermissions
-//  6. --settings (disableAllHooks)
-//  7. --output-format json
+//  2. --model (if task.Model set by model routing)
+//  3. --json-schema (enforces response structure via JSON schema - can be custom or default)
+//  4. --append-system-prompt (for QC tasks, enforces JSON-only output at system level)
+//  5. -p (prompt with explicit JSON format instructions appended)
+//  6. --permission-mode bypassPermissions
+//  7. --settings (disableAllHooks)
+//  8. --output-format json (stream-json --verbose when Streaming)
 //
 // Behavior:
 //   - If task.Agent is specified AND exists in registry: adds --agents flag with JSON definition
@@ -206,6 +191,13 @@ func (inv *Invoker) BuildCommandArgs(task models.Task) []string {
 	// If agent is specified and exists in registry, add --agents flag with JSON definition
 	if task.Agent != "" && inv.Registry != nil {
 		if agent, exists := inv.Registry.Get(task.Agent); exists {
+			// A routed model (v3.6+) overrides the model in the agent definition
+			if task.Model != "" {
+				routed := *agent
+				routed.Model = task.Model
+				agent = &routed
+			}
+
 			// Serialize agent to JSON
 			agentJSON, err := serializeAgentToJSON(agent)
 			if err == nil {
@@ -215,24 +207,29 @@ func (inv *Invoker) BuildCommandArgs(task models.Task) []string {
 		}
 	}
 
+	// Model chosen by model routing (v3.6+)
+	if task.Model != "" {
+		args = append(args, "--model", task.Model)
+	}
+
 	// Add JSON schema for structured responses
 	// If task specifies custom JSONSchema, use it; otherwise use default AgentResponseSchema
 	schemaJSON := task.JSONSchema
 	if schemaJSON == "" {
-		schemaJSON = models.AgentResponseSchema()
+		schemaJSON = models.AgentResponseSchemaWithOptions(inv.Subtasks)
 	}
 	args = append(args, "--json-schema", schemaJSON)
 
 	// Override system prompt to enforce JSON-only output for all tasks
 	// Using --system-prompt (not --append) to fully replace default prompt
 	// This prevents agents from outputting prose, markdown, XML tags, or other content that breaks JSON parsing
-	args = append(args, "--system-prompt", "You are a developer assistant. Your ONLY output must be valid JSON matching the provided schema. No markdown, no code fences, no XML tags, no prose, no explanations. Output raw JSON only.")
+	args = append(args, "--system-prompt", SystemPrompt)
 
 	// Build prompt with formatting instructions
 	// QC review tasks come pre-formatted from BuildReviewPrompt/BuildStructuredReviewPrompt
 	// Regular agent tasks need PrepareAgentPrompt for guaranteed JSON output
 	prompt := task.Prompt
-	if !strings.Contains(task.Name, "QC Review:") {
+	if !isQCReviewTask(task) && !isHelperTask(task) {
 		// Regular agent task - add agent-specific JSON format instructions
 		prompt = PrepareAgentPrompt(prompt)
 	}
@@ -248,7 +245,12 @@ func (inv *Invoker) BuildCommandArgs(task models.Task) []string {
 	args = append(args, "--settings", `{"disableAllHooks": true}`)
 
 	// JSON output for easier parsing (wrapper format, not content format)
-	args = append(args, "--output-format", "json")
+	// Streaming mode (v3.6+) emits one JSON event per line; the CLI requires --verbose for it in print mode
+	if inv.Streaming {
+		args = append(args, "--output-format", "stream-json", "--verbose")
+	} else {
+		args = append(args, "--output-format", "json")
+	}
 
 	return args
 }
@@ -379,6 +381,11 @@ func (inv *Invoker) Invoke(ctx context.Context, task models.Task) (*InvocationRe
 	cmd := exec.CommandContext(ctx, inv.ClaudePath, args...)
 	claude.SetCleanEnv(cmd)
 
+	// Run inside the task's worktree when isolation is enabled
+	if task.WorkDir != "" {
+		cmd.Dir = task.WorkDir
+	}
+
 	// Capture stdout and stderr separately
 	// This prevents Claude CLI stderr noise (e.g., file watcher errors) from
 	// polluting the agent output stored in execution history
@@ -386,7 +393,29 @@ func (inv *Invoker) Invoke(ctx context.Context, task models.Task) (*InvocationRe
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	// In streaming mode (v3.6+) events are parsed as they arrive and the final
+	// result event stands in for the --output-format json envelope
+	var stream *streamWriter
+	if inv.Streaming {
+		stream = &streamWriter{handler: ActivityHandlerFromContext(ctx)}
+		cmd.Stdout = io.MultiWriter(&stdout, stream)
+	}
+
+	err := cmd.Start()
+	if err == nil {
+		if stream != nil && stream.handler != nil {
+			stream.handler(models.AgentActivity{Kind: models.ActivityStart, Time: time.Now()})
+		}
+		err = cmd.Wait()
+	}
+
+	output := stdout.String()
+	if stream != nil {
+		stream.Flush()
+		if stream.envelope != "" {
+			output = stream.envelope
+		}
+	}
 
 	// Log stderr warnings to terminal (but don't store in output)
 	// Filter out known Claude CLI noise (file watcher errors on socket files)
@@ -399,8 +428,9 @@ func (inv *Invoker) Invoke(ctx context.Context, task models.Task) (*InvocationRe
 	}
 
 	result := &InvocationResult{
-		Output:   stdout.String(),
+		Output:   output,
 		Duration: time.Since(startTime),
+		Usage:    claude.ParseUsage([]byte(output)),
 	}
 
 	if err != nil {
@@ -425,7 +455,7 @@ func (inv *Invoker) Invoke(ctx context.Context, task models.Task) (*InvocationRe
 	}
 
 	// Parse agent response from stdout
-	parsedOutput, parseErr := ParseClaudeOutput(stdout.String())
+	parsedOutput, parseErr := ParseClaudeOutput(output)
 	if parseErr == nil && parsedOutput.Content != "" {
 		// Extract session_id from Claude CLI output
 		result.SessionID = parsedOutput.SessionID
@@ -448,7 +478,7 @@ func (inv *Invoker) Invoke(ctx context.Context, task models.Task) (*InvocationRe
 		}
 	} else {
 		// If we couldn't extract content from Claude output, try parsing raw as fallback
-		agentResp, parseErr := parseAgentJSON(stdout.String())
+		agentResp, parseErr := parseAgentJSON(output)
 		if parseErr != nil {
 			result.Error = fmt.Errorf("failed to parse agent response: %w", parseErr)
 		} else {
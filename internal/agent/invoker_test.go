@@ -1217,6 +1217,54 @@ func TestBuildCommandArgsWithResumeSessionID(t *testing.T) {
 	}
 }
 
+func TestBuildCommandArgsWithRoutedModel(t *testing.T) {
+	tmpDir := t.TempDir()
+	agentContent := `---
+name: golang-pro
+description: Go development expert
+model: haiku
+tools:
+  - Read
+---
+Go development content
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "golang-pro.md"), []byte(agentContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry(tmpDir)
+	if _, err := registry.Discover(); err != nil {
+		t.Fatal(err)
+	}
+	inv := NewInvokerWithRegistry(registry)
+
+	args := inv.BuildCommandArgs(models.Task{Number: "1", Name: "Task", Prompt: "Do work", Agent: "golang-pro", Model: "opus"})
+
+	var model, agentsJSON string
+	for i := 0; i+1 < len(args); i++ {
+		switch args[i] {
+		case "--model":
+			model = args[i+1]
+		case "--agents":
+			agentsJSON = args[i+1]
+		}
+	}
+	if model != "opus" {
+		t.Errorf("--model = %q, want opus", model)
+	}
+	if !strings.Contains(agentsJSON, `"model":"opus"`) {
+		t.Errorf("agent definition should use the routed model, got %s", agentsJSON)
+	}
+	if agent, _ := registry.Get("golang-pro"); agent.Model != "haiku" {
+		t.Errorf("registry agent model changed to %q", agent.Model)
+	}
+
+	for _, arg := range inv.BuildCommandArgs(models.Task{Number: "2", Name: "Task", Prompt: "Do work"}) {
+		if arg == "--model" {
+			t.Error("--model should be omitted without a routed model")
+		}
+	}
+}
+
 // TestPrepareAgentPromptXML verifies XML-formatted output with no Markdown headers
 func TestPrepareAgentPromptXML(t *testing.T) {
 	tests := []struct {
@@ -1420,3 +1468,19 @@ func TestQCReviewTaskUsesXML(t *testing.T) {
 		t.Error("QC task prompt should not use Markdown headers for response instructions")
 	}
 }
+
+func TestBuildCommandArgs_SubtasksSchema(t *testing.T) {
+	task := models.Task{Number: "1", Name: "Task", Prompt: "Do it"}
+	for _, subtasks := range []bool{false, true} {
+		inv := NewInvoker()
+		inv.Subtasks = subtasks
+		args := inv.BuildCommandArgs(task)
+		for i, arg := range args {
+			if arg == "--json-schema" && i+1 < len(args) {
+				if got := strings.Contains(args[i+1], `"subtasks"`); got != subtasks {
+					t.Errorf("Subtasks=%v: schema offers subtasks = %v", subtasks, got)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/replay"
+)
+
+// ReplayBackend records or replays the invocations of another Backend (v3.6+).
+// In record mode every result is saved to the store with its raw output, and
+// failed invocations are saved with their error; in replay mode results and
+// failures come from the store and the wrapped backend is never called.
+type ReplayBackend struct {
+	Backend Backend
+	Store   *replay.Store
+}
+
+// NewReplayBackend wraps backend with the given replay store.
+func NewReplayBackend(backend Backend, store *replay.Store) *ReplayBackend {
+	return &ReplayBackend{Backend: backend, Store: store}
+}
+
+// ReplayKey returns the replay key for a task invocation.
+func ReplayKey(task models.Task) string {
+	return replay.Key(replayKind(task), task.Prompt, task.Agent, task.JSONSchema)
+}
+
+// Invoke runs or replays the task.
+func (b *ReplayBackend) Invoke(ctx context.Context, task models.Task) (*InvocationResult, error) {
+	if b.Store.Replaying() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rec, err := b.Store.Load(ReplayKey(task))
+		if err != nil {
+			return nil, fmt.Errorf("task %s: %w", task.Number, err)
+		}
+		return resultFromRecording(rec)
+	}
+
+	result, err := b.Backend.Invoke(ctx, task)
+	if !b.Store.Recording() {
+		return result, err
+	}
+	// A cancelled run is not a failure of the invocation itself
+	if err != nil && ctx.Err() != nil {
+		return result, err
+	}
+
+	rec := replay.Recording{
+		Key:        ReplayKey(task),
+		Kind:       replayKind(task),
+		TaskNumber: task.Number,
+		Agent:      task.Agent,
+		Prompt:     task.Prompt,
+		Schema:     task.JSONSchema,
+	}
+	if result != nil {
+		rec.Output = result.Output
+		rec.ExitCode = result.ExitCode
+		rec.SessionID = result.SessionID
+		rec.DurationMs = result.Duration.Milliseconds()
+		if result.Error != nil {
+			rec.Error = result.Error.Error()
+		}
+		if result.AgentResponse != nil {
+			if data, marshalErr := json.Marshal(result.AgentResponse); marshalErr == nil {
+				rec.AgentResponse = data
+			}
+		}
+	}
+	if err != nil {
+		rec.Error = err.Error()
+		rec.Failed = true
+	}
+	if saveErr := b.Store.Save(rec); saveErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record task %s invocation: %v\n", task.Number, saveErr)
+	}
+
+	return result, err
+}
+
+// replayKind returns the replay kind for a task.
+func replayKind(task models.Task) string {
+	if isQCReviewTask(task) {
+		return replay.KindQC
+	}
+	return replay.KindAgent
+}
+
+// resultFromRecording rebuilds an InvocationResult from a recording.
+// A recorded failure is returned as an error, as the original invocation was.
+func resultFromRecording(rec *replay.Recording) (*InvocationResult, error) {
+	if rec.Failed {
+		return nil, errors.New(rec.Error)
+	}
+	result := &InvocationResult{
+		Output:    rec.Output,
+		ExitCode:  rec.ExitCode,
+		Duration:  time.Duration(rec.DurationMs) * time.Millisecond,
+		SessionID: rec.SessionID,
+	}
+	if rec.Error != "" {
+		result.Error = errors.New(rec.Error)
+	}
+	if len(rec.AgentResponse) > 0 {
+		var resp models.AgentResponse
+		if err := json.Unmarshal(rec.AgentResponse, &resp); err != nil {
+			return nil, fmt.Errorf("failed to decode recorded agent response: %w", err)
+		}
+		result.AgentResponse = &resp
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/replay"
+)
+
+func TestReplayBackend_RecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	task := models.Task{Number: "1", Name: "Build", Agent: "golang-pro", Prompt: "build it"}
+	review := models.Task{Number: "1", Name: "QC Review: Build", Agent: "quality-control", Prompt: "review it"}
+
+	recStore, err := replay.NewStore(dir, replay.ModeRecord)
+	if err != nil {
+		t.Fatalf("NewStore returned error: %v", err)
+	}
+	fake := NewFakeBackend()
+	fake.Verdict = models.StatusRed
+	recorder := NewReplayBackend(fake, recStore)
+
+	recorded, err := recorder.Invoke(context.Background(), task)
+	if err != nil {
+		t.Fatalf("record Invoke returned error: %v", err)
+	}
+	if _, err := recorder.Invoke(context.Background(), review); err != nil {
+		t.Fatalf("record Invoke returned error: %v", err)
+	}
+
+	playStore, err := replay.NewStore(dir, replay.ModeReplay)
+	if err != nil {
+		t.Fatalf("NewStore returned error: %v", err)
+	}
+	live := &namedBackend{name: "live"}
+	player := NewReplayBackend(live, playStore)
+
+	replayed, err := player.Invoke(context.Background(), task)
+	if err != nil {
+		t.Fatalf("replay Invoke returned error: %v", err)
+	}
+	if replayed.Output != recorded.Output {
+		t.Errorf("replayed output = %q, want %q", replayed.Output, recorded.Output)
+	}
+	if replayed.AgentResponse == nil || replayed.AgentResponse.Summary != recorded.AgentResponse.Summary {
+		t.Errorf("replayed AgentResponse = %+v, want %+v", replayed.AgentResponse, recorded.AgentResponse)
+	}
+
+	qcResult, err := player.Invoke(context.Background(), review)
+	if err != nil {
+		t.Fatalf("replay Invoke returned error: %v", err)
+	}
+	parsed, _ := ParseClaudeOutput(qcResult.Output)
+	if parsed == nil || !strings.Contains(parsed.Content, `"verdict":"RED"`) {
+		t.Errorf("expected recorded RED verdict, got %q", qcResult.Output)
+	}
+
+	if len(live.calls) != 0 {
+		t.Errorf("replay should not call the wrapped backend, got %d calls", len(live.calls))
+	}
+
+	changed := task
+	changed.Prompt = "build it differently"
+	if _, err := player.Invoke(context.Background(), changed); err == nil {
+		t.Error("expected error for prompt without a recording")
+	}
+}
+
+// failingBackend returns an invocation error for every task.
+type failingBackend struct{ calls int }
+
+func (b *failingBackend) Invoke(ctx context.Context, task models.Task) (*InvocationResult, error) {
+	b.calls++
+	return &InvocationResult{ExitCode: 1}, errors.New("claude invocation failed: exit status 1")
+}
+
+func TestReplayBackend_ReplaysFailedInvocations(t *testing.T) {
+	dir := t.TempDir()
+	task := models.Task{Number: "1", Name: "Build", Agent: "golang-pro", Prompt: "build it"}
+
+	recStore, err := replay.NewStore(dir, replay.ModeRecord)
+	if err != nil {
+		t.Fatalf("NewStore returned error: %v", err)
+	}
+	if _, err := NewReplayBackend(&failingBackend{}, recStore).Invoke(context.Background(), task); err == nil {
+		t.Fatal("record Invoke should return the backend error")
+	}
+
+	playStore, err := replay.NewStore(dir, replay.ModeReplay)
+	if err != nil {
+		t.Fatalf("NewStore returned error: %v", err)
+	}
+	live := &failingBackend{}
+	_, err = NewReplayBackend(live, playStore).Invoke(context.Background(), task)
+	if err == nil || err.Error() != "claude invocation failed: exit status 1" {
+		t.Errorf("replay error = %v, want the recorded failure", err)
+	}
+	if errors.Is(err, replay.ErrNotRecorded) {
+		t.Error("a recorded failure should not be reported as missing")
+	}
+	if live.calls != 0 {
+		t.Errorf("replay should not call the wrapped backend, got %d calls", live.calls)
+	}
+}
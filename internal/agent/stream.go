@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// ActivityHandler receives live events from a streaming agent invocation (v3.6+).
+// It may be called from the goroutine copying the CLI's stdout, so it must be
+// safe for concurrent use and must not block.
+type ActivityHandler func(activity models.AgentActivity)
+
+type activityHandlerKey struct{}
+
+// WithActivityHandler returns a context that delivers streaming agent events to
+// handler. Backends that do not stream ignore it.
+func WithActivityHandler(ctx context.Context, handler ActivityHandler) context.Context {
+	return context.WithValue(ctx, activityHandlerKey{}, handler)
+}
+
+// ActivityHandlerFromContext returns the handler registered on ctx, or nil.
+func ActivityHandlerFromContext(ctx context.Context) ActivityHandler {
+	handler, _ := ctx.Value(activityHandlerKey{}).(ActivityHandler)
+	return handler
+}
+
+// streamEvent is one line of `claude --output-format stream-json` output.
+type streamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Content []struct {
+			Type  string          `json:"type"`
+			Text  string          `json:"text"`
+			Name  string          `json:"name"`
+			Input json.RawMessage `json:"input"`
+		} `json:"content"`
+	} `json:"message"`
+}
+
+// toolInputKeys are the tool input fields that best describe a tool call, in
+// order of preference.
+var toolInputKeys = []string{"file_path", "path", "command", "pattern", "url", "query", "description", "prompt"}
+
+// parseStreamLine converts one stream-json line into activities.
+// isResult reports whether the line is the final result envelope, which has
+// the same shape as `--output-format json` output.
+func parseStreamLine(line []byte) (activities []models.AgentActivity, isResult bool) {
+	var event streamEvent
+	if err := json.Unmarshal(line, &event); err != nil || event.Type == "" {
+		return nil, false
+	}
+
+	now := time.Now()
+	switch event.Type {
+	case "result":
+		return []models.AgentActivity{{Kind: models.ActivityResult, Time: now}}, true
+	case "assistant":
+		for _, block := range event.Message.Content {
+			switch block.Type {
+			case "text":
+				activities = append(activities, models.AgentActivity{Kind: models.ActivityText, Detail: block.Text, Time: now})
+			case "tool_use":
+				activities = append(activities, models.AgentActivity{Kind: models.ActivityToolUse, Tool: block.Name, Detail: summarizeToolInput(block.Input), Time: now})
+			}
+		}
+	case "user":
+		for _, block := range event.Message.Content {
+			if block.Type == "tool_result" {
+				activities = append(activities, models.AgentActivity{Kind: models.ActivityToolResult, Time: now})
+			}
+		}
+	}
+
+	if len(activities) == 0 {
+		activities = []models.AgentActivity{{Kind: models.ActivityStatus, Detail: event.Type, Time: now}}
+	}
+	return activities, false
+}
+
+// summarizeToolInput picks the most descriptive field of a tool call's input.
+func summarizeToolInput(input json.RawMessage) string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(input, &fields); err != nil {
+		return ""
+	}
+	for _, key := range toolInputKeys {
+		if value, ok := fields[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// streamWriter splits stream-json output into lines and forwards the parsed
+// events to an ActivityHandler as they arrive (v3.6+). The last result event
+// is kept as the invocation's JSON envelope.
+type streamWriter struct {
+	handler  ActivityHandler
+	pending  []byte
+	envelope string
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		idx := bytes.IndexByte(w.pending, '\n')
+		if idx < 0 {
+			break
+		}
+		w.handleLine(w.pending[:idx])
+		w.pending = append(w.pending[:0], w.pending[idx+1:]...)
+	}
+	return len(p), nil
+}
+
+// Flush handles a final line that was not newline-terminated.
+func (w *streamWriter) Flush() {
+	if len(bytes.TrimSpace(w.pending)) > 0 {
+		w.handleLine(w.pending)
+	}
+	w.pending = nil
+}
+
+func (w *streamWriter) handleLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+	activities, isResult := parseStreamLine(line)
+	if isResult {
+		w.envelope = string(line)
+	}
+	if w.handler == nil {
+		return
+	}
+	for _, activity := range activities {
+		w.handler(activity)
+	}
+}
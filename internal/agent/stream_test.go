@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+const streamFixture = `{"type":"system","subtype":"init","session_id":"s-1"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Reading the config"},{"type":"tool_use","name":"Read","input":{"file_path":"internal/config/config.go"}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","content":"..."}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}}]}}
+{"type":"result","subtype":"success","session_id":"s-1","total_cost_usd":0.12,"usage":{"input_tokens":40,"output_tokens":7},"structured_output":{"status":"success","summary":"done","output":"ok","errors":[],"files_modified":[]}}
+`
+
+func TestStreamWriter(t *testing.T) {
+	var activities []models.AgentActivity
+	w := &streamWriter{handler: func(a models.AgentActivity) { activities = append(activities, a) }}
+
+	// Split mid-line to check partial lines are buffered
+	for _, chunk := range []string{streamFixture[:70], streamFixture[70:200], streamFixture[200:]} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	w.Flush()
+
+	var kinds []string
+	var visible []string
+	for _, a := range activities {
+		kinds = append(kinds, a.Kind)
+		if a.IsVisible() {
+			visible = append(visible, a.Description(0))
+		}
+	}
+	wantKinds := []string{
+		models.ActivityStatus, models.ActivityText, models.ActivityToolUse,
+		models.ActivityToolResult, models.ActivityToolUse, models.ActivityResult,
+	}
+	if strings.Join(kinds, ",") != strings.Join(wantKinds, ",") {
+		t.Errorf("kinds = %v, want %v", kinds, wantKinds)
+	}
+	wantVisible := []string{"Reading the config", "Read internal/config/config.go", "Bash go test ./..."}
+	if strings.Join(visible, "|") != strings.Join(wantVisible, "|") {
+		t.Errorf("visible = %q, want %q", visible, wantVisible)
+	}
+	if !strings.HasPrefix(w.envelope, `{"type":"result"`) {
+		t.Errorf("envelope = %q, want the result event", w.envelope)
+	}
+}
+
+func TestBuildCommandArgsStreaming(t *testing.T) {
+	task := models.Task{Number: "1", Name: "Task", Prompt: "Do work"}
+
+	args := strings.Join(NewInvoker().BuildCommandArgs(task), " ")
+	if !strings.HasSuffix(args, "--output-format json") {
+		t.Errorf("default args should end with --output-format json: %s", args)
+	}
+
+	inv := NewInvoker()
+	inv.Streaming = true
+	args = strings.Join(inv.BuildCommandArgs(task), " ")
+	if !strings.HasSuffix(args, "--output-format stream-json --verbose") {
+		t.Errorf("streaming args should end with --output-format stream-json --verbose: %s", args)
+	}
+}
+
+func TestInvokeStreaming(t *testing.T) {
+	tmpDir := t.TempDir()
+	fixture := filepath.Join(tmpDir, "stream.jsonl")
+	if err := os.WriteFile(fixture, []byte(streamFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mockScript := filepath.Join(tmpDir, "mock-claude")
+	script := fmt.Sprintf("#!/bin/sh\ncat '%s'\n", fixture)
+	if err := os.WriteFile(mockScript, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var activities []models.AgentActivity
+	ctx := WithActivityHandler(context.Background(), func(a models.AgentActivity) {
+		mu.Lock()
+		defer mu.Unlock()
+		activities = append(activities, a)
+	})
+
+	inv := &Invoker{ClaudePath: mockScript, Streaming: true}
+	result, err := inv.Invoke(ctx, models.Task{Number: "1", Name: "Task", Prompt: "Do work"})
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+
+	if result.SessionID != "s-1" {
+		t.Errorf("SessionID = %q, want s-1", result.SessionID)
+	}
+	if result.AgentResponse == nil || result.AgentResponse.Summary != "done" {
+		t.Errorf("AgentResponse = %+v, want summary from structured_output", result.AgentResponse)
+	}
+	if result.Usage.CostUSD != 0.12 || result.Usage.InputTokens != 40 {
+		t.Errorf("Usage = %+v, want cost and tokens from the result event", result.Usage)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(activities) != 7 || activities[0].Kind != models.ActivityStart {
+		t.Errorf("activities = %+v, want start followed by 6 stream events", activities)
+	}
+}
@@ -71,7 +71,17 @@ func ValidateTaskAgents(tasks []models.Task, registry *Registry) []ValidationErr
 	return errors
 }
 
-// ValidateQCAgents checks QC agent references exist in registry
+// QCCheckerPrefix marks QC reviewers that are local checker programs rather
+// than agents, e.g. "check:go-vet" in quality_control.agents.explicit_list (v3.6+).
+const QCCheckerPrefix = "check:"
+
+// IsQCChecker reports whether a QC reviewer name refers to a local checker.
+func IsQCChecker(name string) bool {
+	return strings.HasPrefix(name, QCCheckerPrefix)
+}
+
+// ValidateQCAgents checks QC agent references exist in registry.
+// Checkers (QCCheckerPrefix) are not agents and are skipped.
 // Returns a slice of ValidationErrors for each missing agent
 // Empty registry results in errors for all agents (no agents available)
 func ValidateQCAgents(qcAgents []string, registry *Registry) []ValidationError {
@@ -83,8 +93,8 @@ func ValidateQCAgents(qcAgents []string, registry *Registry) []ValidationError {
 	available := registry.ListNames()
 
 	for _, agentName := range qcAgents {
-		if agentName == "" {
-			continue // Skip empty agent names
+		if agentName == "" || IsQCChecker(agentName) {
+			continue // Skip empty agent names and local checkers
 		}
 
 		if !registry.Exists(agentName) {
@@ -3,33 +3,9 @@ package agent
 import (
 	"fmt"
 	"strings"
-)
-
-// claude4Enhancements contains Claude 4-specific prompt patterns
-// per Anthropic best practices documentation
-const claude4Enhancements = `<context_awareness>
-Your context window will be automatically managed. Do not stop tasks early
-due to token budget concerns. Complete tasks fully and persist progress.
-</context_awareness>
-
-<thinking_guidance>
-After receiving tool results, carefully reflect on their quality and
-determine optimal next steps before proceeding. Use your thinking to
-plan and iterate based on new information.
-</thinking_guidance>
 
-<anti_hallucination>
-NEVER speculate about code you have not read. Use the Read tool to
-examine files BEFORE making claims about code. If unsure, investigate
-first rather than guessing.
-</anti_hallucination>
-
-<parallel_tool_calls>
-When multiple independent tool operations are needed (e.g., reading several
-files, running multiple searches), execute them in parallel rather than
-sequentially. Only serialize operations that have dependencies.
-</parallel_tool_calls>
-`
+	"github.com/harrison/conductor/internal/prompts"
+)
 
 // XMLTag wraps content in XML tags: <name>content</name>
 func XMLTag(name, content string) string {
@@ -55,6 +31,7 @@ func XMLList(name string, items []string) string {
 }
 
 // EnhancePromptForClaude4 prepends Claude 4 enhancements to any prompt
+// Rendered from the claude4_enhancements prompt template (v3.6+)
 func EnhancePromptForClaude4(prompt string) string {
-	return claude4Enhancements + "\n" + prompt
+	return prompts.Render(prompts.Claude4Enhancements, prompts.PromptData{Prompt: prompt})
 }
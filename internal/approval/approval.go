@@ -0,0 +1,198 @@
+// Package approval persists human approval gates (v3.6+).
+//
+// A `type: approval` task saves a pending Request when a run reaches it.
+// `conductor approve` and `conductor reject` record the decision, which the
+// waiting run, or the next run of the plan, picks up. Requests are JSON files
+// in .conductor/state/approvals/, next to the paused runs of the budget package,
+// so a gate survives process restarts.
+package approval
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// Status is the state of an approval request.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// DefaultDir is where conductor keeps approval requests, relative to the project root.
+const DefaultDir = ".conductor/state/approvals"
+
+// ErrNoRequest is returned by Load when a gate has no request.
+var ErrNoRequest = errors.New("no approval request")
+
+// ErrRequestExists is returned by Create when a gate already has a request.
+var ErrRequestExists = errors.New("approval request already exists")
+
+// Request is one approval gate of one plan.
+type Request struct {
+	PlanFile    string     `json:"plan_file"` // Absolute path of the plan file the gate task is in
+	TaskNumber  string     `json:"task_number"`
+	TaskName    string     `json:"task_name"`
+	Status      Status     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	Summary     string     `json:"summary,omitempty"` // QC results and changed files of the upstream tasks
+	Diff        string     `json:"diff,omitempty"`    // Accumulated diff of the upstream tasks
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	DecidedBy   string     `json:"decided_by,omitempty"`
+	Reason      string     `json:"reason,omitempty"` // Why the gate was rejected, or a note on approval
+}
+
+// Decided reports whether the request has been approved or rejected.
+func (r *Request) Decided() bool {
+	return r.Status == StatusApproved || r.Status == StatusRejected
+}
+
+// Store saves and loads approval requests.
+type Store struct {
+	dir string // .conductor/state/approvals/
+}
+
+// NewStore creates a store in the given directory.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// path returns the request file of a gate: the plan name, a hash of its
+// absolute path so plans with the same name do not collide, and the task.
+// File: {dir}/{plan}-{hash}-task-{number}.json
+func (s *Store) path(planFile, taskNumber string) string {
+	abs := absPath(planFile)
+	sum := sha256.Sum256([]byte(abs))
+	name := fmt.Sprintf("%s-%s-task-%s.json",
+		unsafeName.ReplaceAllString(filepath.Base(abs), "_"),
+		hex.EncodeToString(sum[:4]),
+		unsafeName.ReplaceAllString(taskNumber, "_"))
+	return filepath.Join(s.dir, name)
+}
+
+// Save writes a request, creating the directory if needed. The file is
+// replaced atomically so a polling run never reads a partial request.
+func (s *Store) Save(req *Request) error {
+	return s.write(req, os.Rename)
+}
+
+// Create writes a new request like Save, but fails with ErrRequestExists when
+// the gate already has one, so a decision made since the gate was last loaded
+// is never overwritten.
+func (s *Store) Create(req *Request) error {
+	return s.write(req, func(tmp, path string) error {
+		if err := os.Link(tmp, path); err != nil {
+			if os.IsExist(err) {
+				return fmt.Errorf("%w for task %s of %s", ErrRequestExists, req.TaskNumber, req.PlanFile)
+			}
+			return err
+		}
+		return os.Remove(tmp)
+	})
+}
+
+// write marshals req to a temporary file in the store and moves it into place
+// with place. Each write has its own temporary file, so concurrent writers
+// never publish each other's request.
+func (s *Store) write(req *Request, place func(tmp, path string) error) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create approval directory: %w", err)
+	}
+	req.PlanFile = absPath(req.PlanFile)
+
+	data, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal approval request: %w", err)
+	}
+
+	path := s.path(req.PlanFile, req.TaskNumber)
+	f, err := os.CreateTemp(s.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write approval request: %w", err)
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, 0644)
+	}
+	if err == nil {
+		err = place(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write approval request: %w", err)
+	}
+	return nil
+}
+
+// Load returns the request of a gate, or ErrNoRequest if it has none.
+func (s *Store) Load(planFile, taskNumber string) (*Request, error) {
+	data, err := os.ReadFile(s.path(planFile, taskNumber))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w for task %s of %s", ErrNoRequest, taskNumber, planFile)
+		}
+		return nil, fmt.Errorf("failed to read approval request: %w", err)
+	}
+
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal approval request: %w", err)
+	}
+	return &req, nil
+}
+
+// Delete removes the request of a gate once its decision has been used.
+func (s *Store) Delete(planFile, taskNumber string) error {
+	if err := os.Remove(s.path(planFile, taskNumber)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete approval request: %w", err)
+	}
+	return nil
+}
+
+// Decide approves or rejects a gate. A gate the run has not reached yet is
+// decided in advance; the decision is used when the run gets there.
+func (s *Store) Decide(planFile, taskNumber, taskName string, status Status, actor, reason string) (*Request, error) {
+	if status != StatusApproved && status != StatusRejected {
+		return nil, fmt.Errorf("invalid approval decision %q", status)
+	}
+
+	req, err := s.Load(planFile, taskNumber)
+	if errors.Is(err, ErrNoRequest) {
+		req = &Request{PlanFile: planFile, TaskNumber: taskNumber, TaskName: taskName, RequestedAt: time.Now()}
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	req.Status = status
+	req.DecidedAt = &now
+	req.DecidedBy = actor
+	req.Reason = reason
+	if err := s.Save(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// absPath returns the absolute form of path, or path itself if it has none.
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
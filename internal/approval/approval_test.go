@@ -0,0 +1,113 @@
+package approval
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_SaveLoadDelete(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "approvals"))
+
+	if _, err := store.Load("plan.md", "5"); !errors.Is(err, ErrNoRequest) {
+		t.Fatalf("expected ErrNoRequest, got %v", err)
+	}
+
+	req := &Request{PlanFile: "plan.md", TaskNumber: "5", TaskName: "Sign off migration", Status: StatusPending, RequestedAt: time.Now(), Summary: "Upstream tasks:\n"}
+	if err := store.Save(req); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load("plan.md", "5")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Status != StatusPending || loaded.Decided() || loaded.Summary != req.Summary || !filepath.IsAbs(loaded.PlanFile) {
+		t.Errorf("unexpected request: %+v", loaded)
+	}
+
+	// A plan with the same name elsewhere is a different gate
+	if _, err := store.Load(filepath.Join("other", "plan.md"), "5"); !errors.Is(err, ErrNoRequest) {
+		t.Errorf("plans with the same name should not share gates, got %v", err)
+	}
+
+	if err := store.Delete("plan.md", "5"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Load("plan.md", "5"); !errors.Is(err, ErrNoRequest) {
+		t.Errorf("request should be gone, got %v", err)
+	}
+	if err := store.Delete("plan.md", "5"); err != nil {
+		t.Errorf("deleting twice should not fail: %v", err)
+	}
+}
+
+func TestStore_Decide(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "approvals")
+	store := NewStore(dir)
+
+	pending := &Request{PlanFile: "plan.md", TaskNumber: "5", Status: StatusPending, RequestedAt: time.Now(), Summary: "2 files"}
+	if err := store.Save(pending); err != nil {
+		t.Fatal(err)
+	}
+	req, err := store.Decide("plan.md", "5", "Sign off", StatusRejected, "alice@host", "breaks the API")
+	if err != nil {
+		t.Fatalf("Decide() error = %v", err)
+	}
+	if req.Status != StatusRejected || req.DecidedBy != "alice@host" || req.Reason != "breaks the API" || req.DecidedAt == nil || req.Summary != "2 files" {
+		t.Errorf("unexpected decision: %+v", req)
+	}
+
+	// Deciding a gate no run has reached yet records it in advance
+	req, err = store.Decide("plan.md", "9", "Release", StatusApproved, "bob", "")
+	if err != nil {
+		t.Fatalf("Decide() error = %v", err)
+	}
+	if loaded, err := store.Load("plan.md", "9"); err != nil || !loaded.Decided() || loaded.TaskName != "Release" {
+		t.Errorf("advance approval not stored: %+v, %v", loaded, err)
+	}
+
+	if _, err := store.Decide("plan.md", "5", "", StatusPending, "bob", ""); err == nil {
+		t.Error("pending is not a decision")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".json" {
+			t.Errorf("unexpected file left behind: %s", e.Name())
+		}
+	}
+}
+
+func TestStore_Create(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "approvals")
+	store := NewStore(dir)
+
+	// A decision made in advance is not overwritten by the run reaching the gate
+	if _, err := store.Decide("plan.md", "5", "Sign off", StatusApproved, "alice@host", ""); err != nil {
+		t.Fatal(err)
+	}
+	pending := &Request{PlanFile: "plan.md", TaskNumber: "5", Status: StatusPending, RequestedAt: time.Now(), Summary: "2 files"}
+	if err := store.Create(pending); !errors.Is(err, ErrRequestExists) {
+		t.Fatalf("expected ErrRequestExists, got %v", err)
+	}
+	if req, err := store.Load("plan.md", "5"); err != nil || req.Status != StatusApproved {
+		t.Errorf("decision should survive, got %+v, %v", req, err)
+	}
+
+	if err := store.Delete("plan.md", "5"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Create(pending); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if req, err := store.Load("plan.md", "5"); err != nil || req.Status != StatusPending || req.Summary != "2 files" {
+		t.Errorf("unexpected request: %+v, %v", req, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("temporary files should be cleaned up, got %v, %v", entries, err)
+	}
+}
@@ -21,6 +21,12 @@ const (
 	StatusExpired ExecutionStatus = "expired" // Too old to resume
 )
 
+// Pause reasons recorded in ExecutionState (v3.6+)
+const (
+	PauseReasonRateLimit = "rate_limit" // Rate limit wait exceeded max_wait_duration
+	PauseReasonSpendCap  = "spend_cap"  // Run reached budget.max_run_usd
+)
+
 // ExecutionState represents a paused execution that can be resumed
 type ExecutionState struct {
 	SessionID      string          `json:"session_id"`
@@ -31,6 +37,8 @@ type ExecutionState struct {
 	PausedAt       time.Time       `json:"paused_at"`
 	ResumeAt       time.Time       `json:"resume_at"`
 	Status         ExecutionStatus `json:"status"`
+	Reason         string          `json:"reason,omitempty"`    // Why the run paused (v3.6+); empty means rate limit
+	SpentUSD       float64         `json:"spent_usd,omitempty"` // Run cost when paused by a spend cap (v3.6+)
 }
 
 // StateManager handles saving/loading execution state
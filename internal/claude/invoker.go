@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/harrison/conductor/internal/budget"
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/replay"
 )
 
 // DefaultSystemPrompt is the standard system prompt enforcing JSON-only output.
@@ -36,6 +40,26 @@ type Invoker struct {
 	// SystemPrompt is the system prompt sent with all invocations.
 	// Defaults to DefaultSystemPrompt if empty when using NewInvoker.
 	SystemPrompt string
+
+	// Replay records or replays every invocation (v3.6+).
+	// Can be nil for normal operation.
+	Replay *replay.Store
+
+	// Backend runs requests instead of the claude CLI (v3.6+), so helper calls
+	// follow the same backends configuration as task agents.
+	// Can be nil to invoke the claude CLI directly.
+	Backend Backend
+
+	// usage accumulates the tokens and cost of every live invocation (v3.6+)
+	mu    sync.Mutex
+	usage models.Usage
+}
+
+// Backend runs a single Claude request on a configured agent runtime (v3.6+).
+// Implementations return RawOutput in the claude CLI JSON envelope so
+// ParseResponse works unchanged.
+type Backend interface {
+	InvokeRequest(ctx context.Context, req Request) (*Response, error)
 }
 
 // Request holds per-invocation configuration for a Claude CLI call.
@@ -59,6 +83,10 @@ type Request struct {
 	// BypassPerms enables --permission-mode bypassPermissions (optional).
 	// Allows file creation without permission prompts.
 	BypassPerms bool
+
+	// Model is the model alias or name for --model (optional, v3.6+).
+	// Empty uses the CLI default.
+	Model string
 }
 
 // Response holds the raw output from a Claude CLI invocation.
@@ -71,6 +99,10 @@ type Response struct {
 	// SessionID is the Claude CLI session identifier.
 	// Used for resuming sessions (e.g., after rate limit recovery).
 	SessionID string
+
+	// Usage is the token usage and cost reported by the CLI (v3.6+).
+	// Zero for replayed responses.
+	Usage models.Usage
 }
 
 // NewInvoker creates a new Invoker with default settings.
@@ -91,7 +123,35 @@ func NewInvoker() *Invoker {
 //  2. On error, check for rate limit via budget.ParseRateLimitFromError
 //  3. If rate limit, wait using budget.NewRateLimitWaiter and retry once
 //  4. Return Response with raw output
+//
+// When Replay is set, responses are recorded to or served from the replay store (v3.6+).
 func (inv *Invoker) Invoke(ctx context.Context, req Request) (*Response, error) {
+	if inv.Replay.Replaying() {
+		return inv.replayResponse(req)
+	}
+
+	resp, err := inv.invokeWithRetry(ctx, req)
+	if resp != nil {
+		inv.mu.Lock()
+		inv.usage.Add(resp.Usage)
+		inv.mu.Unlock()
+	}
+	if inv.Replay.Recording() {
+		inv.recordResponse(req, resp, err)
+	}
+	return resp, err
+}
+
+// Usage returns the combined tokens and cost of all live invocations made
+// through this Invoker (v3.6+). Replayed responses cost nothing and are not counted.
+func (inv *Invoker) Usage() models.Usage {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	return inv.usage
+}
+
+// invokeWithRetry runs the CLI with the configured timeout and rate limit retry.
+func (inv *Invoker) invokeWithRetry(ctx context.Context, req Request) (*Response, error) {
 	// Create context with timeout if Invoker has Timeout set
 	ctxToUse := ctx
 	var cancel context.CancelFunc
@@ -100,6 +160,10 @@ func (inv *Invoker) Invoke(ctx context.Context, req Request) (*Response, error)
 		defer cancel()
 	}
 
+	if inv.Backend != nil {
+		return inv.Backend.InvokeRequest(ctxToUse, req)
+	}
+
 	result, err := inv.invoke(ctxToUse, req)
 
 	// Handle rate limit with retry (follows ClaudeSimilarity pattern)
@@ -123,7 +187,8 @@ func (inv *Invoker) Invoke(ctx context.Context, req Request) (*Response, error)
 
 // invoke performs the actual Claude CLI call.
 // Always includes: --system-prompt, -p, --json-schema (if set), --output-format json, --settings
-// Optional flags based on Request fields: AgentJSON -> --agents, ResumeID -> --resume, BypassPerms -> --permission-mode
+// Optional flags based on Request fields: AgentJSON -> --agents, ResumeID -> --resume, BypassPerms -> --permission-mode,
+// Model -> --model
 func (inv *Invoker) invoke(ctx context.Context, req Request) (*Response, error) {
 	// Build command arguments
 	args := []string{}
@@ -138,6 +203,11 @@ func (inv *Invoker) invoke(ctx context.Context, req Request) (*Response, error)
 		args = append(args, "--agents", req.AgentJSON)
 	}
 
+	// Model override (optional)
+	if req.Model != "" {
+		args = append(args, "--model", req.Model)
+	}
+
 	// System prompt is always included (use default if not set on Invoker)
 	systemPrompt := inv.SystemPrompt
 	if systemPrompt == "" {
@@ -185,9 +255,48 @@ func (inv *Invoker) invoke(ctx context.Context, req Request) (*Response, error)
 
 	return &Response{
 		RawOutput: output,
+		Usage:     ParseUsage(output),
 	}, nil
 }
 
+// replayKey returns the replay key for a request.
+func replayKey(req Request) string {
+	return replay.Key(replay.KindClaude, req.Prompt, req.AgentJSON, req.Schema)
+}
+
+// replayResponse serves a recorded response, including recorded failures.
+func (inv *Invoker) replayResponse(req Request) (*Response, error) {
+	rec, err := inv.Replay.Load(replayKey(req))
+	if err != nil {
+		return nil, fmt.Errorf("claude replay: %w", err)
+	}
+	if rec.Error != "" {
+		return nil, fmt.Errorf("%s", rec.Error)
+	}
+	return &Response{RawOutput: []byte(rec.Output), SessionID: rec.SessionID}, nil
+}
+
+// recordResponse saves a response or failure to the replay store.
+// Recording errors are reported on stderr and never fail the invocation.
+func (inv *Invoker) recordResponse(req Request, resp *Response, invokeErr error) {
+	rec := replay.Recording{
+		Key:    replayKey(req),
+		Kind:   replay.KindClaude,
+		Prompt: req.Prompt,
+		Schema: req.Schema,
+	}
+	if resp != nil {
+		rec.Output = string(resp.RawOutput)
+		rec.SessionID = resp.SessionID
+	}
+	if invokeErr != nil {
+		rec.Error = invokeErr.Error()
+	}
+	if err := inv.Replay.Save(rec); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record claude invocation: %v\n", err)
+	}
+}
+
 // ParseResponse extracts JSON content from Claude CLI output.
 // This implements the same parsing logic as agent.ParseClaudeOutput with
 // JSON extraction fallback, avoiding circular imports.
@@ -271,3 +380,45 @@ func ParseResponse(rawOutput []byte) (content string, sessionID string, err erro
 	// No JSON content found
 	return "", sessionID, nil
 }
+
+// ParseUsage extracts token usage and cost from Claude CLI JSON output (v3.6+).
+// It reads total_cost_usd (falling back to the older cost_usd field) and the
+// usage object. Returns a zero Usage when the output carries no usage data.
+func ParseUsage(rawOutput []byte) models.Usage {
+	var envelope struct {
+		TotalCostUSD *float64 `json:"total_cost_usd"`
+		CostUSD      *float64 `json:"cost_usd"`
+		Usage        struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	}
+
+	if err := json.Unmarshal(rawOutput, &envelope); err != nil {
+		// Claude CLI sometimes outputs warnings before the JSON response
+		output := string(rawOutput)
+		jsonStart := strings.Index(output, "{")
+		jsonEnd := strings.LastIndex(output, "}")
+		if jsonStart < 0 || jsonEnd <= jsonStart {
+			return models.Usage{}
+		}
+		if err := json.Unmarshal([]byte(output[jsonStart:jsonEnd+1]), &envelope); err != nil {
+			return models.Usage{}
+		}
+	}
+
+	usage := models.Usage{
+		InputTokens:              envelope.Usage.InputTokens,
+		OutputTokens:             envelope.Usage.OutputTokens,
+		CacheCreationInputTokens: envelope.Usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     envelope.Usage.CacheReadInputTokens,
+	}
+	if envelope.TotalCostUSD != nil {
+		usage.CostUSD = *envelope.TotalCostUSD
+	} else if envelope.CostUSD != nil {
+		usage.CostUSD = *envelope.CostUSD
+	}
+	return usage
+}
@@ -1,7 +1,14 @@
 package claude
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/replay"
 )
 
 func TestParseResponse(t *testing.T) {
@@ -224,3 +231,100 @@ func containsAt(s, substr string, start int) bool {
 	}
 	return false
 }
+
+func TestInvoker_RecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho '{\"content\":\"recorded\",\"session_id\":\"s-1\",\"total_cost_usd\":0.02}'\n"), 0755); err != nil {
+		t.Fatalf("failed to write fake claude: %v", err)
+	}
+
+	recStore, err := replay.NewStore(dir, replay.ModeRecord)
+	if err != nil {
+		t.Fatalf("NewStore returned error: %v", err)
+	}
+	inv := NewInvoker()
+	inv.ClaudePath = script
+	inv.Replay = recStore
+
+	req := Request{Prompt: "select agents", Schema: `{"type":"object"}`}
+	recorded, err := inv.Invoke(context.Background(), req)
+	if err != nil {
+		t.Fatalf("record Invoke returned error: %v", err)
+	}
+
+	playStore, err := replay.NewStore(dir, replay.ModeReplay)
+	if err != nil {
+		t.Fatalf("NewStore returned error: %v", err)
+	}
+	inv.ClaudePath = filepath.Join(t.TempDir(), "missing-claude")
+	inv.Replay = playStore
+
+	replayed, err := inv.Invoke(context.Background(), req)
+	if err != nil {
+		t.Fatalf("replay Invoke returned error: %v", err)
+	}
+	if string(replayed.RawOutput) != string(recorded.RawOutput) {
+		t.Errorf("replayed output = %q, want %q", replayed.RawOutput, recorded.RawOutput)
+	}
+	if recorded.Usage.CostUSD != 0.02 || !replayed.Usage.IsZero() {
+		t.Errorf("usage = %+v recorded, %+v replayed; want cost on the live call only", recorded.Usage, replayed.Usage)
+	}
+	if got := inv.Usage().CostUSD; got != 0.02 {
+		t.Errorf("Invoker.Usage().CostUSD = %v, want 0.02 (replays are free)", got)
+	}
+	content, _, _ := ParseResponse(replayed.RawOutput)
+	if content != "recorded" {
+		t.Errorf("content = %q, want recorded", content)
+	}
+
+	if _, err := inv.Invoke(context.Background(), Request{Prompt: "unrecorded"}); !errors.Is(err, replay.ErrNotRecorded) {
+		t.Errorf("expected ErrNotRecorded, got %v", err)
+	}
+}
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		name      string
+		rawOutput []byte
+		want      models.Usage
+	}{
+		{
+			name:      "result envelope with usage and total_cost_usd",
+			rawOutput: []byte(`{"type":"result","result":"done","total_cost_usd":0.0123,"usage":{"input_tokens":12,"output_tokens":345,"cache_creation_input_tokens":678,"cache_read_input_tokens":9000}}`),
+			want: models.Usage{
+				InputTokens:              12,
+				OutputTokens:             345,
+				CacheCreationInputTokens: 678,
+				CacheReadInputTokens:     9000,
+				CostUSD:                  0.0123,
+			},
+		},
+		{
+			name:      "older cost_usd field",
+			rawOutput: []byte(`{"result":"done","cost_usd":0.5,"usage":{"input_tokens":1,"output_tokens":2}}`),
+			want:      models.Usage{InputTokens: 1, OutputTokens: 2, CostUSD: 0.5},
+		},
+		{
+			name:      "warnings before JSON",
+			rawOutput: []byte("Warning: something\n{\"total_cost_usd\":1.25,\"usage\":{\"output_tokens\":7}}"),
+			want:      models.Usage{OutputTokens: 7, CostUSD: 1.25},
+		},
+		{
+			name:      "no usage reported",
+			rawOutput: []byte(`{"content":"Hello"}`),
+		},
+		{
+			name:      "not JSON",
+			rawOutput: []byte("plain text"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseUsage(tt.rawOutput); got != tt.want {
+				t.Errorf("ParseUsage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
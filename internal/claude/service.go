@@ -32,6 +32,10 @@ import (
 type Service struct {
 	inv    *Invoker
 	Logger budget.WaiterLogger
+
+	// Model is the model used for this component's calls (v3.6+).
+	// Empty uses the CLI default.
+	Model string
 }
 
 // NewService creates a new Service with the specified timeout.
@@ -90,6 +94,7 @@ func (s *Service) InvokeAndParse(ctx context.Context, prompt, schema string, res
 	req := Request{
 		Prompt: prompt,
 		Schema: schema,
+		Model:  s.Model,
 	}
 
 	resp, err := s.inv.Invoke(ctx, req)
@@ -122,6 +127,7 @@ func (s *Service) InvokeAndParseWithFallback(ctx context.Context, prompt, schema
 	req := Request{
 		Prompt: prompt,
 		Schema: schema,
+		Model:  s.Model,
 	}
 
 	resp, err := s.inv.Invoke(ctx, req)
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harrison/conductor/internal/approval"
+	"github.com/spf13/cobra"
+)
+
+// NewApproveCommand creates the approve command for approval gates (v3.6+)
+func NewApproveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve <plan> <task>",
+		Short: "Approve an approval gate so the tasks that depend on it can run",
+		Long: `Approve a 'type: approval' task. A run waiting at the gate continues within a
+few seconds. A gate the run has not reached yet is approved in advance, and a
+run that was stopped while waiting uses the approval when it gets there again.
+
+Examples:
+  conductor approve plan.md 5 --show     # Upstream QC results and diff the gate is asking about
+  conductor approve plan.md 5            # Approve task 5
+  conductor approve plans/ 5 --reason "Migration reviewed with DBA"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return decideApproval(cmd, args, approval.StatusApproved)
+		},
+	}
+
+	cmd.Flags().String("reason", "", "Note recorded with the approval")
+	cmd.Flags().Bool("show", false, "Show the gate's upstream QC results and diff without approving")
+	cmd.Flags().String("actor", "", "Name recorded with the decision (default: current user)")
+
+	return cmd
+}
+
+// NewRejectCommand creates the reject command for approval gates (v3.6+)
+func NewRejectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reject <plan> <task>",
+		Short: "Reject an approval gate, failing it and blocking the tasks that depend on it",
+		Long: `Reject a 'type: approval' task. The gate fails with the reason, and the tasks
+that depend on it do not run. The next run that reaches the gate asks again.
+
+Examples:
+  conductor reject plan.md 5 --reason "Breaking change to the public API"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return decideApproval(cmd, args, approval.StatusRejected)
+		},
+	}
+
+	cmd.Flags().String("reason", "", "Why the gate is rejected (required)")
+	cmd.Flags().String("actor", "", "Name recorded with the decision (default: current user)")
+	_ = cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+// decideApproval records an approve or reject decision for the gate in args.
+func decideApproval(cmd *cobra.Command, args []string, status approval.Status) error {
+	reason, _ := cmd.Flags().GetString("reason")
+	actorFlag, _ := cmd.Flags().GetString("actor")
+	show, _ := cmd.Flags().GetBool("show")
+	out := cmd.OutOrStdout()
+
+	plan, _, err := loadExplainPlan(args[0])
+	if err != nil {
+		return err
+	}
+	task, ok := getTask(plan.Tasks, args[1])
+	if !ok {
+		return fmt.Errorf("task %s not found in %s", args[1], args[0])
+	}
+	if !task.IsApproval() {
+		return fmt.Errorf("task %s is not an approval gate (type: approval)", task.Number)
+	}
+	if strings.TrimSpace(reason) == "" && status == approval.StatusRejected {
+		return fmt.Errorf("--reason is required to reject a gate")
+	}
+
+	planFile := task.SourceFile
+	if planFile == "" {
+		planFile = args[0]
+	}
+	store := approval.NewStore(approval.DefaultDir)
+
+	if show {
+		req, err := store.Load(planFile, task.Number)
+		if errors.Is(err, approval.ErrNoRequest) {
+			fmt.Fprintf(out, "Task %s has not been reached by a run yet; there is nothing to review.\n", task.Number)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		printApprovalRequest(out, req, true)
+		return nil
+	}
+
+	req, err := store.Decide(planFile, task.Number, task.Name, status, ctlActor(actorFlag), reason)
+	if err != nil {
+		return err
+	}
+
+	verb := "Approved"
+	if status == approval.StatusRejected {
+		verb = "Rejected"
+	}
+	fmt.Fprintf(out, "✓ %s task %s (%s) of %s\n", verb, task.Number, task.Name, planFile)
+	if req.Summary == "" {
+		fmt.Fprintln(out, "No run has reached the gate yet; the decision is used when one does.")
+		return nil
+	}
+	printApprovalRequest(out, req, false)
+	return nil
+}
+
+// printApprovalRequest prints what a gate asks about, with the full diff when withDiff is set.
+func printApprovalRequest(out io.Writer, req *approval.Request, withDiff bool) {
+	fmt.Fprintf(out, "\nTask %s (%s) - %s since %s\n", req.TaskNumber, req.TaskName, req.Status, req.RequestedAt.Format("2006-01-02 15:04:05"))
+	if req.DecidedBy != "" {
+		fmt.Fprintf(out, "Decided by %s", req.DecidedBy)
+		if req.Reason != "" {
+			fmt.Fprintf(out, ": %s", req.Reason)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "\n%s", req.Summary)
+	if withDiff && req.Diff != "" {
+		fmt.Fprintf(out, "\n%s", req.Diff)
+	}
+}
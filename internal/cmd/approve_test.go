@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/approval"
+	"github.com/spf13/cobra"
+)
+
+const approvalPlan = `plan:
+  metadata:
+    feature_name: Schema change
+  tasks:
+    - task_number: "1"
+      name: Add migration
+      files: [migrations/001.sql]
+      description: Add the users.email column
+    - task_number: "2"
+      name: Sign off migration
+      type: approval
+      depends_on: ["1"]
+      description: A DBA reviews the migration before it ships
+`
+
+// runApproval runs an approval command in a temporary project and returns its output.
+func runApproval(t *testing.T, cmd *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestApproveAndRejectCommands(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldWd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("plan.yaml", []byte(approvalPlan), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := approval.NewStore(approval.DefaultDir)
+
+	if _, err := runApproval(t, NewApproveCommand(), "plan.yaml", "1"); err == nil || !strings.Contains(err.Error(), "not an approval gate") {
+		t.Errorf("expected an error for a regular task, got %v", err)
+	}
+	if _, err := runApproval(t, NewRejectCommand(), "plan.yaml", "2"); err == nil {
+		t.Error("reject without --reason should fail")
+	}
+
+	out, err := runApproval(t, NewApproveCommand(), "plan.yaml", "2", "--show")
+	if err != nil || !strings.Contains(out, "has not been reached") {
+		t.Errorf("--show before the run reaches the gate: %q, %v", out, err)
+	}
+
+	// The run reached the gate and is waiting
+	pending := &approval.Request{PlanFile: "plan.yaml", TaskNumber: "2", TaskName: "Sign off migration", Status: approval.StatusPending,
+		RequestedAt: time.Now(), Summary: "Upstream tasks:\n- Task 1 (Add migration): GREEN\n", Diff: "+ALTER TABLE users ADD email TEXT;\n"}
+	if err := store.Save(pending); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runApproval(t, NewApproveCommand(), "plan.yaml", "2", "--show")
+	if err != nil || !strings.Contains(out, "Task 1 (Add migration): GREEN") || !strings.Contains(out, "+ALTER TABLE users") {
+		t.Errorf("--show should print the summary and diff: %q, %v", out, err)
+	}
+	if req, _ := store.Load("plan.yaml", "2"); req.Decided() {
+		t.Error("--show must not decide the gate")
+	}
+
+	out, err = runApproval(t, NewRejectCommand(), "plan.yaml", "2", "--reason", "needs an index", "--actor", "dba")
+	if err != nil || !strings.Contains(out, "✓ Rejected task 2 (Sign off migration)") {
+		t.Fatalf("reject: %q, %v", out, err)
+	}
+	req, err := store.Load(filepath.Join(dir, "plan.yaml"), "2")
+	if err != nil || req.Status != approval.StatusRejected || req.DecidedBy != "dba" || req.Reason != "needs an index" {
+		t.Errorf("rejection not recorded: %+v, %v", req, err)
+	}
+
+	out, err = runApproval(t, NewApproveCommand(), "plan.yaml", "2")
+	if err != nil || !strings.Contains(out, "✓ Approved task 2") {
+		t.Errorf("approve: %q, %v", out, err)
+	}
+	if req, _ := store.Load("plan.yaml", "2"); req.Status != approval.StatusApproved {
+		t.Errorf("a gate can be approved until the run uses the rejection, got %s", req.Status)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/claude"
+	"github.com/harrison/conductor/internal/config"
+)
+
+// buildBackendRouter creates the agent BackendRouter described by the backends
+// config section (v3.6+). The built-in "claude" backend uses the registry for
+// --agents definitions and the built-in "fake" backend is always available.
+// streaming switches claude backends to stream-json output (executor.stream_output).
+// subtasks offers agents the subtasks response field (executor.max_generated_tasks > 0).
+func buildBackendRouter(cfg config.BackendsConfig, registry *agent.Registry, streaming, subtasks bool) (*agent.BackendRouter, error) {
+	defaultName := cfg.Default
+	if defaultName == "" {
+		defaultName = string(config.BackendTypeClaude)
+	}
+
+	claudeInvoker := agent.NewInvokerWithRegistry(registry)
+	claudeInvoker.Streaming = streaming
+	claudeInvoker.Subtasks = subtasks
+	router := agent.NewBackendRouter(string(config.BackendTypeClaude), claudeInvoker)
+	router.Register(string(config.BackendTypeFake), agent.NewFakeBackend())
+
+	for name, def := range cfg.Definitions {
+		backend, err := newBackendFromDefinition(name, def, registry, streaming, subtasks)
+		if err != nil {
+			return nil, err
+		}
+		router.Register(name, backend)
+	}
+
+	if err := router.SetDefault(defaultName); err != nil {
+		return nil, err
+	}
+
+	for agentName, backendName := range cfg.Agents {
+		if err := router.AssignAgent(agentName, backendName); err != nil {
+			return nil, err
+		}
+	}
+
+	return router, nil
+}
+
+// newBackendFromDefinition constructs a backend from its config definition.
+func newBackendFromDefinition(name string, def config.BackendDefinition, registry *agent.Registry, streaming, subtasks bool) (agent.Backend, error) {
+	switch def.Type {
+	case config.BackendTypeClaude:
+		inv := agent.NewInvokerWithRegistry(registry)
+		inv.Streaming = streaming
+		inv.Subtasks = subtasks
+		if def.Command != "" {
+			inv.ClaudePath = def.Command
+		}
+		return inv, nil
+	case config.BackendTypeCommand:
+		if def.Command == "" {
+			return nil, fmt.Errorf("backend %q: command is required", name)
+		}
+		return &agent.CommandBackend{
+			Name:     name,
+			Command:  def.Command,
+			Args:     def.Args,
+			Env:      def.Env,
+			Registry: registry,
+			Subtasks: subtasks,
+		}, nil
+	case config.BackendTypeFake:
+		fake := agent.NewFakeBackend()
+		if def.Verdict != "" {
+			fake.Verdict = def.Verdict
+		}
+		return fake, nil
+	default:
+		return nil, fmt.Errorf("backend %q: unknown type %q", name, def.Type)
+	}
+}
+
+// routeHelperCalls points the shared helper invoker at the default backend
+// (v3.6+), so Claude calls made outside the main task (QC agent selection,
+// estimation, similarity, ...) use the same runtime as task agents. A claude
+// default keeps calling the CLI directly, using the backend's command if set.
+func routeHelperCalls(cfg config.BackendsConfig, router *agent.BackendRouter, inv *claude.Invoker) {
+	defaultName := cfg.Default
+	if defaultName == "" || defaultName == string(config.BackendTypeClaude) {
+		return
+	}
+	if def, ok := cfg.Definitions[defaultName]; ok && def.Type == config.BackendTypeClaude {
+		if def.Command != "" {
+			inv.ClaudePath = def.Command
+		}
+		return
+	}
+	inv.Backend = agent.NewHelperBackend(router)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/claude"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func TestBuildBackendRouter(t *testing.T) {
+	cfg := config.DefaultBackendsConfig()
+	cfg.Default = "fake"
+	cfg.Definitions["my-cli"] = config.BackendDefinition{Type: config.BackendTypeCommand, Command: "my-cli"}
+	cfg.Definitions["strict"] = config.BackendDefinition{Type: config.BackendTypeFake, Verdict: models.StatusRed}
+	cfg.Agents["golang-pro"] = "my-cli"
+
+	router, err := buildBackendRouter(cfg, nil, false, false)
+	if err != nil {
+		t.Fatalf("buildBackendRouter returned error: %v", err)
+	}
+
+	if got := router.BackendName(models.Task{Number: "1"}); got != "fake" {
+		t.Errorf("default backend = %q, want fake", got)
+	}
+	if got := router.BackendName(models.Task{Number: "1", Agent: "golang-pro"}); got != "my-cli" {
+		t.Errorf("agent backend = %q, want my-cli", got)
+	}
+
+	result, err := router.Invoke(context.Background(), models.Task{Number: "1", Name: "QC Review: x", Backend: "strict"})
+	if err != nil {
+		t.Fatalf("Invoke returned error: %v", err)
+	}
+	parsed, _ := agent.ParseClaudeOutput(result.Output)
+	if parsed == nil || parsed.Content == "" {
+		t.Fatalf("expected QC content from strict fake backend, got %q", result.Output)
+	}
+	if want := `"verdict":"RED"`; !strings.Contains(parsed.Content, want) {
+		t.Errorf("expected %s in %s", want, parsed.Content)
+	}
+}
+
+func TestBuildBackendRouter_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.BackendsConfig
+	}{
+		{"unknown default", config.BackendsConfig{Default: "codex"}},
+		{"agent mapped to unknown backend", config.BackendsConfig{Agents: map[string]string{"golang-pro": "missing"}}},
+		{"command without command", config.BackendsConfig{Definitions: map[string]config.BackendDefinition{"x": {Type: config.BackendTypeCommand}}}},
+		{"unknown type", config.BackendsConfig{Definitions: map[string]config.BackendDefinition{"x": {Type: "http"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildBackendRouter(tt.cfg, nil, false, false); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestRouteHelperCalls(t *testing.T) {
+	t.Run("non-claude default", func(t *testing.T) {
+		cfg := config.DefaultBackendsConfig()
+		cfg.Default = "fake"
+		router, err := buildBackendRouter(cfg, nil, false, false)
+		if err != nil {
+			t.Fatalf("buildBackendRouter returned error: %v", err)
+		}
+		inv := claude.NewInvoker()
+		routeHelperCalls(cfg, router, inv)
+
+		resp, err := inv.Invoke(context.Background(), claude.Request{Prompt: "Estimate", Schema: `{"type":"object"}`})
+		if err != nil {
+			t.Fatalf("Invoke returned error: %v", err)
+		}
+		if content, _, _ := claude.ParseResponse(resp.RawOutput); content != "{}" {
+			t.Errorf("expected the fake backend's helper response, got %q", content)
+		}
+	})
+
+	t.Run("claude default", func(t *testing.T) {
+		cfg := config.DefaultBackendsConfig()
+		cfg.Definitions["pinned"] = config.BackendDefinition{Type: config.BackendTypeClaude, Command: "/opt/claude"}
+		router, err := buildBackendRouter(cfg, nil, false, false)
+		if err != nil {
+			t.Fatalf("buildBackendRouter returned error: %v", err)
+		}
+		inv := claude.NewInvoker()
+		routeHelperCalls(cfg, router, inv)
+		if inv.Backend != nil || inv.ClaudePath != "claude" {
+			t.Errorf("the built-in claude default should keep calling the CLI: %+v", inv)
+		}
+
+		cfg.Default = "pinned"
+		routeHelperCalls(cfg, router, inv)
+		if inv.Backend != nil || inv.ClaudePath != "/opt/claude" {
+			t.Errorf("a claude default should use its command: %+v", inv)
+		}
+	})
+}
@@ -156,6 +156,9 @@ func runBudgetListSaved(cmd *cobra.Command, args []string) error {
 		if state.RateLimitInfo != nil {
 			fmt.Printf("  Limit Type: %s\n", state.RateLimitInfo.LimitType)
 		}
+		if state.Reason == budget.PauseReasonSpendCap {
+			fmt.Printf("  Reason: spend cap ($%.4f spent)\n", state.SpentUSD)
+		}
 		fmt.Println()
 	}
 
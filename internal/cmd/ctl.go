@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/control"
+	"github.com/harrison/conductor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// NewCtlCommand creates the ctl command for live run control (v3.6+)
+func NewCtlCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ctl",
+		Short: "Control a running plan (pause, resume, cancel, skip, concurrency)",
+		Long: `Send commands to a running 'conductor run' over its control socket.
+
+Examples:
+  conductor ctl status              # Show paused state and pending/running tasks
+  conductor ctl pause               # Stop launching new tasks (running tasks continue)
+  conductor ctl resume              # Resume launching tasks
+  conductor ctl cancel 4            # Cancel in-flight task 4 (reported as FAILED)
+  conductor ctl skip 7              # Skip pending task 7 (dependents still run)
+  conductor ctl concurrency 2       # Change max concurrency (0 = unlimited)`,
+	}
+
+	cmd.PersistentFlags().String("socket", "", "Control socket path (default: executor.control_socket from config)")
+	cmd.PersistentFlags().String("actor", "", "Name recorded in run logs for changes (default: current user)")
+
+	cmd.AddCommand(newCtlActionCommand("status", "Show run control status", cobra.NoArgs, control.ActionStatus))
+	cmd.AddCommand(newCtlActionCommand("pause", "Stop launching new tasks", cobra.NoArgs, models.ControlActionPause))
+	cmd.AddCommand(newCtlActionCommand("resume", "Resume launching tasks", cobra.NoArgs, models.ControlActionResume))
+	cmd.AddCommand(newCtlActionCommand("cancel <task>", "Cancel an in-flight task", cobra.ExactArgs(1), models.ControlActionCancel))
+	cmd.AddCommand(newCtlActionCommand("skip <task>", "Skip a pending task", cobra.ExactArgs(1), models.ControlActionSkip))
+	cmd.AddCommand(newCtlActionCommand("concurrency <n>", "Change max concurrency (0 = unlimited)", cobra.ExactArgs(1), models.ControlActionConcurrency))
+
+	return cmd
+}
+
+// newCtlActionCommand creates a ctl subcommand that sends a single control request.
+func newCtlActionCommand(use, short string, args cobra.PositionalArgs, action string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := buildCtlRequest(action, args)
+			if err != nil {
+				return err
+			}
+			socketFlag, _ := cmd.Flags().GetString("socket")
+			actorFlag, _ := cmd.Flags().GetString("actor")
+			req.Actor = ctlActor(actorFlag)
+
+			resp, err := control.Send(context.Background(), ctlSocketPath(socketFlag), req)
+			if err != nil {
+				return err
+			}
+			if action != control.ActionStatus {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ %s\n", models.ControlEvent{Action: req.Action, TaskNumber: req.Task, Value: req.Value}.Description())
+			}
+			printCtlStatus(cmd.OutOrStdout(), resp.Status)
+			return nil
+		},
+	}
+}
+
+// buildCtlRequest converts positional arguments into a control request.
+func buildCtlRequest(action string, args []string) (control.Request, error) {
+	req := control.Request{Action: action}
+	switch action {
+	case models.ControlActionCancel, models.ControlActionSkip:
+		req.Task = args[0]
+	case models.ControlActionConcurrency:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 0 {
+			return req, fmt.Errorf("concurrency must be a non-negative integer, got %q", args[0])
+		}
+		req.Value = n
+	}
+	return req, nil
+}
+
+// ctlSocketPath resolves the socket from the flag or the configured executor.control_socket.
+func ctlSocketPath(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if cfg, err := config.LoadConfigFromRootWithBuildTime(GetConductorRepoRoot()); err == nil && cfg.Executor.ControlSocket != "" {
+		return cfg.Executor.ControlSocket
+	}
+	return config.DefaultConfig().Executor.ControlSocket
+}
+
+// ctlActor returns the name recorded for control changes.
+func ctlActor(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = u.Username
+	}
+	if name == "" {
+		name = "unknown"
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return name + "@" + host
+	}
+	return name
+}
+
+// printCtlStatus prints a control status snapshot.
+func printCtlStatus(w io.Writer, status *models.ControlStatus) {
+	if status == nil {
+		return
+	}
+	state := "running"
+	if status.Paused {
+		state = "paused (no new launches)"
+	}
+	fmt.Fprintf(w, "Run: %s\n", state)
+	if status.ConcurrencyOverride {
+		if status.MaxConcurrency == 0 {
+			fmt.Fprintf(w, "Max concurrency: unlimited (override)\n")
+		} else {
+			fmt.Fprintf(w, "Max concurrency: %d (override)\n", status.MaxConcurrency)
+		}
+	}
+	fmt.Fprintf(w, "Running: %s\n", formatTaskList(status.Running))
+	fmt.Fprintf(w, "Pending: %s\n", formatTaskList(status.Pending))
+	fmt.Fprintf(w, "Done: %d task(s)\n", len(status.Done))
+	if len(status.Skipped) > 0 {
+		fmt.Fprintf(w, "Skipped: %s\n", formatTaskList(status.Skipped))
+	}
+	if len(status.Cancelled) > 0 {
+		fmt.Fprintf(w, "Cancelled: %s\n", formatTaskList(status.Cancelled))
+	}
+}
+
+// formatTaskList renders task numbers for display.
+func formatTaskList(tasks []string) string {
+	if len(tasks) == 0 {
+		return "none"
+	}
+	return strings.Join(tasks, ", ")
+}
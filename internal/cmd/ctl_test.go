@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/control"
+	"github.com/harrison/conductor/internal/executor"
+)
+
+// runCtl executes `conductor ctl` with args and returns its output.
+func runCtl(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewCtlCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestCtlCommand(t *testing.T) {
+	controller := executor.NewRunController(nil)
+	controller.Register([]string{"1", "2"})
+	server := control.NewServer(filepath.Join(t.TempDir(), "ctl.sock"), controller)
+	if err := server.Start(); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	defer server.Close()
+	socket := "--socket=" + server.Path()
+
+	out, err := runCtl(t, "pause", socket, "--actor", "ci")
+	if err != nil {
+		t.Fatalf("ctl pause returned error: %v", err)
+	}
+	if !strings.Contains(out, "paused new task launches") || !strings.Contains(out, "Run: paused") {
+		t.Errorf("unexpected pause output:\n%s", out)
+	}
+
+	if out, err = runCtl(t, "skip", "2", socket); err != nil {
+		t.Fatalf("ctl skip returned error: %v", err)
+	}
+	if !strings.Contains(out, "Skipped: 2") {
+		t.Errorf("unexpected skip output:\n%s", out)
+	}
+
+	if out, err = runCtl(t, "concurrency", "3", socket); err != nil {
+		t.Fatalf("ctl concurrency returned error: %v", err)
+	}
+	if !strings.Contains(out, "Max concurrency: 3 (override)") {
+		t.Errorf("unexpected concurrency output:\n%s", out)
+	}
+
+	if _, err = runCtl(t, "cancel", "1", socket); err == nil || !strings.Contains(err.Error(), "has not started") {
+		t.Errorf("ctl cancel on pending task error = %v", err)
+	}
+	if _, err = runCtl(t, "concurrency", "-2", socket); err == nil {
+		t.Error("expected error for negative concurrency")
+	}
+
+	if out, err = runCtl(t, "status", socket); err != nil {
+		t.Fatalf("ctl status returned error: %v", err)
+	}
+	if !strings.Contains(out, "Pending: 1") {
+		t.Errorf("unexpected status output:\n%s", out)
+	}
+}
+
+func TestCtlCommand_NoRun(t *testing.T) {
+	_, err := runCtl(t, "status", "--socket="+filepath.Join(t.TempDir(), "none.sock"))
+	if err == nil || !strings.Contains(err.Error(), "no conductor run is listening") {
+		t.Errorf("expected no-run error, got %v", err)
+	}
+}
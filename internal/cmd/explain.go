@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/architecture"
+	"github.com/harrison/conductor/internal/claude"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/executor"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/logger"
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/parser"
+	"github.com/harrison/conductor/internal/pattern"
+	"github.com/harrison/conductor/internal/prompts"
+	"github.com/harrison/conductor/internal/similarity"
+	"github.com/spf13/cobra"
+)
+
+// NewExplainCommand creates the explain command (v3.6+)
+func NewExplainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <plan>",
+		Short: "Show the prompt an agent and its QC reviewers would receive for a task",
+		Long: `Assemble the prompt for one task exactly as 'conductor run' would, without
+running it: the base prompt plus learning, warm-up, pattern intelligence,
+architecture and integration context, the response format, JSON schema and
+system prompt. Each section is printed with its source and an approximate
+token count.
+
+The pre-task hooks of a real run are run in dry-run mode. Hooks that only read
+history run as configured. Hooks that consult Claude (similarity search, LLM
+enhancement, architecture assessment, intelligent agent selection) make their
+read-only Claude calls; with --offline they make none and fall back to their
+defaults. A hook that fell back is listed under Notes. Nothing is written to the
+plan or git, no history is written to the learning database, and dependency
+checks are not run.
+
+Examples:
+  conductor explain plan.md --task 3          # Agent prompt for task 3
+  conductor explain plan.md --task 3 --qc     # Also the QC review prompt
+  conductor explain plans/ --task 2 --summary # Sections and token counts only
+  conductor explain plan.md --task 3 --offline # Without helper Claude calls`,
+		Args: cobra.ExactArgs(1),
+		RunE: explainCommand,
+	}
+
+	cmd.Flags().String("task", "", "Task number to explain (required)")
+	cmd.Flags().Bool("qc", false, "Also show the QC review prompt")
+	cmd.Flags().Bool("summary", false, "Only list sections with their sources and token counts")
+	cmd.Flags().Bool("offline", false, "Do not call Claude for hook context; those hooks use their fallbacks")
+	cmd.Flags().String("config", "", "Path to config file (default: .conductor/config.yaml)")
+	_ = cmd.MarkFlagRequired("task")
+
+	return cmd
+}
+
+func explainCommand(cmd *cobra.Command, args []string) error {
+	taskNumber, _ := cmd.Flags().GetString("task")
+	includeQC, _ := cmd.Flags().GetBool("qc")
+	summary, _ := cmd.Flags().GetBool("summary")
+	offline, _ := cmd.Flags().GetBool("offline")
+	configPath, _ := cmd.Flags().GetString("config")
+
+	var cfg *config.Config
+	var err error
+	if configPath != "" {
+		cfg, err = config.LoadConfig(configPath)
+	} else {
+		cfg, err = config.LoadConfigFromRootWithBuildTime(GetConductorRepoRoot())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	plan, planFile, err := loadExplainPlan(args[0])
+	if err != nil {
+		return err
+	}
+	applyConfigQC(plan, cfg)
+	parser.ApplyRetryOnRedFallback(plan, 0)
+	task, ok := getTask(plan.Tasks, taskNumber)
+	if !ok {
+		return fmt.Errorf("task %s not found in %s", taskNumber, planFile)
+	}
+
+	promptTemplates, err := prompts.Load(prompts.DefaultDir)
+	if err != nil {
+		return fmt.Errorf("failed to load prompt templates: %w", err)
+	}
+	prompts.SetActive(promptTemplates)
+
+	// Only open an existing learning database; explaining must not create one
+	var learningStore *learning.Store
+	if cfg.Learning.Enabled {
+		if dbPath, err := config.GetLearningDBPath(); err == nil {
+			if _, statErr := os.Stat(dbPath); statErr == nil {
+				if store, err := learning.NewStore(dbPath); err == nil {
+					learningStore = store
+					defer store.Close()
+				}
+			}
+		}
+	}
+
+	registry := agent.NewRegistry("")
+	_, _ = registry.Discover()
+
+	invoker, err := buildBackendRouter(cfg.Backends, registry, cfg.Executor.StreamOutput, cfg.Executor.MaxGeneratedTasks > 0)
+	if err != nil {
+		return fmt.Errorf("failed to configure agent backends: %w", err)
+	}
+
+	// Hook warnings go to stderr so the explained prompt stays clean
+	hookLog := logger.NewConsoleLogger(cmd.ErrOrStderr(), cfg.LogLevel)
+
+	// Hooks make their read-only helper Claude calls unless --offline;
+	// the ones that fall back are listed under Notes
+	helpers := &explainHelperBackend{}
+	if !offline {
+		helpers.next = claude.NewInvoker()
+		helpers.next.Timeout = cfg.Timeouts.LLM
+		routeHelperCalls(cfg.Backends, invoker, helpers.next)
+	}
+	claudeInvoker := claude.NewInvoker()
+	claudeInvoker.Backend = helpers
+
+	qc := executor.NewQualityController(invoker)
+	qc.Registry = registry
+	qc.LearningStore = learningStore
+	qc.AgentConfig = plan.QualityControl.Agents
+	qc.Aggregation = cfg.QualityControl.Aggregation
+	qc.CheckerGate = cfg.QualityControl.CheckerGate
+	qc.DiffReview = cfg.QualityControl.DiffReview
+	qc.Calibration = cfg.QualityControl.Calibration
+	if checkers, err := executor.NewQCCheckers(cfg.QualityControl.Checkers); err == nil {
+		qc.Checkers = checkers
+	}
+
+	taskExec, err := executor.NewTaskExecutor(invoker, qc, nil, executor.TaskExecutorConfig{
+		PlanPath:       planFile,
+		DefaultAgent:   plan.DefaultAgent,
+		QualityControl: plan.QualityControl,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create task executor: %w", err)
+	}
+	taskExec.Plan = plan
+	taskExec.PlanFile = planFile
+	taskExec.Logger = hookLog
+	taskExec.EnforceDependencyChecks = cfg.Executor.EnforceDependencyChecks
+	taskExec.MinFailuresBeforeAdapt = cfg.Learning.MinFailuresBeforeAdapt
+	taskExec.Subtasks = cfg.Executor.MaxGeneratedTasks > 0
+	if cfg.Executor.ContextBudget.MaxTokens > 0 {
+		taskExec.ContextBudget = &cfg.Executor.ContextBudget
+	}
+	if learningStore != nil {
+		taskExec.LearningStore = learningStore
+	}
+	wirePromptHooks(cfg, taskExec, learningStore, claudeInvoker, hookLog)
+	var behavioralHistory *executor.BehavioralHistory
+	if learningStore != nil {
+		behavioralHistory, _ = executor.LoadBehavioralHistory(context.Background(), learningStore, cfg.RiskGate.HistoryLimit)
+	}
+	if riskGate := executor.NewRiskGate(cfg.RiskGate, behavioralHistory); riskGate != nil {
+		riskGate.Registry = registry
+		taskExec.RiskGate = riskGate
+	}
+	if cfg.ModelRouting.Enabled {
+		taskExec.ModelRouter = executor.NewModelRouter(cfg.ModelRouting, nil)
+		if learningStore != nil {
+			taskExec.ModelRouter.Store = learningStore
+		}
+	}
+	if cfg.Executor.IntelligentAgentSelection || plan.QualityControl.Agents.Mode == "intelligent" {
+		taskExec.TaskAgentSelector = executor.NewTaskAgentSelectorWithInvoker(registry, claudeInvoker)
+		taskExec.TaskAgentSelector.AgentRankings = behavioralHistory.AgentRankings()
+		taskExec.IntelligentAgentSelection = true
+	}
+
+	exp, err := taskExec.Explain(context.Background(), *task, includeQC)
+	if err != nil {
+		return err
+	}
+	addAgentDefinition(exp, registry)
+	exp.Notes = append(exp.Notes, helpers.notes()...)
+
+	printExplanation(cmd.OutOrStdout(), exp, summary)
+	return nil
+}
+
+// errExplainOffline is returned for every helper Claude request made with --offline.
+var errExplainOffline = errors.New("not called with --offline")
+
+// explainHelperBackend runs the helper Claude requests hooks make while
+// explaining, and remembers the hooks whose request failed.
+type explainHelperBackend struct {
+	next *claude.Invoker // Nil answers every request with errExplainOffline
+
+	mu       sync.Mutex
+	fallback []string // Hooks that fell back, in request order
+	reasons  map[string]error
+}
+
+func (b *explainHelperBackend) InvokeRequest(ctx context.Context, req claude.Request) (*claude.Response, error) {
+	if b.next == nil {
+		b.fellBack(req, errExplainOffline)
+		return nil, errExplainOffline
+	}
+	resp, err := b.next.Invoke(ctx, req)
+	if err != nil {
+		b.fellBack(req, err)
+	}
+	return resp, err
+}
+
+func (b *explainHelperBackend) fellBack(req claude.Request, err error) {
+	hook := explainHelperHook(req.Schema)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.reasons == nil {
+		b.reasons = make(map[string]error)
+	}
+	if _, seen := b.reasons[hook]; !seen {
+		b.fallback = append(b.fallback, hook)
+		b.reasons[hook] = err
+	}
+}
+
+// notes describes each hook that fell back.
+func (b *explainHelperBackend) notes() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	notes := make([]string, 0, len(b.fallback))
+	for _, hook := range b.fallback {
+		notes = append(notes, fmt.Sprintf("%s fell back to its default (%v); context it adds may be missing", hook, b.reasons[hook]))
+	}
+	return notes
+}
+
+// explainHelperHook names the hook that made a helper request, by its response schema.
+func explainHelperHook(schema string) string {
+	switch schema {
+	case similarity.SimilaritySchema(), similarity.BatchSimilaritySchema():
+		return "similarity search (warm-up context, pattern intelligence)"
+	case pattern.EnhancementSchema():
+		return "pattern intelligence LLM enhancement"
+	case architecture.AssessmentSchema():
+		return "architecture checkpoint"
+	case models.IntelligentSelectionSchema():
+		return "intelligent QC agent selection"
+	case executor.TaskAgentSelectionSchema():
+		return "intelligent agent selection"
+	}
+	return "a helper Claude call"
+}
+
+// loadExplainPlan parses a plan file, or the plan-* files of a directory merged into one plan.
+func loadExplainPlan(path string) (*models.Plan, string, error) {
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		plan, err := parser.ParseFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load plan file: %w", err)
+		}
+		return plan, path, nil
+	}
+
+	planFiles, err := parser.FilterPlanFiles([]string{path})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to filter plan files: %w", err)
+	}
+	var plans []*models.Plan
+	for _, pf := range planFiles {
+		p, err := parser.ParseFile(pf)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse %s: %w", pf, err)
+		}
+		plans = append(plans, p)
+	}
+	plan, err := parser.MergePlans(plans...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to merge plans: %w", err)
+	}
+	return plan, strings.Join(planFiles, ", "), nil
+}
+
+// addAgentDefinition adds the agent definition sent with --agents ahead of the schema.
+func addAgentDefinition(exp *executor.PromptExplanation, registry *agent.Registry) {
+	def, ok := registry.Get(exp.Task.Agent)
+	if !ok || len(exp.Sections) < 2 {
+		return
+	}
+	section := executor.PromptSection{
+		Name:    "Agent definition (" + def.Name + ")",
+		Source:  def.FilePath + " (--agents)",
+		Content: def.Prompt,
+		Tokens:  prompts.EstimateTokens(def.Prompt),
+	}
+	exp.Tokens += section.Tokens
+
+	// Keep the schema and system prompt last, where the invoker adds them
+	at := len(exp.Sections) - 2
+	exp.Sections = append(exp.Sections[:at], append([]executor.PromptSection{section}, exp.Sections[at:]...)...)
+}
+
+// printExplanation writes the sections of an explained prompt.
+func printExplanation(w io.Writer, exp *executor.PromptExplanation, summary bool) {
+	agentName := exp.Task.Agent
+	if agentName == "" {
+		agentName = "(none)"
+	}
+	model := exp.Task.Model
+	if model == "" {
+		model = "(agent default)"
+	}
+	fmt.Fprintf(w, "Task %s: %s\n", exp.Task.Number, exp.Task.Name)
+	fmt.Fprintf(w, "Agent: %s  Model: %s\n\n", agentName, model)
+
+	fmt.Fprintf(w, "=== Agent prompt (~%d tokens) ===\n", exp.Tokens)
+	printSections(w, exp.Sections, summary)
+	printNotes(w, exp.Notes)
+
+	if exp.QC == nil {
+		return
+	}
+	if exp.QC.Prompt == "" {
+		fmt.Fprintf(w, "\n=== QC review prompt ===\n")
+		printNotes(w, exp.QC.Notes)
+		return
+	}
+	fmt.Fprintf(w, "\n=== QC review prompt (~%d tokens) ===\n", exp.QC.Tokens)
+	if len(exp.QC.Agents) > 0 {
+		fmt.Fprintf(w, "Reviewers: %s\n", strings.Join(exp.QC.Agents, ", "))
+	}
+	printSections(w, exp.QC.Sections, summary)
+	printNotes(w, exp.QC.Notes)
+}
+
+func printSections(w io.Writer, sections []executor.PromptSection, summary bool) {
+	for i, section := range sections {
+		fmt.Fprintf(w, "\n[%d] %s  (source: %s, ~%d tokens)\n", i+1, section.Name, section.Source, section.Tokens)
+		if section.Note != "" {
+			fmt.Fprintf(w, "    Note: %s\n", section.Note)
+		}
+		if summary {
+			continue
+		}
+		for _, line := range strings.Split(section.Content, "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
+
+func printNotes(w io.Writer, notes []string) {
+	if len(notes) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nNotes:\n")
+	for _, note := range notes {
+		fmt.Fprintf(w, "  • %s\n", note)
+	}
+}
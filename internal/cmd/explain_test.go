@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/architecture"
+	"github.com/harrison/conductor/internal/claude"
+	"github.com/harrison/conductor/internal/similarity"
+)
+
+const explainPlan = `plan:
+  metadata:
+    feature_name: Explain
+  tasks:
+    - task_number: "1"
+      name: Lexer
+      files: [lexer.go]
+      description: Build the lexer
+    - task_number: "2"
+      name: Parser
+      files: [parser.go]
+      depends_on: ["1"]
+      description: Build the parser
+      success_criteria:
+        - Parses nested expressions
+`
+
+// runExplain executes conductor explain against a plan with QC enabled and learning disabled.
+func runExplain(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.yaml")
+	if err := os.WriteFile(planPath, []byte(explainPlan), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "config.yaml")
+	config := "learning:\n  enabled: false\nquality_control:\n  enabled: true\n  agents:\n    mode: explicit\n    explicit_list: [quality-control]\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewExplainCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(append([]string{planPath, "--config", configPath}, args...))
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestExplainCommand(t *testing.T) {
+	out, err := runExplain(t, "--task", "2", "--qc")
+	if err != nil {
+		t.Fatalf("explain returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Task 2: Parser",
+		"=== Agent prompt (~",
+		"] Task prompt  (source: plan ",
+		"] Integration context  (source: dependencies 1, ~",
+		"    <file>lexer.go</file>",
+		"] JSON schema  (source: default agent response schema (--json-schema), ~",
+		"] System prompt  (source: conductor (--system-prompt), ~",
+		"=== QC review prompt (~",
+		"Reviewers: quality-control",
+		"0. [ ] Parses nested expressions",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExplainCommand_Summary(t *testing.T) {
+	out, err := runExplain(t, "--task", "1", "--summary")
+	if err != nil {
+		t.Fatalf("explain returned error: %v", err)
+	}
+	if !strings.Contains(out, "] Task prompt") || strings.Contains(out, "<response_format>") {
+		t.Errorf("summary should list sections without their content:\n%s", out)
+	}
+	if strings.Contains(out, "QC review prompt") {
+		t.Errorf("QC prompt should only be shown with --qc:\n%s", out)
+	}
+}
+
+func TestExplainCommand_UnknownTask(t *testing.T) {
+	if _, err := runExplain(t, "--task", "9"); err == nil || !strings.Contains(err.Error(), "task 9 not found") {
+		t.Errorf("expected unknown task error, got %v", err)
+	}
+}
+
+func TestExplainHelperBackend_Offline(t *testing.T) {
+	b := &explainHelperBackend{}
+	for _, schema := range []string{similarity.SimilaritySchema(), architecture.AssessmentSchema(), similarity.BatchSimilaritySchema()} {
+		if _, err := b.InvokeRequest(context.Background(), claude.Request{Schema: schema}); !errors.Is(err, errExplainOffline) {
+			t.Fatalf("expected offline error, got %v", err)
+		}
+	}
+
+	notes := b.notes()
+	if len(notes) != 2 {
+		t.Fatalf("expected one note per hook, got %q", notes)
+	}
+	if !strings.HasPrefix(notes[0], "similarity search") || !strings.HasPrefix(notes[1], "architecture checkpoint") {
+		t.Errorf("notes should name the hooks in request order: %q", notes)
+	}
+	if !strings.Contains(notes[0], "--offline") {
+		t.Errorf("note should give the reason: %q", notes[0])
+	}
+}
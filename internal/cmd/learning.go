@@ -17,6 +17,8 @@ insights to improve plan execution over time.`,
 
 	// Add subcommands
 	cmd.AddCommand(NewStatsCommand())
+	cmd.AddCommand(NewQCStatsCommand())
+	cmd.AddCommand(NewQCOverrideCommand())
 	cmd.AddCommand(NewShowCommand())
 	cmd.AddCommand(newClearCommand())
 	cmd.AddCommand(newExportCommand())
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/spf13/cobra"
+)
+
+// NewQCOverrideCommand creates the 'conductor learning qc-override' command
+func NewQCOverrideCommand() *cobra.Command {
+	var dbPath string
+
+	cmd := &cobra.Command{
+		Use:   "qc-override <plan-file> <task-number> <good|bad>",
+		Short: "Record whether a task's last QC review was right",
+		Long: `Record the outcome of a task's most recent QC review, for QC reviewer
+calibration. Use "bad" when the reviewed work turned out to need fixing and
+"good" when it was sound. A human override takes precedence over outcomes
+inferred from fix tasks and test results.
+
+Examples:
+  # Task 4 passed QC but was broken
+  conductor learning qc-override plan.yaml 4 bad
+
+  # Task 7 was failed by a reviewer but was fine
+  conductor learning qc-override plan.yaml 7 good`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQCOverride(cmd, args, dbPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&dbPath, "db-path", "", "Path to learning database (for testing)")
+
+	return cmd
+}
+
+// runQCOverride executes the qc-override command
+func runQCOverride(cmd *cobra.Command, args []string, dbPathOverride string) error {
+	planFile, taskNumber, outcome := args[0], args[1], args[2]
+	if outcome != learning.QCOutcomeGood && outcome != learning.QCOutcomeBad {
+		return fmt.Errorf("outcome must be %q or %q, got %q", learning.QCOutcomeGood, learning.QCOutcomeBad, outcome)
+	}
+
+	dbPath, err := learningDBPath(dbPathOverride)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return fmt.Errorf("no learning database at %s", dbPath)
+	}
+
+	store, err := learning.NewStore(dbPath)
+	if err != nil {
+		return fmt.Errorf("open learning store: %w", err)
+	}
+	defer store.Close()
+
+	updated, err := store.SetQCReviewOutcome(context.Background(), planFile, taskNumber, outcome, learning.QCOutcomeSourceOverride)
+	if err != nil {
+		return fmt.Errorf("record qc outcome: %w", err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("no multi-agent QC review recorded for task %s of %s", taskNumber, filepath.Base(planFile))
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Recorded task %s's last QC review as %s (%d reviewer verdicts)\n", taskNumber, outcome, updated)
+	return nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/learning"
+)
+
+func TestQCOverrideCommand(t *testing.T) {
+	dbPath := seedQCReviews(t)
+
+	run := func(args ...string) (string, error) {
+		cmd := NewQCOverrideCommand()
+		cmd.SetArgs(append(args, "--db-path", dbPath))
+		var output bytes.Buffer
+		cmd.SetOut(&output)
+		cmd.SetErr(&output)
+		err := cmd.Execute()
+		return output.String(), err
+	}
+
+	out, err := run("plan.yaml", "2", "bad")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(out, "Recorded task 2's last QC review as bad (2 reviewer verdicts)") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	store, err := learning.NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	defer store.Close()
+	byAgent, _, err := store.GetQCCalibration(context.Background(), "plan.yaml")
+	if err != nil {
+		t.Fatalf("GetQCCalibration() error = %v", err)
+	}
+	if got := byAgent["code-reviewer"]; got.Labeled != 2 || got.FalseNegatives != 1 {
+		t.Errorf("override should label task 2's review: %+v", got)
+	}
+
+	if _, err := run("plan.yaml", "9", "bad"); err == nil {
+		t.Error("expected an error for a task without a recorded review")
+	}
+	if _, err := run("plan.yaml", "2", "wrong"); err == nil {
+		t.Error("expected an error for an invalid outcome")
+	}
+}
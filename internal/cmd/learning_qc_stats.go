@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/spf13/cobra"
+)
+
+// NewQCStatsCommand creates the 'conductor learning qc-stats' command
+func NewQCStatsCommand() *cobra.Command {
+	var dbPath string
+
+	cmd := &cobra.Command{
+		Use:   "qc-stats [plan-file]",
+		Short: "Show how well QC reviewers' verdicts matched task outcomes",
+		Long: `Display QC reviewer calibration: how often each QC agent's verdict
+matched what later happened to the reviewed task, per reviewer and per file type.
+
+A review's outcome is known when:
+  - a later task lists the reviewed task under fixes (the work needed fixing)
+  - a reviewer's RED was contradicted by another reviewer and every test
+    command passed (the work was sound)
+  - it was set with 'conductor learning qc-override'
+
+RED counts as the positive verdict: precision is the share of RED verdicts
+that were right, recall the share of work needing fixes that got a RED.
+
+Without a plan file, reviews from every plan are included.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQCStats(cmd, args, dbPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&dbPath, "db-path", "", "Path to learning database (for testing)")
+
+	return cmd
+}
+
+// runQCStats executes the qc-stats command
+func runQCStats(cmd *cobra.Command, args []string, dbPathOverride string) error {
+	output := cmd.OutOrStdout()
+
+	planFile, title := "", "all plans"
+	if len(args) == 1 {
+		planFile = filepath.Base(args[0])
+		title = planFile
+	}
+
+	dbPath, err := learningDBPath(dbPathOverride)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		fmt.Fprintf(output, "No QC reviews recorded for %s\n", title)
+		fmt.Fprintf(output, "Database path: %s\n", dbPath)
+		return nil
+	}
+
+	store, err := learning.NewStore(dbPath)
+	if err != nil {
+		return fmt.Errorf("open learning store: %w", err)
+	}
+	defer store.Close()
+
+	byAgent, byFileType, err := store.GetQCCalibration(context.Background(), planFile)
+	if err != nil {
+		return fmt.Errorf("get qc calibration: %w", err)
+	}
+	if len(byAgent) == 0 {
+		fmt.Fprintf(output, "No QC reviews recorded for %s\n", title)
+		return nil
+	}
+
+	printQCCalibration(output, title, byAgent, byFileType)
+	return nil
+}
+
+// printQCCalibration formats and prints reviewer calibration
+func printQCCalibration(w io.Writer, title string, byAgent, byFileType map[string]learning.QCCalibration) {
+	cyan := color.New(color.FgCyan, color.Bold)
+
+	cyan.Fprintf(w, "\n=== QC Reviewer Calibration for %s ===\n", title)
+
+	fmt.Fprintf(w, "\n")
+	cyan.Fprintf(w, "Reviewers:\n")
+	printQCCalibrationGroup(w, byAgent)
+
+	if len(byFileType) > 0 {
+		fmt.Fprintf(w, "\n")
+		cyan.Fprintf(w, "By File Type:\n")
+		printQCCalibrationGroup(w, byFileType)
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// printQCCalibrationGroup prints calibration entries, most reviewed first
+func printQCCalibrationGroup(w io.Writer, group map[string]learning.QCCalibration) {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+
+	entries := make([]learning.QCCalibration, 0, len(group))
+	for _, c := range group {
+		entries = append(entries, c)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Labeled != entries[j].Labeled {
+			return entries[i].Labeled > entries[j].Labeled
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	for _, c := range entries {
+		fmt.Fprintf(w, "\n  %s:\n", c.Name)
+		fmt.Fprintf(w, "    Reviews: %d (%d with a known outcome)\n", c.Reviews, c.Labeled)
+		if c.Labeled == 0 {
+			continue
+		}
+
+		accuracy := c.Accuracy() * 100
+		fmt.Fprintf(w, "    Accuracy: ")
+		if accuracy >= 70 {
+			green.Fprintf(w, "%.1f%%", accuracy)
+		} else if accuracy >= 40 {
+			yellow.Fprintf(w, "%.1f%%", accuracy)
+		} else {
+			red.Fprintf(w, "%.1f%%", accuracy)
+		}
+		fmt.Fprintf(w, " (%d/%d)\n", c.TruePositives+c.TrueNegatives, c.Labeled)
+
+		if reds := c.TruePositives + c.FalsePositives; reds > 0 {
+			fmt.Fprintf(w, "    Precision: %.1f%% (%d/%d RED verdicts right)\n", c.Precision()*100, c.TruePositives, reds)
+		} else {
+			fmt.Fprintf(w, "    Precision: - (no RED verdicts)\n")
+		}
+		if bad := c.TruePositives + c.FalseNegatives; bad > 0 {
+			fmt.Fprintf(w, "    Recall: %.1f%% (%d/%d problems caught)\n", c.Recall()*100, c.TruePositives, bad)
+		} else {
+			fmt.Fprintf(w, "    Recall: - (no work that needed fixing)\n")
+		}
+	}
+}
+
+// learningDBPath returns the override if set, else the centralized learning database path
+func learningDBPath(override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	dbPath, err := config.GetLearningDBPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to get learning database path: %w", err)
+	}
+	return dbPath, nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/learning"
+)
+
+// seedQCReviews records two reviews of plan.yaml: task 1 where strict-reviewer's
+// RED was contradicted by tests, and task 2 left without an outcome.
+func seedQCReviews(t *testing.T) string {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "executions.db")
+	store, err := learning.NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	reviews := [][]learning.QCAgentVerdict{
+		{
+			{PlanFile: "/work/plan.yaml", TaskNumber: "1", Agent: "code-reviewer", Verdict: "GREEN", FinalVerdict: "GREEN",
+				FileTypes: []string{".go"}, Outcome: learning.QCOutcomeGood, OutcomeSource: learning.QCOutcomeSourceTests},
+			{PlanFile: "/work/plan.yaml", TaskNumber: "1", Agent: "strict-reviewer", Verdict: "RED", FinalVerdict: "GREEN",
+				FileTypes: []string{".go"}, Outcome: learning.QCOutcomeGood, OutcomeSource: learning.QCOutcomeSourceTests},
+		},
+		{
+			{PlanFile: "/work/plan.yaml", TaskNumber: "2", Agent: "code-reviewer", Verdict: "GREEN", FinalVerdict: "GREEN", FileTypes: []string{".py"}},
+			{PlanFile: "/work/plan.yaml", TaskNumber: "2", Agent: "strict-reviewer", Verdict: "GREEN", FinalVerdict: "GREEN", FileTypes: []string{".py"}},
+		},
+	}
+	for _, verdicts := range reviews {
+		if err := store.RecordQCVerdicts(ctx, verdicts); err != nil {
+			t.Fatalf("RecordQCVerdicts() error = %v", err)
+		}
+	}
+	return dbPath
+}
+
+func TestQCStatsCommand(t *testing.T) {
+	dbPath := seedQCReviews(t)
+
+	cmd := NewQCStatsCommand()
+	cmd.SetArgs([]string{"plans/plan.yaml", "--db-path", dbPath})
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	out := output.String()
+	for _, want := range []string{
+		"QC Reviewer Calibration for plan.yaml",
+		"strict-reviewer:",
+		"Reviews: 2 (1 with a known outcome)",
+		"Precision: 0.0% (0/1 RED verdicts right)",
+		"Recall: - (no work that needed fixing)",
+		"By File Type:",
+		".py:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	// Another plan has no reviews
+	cmd = NewQCStatsCommand()
+	cmd.SetArgs([]string{"other.yaml", "--db-path", dbPath})
+	output.Reset()
+	cmd.SetOut(&output)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "No QC reviews recorded for other.yaml") {
+		t.Errorf("unexpected output: %s", output.String())
+	}
+}
@@ -24,9 +24,11 @@ func NewStatsCommand() *cobra.Command {
 		Long: `Display learning statistics for a plan file including:
   - Overall success rates
   - Agent performance metrics
+  - Model routing outcomes
   - Task-level statistics
   - Common failure patterns
-  - Average execution durations`,
+  - Average execution durations
+  - Total and per-task cost`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStats(cmd, args, dbPath)
@@ -106,10 +108,13 @@ type Statistics struct {
 	FailedExecs       int
 	SuccessRate       float64
 	AgentPerformance  map[string]*AgentStats
+	ModelPerformance  map[string]*ModelStats // Outcomes per routed model (v3.6+)
 	TaskMetrics       map[string]*TaskStats
 	CommonFailures    map[string]int
 	AverageDuration   float64
 	TotalDurationSecs int64
+	TotalCostUSD      float64 // Cost of all recorded attempts (v3.6+)
+	TotalTokens       int64   // Tokens of all recorded attempts (v3.6+)
 }
 
 // AgentStats tracks performance for a specific agent
@@ -122,6 +127,15 @@ type AgentStats struct {
 	AvgDuration float64
 }
 
+// ModelStats tracks outcomes for a model chosen by model routing (v3.6+)
+type ModelStats struct {
+	Name        string
+	TotalExecs  int
+	Successes   int
+	SuccessRate float64
+	CostUSD     float64
+}
+
 // TaskStats tracks metrics for a specific task
 type TaskStats struct {
 	TaskNumber    string
@@ -131,18 +145,22 @@ type TaskStats struct {
 	Failures      int
 	SuccessRate   float64
 	AvgDuration   float64
+	CostUSD       float64 // Cost of all recorded attempts (v3.6+)
 }
 
 // getStatistics queries the database and calculates statistics
 func getStatistics(store *learning.Store, planFile string) (*Statistics, error) {
 	stats := &Statistics{
 		AgentPerformance: make(map[string]*AgentStats),
+		ModelPerformance: make(map[string]*ModelStats),
 		TaskMetrics:      make(map[string]*TaskStats),
 		CommonFailures:   make(map[string]int),
 	}
 
 	// Query all executions for this plan
-	query := `SELECT task_number, task_name, agent, success, output, error_message, duration_seconds
+	query := `SELECT task_number, task_name, agent, success, output, error_message, duration_seconds,
+		COALESCE(cost_usd, 0), COALESCE(input_tokens, 0) + COALESCE(output_tokens, 0) + COALESCE(cache_creation_tokens, 0) + COALESCE(cache_read_tokens, 0),
+		COALESCE(model, '')
 		FROM task_executions
 		WHERE plan_file = ?
 		ORDER BY id DESC`
@@ -154,17 +172,20 @@ func getStatistics(store *learning.Store, planFile string) (*Statistics, error)
 	defer rows.Close()
 
 	for rows.Next() {
-		var taskNumber, taskName, agent, output, errorMsg string
+		var taskNumber, taskName, agent, output, errorMsg, model string
 		var success bool
-		var duration int64
+		var duration, tokens int64
+		var cost float64
 
-		if err := rows.Scan(&taskNumber, &taskName, &agent, &success, &output, &errorMsg, &duration); err != nil {
+		if err := rows.Scan(&taskNumber, &taskName, &agent, &success, &output, &errorMsg, &duration, &cost, &tokens, &model); err != nil {
 			return nil, fmt.Errorf("scan row: %w", err)
 		}
 
 		// Update overall stats
 		stats.TotalExecutions++
 		stats.TotalDurationSecs += duration
+		stats.TotalCostUSD += cost
+		stats.TotalTokens += tokens
 		if success {
 			stats.SuccessfulExecs++
 		} else {
@@ -189,6 +210,20 @@ func getStatistics(store *learning.Store, planFile string) (*Statistics, error)
 			agentStats.AvgDuration = ((agentStats.AvgDuration * float64(agentStats.TotalExecs-1)) + float64(duration)) / float64(agentStats.TotalExecs)
 		}
 
+		// Update model routing outcomes
+		if model != "" {
+			modelStats, exists := stats.ModelPerformance[model]
+			if !exists {
+				modelStats = &ModelStats{Name: model}
+				stats.ModelPerformance[model] = modelStats
+			}
+			modelStats.TotalExecs++
+			modelStats.CostUSD += cost
+			if success {
+				modelStats.Successes++
+			}
+		}
+
 		// Update task metrics
 		taskStats, exists := stats.TaskMetrics[taskNumber]
 		if !exists {
@@ -199,6 +234,7 @@ func getStatistics(store *learning.Store, planFile string) (*Statistics, error)
 			stats.TaskMetrics[taskNumber] = taskStats
 		}
 		taskStats.TotalAttempts++
+		taskStats.CostUSD += cost
 		if success {
 			taskStats.Successes++
 		} else {
@@ -224,6 +260,13 @@ func getStatistics(store *learning.Store, planFile string) (*Statistics, error)
 		}
 	}
 
+	// Calculate model success rates
+	for _, modelStats := range stats.ModelPerformance {
+		if modelStats.TotalExecs > 0 {
+			modelStats.SuccessRate = (float64(modelStats.Successes) / float64(modelStats.TotalExecs)) * 100
+		}
+	}
+
 	// Calculate task success rates
 	for _, taskStats := range stats.TaskMetrics {
 		if taskStats.TotalAttempts > 0 {
@@ -285,6 +328,9 @@ func printStatistics(w io.Writer, stats *Statistics, planFile string) {
 		red.Fprintf(w, "%.1f%%\n", stats.SuccessRate)
 	}
 	fmt.Fprintf(w, "  Average duration: %.1f seconds\n", stats.AverageDuration)
+	if stats.TotalCostUSD > 0 || stats.TotalTokens > 0 {
+		fmt.Fprintf(w, "  Total cost: $%.4f (%d tokens)\n", stats.TotalCostUSD, stats.TotalTokens)
+	}
 
 	// Agent Performance
 	if len(stats.AgentPerformance) > 0 {
@@ -316,6 +362,27 @@ func printStatistics(w io.Writer, stats *Statistics, planFile string) {
 		}
 	}
 
+	// Model Performance (v3.6+)
+	if len(stats.ModelPerformance) > 0 {
+		fmt.Fprintf(w, "\n")
+		cyan.Fprintf(w, "Model Performance:\n")
+
+		modelNames := make([]string, 0, len(stats.ModelPerformance))
+		for name := range stats.ModelPerformance {
+			modelNames = append(modelNames, name)
+		}
+		sort.Strings(modelNames)
+
+		for _, name := range modelNames {
+			modelStats := stats.ModelPerformance[name]
+			fmt.Fprintf(w, "  %s: %.1f%% success (%d/%d)", name, modelStats.SuccessRate, modelStats.Successes, modelStats.TotalExecs)
+			if modelStats.CostUSD > 0 {
+				fmt.Fprintf(w, ", $%.4f", modelStats.CostUSD)
+			}
+			fmt.Fprintf(w, "\n")
+		}
+	}
+
 	// Task Metrics
 	if len(stats.TaskMetrics) > 0 {
 		fmt.Fprintf(w, "\n")
@@ -343,6 +410,9 @@ func printStatistics(w io.Writer, stats *Statistics, planFile string) {
 			}
 			fmt.Fprintf(w, " (%d/%d)\n", taskStats.Successes, taskStats.TotalAttempts)
 			fmt.Fprintf(w, "    Avg duration: %.1f seconds\n", taskStats.AvgDuration)
+			if taskStats.CostUSD > 0 {
+				fmt.Fprintf(w, "    Cost: $%.4f\n", taskStats.CostUSD)
+			}
 		}
 	}
 
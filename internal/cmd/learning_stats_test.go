@@ -578,3 +578,60 @@ func TestStatsCommand_AverageDuration(t *testing.T) {
 		t.Log("Expected average duration in output (optional)")
 	}
 }
+
+func TestStatsCommand_ModelPerformance(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := "model-plan.md"
+	dbPath := filepath.Join(tmpDir, "executions.db")
+
+	store, err := learning.NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	records := []struct {
+		model   string
+		success bool
+	}{
+		{"haiku", false},
+		{"sonnet", true},
+		{"sonnet", true},
+		{"", true}, // routing off - not listed
+	}
+	for i, r := range records {
+		exec := &learning.TaskExecution{
+			PlanFile:   planFile,
+			RunNumber:  i + 1,
+			TaskNumber: "1",
+			TaskName:   "Routed Task",
+			Prompt:     "Do task",
+			Success:    r.success,
+			Model:      r.model,
+		}
+		if err := store.RecordExecution(context.Background(), exec); err != nil {
+			t.Fatalf("Failed to record execution: %v", err)
+		}
+	}
+
+	planPath := filepath.Join(tmpDir, planFile)
+	if err := os.WriteFile(planPath, []byte("# Model Plan"), 0644); err != nil {
+		t.Fatalf("Failed to create plan file: %v", err)
+	}
+
+	cmd := NewStatsCommand()
+	cmd.SetArgs([]string{planPath, "--db-path", dbPath})
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	outputStr := output.String()
+	for _, want := range []string{"Model Performance:", "haiku: 0.0% success (0/1)", "sonnet: 100.0% success (2/2)"} {
+		if !strings.Contains(outputStr, want) {
+			t.Errorf("expected %q in output:\n%s", want, outputStr)
+		}
+	}
+}
@@ -43,6 +43,10 @@ and orchestrates parallel execution of tasks across multiple agents.`,
 	cmd.AddCommand(NewLearningCommand())
 	cmd.AddCommand(NewObserveCommand())
 	cmd.AddCommand(NewBudgetCommand())
+	cmd.AddCommand(NewCtlCommand())
+	cmd.AddCommand(NewExplainCommand())
+	cmd.AddCommand(NewApproveCommand())
+	cmd.AddCommand(NewRejectCommand())
 
 	return cmd
 }
@@ -107,14 +107,14 @@ func TestLearningCommand_SubcommandsRegistered(t *testing.T) {
 		t.Fatal("Learning command should be registered with root command")
 	}
 
-	// Verify all 4 subcommands are registered
+	// Verify all 6 subcommands are registered
 	subcommands := learningCmd.Commands()
-	if len(subcommands) != 4 {
-		t.Errorf("Expected 4 subcommands, got %d", len(subcommands))
+	if len(subcommands) != 6 {
+		t.Errorf("Expected 6 subcommands, got %d", len(subcommands))
 	}
 
 	// Verify specific subcommands exist
-	expectedSubcommands := []string{"stats", "show", "clear", "export"}
+	expectedSubcommands := []string{"stats", "qc-stats", "qc-override", "show", "clear", "export"}
 	for _, expectedName := range expectedSubcommands {
 		found := false
 		for _, subcmd := range subcommands {
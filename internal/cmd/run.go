@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -11,20 +12,27 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/approval"
 	"github.com/harrison/conductor/internal/architecture"
 	"github.com/harrison/conductor/internal/budget"
 	"github.com/harrison/conductor/internal/claude"
 	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/control"
 	"github.com/harrison/conductor/internal/display"
 	"github.com/harrison/conductor/internal/estimation"
 	"github.com/harrison/conductor/internal/executor"
 	"github.com/harrison/conductor/internal/learning"
 	"github.com/harrison/conductor/internal/logger"
 	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/notify"
 	"github.com/harrison/conductor/internal/parser"
 	"github.com/harrison/conductor/internal/pattern"
+	"github.com/harrison/conductor/internal/prompts"
+	"github.com/harrison/conductor/internal/replay"
+	"github.com/harrison/conductor/internal/report"
 	"github.com/harrison/conductor/internal/similarity"
 	"github.com/harrison/conductor/internal/tts"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -63,6 +71,12 @@ func (l *consoleLogger) LogSummary(result models.ExecutionResult) {
 	fmt.Fprintf(l.writer, "  Total tasks: %d\n", result.TotalTasks)
 	fmt.Fprintf(l.writer, "  Completed: %d\n", result.Completed)
 	fmt.Fprintf(l.writer, "  Failed: %d\n", result.Failed)
+	if result.Blocked > 0 {
+		fmt.Fprintf(l.writer, "  Blocked: %d\n", result.Blocked)
+	}
+	if result.Skipped > 0 {
+		fmt.Fprintf(l.writer, "  Skipped: %d\n", result.Skipped)
+	}
 	fmt.Fprintf(l.writer, "  Total duration: %s\n", result.Duration.Round(time.Second))
 
 	if len(result.FailedTasks) > 0 {
@@ -113,7 +127,8 @@ Examples:
   conductor run --log-dir ./logs plan.md   # Use custom log directory
   conductor run --config custom.yaml plan.md  # Use custom config file
   conductor run --skip-completed plan.md   # Skip already completed tasks
-  conductor run --retry-failed plan.md     # Retry failed tasks`,
+  conductor run --retry-failed plan.md     # Retry failed tasks
+  conductor run --keep-going plan.md       # Block only dependents of failed tasks`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: runCommand,
 	}
@@ -129,6 +144,7 @@ Examples:
 	cmd.Flags().Bool("no-skip-completed", false, "Do not skip completed tasks (overrides config)")
 	cmd.Flags().Bool("retry-failed", false, "Retry tasks that failed")
 	cmd.Flags().Bool("no-retry-failed", false, "Do not retry failed tasks (overrides config)")
+	cmd.Flags().Bool("keep-going", false, "Continue after a task fails, blocking only its dependent tasks")
 
 	// Multi-agent QC flags (v2.2+)
 	cmd.Flags().String("qc-agents", "", "Override QC agents (comma-separated, e.g., golang-pro,code-reviewer)")
@@ -144,6 +160,13 @@ Examples:
 	// Single task execution flag
 	cmd.Flags().String("task", "", "Run only the specified task number")
 
+	// Record/replay flags (v3.6+)
+	cmd.Flags().String("record", "", "Record every agent, QC and Claude CLI invocation to this directory")
+	cmd.Flags().String("replay", "", "Replay invocations recorded with --record from this directory (no CLI calls)")
+
+	// CI report flag (v3.6+)
+	cmd.Flags().StringArray("report", nil, "Write a report after the run: junit=PATH or sarif=PATH (repeatable)")
+
 	return cmd
 }
 
@@ -292,11 +315,44 @@ func runCommand(cmd *cobra.Command, args []string) error {
 		cfg.Executor.EnforceDocTargets = false
 	}
 
+	// Keep-going policy (v3.6+)
+	if keepGoing, _ := cmd.Flags().GetBool("keep-going"); keepGoing {
+		cfg.Executor.KeepGoing = true
+	}
+
 	// Validate merged configuration
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Record/replay store (v3.6+)
+	recordDir, _ := cmd.Flags().GetString("record")
+	replayDir, _ := cmd.Flags().GetString("replay")
+	if recordDir != "" && replayDir != "" {
+		return fmt.Errorf("cannot use both --record and --replay")
+	}
+	var replayStore *replay.Store
+	if recordDir != "" {
+		store, err := replay.NewStore(recordDir, replay.ModeRecord)
+		if err != nil {
+			return err
+		}
+		replayStore = store
+	} else if replayDir != "" {
+		store, err := replay.NewStore(replayDir, replay.ModeReplay)
+		if err != nil {
+			return err
+		}
+		replayStore = store
+	}
+
+	// CI reports (v3.6+)
+	reportFlags, _ := cmd.Flags().GetStringArray("report")
+	reportSpecs, err := report.ParseSpecs(reportFlags)
+	if err != nil {
+		return err
+	}
+
 	// Initialize learning store if enabled
 	var learningStore *learning.Store
 	if cfg.Learning.Enabled {
@@ -452,23 +508,7 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	}
 
 	// Merge config QC settings into plan if plan doesn't explicitly set QC
-	// Configuration priority: plan frontmatter (explicit) > config file > defaults
-	// This ensures plans without QC frontmatter get sensible defaults from config
-	if !plan.QualityControl.Enabled && cfg.QualityControl.Enabled {
-		plan.QualityControl = models.QualityControlConfig{
-			Enabled: cfg.QualityControl.Enabled,
-			Agents: models.QCAgentConfig{
-				Mode:              cfg.QualityControl.Agents.Mode,
-				ExplicitList:      cfg.QualityControl.Agents.ExplicitList,
-				AdditionalAgents:  cfg.QualityControl.Agents.AdditionalAgents,
-				BlockedAgents:     cfg.QualityControl.Agents.BlockedAgents,
-				MaxAgents:         cfg.QualityControl.Agents.MaxAgents,
-				CacheTTLSeconds:   cfg.QualityControl.Agents.CacheTTLSeconds,
-				RequireCodeReview: cfg.QualityControl.Agents.RequireCodeReview,
-			},
-			RetryOnRed: cfg.QualityControl.RetryOnRed,
-		}
-	}
+	applyConfigQC(plan, cfg)
 
 	// Apply retry_on_red fallback logic: plan value -> default 2
 	// This ensures plans without explicit retry_on_red get sensible defaults
@@ -502,6 +542,23 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	if maxConcurrency > 0 {
 		fmt.Fprintf(cmd.OutOrStdout(), "  Max concurrency: %d\n", maxConcurrency)
 	}
+	if cfg.Executor.Scheduler == config.SchedulerModeDependency {
+		fmt.Fprintf(cmd.OutOrStdout(), "  Scheduler: dependency (tasks start when dependencies pass)\n")
+	}
+	if cfg.Executor.KeepGoing {
+		fmt.Fprintf(cmd.OutOrStdout(), "  Keep going: enabled (failures block only dependent tasks)\n")
+	}
+	if cfg.Backends.Default != "" && cfg.Backends.Default != string(config.BackendTypeClaude) {
+		fmt.Fprintf(cmd.OutOrStdout(), "  Agent backend: %s\n", cfg.Backends.Default)
+	}
+	if cfg.Executor.Isolation == config.IsolationModeWorktree {
+		fmt.Fprintf(cmd.OutOrStdout(), "  Isolation: git worktree per task (%s)\n", cfg.Executor.WorktreeDir)
+	}
+	if replayStore.Recording() {
+		fmt.Fprintf(cmd.OutOrStdout(), "  Recording invocations: %s\n", replayStore.Dir())
+	} else if replayStore.Replaying() {
+		fmt.Fprintf(cmd.OutOrStdout(), "  Replaying invocations: %s (no CLI calls)\n", replayStore.Dir())
+	}
 	if configPath != "" {
 		fmt.Fprintf(cmd.OutOrStdout(), "  Config: %s\n", configPath)
 	}
@@ -574,9 +631,17 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	}
 	defer fileLog.Close()
 
-	// Create multi-logger that writes to both console and file
+	// Create structured event stream alongside the text log (v3.6+)
+	eventLog, err := logger.NewEventLogger(fileLog.LogDir(), fileLog.RunID())
+	if err != nil {
+		return fmt.Errorf("failed to create event logger: %w", err)
+	}
+	defer eventLog.Close()
+	eventLog.LogRunStart(Version)
+
+	// Create multi-logger that writes to console, file and event stream
 	multiLog := &multiLogger{
-		loggers: []executor.Logger{consoleLog, fileLog},
+		loggers: []executor.Logger{consoleLog, fileLog, eventLog},
 	}
 
 	// Wire optional TTS logger if enabled and available
@@ -590,6 +655,17 @@ func runCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Wire webhook notifications if configured (v3.6+)
+	if len(cfg.Notifications.Webhooks) > 0 {
+		notifier, err := notify.NewNotifier(cfg.Notifications, cfg.Timeouts.HTTP)
+		if err != nil {
+			return fmt.Errorf("failed to configure notifications: %w", err)
+		}
+		defer notifier.Close()
+		notifier.Plan = planFile
+		multiLog.loggers = append(multiLog.loggers, notifier)
+	}
+
 	// Create shared Claude CLI invoker for all components (v3.1+)
 	// This centralizes Claude CLI configuration across:
 	// SetupIntrospector, ClaudeSimilarity, ClaudeEnhancer, IntelligentSelector,
@@ -598,6 +674,7 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	claudeInvoker := claude.NewInvoker()
 	claudeInvoker.Timeout = cfg.Timeouts.LLM
 	claudeInvoker.Logger = multiLog
+	claudeInvoker.Replay = replayStore
 
 	// Create agent registry for agent discovery
 	agentRegistry := agent.NewRegistry("")
@@ -627,19 +704,68 @@ func runCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Local checkers listed as QC reviewers must be defined (v3.6+)
+	qcCheckers, err := executor.NewQCCheckers(cfg.QualityControl.Checkers)
+	if err != nil {
+		return fmt.Errorf("invalid quality_control.checkers: %w", err)
+	}
+	if plan.QualityControl.Enabled {
+		reviewers := append(append([]string{}, plan.QualityControl.Agents.ExplicitList...), plan.QualityControl.Agents.AdditionalAgents...)
+		if unknown := executor.UnknownQCCheckers(reviewers, qcCheckers); len(unknown) > 0 {
+			return fmt.Errorf("cannot execute: unknown QC checker(s) %s; define them under quality_control.checkers", strings.Join(unknown, ", "))
+		}
+	}
+
 	fmt.Fprintf(cmd.OutOrStdout(), "✓ Agent validation passed\n\n")
 
-	// Create invoker for Claude CLI calls WITH registry
-	invoker := agent.NewInvokerWithRegistry(agentRegistry)
+	// Load project prompt template overrides from .conductor/templates (v3.6+)
+	promptTemplates, err := prompts.Load(prompts.DefaultDir)
+	if err != nil {
+		return fmt.Errorf("failed to load prompt templates: %w", err)
+	}
+	prompts.SetActive(promptTemplates)
+	if len(promptTemplates.Overridden) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "Using custom prompt templates: %s\n", strings.Join(promptTemplates.Overridden, ", "))
+	}
+
+	// Create agent invoker WITH registry (v3.6+: routed per task/agent across backends)
+	// The built-in "claude" backend is the default; backends config can route
+	// agents or tasks to command-template or fake backends instead
+	invoker, err := buildBackendRouter(cfg.Backends, agentRegistry, cfg.Executor.StreamOutput, cfg.Executor.MaxGeneratedTasks > 0)
+	if err != nil {
+		return fmt.Errorf("failed to configure agent backends: %w", err)
+	}
+	if backendErrors := invoker.ValidateTasks(plan.Tasks); len(backendErrors) > 0 {
+		fmt.Fprintf(cmd.OutOrStderr(), "Backend validation failed:\n")
+		for _, err := range backendErrors {
+			fmt.Fprintf(cmd.OutOrStderr(), "  • %s\n", err.Error())
+		}
+		return fmt.Errorf("cannot execute: %d task(s) reference unknown backends", len(backendErrors))
+	}
+
+	// Helper Claude calls use the default backend too (v3.6+)
+	routeHelperCalls(cfg.Backends, invoker, claudeInvoker)
+
+	// Record or replay agent and QC invocations (v3.6+)
+	var taskInvoker executor.InvokerInterface = invoker
+	if replayStore != nil {
+		taskInvoker = agent.NewReplayBackend(invoker, replayStore)
+	}
 
 	// Create quality controller with learning integration
-	qc := executor.NewQualityController(invoker)
+	qc := executor.NewQualityController(taskInvoker)
 	qc.Registry = agentRegistry                 // Wire registry for agent verification
 	qc.LearningStore = learningStore            // Enable historical context loading
 	qc.AgentConfig = plan.QualityControl.Agents // Apply plan-level QC agent configuration
 	qc.Logger = multiLog                        // Wire logger for QC events
 	qc.LLMTimeout = cfg.Timeouts.LLM            // Wire timeouts.llm for intelligent selection
 	qc.ClaudeInvoker = claudeInvoker            // Shared invoker for IntelligentSelector (v3.1+)
+	qc.SelectionModel = cfg.ModelRouting.Internal.QCSelection
+	qc.Aggregation = cfg.QualityControl.Aggregation // Multi-agent verdict aggregation (v3.6+)
+	qc.Checkers = qcCheckers                        // Local checker reviewers (v3.6+)
+	qc.CheckerGate = cfg.QualityControl.CheckerGate
+	qc.DiffReview = cfg.QualityControl.DiffReview   // Review the task's git diff (v3.6+)
+	qc.Calibration = cfg.QualityControl.Calibration // Calibration-aware agent selection (v3.6+)
 
 	// Create task executor config
 	taskExecCfg := executor.TaskExecutorConfig{
@@ -649,7 +775,7 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create task executor with QC and updater
-	taskExec, err := executor.NewTaskExecutor(invoker, qc, nil, taskExecCfg)
+	taskExec, err := executor.NewTaskExecutor(taskInvoker, qc, nil, taskExecCfg)
 	if err != nil {
 		return fmt.Errorf("failed to create task executor: %w", err)
 	}
@@ -665,12 +791,82 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	taskExec.Logger = consoleLog    // Runtime enforcement logging
 	taskExec.EventLogger = multiLog // Event logging (TTS agent announcements, etc.)
 
+	// Wire persistent QC result cache (v3.6+, opt-in, stored in the learning DB)
+	if cfg.QualityControl.ResultCache.Enabled {
+		if learningStore != nil {
+			ttl := cfg.QualityControl.ResultCache.TTL
+			if ttl > 0 {
+				// Expired verdicts are never reused, so drop them up front
+				_, _ = learningStore.DeleteQCResults(context.Background(), time.Now().Add(-ttl))
+			}
+			taskExec.QCResultCache = executor.NewQCResultCache(learningStore, ttl)
+		} else {
+			fmt.Fprintf(cmd.OutOrStderr(), "Warning: quality_control.result_cache requires learning to be enabled; QC results will not be cached\n")
+		}
+	}
+
 	// Wire runtime enforcement flags (v2.9+)
 	taskExec.EnforceTestCommands = cfg.Executor.EnforceTestCommands
 	taskExec.VerifyCriteria = cfg.Executor.VerifyCriteria
 	taskExec.EnableErrorPatternDetection = cfg.Executor.EnableErrorPatternDetection
 	taskExec.EnableClaudeClassification = cfg.Executor.EnableClaudeClassification
 
+	// Wire the stall watchdog for streaming agents (v3.6+)
+	// Only streamed events count as heartbeats, so it needs stream_output
+	if cfg.Executor.StreamOutput {
+		taskExec.StallTimeout = cfg.Executor.StallTimeout
+		taskExec.StallRetries = cfg.Executor.StallRetries
+	}
+
+	// Wire model routing (v3.6+)
+	// Picks haiku/sonnet/opus per task from its signals and escalates on RED before agent swaps
+	if cfg.ModelRouting.Enabled {
+		taskExec.ModelRouter = executor.NewModelRouter(cfg.ModelRouting, nil)
+		if learningStore != nil {
+			// Past failure rates need the learning database
+			taskExec.ModelRouter.Store = learningStore
+		}
+	}
+
+	// Load session history for the risk gate and agent rankings (v3.6+)
+	// Behavioral failure prediction and agent scoring need the learning database
+	var behavioralHistory *executor.BehavioralHistory
+	if learningStore != nil {
+		history, err := executor.LoadBehavioralHistory(context.Background(), learningStore, cfg.RiskGate.HistoryLimit)
+		if err != nil {
+			consoleLog.Warnf("Session history unavailable, risk gate and agent rankings disabled: %v", err)
+		} else {
+			behavioralHistory = history
+		}
+	}
+
+	// Wire the pre-task risk gate (v3.6+)
+	// High-risk tasks get a stronger agent, an extra QC reviewer or an approval pause
+	if riskGate := executor.NewRiskGate(cfg.RiskGate, behavioralHistory); riskGate != nil {
+		riskGate.Registry = agentRegistry
+		if isatty.IsTerminal(os.Stdin.Fd()) {
+			riskGate.Approver = executor.NewTerminalApprover(os.Stdin, cmd.OutOrStdout())
+		}
+		taskExec.RiskGate = riskGate
+	}
+
+	// Wire approval gates (v3.6+)
+	// type: approval tasks wait for conductor approve/reject; requests survive restarts
+	if planHasApprovalGates(plan) {
+		taskExec.ApprovalGate = executor.NewApprovalGate(approval.NewStore(approval.DefaultDir))
+	}
+
+	// Wire conditional execution (v3.6+)
+	// Tasks with a when: expression are evaluated at launch and SKIPPED when it is false
+	if planHasConditions(plan) {
+		taskExec.Conditions = executor.NewTaskConditions()
+	}
+
+	// Prompt context budget (v3.6+)
+	if cfg.Executor.ContextBudget.MaxTokens > 0 {
+		taskExec.ContextBudget = &cfg.Executor.ContextBudget
+	}
+
 	// Wire budget/rate limit handling (v2.20+)
 	if cfg.Budget.Enabled {
 		taskExec.BudgetConfig = &cfg.Budget
@@ -685,44 +881,22 @@ func runCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Create shared ClaudeSimilarity for semantic matching (v2.32+)
-	// This instance is shared by Pattern Intelligence and Warm-Up Provider
-	// for consistent configuration and rate limit handling across both systems.
-	// Uses shared claudeInvoker for consistent configuration (v3.1+)
-	var claudeSim *similarity.ClaudeSimilarity
-	if cfg.Pattern.Enabled || cfg.Learning.Enabled {
-		claudeSim = similarity.NewClaudeSimilarityWithInvoker(claudeInvoker)
-	}
-
-	// Wire Pattern Intelligence (v2.24+) with shared ClaudeSimilarity
-	if cfg.Pattern.Enabled && claudeSim != nil {
-		pi := pattern.NewPatternIntelligence(&cfg.Pattern, learningStore, claudeSim, cfg.Timeouts.Search)
-		if pi != nil {
-			// Set up LLM enhancement if enabled
-			// Uses shared claudeInvoker for consistent configuration (v3.1+)
-			if cfg.Pattern.LLMEnhancementEnabled {
-				enhancer := pattern.NewClaudeEnhancerWithInvoker(claudeInvoker)
-				if impl, ok := pi.(*pattern.PatternIntelligenceImpl); ok {
-					impl.SetEnhancer(enhancer)
-				}
-			}
-			taskExec.PatternHook = executor.NewPatternIntelligenceHook(pi, &cfg.Pattern, consoleLog)
+	// Wire spend tracking and caps (v3.6+)
+	// Usage is always accumulated, including helper Claude calls; the caps only apply
+	// when budget is enabled. Run cap exits save state through the same StateManager
+	// as rate limit exits
+	taskExec.Spend = executor.NewSpendTracker(0, 0)
+	taskExec.Spend.Helpers = claudeInvoker
+	if cfg.Budget.Enabled && (cfg.Budget.MaxRunUSD > 0 || cfg.Budget.MaxTaskUSD > 0) {
+		taskExec.Spend.MaxRunUSD = cfg.Budget.MaxRunUSD
+		taskExec.Spend.MaxTaskUSD = cfg.Budget.MaxTaskUSD
+		if taskExec.StateManager == nil {
+			taskExec.StateManager = budget.NewStateManager(".conductor/state")
 		}
 	}
 
-	// Wire Warm-Up Provider (v2.32+) with shared ClaudeSimilarity
-	// WarmUpProvider primes agents with historical context from similar tasks
-	if cfg.Learning.Enabled && cfg.Learning.WarmUpEnabled && learningStore != nil {
-		warmUpProvider := learning.NewWarmUpProvider(learningStore, claudeSim)
-		taskExec.WarmUpHook = executor.NewWarmUpHook(warmUpProvider, consoleLog)
-	}
-
-	// Wire Architecture Checkpoint (v2.27+)
-	// Uses shared claudeInvoker for consistent configuration (v3.1+)
-	if cfg.Architecture.Enabled {
-		assessor := architecture.NewAssessorWithInvoker(claudeInvoker)
-		taskExec.ArchitectureHook = executor.NewArchitectureCheckpointHook(assessor, &cfg.Architecture, consoleLog)
-	}
+	// Wire the hooks that shape task prompts: pattern intelligence, warm-up and architecture checkpoint
+	claudeSim := wirePromptHooks(cfg, taskExec, learningStore, claudeInvoker, consoleLog)
 
 	// Wire Setup Introspector (v3.0+)
 	// SetupIntrospector uses Claude to analyze the project and determine pre-wave setup commands
@@ -730,6 +904,7 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	var setupHook *executor.SetupHook
 	if cfg.Setup.Enabled {
 		introspector := executor.NewSetupIntrospectorWithInvoker(claudeInvoker)
+		introspector.Model = cfg.ModelRouting.Internal.SetupIntrospection
 		setupHook = executor.NewSetupHook(introspector, consoleLog)
 	}
 
@@ -766,6 +941,14 @@ func runCommand(cmd *cobra.Command, args []string) error {
 		taskExec.RollbackHook = rollbackHook
 	}
 
+	// Wire worktree isolation (v3.6+)
+	// Each task runs in its own git worktree branched from the current run branch
+	// and is merged back when QC passes; merge conflicts fail the task
+	if cfg.Executor.Isolation == config.IsolationModeWorktree {
+		worktreeManager := executor.NewWorktreeManager(executor.NewGitCheckpointerWithWorkDir(&cfg.Rollback, ""), "", cfg.Executor.WorktreeDir)
+		taskExec.WorktreeHook = executor.NewWorktreeHook(worktreeManager, consoleLog)
+	}
+
 	// Initialize LOC tracker if enabled (v3.4+)
 	if cfg.Metrics.LOCTracking {
 		taskExec.LOCTrackerHook = executor.NewLOCTrackerHook(true, "", consoleLog)
@@ -774,6 +957,7 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	// Initialize human time estimation if enabled (v3.5+)
 	if cfg.Metrics.HumanEstimation {
 		estimator := estimation.NewEstimator(cfg.Timeouts.LLM, multiLog)
+		estimator.Model = cfg.ModelRouting.Internal.Estimation
 		taskExec.EstimationHook = executor.NewEstimationHook(true, estimator, consoleLog)
 	}
 
@@ -784,6 +968,7 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	// Uses shared claudeInvoker for consistent configuration (v3.1+)
 	if agentRegistry != nil && (cfg.Executor.IntelligentAgentSelection || plan.QualityControl.Agents.Mode == "intelligent") {
 		taskExec.TaskAgentSelector = executor.NewTaskAgentSelectorWithInvoker(agentRegistry, claudeInvoker)
+		taskExec.TaskAgentSelector.AgentRankings = behavioralHistory.AgentRankings()
 		taskExec.IntelligentAgentSelection = true
 	}
 
@@ -797,11 +982,38 @@ func runCommand(cmd *cobra.Command, args []string) error {
 			nil, // LIP store not yet implemented
 			claudeInvoker,
 		)
+		taskExec.IntelligentAgentSwapper.AgentRankings = behavioralHistory.AgentRankings()
 	}
 
 	// Create wave executor with task executor and config
 	waveExec := executor.NewWaveExecutorWithPackageGuard(taskExec, multiLog, cfg.SkipCompleted, cfg.RetryFailed, cfg.Executor.EnforcePackageGuard)
 
+	// Select scheduling strategy (v3.6+): wave barriers (default) or dependency-driven
+	waveExec.SetSchedulerMode(cfg.Executor.Scheduler)
+
+	// Keep-going policy (v3.6+): failures only block their downstream tasks
+	waveExec.SetKeepGoing(cfg.Executor.KeepGoing)
+
+	// Agent subtasks (v3.6+): let agents add subtasks to the running plan
+	if cfg.Executor.MaxGeneratedTasks > 0 {
+		subtaskPlanner := executor.NewSubtaskPlanner(cfg.Executor.MaxGeneratedTasks)
+		subtaskPlanner.Logger = consoleLog
+		waveExec.SetSubtaskPlanner(subtaskPlanner)
+	}
+
+	// Live run control (v3.6+): serve the control socket for `conductor ctl`
+	if cfg.Executor.ControlSocket != "" {
+		runController := executor.NewRunController(multiLog)
+		controlServer := control.NewServer(cfg.Executor.ControlSocket, runController)
+		if err := controlServer.Start(); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: run control disabled: %v\n", err)
+		} else {
+			defer controlServer.Close()
+			waveExec.SetRunController(runController)
+			fmt.Fprintf(cmd.OutOrStdout(), "Run control: %s (use 'conductor ctl')\n", controlServer.Path())
+		}
+	}
+
 	// Create orchestrator with learning integration
 	orch := executor.NewOrchestratorFromConfig(executor.OrchestratorConfig{
 		WaveExecutor:    waveExec,
@@ -836,14 +1048,33 @@ func runCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Write CI reports even when tasks failed (v3.6+)
+	if result != nil {
+		for _, spec := range reportSpecs {
+			if reportErr := report.WriteFile(spec, result, Version); reportErr != nil {
+				fmt.Fprintf(cmd.OutOrStderr(), "Warning: %v\n", reportErr)
+				continue
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s report written to: %s\n", strings.ToUpper(spec.Format), spec.Path)
+		}
+	}
+
 	// Check for errors
 	if err != nil {
+		var spendCap *executor.ErrSpendCapExceeded
+		if errors.As(err, &spendCap) && spendCap.Scope == executor.SpendScopeRun && spendCap.StateID != "" {
+			fmt.Fprintf(cmd.OutOrStderr(), "Run paused at its spend cap. Raise budget.max_run_usd, then run: conductor budget resume %s\n", spendCap.StateID)
+		}
 		return fmt.Errorf("execution failed: %w", err)
 	}
 
 	// Display completion message
 	if result.Failed > 0 {
-		fmt.Fprintf(cmd.OutOrStdout(), "\nExecution completed with %d failed task(s).\n", result.Failed)
+		if result.Blocked > 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "\nExecution completed with %d failed task(s) and %d blocked task(s).\n", result.Failed, result.Blocked)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "\nExecution completed with %d failed task(s).\n", result.Failed)
+		}
 		return fmt.Errorf("%d task(s) failed", result.Failed)
 	}
 
@@ -857,6 +1088,94 @@ func runCommand(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// planHasApprovalGates reports whether any task in plan is an approval gate.
+func planHasApprovalGates(plan *models.Plan) bool {
+	for i := range plan.Tasks {
+		if plan.Tasks[i].IsApproval() {
+			return true
+		}
+	}
+	return false
+}
+
+// planHasConditions reports whether any task in plan has a when: expression.
+func planHasConditions(plan *models.Plan) bool {
+	for i := range plan.Tasks {
+		if plan.Tasks[i].When != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyConfigQC merges config QC settings into plan if the plan doesn't explicitly set QC.
+// Configuration priority: plan frontmatter (explicit) > config file > defaults
+// This ensures plans without QC frontmatter get sensible defaults from config
+func applyConfigQC(plan *models.Plan, cfg *config.Config) {
+	if !plan.QualityControl.Enabled && cfg.QualityControl.Enabled {
+		plan.QualityControl = models.QualityControlConfig{
+			Enabled: cfg.QualityControl.Enabled,
+			Agents: models.QCAgentConfig{
+				Mode:              cfg.QualityControl.Agents.Mode,
+				ExplicitList:      cfg.QualityControl.Agents.ExplicitList,
+				AdditionalAgents:  cfg.QualityControl.Agents.AdditionalAgents,
+				BlockedAgents:     cfg.QualityControl.Agents.BlockedAgents,
+				MaxAgents:         cfg.QualityControl.Agents.MaxAgents,
+				CacheTTLSeconds:   cfg.QualityControl.Agents.CacheTTLSeconds,
+				RequireCodeReview: cfg.QualityControl.Agents.RequireCodeReview,
+			},
+			RetryOnRed: cfg.QualityControl.RetryOnRed,
+		}
+	}
+}
+
+// wirePromptHooks wires the pre-task hooks that inject context into task
+// prompts. Shared by run and explain so both assemble the same prompt.
+// Returns the shared ClaudeSimilarity, nil when pattern and learning are disabled.
+func wirePromptHooks(cfg *config.Config, taskExec *executor.DefaultTaskExecutor, learningStore *learning.Store, claudeInvoker *claude.Invoker, consoleLog executor.RuntimeEnforcementLogger) *similarity.ClaudeSimilarity {
+	// Create shared ClaudeSimilarity for semantic matching (v2.32+)
+	// This instance is shared by Pattern Intelligence and Warm-Up Provider
+	// for consistent configuration and rate limit handling across both systems.
+	// Uses shared claudeInvoker for consistent configuration (v3.1+)
+	var claudeSim *similarity.ClaudeSimilarity
+	if cfg.Pattern.Enabled || cfg.Learning.Enabled {
+		claudeSim = similarity.NewClaudeSimilarityWithInvoker(claudeInvoker)
+		claudeSim.Model = cfg.ModelRouting.Internal.Similarity
+	}
+
+	// Wire Pattern Intelligence (v2.24+) with shared ClaudeSimilarity
+	if cfg.Pattern.Enabled && claudeSim != nil {
+		pi := pattern.NewPatternIntelligence(&cfg.Pattern, learningStore, claudeSim, cfg.Timeouts.Search)
+		if pi != nil {
+			// Set up LLM enhancement if enabled
+			// Uses shared claudeInvoker for consistent configuration (v3.1+)
+			if cfg.Pattern.LLMEnhancementEnabled {
+				enhancer := pattern.NewClaudeEnhancerWithInvoker(claudeInvoker)
+				if impl, ok := pi.(*pattern.PatternIntelligenceImpl); ok {
+					impl.SetEnhancer(enhancer)
+				}
+			}
+			taskExec.PatternHook = executor.NewPatternIntelligenceHook(pi, &cfg.Pattern, consoleLog)
+		}
+	}
+
+	// Wire Warm-Up Provider (v2.32+) with shared ClaudeSimilarity
+	// WarmUpProvider primes agents with historical context from similar tasks
+	if cfg.Learning.Enabled && cfg.Learning.WarmUpEnabled && learningStore != nil {
+		warmUpProvider := learning.NewWarmUpProvider(learningStore, claudeSim)
+		taskExec.WarmUpHook = executor.NewWarmUpHook(warmUpProvider, consoleLog)
+	}
+
+	// Wire Architecture Checkpoint (v2.27+)
+	// Uses shared claudeInvoker for consistent configuration (v3.1+)
+	if cfg.Architecture.Enabled {
+		assessor := architecture.NewAssessorWithInvoker(claudeInvoker)
+		taskExec.ArchitectureHook = executor.NewArchitectureCheckpointHook(assessor, &cfg.Architecture, consoleLog)
+	}
+
+	return claudeSim
+}
+
 // multiLogger implements executor.Logger by delegating to multiple loggers
 type multiLogger struct {
 	loggers []executor.Logger
@@ -950,6 +1269,20 @@ func (ml *multiLogger) LogAnomaly(anomaly interface{}) {
 	}
 }
 
+// LogControlEvent forwards to all loggers
+func (ml *multiLogger) LogControlEvent(event models.ControlEvent) {
+	for _, logger := range ml.loggers {
+		logger.LogControlEvent(event)
+	}
+}
+
+// LogTaskActivity forwards to all loggers
+func (ml *multiLogger) LogTaskActivity(activity models.AgentActivity) {
+	for _, logger := range ml.loggers {
+		logger.LogTaskActivity(activity)
+	}
+}
+
 // LogBudgetStatus forwards to all loggers
 func (ml *multiLogger) LogBudgetStatus(status interface{}) {
 	for _, logger := range ml.loggers {
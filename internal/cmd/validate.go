@@ -557,7 +557,7 @@ func validateAgents(plan *models.Plan, registry *agent.Registry) []string {
 		// Check agents format (explicit_list)
 		if plan.QualityControl.Agents.Mode == "explicit" && len(plan.QualityControl.Agents.ExplicitList) > 0 {
 			for _, agentName := range plan.QualityControl.Agents.ExplicitList {
-				if !checkedAgents[agentName] {
+				if !checkedAgents[agentName] && !agent.IsQCChecker(agentName) {
 					if !registry.Exists(agentName) {
 						errors = append(errors, fmt.Sprintf("QC agent '%s' not found in registry", agentName))
 					}
@@ -569,7 +569,7 @@ func validateAgents(plan *models.Plan, registry *agent.Registry) []string {
 		// Check additional agents
 		if len(plan.QualityControl.Agents.AdditionalAgents) > 0 {
 			for _, agentName := range plan.QualityControl.Agents.AdditionalAgents {
-				if !checkedAgents[agentName] {
+				if !checkedAgents[agentName] && !agent.IsQCChecker(agentName) {
 					if !registry.Exists(agentName) {
 						errors = append(errors, fmt.Sprintf("Additional QC agent '%s' not found in registry", agentName))
 					}
@@ -0,0 +1,280 @@
+// Package condition parses and evaluates the `when:` expressions of plan
+// tasks (v3.6+).
+//
+// A task with a `when:` expression runs only if the expression is true when
+// the task is about to launch; otherwise it is reported as SKIPPED. The
+// language is deliberately small:
+//
+//	status("2") == "GREEN"            status of an upstream task in this run
+//	verdict("2") != "RED"             last QC verdict of an upstream task
+//	changed("db/schema/**")           a file changed by an upstream task matches
+//	changed("*.sql", "2")             ... restricted to the given tasks
+//	env("CI") == "true"               environment variable ("" when unset)
+//	probe("test -f openapi.yaml")     a shell command exits 0
+//
+// Expressions combine with ==, !=, !, &&, || and parentheses. Arguments are
+// string literals in double or single quotes; task numbers may be left
+// unquoted.
+package condition
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Env supplies the values an expression refers to.
+type Env interface {
+	// Status returns the result status of a task (GREEN, YELLOW, RED, FAILED,
+	// BLOCKED or SKIPPED), or "" if it has no result.
+	Status(task string) string
+	// Verdict returns the last QC verdict of a task, or "" if it was not reviewed.
+	Verdict(task string) string
+	// Changed reports whether a file changed by the given tasks, or by every
+	// upstream task when tasks is empty, matches pattern.
+	Changed(pattern string, tasks []string) bool
+	// Getenv returns an environment variable, or "" if it is unset.
+	Getenv(name string) string
+	// Probe runs a shell command and reports whether it exited 0.
+	Probe(ctx context.Context, command string) (bool, error)
+}
+
+// Expr is a parsed `when:` expression.
+type Expr struct {
+	src  string
+	root node
+}
+
+// Parse parses and type-checks an expression. The expression must be boolean.
+func Parse(src string) (*Expr, error) {
+	p := &parser{src: src}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", tok, tok.pos+1)
+	}
+	if root.kind() != kindBool {
+		return nil, fmt.Errorf("expression is a string, not a condition (compare it with == or !=)")
+	}
+	return &Expr{src: strings.TrimSpace(src), root: root}, nil
+}
+
+// String returns the expression as written in the plan.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression against env.
+func (e *Expr) Eval(ctx context.Context, env Env) (bool, error) {
+	v, err := e.root.eval(ctx, env)
+	if err != nil {
+		return false, err
+	}
+	return v.b, nil
+}
+
+// Tasks returns the task numbers the expression refers to, in order of
+// appearance and without duplicates.
+func (e *Expr) Tasks() []string {
+	seen := make(map[string]bool)
+	var tasks []string
+	walk(e.root, func(n node) {
+		c, ok := n.(*call)
+		if !ok {
+			return
+		}
+		for _, t := range c.taskArgs() {
+			if !seen[t] {
+				seen[t] = true
+				tasks = append(tasks, t)
+			}
+		}
+	})
+	return tasks
+}
+
+// Match reports whether a slash-separated file path matches pattern. Patterns
+// use path.Match syntax, and ** matches any number of directories. A pattern
+// without a slash matches the file name at any depth, as in .gitignore.
+func Match(pattern, name string) bool {
+	name = strings.TrimPrefix(path.Clean(strings.ReplaceAll(name, "\\", "/")), "./")
+	pattern = strings.TrimPrefix(pattern, "./")
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+type valueKind int
+
+const (
+	kindBool valueKind = iota
+	kindString
+)
+
+func (k valueKind) String() string {
+	if k == kindBool {
+		return "condition"
+	}
+	return "string"
+}
+
+type value struct {
+	b bool
+	s string
+}
+
+type node interface {
+	kind() valueKind
+	eval(ctx context.Context, env Env) (value, error)
+}
+
+func walk(n node, fn func(node)) {
+	fn(n)
+	switch n := n.(type) {
+	case *not:
+		walk(n.x, fn)
+	case *binary:
+		walk(n.left, fn)
+		walk(n.right, fn)
+	}
+}
+
+type literal struct {
+	k valueKind
+	v value
+}
+
+func (l *literal) kind() valueKind { return l.k }
+
+func (l *literal) eval(context.Context, Env) (value, error) { return l.v, nil }
+
+type not struct {
+	x node
+}
+
+func (n *not) kind() valueKind { return kindBool }
+
+func (n *not) eval(ctx context.Context, env Env) (value, error) {
+	v, err := n.x.eval(ctx, env)
+	return value{b: !v.b}, err
+}
+
+type binary struct {
+	op          string
+	left, right node
+}
+
+func (b *binary) kind() valueKind { return kindBool }
+
+func (b *binary) eval(ctx context.Context, env Env) (value, error) {
+	l, err := b.left.eval(ctx, env)
+	if err != nil {
+		return value{}, err
+	}
+	// && and || short-circuit, so a probe is only run when it matters
+	switch b.op {
+	case "&&":
+		if !l.b {
+			return value{}, nil
+		}
+	case "||":
+		if l.b {
+			return value{b: true}, nil
+		}
+	}
+	r, err := b.right.eval(ctx, env)
+	if err != nil {
+		return value{}, err
+	}
+	switch b.op {
+	case "==":
+		return value{b: l == r}, nil
+	case "!=":
+		return value{b: l != r}, nil
+	default:
+		return value{b: r.b}, nil
+	}
+}
+
+// function describes a built-in function.
+type function struct {
+	result   valueKind
+	minArgs  int
+	maxArgs  int // -1 for no limit
+	taskArgs int // Index of the first argument naming a task, -1 for none
+}
+
+var functions = map[string]function{
+	"status":  {result: kindString, minArgs: 1, maxArgs: 1, taskArgs: 0},
+	"verdict": {result: kindString, minArgs: 1, maxArgs: 1, taskArgs: 0},
+	"changed": {result: kindBool, minArgs: 1, maxArgs: -1, taskArgs: 1},
+	"env":     {result: kindString, minArgs: 1, maxArgs: 1, taskArgs: -1},
+	"probe":   {result: kindBool, minArgs: 1, maxArgs: 1, taskArgs: -1},
+}
+
+type call struct {
+	name string
+	args []string
+}
+
+func (c *call) kind() valueKind { return functions[c.name].result }
+
+func (c *call) taskArgs() []string {
+	first := functions[c.name].taskArgs
+	if first < 0 || first >= len(c.args) {
+		return nil
+	}
+	return c.args[first:]
+}
+
+func (c *call) eval(ctx context.Context, env Env) (value, error) {
+	switch c.name {
+	case "status":
+		return value{s: env.Status(c.args[0])}, nil
+	case "verdict":
+		return value{s: env.Verdict(c.args[0])}, nil
+	case "changed":
+		return value{b: env.Changed(c.args[0], c.taskArgs())}, nil
+	case "env":
+		return value{s: env.Getenv(c.args[0])}, nil
+	case "probe":
+		ok, err := env.Probe(ctx, c.args[0])
+		if err != nil {
+			return value{}, fmt.Errorf("probe(%q): %w", c.args[0], err)
+		}
+		return value{b: ok}, nil
+	}
+	return value{}, fmt.Errorf("unknown function %s", c.name)
+}
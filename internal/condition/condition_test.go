@@ -0,0 +1,182 @@
+package condition
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeEnv answers expressions from fixed data and records the probes it runs.
+type fakeEnv struct {
+	status  map[string]string
+	verdict map[string]string
+	changed map[string][]string // task -> files
+	env     map[string]string
+	probes  map[string]bool
+	ran     []string
+}
+
+func (f *fakeEnv) Status(task string) string  { return f.status[task] }
+func (f *fakeEnv) Verdict(task string) string { return f.verdict[task] }
+func (f *fakeEnv) Getenv(name string) string  { return f.env[name] }
+
+func (f *fakeEnv) Changed(pattern string, tasks []string) bool {
+	for task, files := range f.changed {
+		if len(tasks) > 0 && !contains(tasks, task) {
+			continue
+		}
+		for _, file := range files {
+			if Match(pattern, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *fakeEnv) Probe(ctx context.Context, command string) (bool, error) {
+	f.ran = append(f.ran, command)
+	ok, known := f.probes[command]
+	if !known {
+		return false, errors.New("command timed out")
+	}
+	return ok, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEval(t *testing.T) {
+	env := &fakeEnv{
+		status:  map[string]string{"1": "GREEN", "2": "YELLOW"},
+		verdict: map[string]string{"1": "GREEN", "2": "YELLOW"},
+		changed: map[string][]string{"1": {"db/schema/users.sql", "README.md"}, "2": {"api/handler.go"}},
+		env:     map[string]string{"CI": "true"},
+		probes:  map[string]bool{"test -f openapi.yaml": true, "false": false},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`status("1") == "GREEN"`, true},
+		{`status(2) == 'GREEN'`, false},
+		{`status("3") == ""`, true},
+		{`verdict("2") != "RED"`, true},
+		{`changed("db/schema/**")`, true},
+		{`changed("db/**/*.sql", "2")`, false},
+		{`changed("*.go")`, true},
+		{`changed("*.go", "1")`, false},
+		{`changed("db/*.sql")`, false},
+		{`env("CI") == "true" && env("DEPLOY") == ""`, true},
+		{`probe("test -f openapi.yaml")`, true},
+		{`!probe("false")`, true},
+		{`changed("api/**") && (status("1") == "RED" || status("2") == "YELLOW")`, true},
+		{`true && !false`, true},
+		{`probe("false") == false`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got, err := expr.Eval(context.Background(), env)
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEval_ShortCircuitsProbes(t *testing.T) {
+	env := &fakeEnv{status: map[string]string{"1": "GREEN"}, probes: map[string]bool{}}
+
+	expr, err := Parse(`status("1") == "GREEN" || probe("slow")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := expr.Eval(context.Background(), env); !ok || err != nil {
+		t.Errorf("Eval() = %v, %v", ok, err)
+	}
+	if len(env.ran) != 0 {
+		t.Errorf("probe should not run once the result is known, ran %v", env.ran)
+	}
+
+	expr, _ = Parse(`status("1") == "RED" || probe("slow")`)
+	if _, err := expr.Eval(context.Background(), env); err == nil || !strings.Contains(err.Error(), `probe("slow")`) {
+		t.Errorf("a failing probe should be an error, got %v", err)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{``, "empty expression"},
+		{`status("1")`, "is a string, not a condition"},
+		{`status("1") == GREEN`, `unknown name "GREEN"`},
+		{`status("1") && changed("x")`, "needs conditions on both sides"},
+		{`changed("x") == "true"`, "compares a condition with a string"},
+		{`!env("CI")`, "needs a condition"},
+		{`status("1", "2") == ""`, "takes 1 argument, got 2"},
+		{`changed() `, "takes at least 1 argument, got 0"},
+		{`upstream("1") == ""`, `unknown name "upstream"`},
+		{`(changed("x")`, `expected ")"`},
+		{`changed("x`, "unterminated string"},
+		{`changed("x") changed("y")`, "unexpected"},
+		{`changed("x") ; true`, `unexpected ';'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %v, want %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpr_Tasks(t *testing.T) {
+	expr, err := Parse(`status("2") == "GREEN" && (changed("db/**", "1", "2") || verdict(3) != "RED") && env("X") == "" && probe("true")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := expr.Tasks(), []string{"2", "1", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tasks() = %v, want %v", got, want)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"db/schema/**", "db/schema/users.sql", true},
+		{"db/schema/**", "db/schema/v2/users.sql", true},
+		{"db/**/*.sql", "db/users.sql", true},
+		{"db/*.sql", "db/schema/users.sql", false},
+		{"*.sql", "db/schema/users.sql", true},
+		{"schema.sql", "./db/schema.sql", true},
+		{"./api/*.go", "api/handler.go", true},
+		{"api/*.go", "internal/api/handler.go", false},
+		{"**/handler.go", "internal/api/handler.go", true},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
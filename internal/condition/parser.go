@@ -0,0 +1,239 @@
+package condition
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp // ( ) , ! == != && ||
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return fmt.Sprintf("string %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	next   int
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// tokenize splits the source into words, string literals and operators.
+func (p *parser) tokenize() error {
+	src := p.src
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			var text strings.Builder
+			j := i + 1
+			for ; j < len(src) && src[j] != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				text.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return fmt.Errorf("unterminated string at position %d", i+1)
+			}
+			p.tokens = append(p.tokens, token{kind: tokString, text: text.String(), pos: i})
+			i = j + 1
+		case c == '(' || c == ')' || c == ',':
+			p.tokens = append(p.tokens, token{kind: tokOp, text: string(c), pos: i})
+			i++
+		case strings.HasPrefix(src[i:], "==") || strings.HasPrefix(src[i:], "!=") ||
+			strings.HasPrefix(src[i:], "&&") || strings.HasPrefix(src[i:], "||"):
+			p.tokens = append(p.tokens, token{kind: tokOp, text: src[i : i+2], pos: i})
+			i += 2
+		case c == '!':
+			p.tokens = append(p.tokens, token{kind: tokOp, text: "!", pos: i})
+			i++
+		case isWordChar(c):
+			j := i
+			for j < len(src) && isWordChar(src[j]) {
+				j++
+			}
+			p.tokens = append(p.tokens, token{kind: tokWord, text: src[i:j], pos: i})
+			i = j
+		default:
+			return fmt.Errorf("unexpected %q at position %d", c, i+1)
+		}
+	}
+	return nil
+}
+
+func (p *parser) peek() token {
+	if p.next < len(p.tokens) {
+		return p.tokens[p.next]
+	}
+	return token{kind: tokEOF, pos: len(p.src)}
+}
+
+func (p *parser) take() token {
+	tok := p.peek()
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) isOp(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokOp && tok.text == text
+}
+
+func (p *parser) expect(text string) error {
+	if tok := p.take(); tok.kind != tokOp || tok.text != text {
+		return fmt.Errorf("expected %q, got %s at position %d", text, tok, tok.pos+1)
+	}
+	return nil
+}
+
+// parseOr parses a || b || ...
+func (p *parser) parseOr() (node, error) {
+	return p.parseLogical("||", p.parseAnd)
+}
+
+// parseAnd parses a && b && ...
+func (p *parser) parseAnd() (node, error) {
+	return p.parseLogical("&&", p.parseUnary)
+}
+
+func (p *parser) parseLogical(op string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp(op) {
+		tok := p.take()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.kind() != kindBool || right.kind() != kindBool {
+			return nil, fmt.Errorf("%s at position %d needs conditions on both sides, not strings", op, tok.pos+1)
+		}
+		left = &binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses !x and comparisons.
+func (p *parser) parseUnary() (node, error) {
+	if p.isOp("!") {
+		tok := p.take()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if x.kind() != kindBool {
+			return nil, fmt.Errorf("! at position %d needs a condition, not a string", tok.pos+1)
+		}
+		return &not{x: x}, nil
+	}
+
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOp("==") || p.isOp("!=") {
+		tok := p.take()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if left.kind() != right.kind() {
+			return nil, fmt.Errorf("%s at position %d compares a %s with a %s", tok.text, tok.pos+1, left.kind(), right.kind())
+		}
+		return &binary{op: tok.text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+// parsePrimary parses literals, function calls and parenthesized expressions.
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.take()
+	switch {
+	case tok.kind == tokOp && tok.text == "(":
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	case tok.kind == tokString:
+		return &literal{k: kindString, v: value{s: tok.text}}, nil
+	case tok.kind == tokWord && (tok.text == "true" || tok.text == "false"):
+		return &literal{k: kindBool, v: value{b: tok.text == "true"}}, nil
+	case tok.kind == tokWord:
+		if _, ok := functions[tok.text]; ok && p.isOp("(") {
+			return p.parseCall(tok)
+		}
+		return nil, fmt.Errorf("unknown name %q at position %d (functions: status, verdict, changed, env, probe)", tok.text, tok.pos+1)
+	default:
+		return nil, fmt.Errorf("unexpected %s at position %d", tok, tok.pos+1)
+	}
+}
+
+// parseCall parses the argument list of a function call.
+func (p *parser) parseCall(name token) (node, error) {
+	fn := functions[name.text]
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	c := &call{name: name.text}
+	for !p.isOp(")") {
+		if len(c.args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg := p.take()
+		// Task numbers may be written without quotes: status(2)
+		if arg.kind != tokString && arg.kind != tokWord {
+			return nil, fmt.Errorf("%s: expected a string argument, got %s at position %d", name.text, arg, arg.pos+1)
+		}
+		c.args = append(c.args, arg.text)
+	}
+	p.take()
+
+	if len(c.args) < fn.minArgs || (fn.maxArgs >= 0 && len(c.args) > fn.maxArgs) {
+		want := fmt.Sprintf("%d argument", fn.minArgs)
+		if fn.maxArgs < 0 {
+			want = fmt.Sprintf("at least %d argument", fn.minArgs)
+		}
+		if fn.minArgs != 1 {
+			want += "s"
+		}
+		return nil, fmt.Errorf("%s at position %d takes %s, got %d", name.text, name.pos+1, want, len(c.args))
+	}
+	return c, nil
+}
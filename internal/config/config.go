@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -100,8 +101,160 @@ type QualityControlConfig struct {
 
 	// RetryOnRed is the maximum number of retries when QC review is RED
 	RetryOnRed int `yaml:"retry_on_red"`
+
+	// ResultCache reuses QC verdicts across runs for unchanged tasks (v3.6+)
+	ResultCache QCResultCacheConfig `yaml:"result_cache"`
+
+	// Aggregation decides how multi-agent verdicts combine into one (v3.6+)
+	Aggregation QCAggregationConfig `yaml:"aggregation"`
+
+	// Checkers defines local checker programs used as QC reviewers (v3.6+).
+	// A checker is selected by listing "check:<name>" in agents.explicit_list
+	// or agents.additional. Built-in checkers (go-vet, gofmt, staticcheck) need
+	// no definition; an entry with the same name replaces the built-in.
+	Checkers map[string]QCCheckerConfig `yaml:"checkers"`
+
+	// CheckerGate fails the review without invoking any QC agent when a
+	// checker returns RED
+	// Default: true
+	CheckerGate bool `yaml:"checker_gate"`
+
+	// DiffReview gives QC agents the git diff of the task's changes (v3.6+)
+	DiffReview QCDiffReviewConfig `yaml:"diff_review"`
+
+	// Calibration picks QC agents with a poor record against task outcomes
+	// less often (v3.6+)
+	Calibration QCCalibrationConfig `yaml:"calibration"`
+}
+
+// QCCalibrationConfig controls calibration-aware QC agent selection (v3.6+).
+// Each agent's verdicts are scored against what happened to the task: a
+// later fix task, test commands that contradicted a RED, or a human override
+// via "conductor learning qc-override". Auto-selected and intelligently
+// recommended agents whose accuracy falls below MinAccuracy are dropped.
+type QCCalibrationConfig struct {
+	// Enabled turns on calibration-aware selection. Outcomes are recorded
+	// either way, for "conductor learning qc-stats".
+	// Default: true
+	Enabled bool `yaml:"enabled"`
+
+	// MinSamples is the number of reviews with a known outcome an agent needs
+	// before its accuracy affects selection
+	// Default: 10
+	MinSamples int `yaml:"min_samples"`
+
+	// MinAccuracy is the accuracy below which an agent counts as poorly
+	// calibrated, between 0 and 1
+	// Default: 0.5
+	MinAccuracy float64 `yaml:"min_accuracy"`
+}
+
+// QCDiffReviewConfig controls diff-based QC review (v3.6+). QC agents receive
+// the git diff of the commit the task produced, or of everything changed since
+// the task started, next to the agent's own output. Diffs larger than
+// ChunkBytes are split by file and each part is reviewed on its own.
+type QCDiffReviewConfig struct {
+	// Enabled turns on diff-based review
+	// Default: false
+	Enabled bool `yaml:"enabled"`
+
+	// ChunkBytes is the largest diff reviewed in one QC invocation
+	// Default: 40000
+	ChunkBytes int `yaml:"chunk_bytes"`
+
+	// MaxChunks caps the QC invocations per review; files beyond it are
+	// listed by name only
+	// Default: 5
+	MaxChunks int `yaml:"max_chunks"`
 }
 
+// QCCheckerConfig defines a command run as a deterministic QC reviewer (v3.6+).
+// The command runs through the shell in the task's working directory.
+// {files} expands to the task files matching Extensions and {packages} to
+// their directories as ./dir. Exit code 0 is GREEN, codes in YellowExitCodes
+// are YELLOW and any other code is RED.
+type QCCheckerConfig struct {
+	// Command is the shell command to run
+	Command string `yaml:"command"`
+
+	// Extensions limits the checker to tasks with files of these extensions;
+	// tasks without such files are not reviewed by it (empty = every task)
+	Extensions []string `yaml:"extensions"`
+
+	// YellowExitCodes are non-zero exit codes that mean YELLOW instead of RED
+	YellowExitCodes []int `yaml:"yellow_exit_codes"`
+
+	// FailOnOutput treats any output as RED even when the command exits 0,
+	// for tools like gofmt -l that list problems without failing
+	FailOnOutput bool `yaml:"fail_on_output"`
+
+	// IssuePattern is a regular expression matched against each output line.
+	// Named groups file, line and message become a models.Issue.
+	IssuePattern string `yaml:"issue_pattern"`
+
+	// IssueMessage is the issue description when IssuePattern has no message group
+	IssueMessage string `yaml:"issue_message"`
+
+	// Timeout bounds one run of the command
+	// Default: 5m
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// DefaultQCCheckerTimeout bounds a checker run without an explicit timeout.
+const DefaultQCCheckerTimeout = 5 * time.Minute
+
+// QC verdict aggregation strategies (v3.6+)
+const (
+	QCAggregationStrictest = "strictest" // Any RED wins, then any YELLOW
+	QCAggregationMajority  = "majority"  // More passing than RED verdicts, ties are RED
+	QCAggregationQuorum    = "quorum"    // At least Quorum GREEN verdicts
+	QCAggregationWeighted  = "weighted"  // Majority weighted by each agent's agreement with past review outcomes
+)
+
+// QCAggregationConfig controls how the verdicts of multiple QC agents are
+// combined (v3.6+). The strategy applies to the overall verdict and, for tasks
+// with success criteria, to each criterion.
+type QCAggregationConfig struct {
+	// Strategy is one of strictest, majority, quorum or weighted
+	// Default: strictest
+	Strategy string `yaml:"strategy"`
+
+	// Quorum is the number of GREEN verdicts the quorum strategy needs.
+	// It is capped at the number of selected agents, leaving out checkers that
+	// do not apply (and all checkers for criteria); agents that return no
+	// verdict count as not GREEN.
+	// Default: 2
+	Quorum int `yaml:"quorum"`
+
+	// VetoAgents are agents whose RED verdict fails the review whatever the
+	// other agents decided
+	// Default: none
+	VetoAgents []string `yaml:"veto_agents"`
+
+	// MinSamples is the number of reviews with a known outcome an agent needs
+	// before the weighted strategy uses its agreement rate; until then its weight is 0.5
+	// Default: 5
+	MinSamples int `yaml:"min_samples"`
+}
+
+// QCResultCacheConfig controls the persistent QC result cache (v3.6+).
+// Verdicts are stored in the learning database, keyed by the task definition,
+// the contents of its files, its criteria and the QC agent set. A task whose
+// key matches an earlier review reuses that verdict instead of invoking QC agents.
+// Requires learning.enabled.
+type QCResultCacheConfig struct {
+	// Enabled turns on verdict reuse (opt-in)
+	// Default: false
+	Enabled bool `yaml:"enabled"`
+
+	// TTL is how long a cached verdict stays valid (0 = never expires)
+	// Default: 168h (7 days)
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// DefaultQCResultCacheTTL is the default lifetime of a cached QC verdict.
+const DefaultQCResultCacheTTL = 7 * 24 * time.Hour
+
 // CostModelConfig represents Claude model token pricing configuration
 type CostModelConfig struct {
 	// SonnetInput is the cost per 1M input tokens for Claude Sonnet
@@ -177,6 +330,15 @@ type BudgetConfig struct {
 
 	// SafetyBuffer is extra wait time after reset (default: 60s)
 	SafetyBuffer time.Duration `yaml:"safety_buffer"`
+
+	// Spend caps (v3.6+)
+	// MaxRunUSD stops the run once agent and QC invocations have cost this much.
+	// Execution state is saved so the run can be resumed. Default: 0 (no limit)
+	MaxRunUSD float64 `yaml:"max_run_usd"`
+
+	// MaxTaskUSD stops retrying a task once its attempts have cost this much.
+	// Default: 0 (no limit)
+	MaxTaskUSD float64 `yaml:"max_task_usd"`
 }
 
 // PatternConfig represents Pattern Intelligence configuration
@@ -236,6 +398,271 @@ type MetricsConfig struct {
 	HumanEstimation bool `yaml:"human_estimation"`
 }
 
+// BackendType identifies the agent runtime implementation behind a backend
+type BackendType string
+
+const (
+	// BackendTypeClaude invokes the claude CLI (default)
+	BackendTypeClaude BackendType = "claude"
+
+	// BackendTypeCommand runs a command that reads the prompt on stdin and
+	// writes the JSON response on stdout
+	BackendTypeCommand BackendType = "command"
+
+	// BackendTypeFake returns canned successful responses without invoking any runtime
+	BackendTypeFake BackendType = "fake"
+)
+
+// BackendDefinition describes a named agent backend (v3.6+)
+type BackendDefinition struct {
+	// Type selects the backend implementation: "claude", "command" or "fake"
+	Type BackendType `yaml:"type"`
+
+	// Command is the executable to run.
+	// Required for "command" backends; optional claude binary path for "claude" backends.
+	Command string `yaml:"command"`
+
+	// Args are passed to Command. Go text/template placeholders are expanded per task:
+	// {{.Agent}}, {{.Model}}, {{.TaskNumber}}, {{.TaskName}}, {{.WorkDir}}
+	Args []string `yaml:"args"`
+
+	// Env holds extra environment variables for the command
+	Env map[string]string `yaml:"env"`
+
+	// Verdict is the QC verdict returned by "fake" backends (default: GREEN)
+	Verdict string `yaml:"verdict"`
+}
+
+// BackendsConfig selects which agent runtime executes task and QC invocations (v3.6+).
+// Backends are chosen per task (task "backend" field), then per agent, then Default.
+// The built-in "claude" and "fake" backends are always available.
+type BackendsConfig struct {
+	// Default is the backend name used when neither the task nor its agent selects one.
+	// Default: "claude"
+	Default string `yaml:"default"`
+
+	// Agents maps agent names to backend names
+	Agents map[string]string `yaml:"agents"`
+
+	// Definitions declares named backends
+	Definitions map[string]BackendDefinition `yaml:"definitions"`
+}
+
+// HasBackend reports whether name refers to a built-in or defined backend.
+func (b BackendsConfig) HasBackend(name string) bool {
+	if name == string(BackendTypeClaude) || name == string(BackendTypeFake) {
+		return true
+	}
+	_, ok := b.Definitions[name]
+	return ok
+}
+
+// DefaultBackendsConfig returns BackendsConfig that routes everything to the claude CLI.
+func DefaultBackendsConfig() BackendsConfig {
+	return BackendsConfig{
+		Default:     string(BackendTypeClaude),
+		Agents:      map[string]string{},
+		Definitions: map[string]BackendDefinition{},
+	}
+}
+
+// WebhookConfig describes one HTTP endpoint that receives notification events (v3.6+)
+type WebhookConfig struct {
+	// Name identifies the webhook in warnings (default: the URL host)
+	Name string `yaml:"name"`
+
+	// URL receives a POST per event
+	URL string `yaml:"url"`
+
+	// Events lists the event types to send (e.g. task.failed, rate_limit.pause).
+	// Empty sends every event.
+	Events []string `yaml:"events"`
+
+	// Secret signs each body with HMAC-SHA256 in the X-Conductor-Signature header
+	Secret string `yaml:"secret"`
+
+	// SecretEnv names an environment variable holding the secret (overrides Secret)
+	SecretEnv string `yaml:"secret_env"`
+
+	// Template is a Go text/template rendering the request body from the event.
+	// Empty sends the event as JSON.
+	Template string `yaml:"template"`
+
+	// Headers are added to every request
+	Headers map[string]string `yaml:"headers"`
+
+	// MaxRetries is the number of retries after a failed delivery.
+	// Default: 3
+	MaxRetries int `yaml:"max_retries"`
+}
+
+// NotificationsConfig posts run events to HTTP webhooks (v3.6+).
+// Requests use timeouts.http.
+type NotificationsConfig struct {
+	// Webhooks receive notification events. Default: none
+	Webhooks []WebhookConfig `yaml:"webhooks"`
+}
+
+// DefaultWebhookMaxRetries is the retry count for webhooks that don't set max_retries.
+const DefaultWebhookMaxRetries = 3
+
+// DefaultNotificationsConfig returns NotificationsConfig with no webhooks.
+func DefaultNotificationsConfig() NotificationsConfig {
+	return NotificationsConfig{Webhooks: []WebhookConfig{}}
+}
+
+// ModelRoutingConfig picks the Claude model per task invocation and per retry (v3.6+).
+// Models are claude CLI aliases (haiku, sonnet, opus) or full model names.
+// A task starts on the weakest tier when it is small and quick, on the strongest
+// tier when it is large, long or has often failed before, and on Default otherwise.
+type ModelRoutingConfig struct {
+	// Enabled turns on per-task model routing
+	// Default: false
+	Enabled bool `yaml:"enabled"`
+
+	// Tiers lists models from weakest to strongest. Escalation moves up this list.
+	// Default: [haiku, sonnet, opus]
+	Tiers []string `yaml:"tiers"`
+
+	// Default is the model for tasks that match no routing signal
+	// Default: "sonnet"
+	Default string `yaml:"default"`
+
+	// SimpleMaxFiles and SimpleMaxEstimate route tasks with at most this many files
+	// and an estimated time of at most this duration to the weakest tier.
+	// Default: 1 file, 15m
+	SimpleMaxFiles    int           `yaml:"simple_max_files"`
+	SimpleMaxEstimate time.Duration `yaml:"simple_max_estimate"`
+
+	// ComplexMinFiles and ComplexMinEstimate route tasks with at least this many files
+	// or an estimated time of at least this duration to the strongest tier.
+	// Default: 6 files, 1h
+	ComplexMinFiles    int           `yaml:"complex_min_files"`
+	ComplexMinEstimate time.Duration `yaml:"complex_min_estimate"`
+
+	// FailureRate routes tasks whose past failure rate in the learning database
+	// is at least this fraction to the strongest tier (0 disables the signal)
+	// Default: 0.5
+	FailureRate float64 `yaml:"failure_rate"`
+
+	// TaskTypes maps task types (e.g. integration) to a model, overriding the other signals
+	TaskTypes map[string]string `yaml:"task_types"`
+
+	// EscalateOnRed retries a RED task on the next stronger tier before swapping agents
+	// Default: true
+	EscalateOnRed bool `yaml:"escalate_on_red"`
+
+	// Internal sets the models for conductor's own Claude calls.
+	// Applies whether or not task routing is enabled.
+	Internal InternalModelsConfig `yaml:"internal"`
+}
+
+// InternalModelsConfig sets the model per internal Claude call (v3.6+).
+// Empty values use the claude CLI default model.
+type InternalModelsConfig struct {
+	// QCSelection is used by intelligent QC agent selection
+	QCSelection string `yaml:"qc_selection"`
+
+	// Similarity is used by semantic task similarity (pattern intelligence, warm-up)
+	Similarity string `yaml:"similarity"`
+
+	// Estimation is used by human time estimation
+	Estimation string `yaml:"estimation"`
+
+	// SetupIntrospection is used by the pre-wave setup phase
+	SetupIntrospection string `yaml:"setup_introspection"`
+}
+
+// DefaultModelRoutingConfig returns ModelRoutingConfig with routing disabled.
+func DefaultModelRoutingConfig() ModelRoutingConfig {
+	return ModelRoutingConfig{
+		Enabled:            false,
+		Tiers:              []string{"haiku", "sonnet", "opus"},
+		Default:            "sonnet",
+		SimpleMaxFiles:     1,
+		SimpleMaxEstimate:  15 * time.Minute,
+		ComplexMinFiles:    6,
+		ComplexMinEstimate: time.Hour,
+		FailureRate:        0.5,
+		TaskTypes:          map[string]string{},
+		EscalateOnRed:      true,
+	}
+}
+
+// Risk gate actions for high-risk tasks (v3.6+)
+const (
+	// RiskActionStrongerAgent runs the task with the escalation agent
+	RiskActionStrongerAgent = "stronger_agent"
+
+	// RiskActionExtraQC adds QCAgent to the task's QC reviewers
+	RiskActionExtraQC = "extra_qc"
+
+	// RiskActionApproval pauses the task until a human approves it
+	RiskActionApproval = "approval"
+)
+
+// RiskGateConfig scores each task's predicted failure probability from ingested
+// session history before it runs, and escalates tasks at or above Threshold (v3.6+).
+// Predictions come from the behavioral FailurePredictor; every prediction is
+// reported as an anomaly. Requires the learning database.
+type RiskGateConfig struct {
+	// Enabled turns on the pre-task risk gate
+	// Default: false
+	Enabled bool `yaml:"enabled"`
+
+	// Threshold is the predicted failure probability at which a task is high risk
+	// Default: 0.7
+	Threshold float64 `yaml:"threshold"`
+
+	// MinConfidence is the prediction confidence needed to act on a high risk.
+	// Less confident predictions are only logged.
+	// Default: 0.3
+	MinConfidence float64 `yaml:"min_confidence"`
+
+	// MinSessions is the number of ingested sessions needed before predicting
+	// Default: 10
+	MinSessions int `yaml:"min_sessions"`
+
+	// HistoryLimit caps the most recent sessions loaded at run start
+	// Default: 1000
+	HistoryLimit int `yaml:"history_limit"`
+
+	// Actions taken for high-risk tasks: stronger_agent, extra_qc, approval
+	// Default: [extra_qc]
+	Actions []string `yaml:"actions"`
+
+	// EscalationAgent runs high-risk tasks for stronger_agent. Empty picks the
+	// best-scored agent in the task agent's domain from past sessions.
+	EscalationAgent string `yaml:"escalation_agent"`
+
+	// QCAgent is the reviewer extra_qc adds
+	// Default: "code-reviewer"
+	QCAgent string `yaml:"qc_agent"`
+}
+
+// HasAction reports whether action is configured for high-risk tasks.
+func (c RiskGateConfig) HasAction(action string) bool {
+	for _, a := range c.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRiskGateConfig returns RiskGateConfig with the gate disabled.
+func DefaultRiskGateConfig() RiskGateConfig {
+	return RiskGateConfig{
+		Enabled:       false,
+		Threshold:     0.7,
+		MinConfidence: 0.3,
+		MinSessions:   10,
+		HistoryLimit:  1000,
+		Actions:       []string{RiskActionExtraQC},
+		QCAgent:       "code-reviewer",
+	}
+}
+
 // TimeoutsConfig controls timeout durations for different operation types
 type TimeoutsConfig struct {
 	// Task is the timeout for main agent task execution (default: 12h)
@@ -316,6 +743,74 @@ type RollbackConfig struct {
 	KeepCheckpointDays int `yaml:"keep_checkpoint_days"`
 }
 
+// SchedulerMode specifies how the executor decides when a task may start
+type SchedulerMode string
+
+const (
+	// SchedulerModeWave runs waves sequentially with a barrier between each wave
+	SchedulerModeWave SchedulerMode = "wave"
+
+	// SchedulerModeDependency starts each task as soon as its dependencies are GREEN/YELLOW
+	SchedulerModeDependency SchedulerMode = "dependency"
+)
+
+// IsolationMode specifies where task agents make their changes
+type IsolationMode string
+
+const (
+	// IsolationModeNone runs every task directly in the main working tree
+	IsolationModeNone IsolationMode = "none"
+
+	// IsolationModeWorktree runs each task in its own git worktree and merges it back on success
+	IsolationModeWorktree IsolationMode = "worktree"
+)
+
+// Prompt context sections that hooks add to a task prompt (v3.6+).
+// These are the keys of executor.context_budget.priorities.
+const (
+	ContextSectionLearning     = "learning"      // Past failure analysis
+	ContextSectionWarmUp       = "warm_up"       // Approaches from similar successful tasks
+	ContextSectionPattern      = "pattern"       // Pattern intelligence STOP context
+	ContextSectionArchitecture = "architecture"  // Architecture checkpoint assessment
+	ContextSectionIntegration  = "integration"   // Dependency files to read
+	ContextSectionQCFeedback   = "qc_feedback"   // QC feedback from a previous attempt
+	ContextSectionTestFeedback = "test_feedback" // Test failures from a previous attempt
+)
+
+// ContextBudgetConfig limits how much context hooks may add to a task prompt (v3.6+).
+// When the estimated prompt exceeds MaxTokens, sections are summarized and then
+// dropped in priority order (lowest first, older retry feedback before newer).
+// The plan's own task prompt is never cut.
+type ContextBudgetConfig struct {
+	// MaxTokens is the approximate prompt size limit (0 = unlimited)
+	// Default: 0
+	MaxTokens int `yaml:"max_tokens"`
+
+	// SummaryTokens is the size a section is cut down to before it is dropped
+	// (0 = drop without summarizing)
+	// Default: 300
+	SummaryTokens int `yaml:"summary_tokens"`
+
+	// Priorities ranks sections; higher values are kept longer. Sections not
+	// listed keep their default priority.
+	// Default: qc_feedback 90, test_feedback 90, integration 80, learning 60,
+	// architecture 50, pattern 40, warm_up 30
+	Priorities map[string]int `yaml:"priorities"`
+}
+
+// DefaultContextPriorities returns the default priority of each prompt context section.
+func DefaultContextPriorities() map[string]int {
+	return map[string]int{
+		ContextSectionQCFeedback:   90,
+		ContextSectionTestFeedback: 90,
+		ContextSectionIntegration:  80,
+		ContextSectionLearning:     60,
+		ContextSectionArchitecture: 50,
+		ContextSectionPattern:      40,
+		ContextSectionWarmUp:       30,
+	}
+}
+
 // ExecutorConfig controls task execution behavior
 type ExecutorConfig struct {
 	// EnforceDependencyChecks enables running dependency check commands before task invocation.
@@ -370,6 +865,66 @@ type ExecutorConfig struct {
 	// Also automatically enabled when quality_control.agents.mode is "intelligent".
 	// Default: false
 	IntelligentAgentSelection bool `yaml:"intelligent_agent_selection"`
+
+	// Scheduler selects the task scheduling strategy (v3.6+).
+	// "wave" runs plan waves one after another, waiting for every task in a wave
+	// before starting the next. "dependency" ignores wave barriers and launches each
+	// task once all of its dependencies finished GREEN/YELLOW, still honoring
+	// max_concurrency, package guard locks and file overlaps between running tasks.
+	// Default: "wave"
+	Scheduler SchedulerMode `yaml:"scheduler"`
+
+	// KeepGoing continues execution after a task ends RED/FAILED (v3.6+).
+	// Only the failed task's transitive dependents are marked BLOCKED and skipped;
+	// unrelated branches of the plan keep running. Can be enabled with --keep-going.
+	// Default: false
+	KeepGoing bool `yaml:"keep_going"`
+
+	// Isolation selects where task agents make their changes (v3.6+).
+	// "none" runs every agent in the main working tree. "worktree" creates a
+	// git worktree per task (or per worktree group) from the current branch,
+	// merges it back when QC is GREEN/YELLOW and reports merge conflicts as
+	// task failures.
+	// Default: "none"
+	Isolation IsolationMode `yaml:"isolation"`
+
+	// WorktreeDir is the directory where task worktrees are created (v3.6+).
+	// Relative paths are resolved against the repository root.
+	// Default: ".conductor/worktrees"
+	WorktreeDir string `yaml:"worktree_dir"`
+
+	// ControlSocket is the Unix domain socket served during a run for
+	// `conductor ctl` (v3.6+): pause/resume launches, cancel or skip a task,
+	// change max concurrency. Empty disables run control.
+	// Default: ".conductor/conductor.sock"
+	ControlSocket string `yaml:"control_socket"`
+
+	// StreamOutput runs claude agents with --output-format stream-json (v3.6+).
+	// Tool calls and assistant text are reported as live per-task activity
+	// lines while the agent works instead of only when it exits.
+	// Default: false
+	StreamOutput bool `yaml:"stream_output"`
+
+	// StallTimeout kills and restarts an agent that has streamed no events for
+	// this long (v3.6+). Only applies when stream_output is enabled. 0 disables
+	// the watchdog.
+	// Default: 10m
+	StallTimeout time.Duration `yaml:"stall_timeout"`
+
+	// StallRetries is how many times a stalled agent is restarted before its
+	// task fails (v3.6+).
+	// Default: 1
+	StallRetries int `yaml:"stall_retries"`
+
+	// MaxGeneratedTasks caps how many subtasks agents may add to the plan
+	// during one run (v3.6+). Accepted subtasks run after the task that
+	// proposed them and are written back into the plan file. 0 ignores
+	// proposed subtasks.
+	// Default: 0
+	MaxGeneratedTasks int `yaml:"max_generated_tasks"`
+
+	// ContextBudget caps the context hooks add to task prompts (v3.6+)
+	ContextBudget ContextBudgetConfig `yaml:"context_budget"`
 }
 
 // Config represents conductor configuration options
@@ -433,6 +988,18 @@ type Config struct {
 
 	// Metrics controls execution metrics collection (v3.4+)
 	Metrics MetricsConfig `yaml:"metrics"`
+
+	// Backends selects the agent runtime per agent or per task (v3.6+)
+	Backends BackendsConfig `yaml:"backends"`
+
+	// Notifications posts run events to webhooks (v3.6+)
+	Notifications NotificationsConfig `yaml:"notifications"`
+
+	// ModelRouting picks the Claude model per task and per retry (v3.6+)
+	ModelRouting ModelRoutingConfig `yaml:"model_routing"`
+
+	// RiskGate escalates tasks with a high predicted failure probability (v3.6+)
+	RiskGate RiskGateConfig `yaml:"risk_gate"`
 }
 
 // ArchitectureMode specifies the Architecture Checkpoint operating mode
@@ -625,6 +1192,26 @@ func DefaultConfig() *Config {
 				RequireCodeReview: true,
 			},
 			RetryOnRed: 2,
+			ResultCache: QCResultCacheConfig{
+				Enabled: false,
+				TTL:     DefaultQCResultCacheTTL,
+			},
+			Aggregation: QCAggregationConfig{
+				Strategy:   QCAggregationStrictest,
+				Quorum:     2,
+				MinSamples: 5,
+			},
+			CheckerGate: true,
+			DiffReview: QCDiffReviewConfig{
+				Enabled:    false,
+				ChunkBytes: 40000,
+				MaxChunks:  5,
+			},
+			Calibration: QCCalibrationConfig{
+				Enabled:     true,
+				MinSamples:  10,
+				MinAccuracy: 0.5,
+			},
 		},
 		AgentWatch: DefaultAgentWatchConfig(),
 		Validation: ValidationConfig{
@@ -640,6 +1227,20 @@ func DefaultConfig() *Config {
 			EnableErrorPatternDetection: true,
 			EnableClaudeClassification:  false,
 			IntelligentAgentSelection:   false, // Disabled by default, also enabled when QC mode is "intelligent"
+			Scheduler:                   SchedulerModeWave,
+			KeepGoing:                   false,
+			Isolation:                   IsolationModeNone,
+			WorktreeDir:                 ".conductor/worktrees",
+			ControlSocket:               ".conductor/conductor.sock",
+			StreamOutput:                false,
+			StallTimeout:                10 * time.Minute,
+			StallRetries:                1,
+			MaxGeneratedTasks:           0,
+			ContextBudget: ContextBudgetConfig{
+				MaxTokens:     0,
+				SummaryTokens: 300,
+				Priorities:    DefaultContextPriorities(),
+			},
 		},
 		TTS:          DefaultTTSConfig(),
 		Setup:        DefaultSetupConfig(),
@@ -649,6 +1250,11 @@ func DefaultConfig() *Config {
 		Architecture: DefaultArchitectureConfig(),
 		Timeouts:     DefaultTimeoutsConfig(),
 		Metrics:      DefaultMetricsConfig(),
+		Backends:     DefaultBackendsConfig(),
+
+		Notifications: DefaultNotificationsConfig(),
+		ModelRouting:  DefaultModelRoutingConfig(),
+		RiskGate:      DefaultRiskGateConfig(),
 	}
 }
 
@@ -698,11 +1304,13 @@ func LoadConfig(path string) (*Config, error) {
 		Search string `yaml:"search"`
 	}
 	type yamlBudgetConfig struct {
-		Enabled          bool   `yaml:"enabled"`
-		AutoResume       bool   `yaml:"auto_resume"`
-		MaxWaitDuration  string `yaml:"max_wait_duration"`
-		AnnounceInterval string `yaml:"announce_interval"`
-		SafetyBuffer     string `yaml:"safety_buffer"`
+		Enabled          bool    `yaml:"enabled"`
+		AutoResume       bool    `yaml:"auto_resume"`
+		MaxWaitDuration  string  `yaml:"max_wait_duration"`
+		AnnounceInterval string  `yaml:"announce_interval"`
+		SafetyBuffer     string  `yaml:"safety_buffer"`
+		MaxRunUSD        float64 `yaml:"max_run_usd"`
+		MaxTaskUSD       float64 `yaml:"max_task_usd"`
 	}
 	type yamlConfig struct {
 		MaxConcurrency int                  `yaml:"max_concurrency"`
@@ -725,6 +1333,10 @@ func LoadConfig(path string) (*Config, error) {
 		Architecture   ArchitectureConfig   `yaml:"architecture"`
 		Timeouts       yamlTimeoutsConfig   `yaml:"timeouts"`
 		Metrics        MetricsConfig        `yaml:"metrics"`
+		Backends       BackendsConfig       `yaml:"backends"`
+		Notifications  NotificationsConfig  `yaml:"notifications"`
+		ModelRouting   ModelRoutingConfig   `yaml:"model_routing"`
+		RiskGate       RiskGateConfig       `yaml:"risk_gate"`
 	}
 
 	var yamlCfg yamlConfig
@@ -809,6 +1421,70 @@ func LoadConfig(path string) (*Config, error) {
 				cfg.QualityControl.RetryOnRed = qc.RetryOnRed
 			}
 
+			// Handle QC result cache configuration (v3.6+)
+			if cacheSection, exists := qcMap["result_cache"]; exists && cacheSection != nil {
+				cacheMap, _ := cacheSection.(map[string]interface{})
+				if _, exists := cacheMap["enabled"]; exists {
+					cfg.QualityControl.ResultCache.Enabled = qc.ResultCache.Enabled
+				}
+				if _, exists := cacheMap["ttl"]; exists {
+					cfg.QualityControl.ResultCache.TTL = qc.ResultCache.TTL
+				}
+			}
+
+			// Handle QC checkers configuration (v3.6+)
+			if _, exists := qcMap["checkers"]; exists {
+				cfg.QualityControl.Checkers = qc.Checkers
+			}
+			if _, exists := qcMap["checker_gate"]; exists {
+				cfg.QualityControl.CheckerGate = qc.CheckerGate
+			}
+
+			// Handle diff-based QC review configuration (v3.6+)
+			if diffSection, exists := qcMap["diff_review"]; exists && diffSection != nil {
+				diffMap, _ := diffSection.(map[string]interface{})
+				if _, exists := diffMap["enabled"]; exists {
+					cfg.QualityControl.DiffReview.Enabled = qc.DiffReview.Enabled
+				}
+				if _, exists := diffMap["chunk_bytes"]; exists {
+					cfg.QualityControl.DiffReview.ChunkBytes = qc.DiffReview.ChunkBytes
+				}
+				if _, exists := diffMap["max_chunks"]; exists {
+					cfg.QualityControl.DiffReview.MaxChunks = qc.DiffReview.MaxChunks
+				}
+			}
+
+			// Handle QC calibration configuration (v3.6+)
+			if calSection, exists := qcMap["calibration"]; exists && calSection != nil {
+				calMap, _ := calSection.(map[string]interface{})
+				if _, exists := calMap["enabled"]; exists {
+					cfg.QualityControl.Calibration.Enabled = qc.Calibration.Enabled
+				}
+				if _, exists := calMap["min_samples"]; exists {
+					cfg.QualityControl.Calibration.MinSamples = qc.Calibration.MinSamples
+				}
+				if _, exists := calMap["min_accuracy"]; exists {
+					cfg.QualityControl.Calibration.MinAccuracy = qc.Calibration.MinAccuracy
+				}
+			}
+
+			// Handle QC verdict aggregation configuration (v3.6+)
+			if aggSection, exists := qcMap["aggregation"]; exists && aggSection != nil {
+				aggMap, _ := aggSection.(map[string]interface{})
+				if _, exists := aggMap["strategy"]; exists {
+					cfg.QualityControl.Aggregation.Strategy = qc.Aggregation.Strategy
+				}
+				if _, exists := aggMap["quorum"]; exists {
+					cfg.QualityControl.Aggregation.Quorum = qc.Aggregation.Quorum
+				}
+				if _, exists := aggMap["veto_agents"]; exists {
+					cfg.QualityControl.Aggregation.VetoAgents = qc.Aggregation.VetoAgents
+				}
+				if _, exists := aggMap["min_samples"]; exists {
+					cfg.QualityControl.Aggregation.MinSamples = qc.Aggregation.MinSamples
+				}
+			}
+
 			// Handle new multi-agent QC configuration (v2.2+)
 			if agentsSection, exists := qcMap["agents"]; exists && agentsSection != nil {
 				agentsMap, _ := agentsSection.(map[string]interface{})
@@ -944,6 +1620,48 @@ func LoadConfig(path string) (*Config, error) {
 			if _, exists := executorMap["intelligent_agent_selection"]; exists {
 				cfg.Executor.IntelligentAgentSelection = executor.IntelligentAgentSelection
 			}
+			if _, exists := executorMap["scheduler"]; exists {
+				cfg.Executor.Scheduler = executor.Scheduler
+			}
+			if _, exists := executorMap["keep_going"]; exists {
+				cfg.Executor.KeepGoing = executor.KeepGoing
+			}
+			if _, exists := executorMap["isolation"]; exists {
+				cfg.Executor.Isolation = executor.Isolation
+			}
+			if _, exists := executorMap["worktree_dir"]; exists {
+				cfg.Executor.WorktreeDir = executor.WorktreeDir
+			}
+			if _, exists := executorMap["control_socket"]; exists {
+				cfg.Executor.ControlSocket = executor.ControlSocket
+			}
+			if _, exists := executorMap["stream_output"]; exists {
+				cfg.Executor.StreamOutput = executor.StreamOutput
+			}
+			if _, exists := executorMap["stall_timeout"]; exists {
+				cfg.Executor.StallTimeout = executor.StallTimeout
+			}
+			if _, exists := executorMap["stall_retries"]; exists {
+				cfg.Executor.StallRetries = executor.StallRetries
+			}
+			if _, exists := executorMap["max_generated_tasks"]; exists {
+				cfg.Executor.MaxGeneratedTasks = executor.MaxGeneratedTasks
+			}
+
+			// Handle prompt context budget (v3.6+)
+			if budgetSection, exists := executorMap["context_budget"]; exists && budgetSection != nil {
+				budgetMap, _ := budgetSection.(map[string]interface{})
+				if _, exists := budgetMap["max_tokens"]; exists {
+					cfg.Executor.ContextBudget.MaxTokens = executor.ContextBudget.MaxTokens
+				}
+				if _, exists := budgetMap["summary_tokens"]; exists {
+					cfg.Executor.ContextBudget.SummaryTokens = executor.ContextBudget.SummaryTokens
+				}
+				// Listed priorities override the defaults one section at a time
+				for section, priority := range executor.ContextBudget.Priorities {
+					cfg.Executor.ContextBudget.Priorities[section] = priority
+				}
+			}
 		}
 
 		// Merge TTS config
@@ -1037,6 +1755,12 @@ func LoadConfig(path string) (*Config, error) {
 				}
 				cfg.Budget.SafetyBuffer = d
 			}
+			if _, exists := budgetMap["max_run_usd"]; exists {
+				cfg.Budget.MaxRunUSD = budget.MaxRunUSD
+			}
+			if _, exists := budgetMap["max_task_usd"]; exists {
+				cfg.Budget.MaxTaskUSD = budget.MaxTaskUSD
+			}
 		}
 
 		// Merge Pattern config
@@ -1152,6 +1876,112 @@ func LoadConfig(path string) (*Config, error) {
 			}
 		}
 
+		// Merge Backends config (v3.6+)
+		if backendsSection, exists := rawMap["backends"]; exists && backendsSection != nil {
+			backends := yamlCfg.Backends
+			backendsMap, _ := backendsSection.(map[string]interface{})
+
+			if _, exists := backendsMap["default"]; exists {
+				cfg.Backends.Default = backends.Default
+			}
+			if _, exists := backendsMap["agents"]; exists && backends.Agents != nil {
+				cfg.Backends.Agents = backends.Agents
+			}
+			if _, exists := backendsMap["definitions"]; exists && backends.Definitions != nil {
+				cfg.Backends.Definitions = backends.Definitions
+			}
+		}
+
+		// Merge Notifications config (v3.6+)
+		if notificationsSection, exists := rawMap["notifications"]; exists && notificationsSection != nil {
+			notificationsMap, _ := notificationsSection.(map[string]interface{})
+
+			if rawWebhooks, exists := notificationsMap["webhooks"]; exists && yamlCfg.Notifications.Webhooks != nil {
+				webhookList, _ := rawWebhooks.([]interface{})
+				for i := range yamlCfg.Notifications.Webhooks {
+					var webhookMap map[string]interface{}
+					if i < len(webhookList) {
+						webhookMap, _ = webhookList[i].(map[string]interface{})
+					}
+					if _, exists := webhookMap["max_retries"]; !exists {
+						yamlCfg.Notifications.Webhooks[i].MaxRetries = DefaultWebhookMaxRetries
+					}
+				}
+				cfg.Notifications.Webhooks = yamlCfg.Notifications.Webhooks
+			}
+		}
+
+		// Merge ModelRouting config (v3.6+)
+		if routingSection, exists := rawMap["model_routing"]; exists && routingSection != nil {
+			routing := yamlCfg.ModelRouting
+			routingMap, _ := routingSection.(map[string]interface{})
+
+			if _, exists := routingMap["enabled"]; exists {
+				cfg.ModelRouting.Enabled = routing.Enabled
+			}
+			if _, exists := routingMap["tiers"]; exists && routing.Tiers != nil {
+				cfg.ModelRouting.Tiers = routing.Tiers
+			}
+			if _, exists := routingMap["default"]; exists {
+				cfg.ModelRouting.Default = routing.Default
+			}
+			if _, exists := routingMap["simple_max_files"]; exists {
+				cfg.ModelRouting.SimpleMaxFiles = routing.SimpleMaxFiles
+			}
+			if _, exists := routingMap["simple_max_estimate"]; exists {
+				cfg.ModelRouting.SimpleMaxEstimate = routing.SimpleMaxEstimate
+			}
+			if _, exists := routingMap["complex_min_files"]; exists {
+				cfg.ModelRouting.ComplexMinFiles = routing.ComplexMinFiles
+			}
+			if _, exists := routingMap["complex_min_estimate"]; exists {
+				cfg.ModelRouting.ComplexMinEstimate = routing.ComplexMinEstimate
+			}
+			if _, exists := routingMap["failure_rate"]; exists {
+				cfg.ModelRouting.FailureRate = routing.FailureRate
+			}
+			if _, exists := routingMap["task_types"]; exists && routing.TaskTypes != nil {
+				cfg.ModelRouting.TaskTypes = routing.TaskTypes
+			}
+			if _, exists := routingMap["escalate_on_red"]; exists {
+				cfg.ModelRouting.EscalateOnRed = routing.EscalateOnRed
+			}
+			if _, exists := routingMap["internal"]; exists {
+				cfg.ModelRouting.Internal = routing.Internal
+			}
+		}
+
+		// Merge RiskGate config (v3.6+)
+		if riskSection, exists := rawMap["risk_gate"]; exists && riskSection != nil {
+			risk := yamlCfg.RiskGate
+			riskMap, _ := riskSection.(map[string]interface{})
+
+			if _, exists := riskMap["enabled"]; exists {
+				cfg.RiskGate.Enabled = risk.Enabled
+			}
+			if _, exists := riskMap["threshold"]; exists {
+				cfg.RiskGate.Threshold = risk.Threshold
+			}
+			if _, exists := riskMap["min_confidence"]; exists {
+				cfg.RiskGate.MinConfidence = risk.MinConfidence
+			}
+			if _, exists := riskMap["min_sessions"]; exists {
+				cfg.RiskGate.MinSessions = risk.MinSessions
+			}
+			if _, exists := riskMap["history_limit"]; exists {
+				cfg.RiskGate.HistoryLimit = risk.HistoryLimit
+			}
+			if _, exists := riskMap["actions"]; exists && risk.Actions != nil {
+				cfg.RiskGate.Actions = risk.Actions
+			}
+			if _, exists := riskMap["escalation_agent"]; exists {
+				cfg.RiskGate.EscalationAgent = risk.EscalationAgent
+			}
+			if _, exists := riskMap["qc_agent"]; exists {
+				cfg.RiskGate.QCAgent = risk.QCAgent
+			}
+		}
+
 	}
 
 	return cfg, nil
@@ -1286,6 +2116,56 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("quality_control.retry_on_red must be >= 0, got %d", c.QualityControl.RetryOnRed)
 		}
 
+		// Validate QC result cache (v3.6+)
+		if c.QualityControl.ResultCache.TTL < 0 {
+			return fmt.Errorf("quality_control.result_cache.ttl must be >= 0, got %v", c.QualityControl.ResultCache.TTL)
+		}
+
+		// Validate QC verdict aggregation (v3.6+)
+		switch c.QualityControl.Aggregation.Strategy {
+		case "", QCAggregationStrictest, QCAggregationMajority, QCAggregationQuorum, QCAggregationWeighted:
+		default:
+			return fmt.Errorf("quality_control.aggregation.strategy must be one of: strictest, majority, quorum, weighted; got %q", c.QualityControl.Aggregation.Strategy)
+		}
+		if c.QualityControl.Aggregation.Strategy == QCAggregationQuorum && c.QualityControl.Aggregation.Quorum < 1 {
+			return fmt.Errorf("quality_control.aggregation.quorum must be >= 1, got %d", c.QualityControl.Aggregation.Quorum)
+		}
+		if c.QualityControl.Aggregation.MinSamples < 0 {
+			return fmt.Errorf("quality_control.aggregation.min_samples must be >= 0, got %d", c.QualityControl.Aggregation.MinSamples)
+		}
+		for name, checker := range c.QualityControl.Checkers {
+			if strings.TrimSpace(checker.Command) == "" {
+				return fmt.Errorf("quality_control.checkers.%s.command cannot be empty", name)
+			}
+			if checker.IssuePattern != "" {
+				if _, err := regexp.Compile(checker.IssuePattern); err != nil {
+					return fmt.Errorf("quality_control.checkers.%s.issue_pattern: %w", name, err)
+				}
+			}
+			if checker.Timeout < 0 {
+				return fmt.Errorf("quality_control.checkers.%s.timeout must be >= 0, got %v", name, checker.Timeout)
+			}
+		}
+		if c.QualityControl.DiffReview.Enabled {
+			if c.QualityControl.DiffReview.ChunkBytes < 1 {
+				return fmt.Errorf("quality_control.diff_review.chunk_bytes must be >= 1, got %d", c.QualityControl.DiffReview.ChunkBytes)
+			}
+			if c.QualityControl.DiffReview.MaxChunks < 1 {
+				return fmt.Errorf("quality_control.diff_review.max_chunks must be >= 1, got %d", c.QualityControl.DiffReview.MaxChunks)
+			}
+		}
+		if c.QualityControl.Calibration.MinSamples < 0 {
+			return fmt.Errorf("quality_control.calibration.min_samples must be >= 0, got %d", c.QualityControl.Calibration.MinSamples)
+		}
+		if c.QualityControl.Calibration.MinAccuracy < 0 || c.QualityControl.Calibration.MinAccuracy > 1 {
+			return fmt.Errorf("quality_control.calibration.min_accuracy must be between 0 and 1, got %v", c.QualityControl.Calibration.MinAccuracy)
+		}
+		for i, agent := range c.QualityControl.Aggregation.VetoAgents {
+			if strings.TrimSpace(agent) == "" {
+				return fmt.Errorf("quality_control.aggregation.veto_agents[%d] cannot be empty", i)
+			}
+		}
+
 		// Validate multi-agent QC configuration
 		validModes := map[string]bool{
 			"auto":        true,
@@ -1324,6 +2204,141 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate executor scheduler mode
+	if c.Executor.Scheduler == "" {
+		c.Executor.Scheduler = SchedulerModeWave
+	}
+	validSchedulerModes := map[SchedulerMode]bool{
+		SchedulerModeWave:       true,
+		SchedulerModeDependency: true,
+	}
+	if !validSchedulerModes[c.Executor.Scheduler] {
+		return fmt.Errorf("executor.scheduler must be one of: wave, dependency; got %q", c.Executor.Scheduler)
+	}
+
+	// Validate agent backends (v3.6+)
+	if c.Backends.Default == "" {
+		c.Backends.Default = string(BackendTypeClaude)
+	}
+	for name, def := range c.Backends.Definitions {
+		switch def.Type {
+		case BackendTypeClaude, BackendTypeFake:
+		case BackendTypeCommand:
+			if strings.TrimSpace(def.Command) == "" {
+				return fmt.Errorf("backends.definitions.%s.command is required for command backends", name)
+			}
+		default:
+			return fmt.Errorf("backends.definitions.%s.type must be one of: claude, command, fake; got %q", name, def.Type)
+		}
+		if def.Verdict != "" && def.Verdict != "GREEN" && def.Verdict != "YELLOW" && def.Verdict != "RED" {
+			return fmt.Errorf("backends.definitions.%s.verdict must be one of: GREEN, YELLOW, RED; got %q", name, def.Verdict)
+		}
+	}
+	if !c.Backends.HasBackend(c.Backends.Default) {
+		return fmt.Errorf("backends.default refers to unknown backend %q", c.Backends.Default)
+	}
+	for agentName, backendName := range c.Backends.Agents {
+		if !c.Backends.HasBackend(backendName) {
+			return fmt.Errorf("backends.agents.%s refers to unknown backend %q", agentName, backendName)
+		}
+	}
+
+	// Validate notification webhooks (v3.6+)
+	for i := range c.Notifications.Webhooks {
+		webhook := &c.Notifications.Webhooks[i]
+		if strings.TrimSpace(webhook.URL) == "" {
+			return fmt.Errorf("notifications.webhooks[%d].url is required", i)
+		}
+		if !strings.HasPrefix(webhook.URL, "http://") && !strings.HasPrefix(webhook.URL, "https://") {
+			return fmt.Errorf("notifications.webhooks[%d].url must be an http or https URL, got %q", i, webhook.URL)
+		}
+		if webhook.MaxRetries < 0 {
+			return fmt.Errorf("notifications.webhooks[%d].max_retries must be >= 0, got %d", i, webhook.MaxRetries)
+		}
+	}
+
+	// Validate model routing (v3.6+)
+	if c.ModelRouting.Enabled {
+		if len(c.ModelRouting.Tiers) == 0 {
+			return fmt.Errorf("model_routing.tiers cannot be empty when model_routing is enabled")
+		}
+		for i, tier := range c.ModelRouting.Tiers {
+			if strings.TrimSpace(tier) == "" {
+				return fmt.Errorf("model_routing.tiers[%d] cannot be empty", i)
+			}
+		}
+		if strings.TrimSpace(c.ModelRouting.Default) == "" {
+			return fmt.Errorf("model_routing.default cannot be empty when model_routing is enabled")
+		}
+		if c.ModelRouting.SimpleMaxFiles < 0 || c.ModelRouting.ComplexMinFiles < 0 {
+			return fmt.Errorf("model_routing file thresholds must be >= 0")
+		}
+		if c.ModelRouting.SimpleMaxEstimate < 0 || c.ModelRouting.ComplexMinEstimate < 0 {
+			return fmt.Errorf("model_routing estimate thresholds must be >= 0")
+		}
+		if c.ModelRouting.FailureRate < 0 || c.ModelRouting.FailureRate > 1 {
+			return fmt.Errorf("model_routing.failure_rate must be between 0 and 1, got %f", c.ModelRouting.FailureRate)
+		}
+	}
+
+	// Validate risk gate (v3.6+)
+	if c.RiskGate.Enabled {
+		if c.RiskGate.Threshold < 0 || c.RiskGate.Threshold > 1 {
+			return fmt.Errorf("risk_gate.threshold must be between 0 and 1, got %f", c.RiskGate.Threshold)
+		}
+		if c.RiskGate.MinConfidence < 0 || c.RiskGate.MinConfidence > 1 {
+			return fmt.Errorf("risk_gate.min_confidence must be between 0 and 1, got %f", c.RiskGate.MinConfidence)
+		}
+		if c.RiskGate.MinSessions < 0 || c.RiskGate.HistoryLimit < 0 {
+			return fmt.Errorf("risk_gate.min_sessions and history_limit must be >= 0")
+		}
+		for i, action := range c.RiskGate.Actions {
+			switch action {
+			case RiskActionStrongerAgent, RiskActionExtraQC, RiskActionApproval:
+			default:
+				return fmt.Errorf("risk_gate.actions[%d] must be one of: stronger_agent, extra_qc, approval; got %q", i, action)
+			}
+		}
+		if c.RiskGate.HasAction(RiskActionExtraQC) && strings.TrimSpace(c.RiskGate.QCAgent) == "" {
+			return fmt.Errorf("risk_gate.qc_agent cannot be empty when extra_qc is an action")
+		}
+	}
+
+	// Validate executor isolation mode
+	if c.Executor.Isolation == "" {
+		c.Executor.Isolation = IsolationModeNone
+	}
+	validIsolationModes := map[IsolationMode]bool{
+		IsolationModeNone:     true,
+		IsolationModeWorktree: true,
+	}
+	if !validIsolationModes[c.Executor.Isolation] {
+		return fmt.Errorf("executor.isolation must be one of: none, worktree; got %q", c.Executor.Isolation)
+	}
+	if c.Executor.Isolation == IsolationModeWorktree && strings.TrimSpace(c.Executor.WorktreeDir) == "" {
+		return fmt.Errorf("executor.worktree_dir cannot be empty when executor.isolation is worktree")
+	}
+	if c.Executor.StallTimeout < 0 {
+		return fmt.Errorf("executor.stall_timeout must be >= 0, got %s", c.Executor.StallTimeout)
+	}
+	if c.Executor.StallRetries < 0 {
+		return fmt.Errorf("executor.stall_retries must be >= 0, got %d", c.Executor.StallRetries)
+	}
+	if c.Executor.MaxGeneratedTasks < 0 {
+		return fmt.Errorf("executor.max_generated_tasks must be >= 0, got %d", c.Executor.MaxGeneratedTasks)
+	}
+	if c.Executor.ContextBudget.MaxTokens < 0 {
+		return fmt.Errorf("executor.context_budget.max_tokens must be >= 0, got %d", c.Executor.ContextBudget.MaxTokens)
+	}
+	if c.Executor.ContextBudget.SummaryTokens < 0 {
+		return fmt.Errorf("executor.context_budget.summary_tokens must be >= 0, got %d", c.Executor.ContextBudget.SummaryTokens)
+	}
+	for section := range c.Executor.ContextBudget.Priorities {
+		if _, known := DefaultContextPriorities()[section]; !known {
+			return fmt.Errorf("executor.context_budget.priorities: unknown section %q", section)
+		}
+	}
+
 	// Validate Agent Watch configuration
 	if c.AgentWatch.Enabled {
 		// Validate base_dir is not empty
@@ -1379,6 +2394,12 @@ func (c *Config) Validate() error {
 		if c.Budget.SafetyBuffer < 0 {
 			return fmt.Errorf("budget.safety_buffer must be >= 0, got %v", c.Budget.SafetyBuffer)
 		}
+		if c.Budget.MaxRunUSD < 0 {
+			return fmt.Errorf("budget.max_run_usd must be >= 0, got %v", c.Budget.MaxRunUSD)
+		}
+		if c.Budget.MaxTaskUSD < 0 {
+			return fmt.Errorf("budget.max_task_usd must be >= 0, got %v", c.Budget.MaxTaskUSD)
+		}
 	}
 
 	// Validate Rollback configuration
@@ -2439,6 +2439,294 @@ func TestLoadConfigExecutorIntelligentAgentSelection(t *testing.T) {
 	})
 }
 
+// TestLoadConfigExecutorScheduler tests loading and validating executor.scheduler from YAML
+func TestLoadConfigExecutorScheduler(t *testing.T) {
+	t.Run("defaults to wave", func(t *testing.T) {
+		cfg := DefaultConfig()
+		if cfg.Executor.Scheduler != SchedulerModeWave {
+			t.Errorf("Executor.Scheduler = %q, want %q", cfg.Executor.Scheduler, SchedulerModeWave)
+		}
+	})
+
+	t.Run("dependency via config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		configPath := filepath.Join(tmpDir, "config.yaml")
+
+		configContent := `executor:
+  scheduler: dependency
+`
+		if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+			t.Fatalf("failed to write test config: %v", err)
+		}
+
+		cfg, err := LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+
+		if cfg.Executor.Scheduler != SchedulerModeDependency {
+			t.Errorf("Executor.Scheduler = %q, want %q", cfg.Executor.Scheduler, SchedulerModeDependency)
+		}
+		// Other executor defaults must be preserved
+		if !cfg.Executor.EnforcePackageGuard {
+			t.Errorf("Executor.EnforcePackageGuard = %v, want true (default)", cfg.Executor.EnforcePackageGuard)
+		}
+	})
+
+	t.Run("invalid mode rejected", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Executor.Scheduler = "eager"
+		if err := cfg.Validate(); err == nil {
+			t.Error("Validate() expected error for invalid executor.scheduler, got nil")
+		}
+	})
+
+	t.Run("empty mode normalized to wave", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Executor.Scheduler = ""
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if cfg.Executor.Scheduler != SchedulerModeWave {
+			t.Errorf("Executor.Scheduler = %q, want %q", cfg.Executor.Scheduler, SchedulerModeWave)
+		}
+	})
+}
+
+// TestLoadConfigExecutorKeepGoing tests loading executor.keep_going from YAML
+func TestLoadConfigExecutorKeepGoing(t *testing.T) {
+	if DefaultConfig().Executor.KeepGoing {
+		t.Error("Executor.KeepGoing should be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `executor:
+  keep_going: true
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if !cfg.Executor.KeepGoing {
+		t.Errorf("Executor.KeepGoing = %v, want true", cfg.Executor.KeepGoing)
+	}
+	if cfg.Executor.Scheduler != SchedulerModeWave {
+		t.Errorf("Executor.Scheduler = %q, want %q (default)", cfg.Executor.Scheduler, SchedulerModeWave)
+	}
+}
+
+func TestLoadConfigExecutorIsolation(t *testing.T) {
+	defaults := DefaultConfig()
+	if defaults.Executor.Isolation != IsolationModeNone {
+		t.Errorf("default Executor.Isolation = %q, want %q", defaults.Executor.Isolation, IsolationModeNone)
+	}
+	if defaults.Executor.WorktreeDir != ".conductor/worktrees" {
+		t.Errorf("default Executor.WorktreeDir = %q, want %q", defaults.Executor.WorktreeDir, ".conductor/worktrees")
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		wantMode    IsolationMode
+		wantDir     string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:     "worktree mode keeps default dir",
+			content:  "executor:\n  isolation: worktree\n",
+			wantMode: IsolationModeWorktree,
+			wantDir:  ".conductor/worktrees",
+		},
+		{
+			name:     "custom worktree dir",
+			content:  "executor:\n  isolation: worktree\n  worktree_dir: /tmp/conductor-worktrees\n",
+			wantMode: IsolationModeWorktree,
+			wantDir:  "/tmp/conductor-worktrees",
+		},
+		{
+			name:        "invalid mode",
+			content:     "executor:\n  isolation: container\n",
+			wantErr:     true,
+			errContains: "executor.isolation must be one of",
+		},
+		{
+			name:        "empty worktree dir",
+			content:     "executor:\n  isolation: worktree\n  worktree_dir: \"\"\n",
+			wantErr:     true,
+			errContains: "executor.worktree_dir cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cfg, err := LoadConfig(configPath)
+			if err == nil {
+				err = cfg.Validate()
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error = %q, want substring %q", err.Error(), tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+			if cfg.Executor.Isolation != tt.wantMode {
+				t.Errorf("Executor.Isolation = %q, want %q", cfg.Executor.Isolation, tt.wantMode)
+			}
+			if cfg.Executor.WorktreeDir != tt.wantDir {
+				t.Errorf("Executor.WorktreeDir = %q, want %q", cfg.Executor.WorktreeDir, tt.wantDir)
+			}
+		})
+	}
+}
+
+func TestLoadConfigControlSocket(t *testing.T) {
+	if got := DefaultConfig().Executor.ControlSocket; got != ".conductor/conductor.sock" {
+		t.Errorf("default Executor.ControlSocket = %q, want .conductor/conductor.sock", got)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"custom path", "executor:\n  control_socket: /tmp/run.sock\n", "/tmp/run.sock"},
+		{"disabled", "executor:\n  control_socket: \"\"\n", ""},
+		{"unset keeps default", "executor:\n  keep_going: true\n", ".conductor/conductor.sock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+			cfg, err := LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadConfig returned error: %v", err)
+			}
+			if cfg.Executor.ControlSocket != tt.want {
+				t.Errorf("Executor.ControlSocket = %q, want %q", cfg.Executor.ControlSocket, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigBackends(t *testing.T) {
+	defaults := DefaultConfig()
+	if defaults.Backends.Default != "claude" {
+		t.Errorf("default Backends.Default = %q, want %q", defaults.Backends.Default, "claude")
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "command backend mapped to agent",
+			content: `backends:
+  default: claude
+  agents:
+    golang-pro: my-cli
+  definitions:
+    my-cli:
+      type: command
+      command: my-agent
+      args: ["--model", "{{.Model}}"]
+`,
+		},
+		{
+			name:    "fake default",
+			content: "backends:\n  default: fake\n",
+		},
+		{
+			name:        "unknown default",
+			content:     "backends:\n  default: codex\n",
+			wantErr:     true,
+			errContains: "backends.default refers to unknown backend",
+		},
+		{
+			name:        "command without command",
+			content:     "backends:\n  definitions:\n    broken:\n      type: command\n",
+			wantErr:     true,
+			errContains: "command is required",
+		},
+		{
+			name:        "invalid type",
+			content:     "backends:\n  definitions:\n    broken:\n      type: http\n",
+			wantErr:     true,
+			errContains: "type must be one of",
+		},
+		{
+			name:        "agent mapped to unknown backend",
+			content:     "backends:\n  agents:\n    golang-pro: missing\n",
+			wantErr:     true,
+			errContains: "backends.agents.golang-pro refers to unknown backend",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cfg, err := LoadConfig(configPath)
+			if err == nil {
+				err = cfg.Validate()
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error = %q, want substring %q", err.Error(), tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+		})
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(tests[0].content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	def := cfg.Backends.Definitions["my-cli"]
+	if def.Type != BackendTypeCommand || def.Command != "my-agent" || len(def.Args) != 2 {
+		t.Errorf("unexpected my-cli definition: %+v", def)
+	}
+	if cfg.Backends.Agents["golang-pro"] != "my-cli" {
+		t.Errorf("Backends.Agents[golang-pro] = %q, want my-cli", cfg.Backends.Agents["golang-pro"])
+	}
+}
+
 // TestDefaultTTSConfig tests default TTS configuration values
 func TestDefaultTTSConfig(t *testing.T) {
 	cfg := DefaultTTSConfig()
@@ -3175,3 +3463,548 @@ metrics:
 		t.Errorf("MaxConcurrency = %d, want 4", cfg.MaxConcurrency)
 	}
 }
+
+func TestLoadConfigNotifications(t *testing.T) {
+	if got := DefaultConfig().Notifications.Webhooks; len(got) != 0 {
+		t.Errorf("default Notifications.Webhooks = %v, want none", got)
+	}
+
+	content := `notifications:
+  webhooks:
+    - name: chat
+      url: https://hooks.example.com/T000
+      events: [task.failed, rate_limit.pause]
+      secret_env: CHAT_WEBHOOK_SECRET
+      template: '{"text": "{{.Message}}"}'
+    - url: http://localhost:9000/events
+      max_retries: 0
+      headers:
+        Authorization: Bearer abc
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	webhooks := cfg.Notifications.Webhooks
+	if len(webhooks) != 2 {
+		t.Fatalf("expected 2 webhooks, got %d", len(webhooks))
+	}
+	if webhooks[0].Name != "chat" || len(webhooks[0].Events) != 2 || webhooks[0].SecretEnv != "CHAT_WEBHOOK_SECRET" {
+		t.Errorf("unexpected first webhook: %+v", webhooks[0])
+	}
+	if webhooks[0].MaxRetries != DefaultWebhookMaxRetries {
+		t.Errorf("unset max_retries = %d, want default %d", webhooks[0].MaxRetries, DefaultWebhookMaxRetries)
+	}
+	if webhooks[1].MaxRetries != 0 || webhooks[1].Headers["Authorization"] != "Bearer abc" {
+		t.Errorf("unexpected second webhook: %+v", webhooks[1])
+	}
+
+	invalid := []struct {
+		content     string
+		errContains string
+	}{
+		{"notifications:\n  webhooks:\n    - name: empty\n", "notifications.webhooks[0].url is required"},
+		{"notifications:\n  webhooks:\n    - url: ftp://example.com\n", "must be an http or https URL"},
+		{"notifications:\n  webhooks:\n    - url: https://example.com\n      max_retries: -1\n", "max_retries must be >= 0"},
+	}
+	for _, tt := range invalid {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+			t.Fatalf("failed to write test config: %v", err)
+		}
+		cfg, err := LoadConfig(configPath)
+		if err == nil {
+			err = cfg.Validate()
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+			t.Errorf("error = %v, want substring %q", err, tt.errContains)
+		}
+	}
+}
+
+func TestLoadConfigQCResultCache(t *testing.T) {
+	defaults := DefaultConfig().QualityControl.ResultCache
+	if defaults.Enabled || defaults.TTL != DefaultQCResultCacheTTL {
+		t.Errorf("default ResultCache = %+v, want disabled with %v TTL", defaults, DefaultQCResultCacheTTL)
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		wantEnabled bool
+		wantTTL     time.Duration
+		wantErr     bool
+	}{
+		{
+			name: "enabled with default ttl",
+			content: `quality_control:
+  enabled: true
+  result_cache:
+    enabled: true
+`,
+			wantEnabled: true,
+			wantTTL:     DefaultQCResultCacheTTL,
+		},
+		{
+			name: "custom ttl",
+			content: `quality_control:
+  enabled: true
+  result_cache:
+    enabled: true
+    ttl: 24h
+`,
+			wantEnabled: true,
+			wantTTL:     24 * time.Hour,
+		},
+		{
+			name: "negative ttl rejected",
+			content: `quality_control:
+  enabled: true
+  result_cache:
+    ttl: -1h
+`,
+			wantTTL: -time.Hour,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+			cfg, err := LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+
+			got := cfg.QualityControl.ResultCache
+			if got.Enabled != tt.wantEnabled || got.TTL != tt.wantTTL {
+				t.Errorf("ResultCache = %+v, want enabled=%v ttl=%v", got, tt.wantEnabled, tt.wantTTL)
+			}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigBudgetSpendCaps(t *testing.T) {
+	defaults := DefaultConfig().Budget
+	if defaults.MaxRunUSD != 0 || defaults.MaxTaskUSD != 0 {
+		t.Errorf("default spend caps = %v/%v, want no limit", defaults.MaxRunUSD, defaults.MaxTaskUSD)
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		wantRunUSD  float64
+		wantTaskUSD float64
+		wantErr     bool
+	}{
+		{
+			name: "both caps",
+			content: `budget:
+  enabled: true
+  max_run_usd: 25
+  max_task_usd: 2.5
+`,
+			wantRunUSD:  25,
+			wantTaskUSD: 2.5,
+		},
+		{
+			name: "run cap only",
+			content: `budget:
+  enabled: true
+  max_run_usd: 10.75
+`,
+			wantRunUSD: 10.75,
+		},
+		{
+			name: "negative cap rejected",
+			content: `budget:
+  enabled: true
+  max_task_usd: -1
+`,
+			wantTaskUSD: -1,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+			cfg, err := LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+
+			if cfg.Budget.MaxRunUSD != tt.wantRunUSD || cfg.Budget.MaxTaskUSD != tt.wantTaskUSD {
+				t.Errorf("spend caps = %v/%v, want %v/%v", cfg.Budget.MaxRunUSD, cfg.Budget.MaxTaskUSD, tt.wantRunUSD, tt.wantTaskUSD)
+			}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigModelRouting(t *testing.T) {
+	defaults := DefaultConfig().ModelRouting
+	if defaults.Enabled || defaults.Default != "sonnet" || len(defaults.Tiers) != 3 || !defaults.EscalateOnRed {
+		t.Errorf("unexpected model routing defaults: %+v", defaults)
+	}
+
+	content := `model_routing:
+  enabled: true
+  default: haiku
+  complex_min_estimate: 45m
+  escalate_on_red: false
+  task_types:
+    integration: opus
+  internal:
+    qc_selection: haiku
+    setup_introspection: sonnet
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	routing := cfg.ModelRouting
+	if !routing.Enabled || routing.Default != "haiku" || routing.EscalateOnRed {
+		t.Errorf("unexpected routing config: %+v", routing)
+	}
+	if routing.ComplexMinEstimate != 45*time.Minute || routing.ComplexMinFiles != 6 {
+		t.Errorf("thresholds = %v/%d, want 45m and default 6 files", routing.ComplexMinEstimate, routing.ComplexMinFiles)
+	}
+	if routing.TaskTypes["integration"] != "opus" || routing.Internal.QCSelection != "haiku" || routing.Internal.SetupIntrospection != "sonnet" {
+		t.Errorf("unexpected task types/internal models: %+v / %+v", routing.TaskTypes, routing.Internal)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.ModelRouting.FailureRate = 1.5
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected failure_rate above 1 to be rejected")
+	}
+}
+
+func TestLoadConfigRiskGate(t *testing.T) {
+	defaults := DefaultConfig().RiskGate
+	if defaults.Enabled || defaults.Threshold != 0.7 || !defaults.HasAction(RiskActionExtraQC) || defaults.QCAgent != "code-reviewer" {
+		t.Errorf("unexpected risk gate defaults: %+v", defaults)
+	}
+
+	content := `risk_gate:
+  enabled: true
+  threshold: 0.6
+  actions: [stronger_agent, approval]
+  escalation_agent: golang-pro
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	risk := cfg.RiskGate
+	if !risk.Enabled || risk.Threshold != 0.6 || risk.EscalationAgent != "golang-pro" || risk.MinSessions != 10 {
+		t.Errorf("unexpected risk gate config: %+v", risk)
+	}
+	if !risk.HasAction(RiskActionStrongerAgent) || !risk.HasAction(RiskActionApproval) || risk.HasAction(RiskActionExtraQC) {
+		t.Errorf("actions = %v, want stronger_agent and approval", risk.Actions)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.RiskGate.Actions = []string{"pray"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected unknown action to be rejected")
+	}
+}
+
+func TestLoadConfigStreamOutput(t *testing.T) {
+	defaults := DefaultConfig().Executor
+	if defaults.StreamOutput || defaults.StallTimeout != 10*time.Minute || defaults.StallRetries != 1 {
+		t.Errorf("unexpected streaming defaults: %v/%v/%d", defaults.StreamOutput, defaults.StallTimeout, defaults.StallRetries)
+	}
+
+	content := `executor:
+  stream_output: true
+  stall_timeout: 5m
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if !cfg.Executor.StreamOutput || cfg.Executor.StallTimeout != 5*time.Minute || cfg.Executor.StallRetries != 1 {
+		t.Errorf("unexpected executor streaming config: %+v", cfg.Executor)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.Executor.StallTimeout = -time.Minute
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected negative stall_timeout to be rejected")
+	}
+}
+
+func TestLoadConfigMaxGeneratedTasks(t *testing.T) {
+	if got := DefaultConfig().Executor.MaxGeneratedTasks; got != 0 {
+		t.Errorf("default max_generated_tasks = %d, want 0", got)
+	}
+
+	content := `executor:
+  max_generated_tasks: 5
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Executor.MaxGeneratedTasks != 5 {
+		t.Errorf("max_generated_tasks = %d, want 5", cfg.Executor.MaxGeneratedTasks)
+	}
+
+	cfg.Executor.MaxGeneratedTasks = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected negative max_generated_tasks to be rejected")
+	}
+}
+
+func TestLoadConfigContextBudget(t *testing.T) {
+	defaults := DefaultConfig().Executor.ContextBudget
+	if defaults.MaxTokens != 0 || defaults.SummaryTokens != 300 || defaults.Priorities[ContextSectionQCFeedback] != 90 {
+		t.Errorf("unexpected context budget defaults: %+v", defaults)
+	}
+
+	content := `executor:
+  context_budget:
+    max_tokens: 8000
+    priorities:
+      warm_up: 95
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	budget := cfg.Executor.ContextBudget
+	if budget.MaxTokens != 8000 || budget.SummaryTokens != 300 {
+		t.Errorf("unexpected context budget: %+v", budget)
+	}
+	if budget.Priorities[ContextSectionWarmUp] != 95 || budget.Priorities[ContextSectionPattern] != 40 {
+		t.Errorf("listed priorities should overlay the defaults: %v", budget.Priorities)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.Executor.ContextBudget.Priorities["behavior"] = 10
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected unknown context section to be rejected")
+	}
+}
+
+func TestLoadConfigQCAggregation(t *testing.T) {
+	defaults := DefaultConfig().QualityControl.Aggregation
+	if defaults.Strategy != QCAggregationStrictest || defaults.Quorum != 2 || defaults.MinSamples != 5 || len(defaults.VetoAgents) != 0 {
+		t.Errorf("unexpected aggregation defaults: %+v", defaults)
+	}
+
+	content := `quality_control:
+  enabled: true
+  agents:
+    mode: auto
+  aggregation:
+    strategy: quorum
+    quorum: 3
+    veto_agents: [security-auditor]
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	agg := cfg.QualityControl.Aggregation
+	if agg.Strategy != QCAggregationQuorum || agg.Quorum != 3 || agg.MinSamples != 5 {
+		t.Errorf("unexpected aggregation config: %+v", agg)
+	}
+	if len(agg.VetoAgents) != 1 || agg.VetoAgents[0] != "security-auditor" {
+		t.Errorf("VetoAgents = %v, want [security-auditor]", agg.VetoAgents)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.QualityControl.Aggregation.Quorum = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected quorum 0 to be rejected for the quorum strategy")
+	}
+	cfg.QualityControl.Aggregation.Strategy = "unanimous"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected unknown strategy to be rejected")
+	}
+}
+
+func TestLoadConfigQCCheckers(t *testing.T) {
+	if !DefaultConfig().QualityControl.CheckerGate {
+		t.Error("checker_gate should default to true")
+	}
+
+	content := `quality_control:
+  enabled: true
+  agents:
+    mode: explicit
+    explicit_list: [check:go-vet, check:eslint, code-reviewer]
+  checker_gate: false
+  checkers:
+    eslint:
+      command: "npx eslint {files}"
+      extensions: [.ts, .tsx]
+      yellow_exit_codes: [1]
+      issue_pattern: '^(?P<file>\S+):(?P<line>\d+)'
+      timeout: 2m
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	qc := cfg.QualityControl
+	if qc.CheckerGate {
+		t.Error("checker_gate: false should be honored")
+	}
+	eslint, ok := qc.Checkers["eslint"]
+	if !ok || eslint.Command != "npx eslint {files}" || len(eslint.Extensions) != 2 || eslint.Timeout != 2*time.Minute {
+		t.Fatalf("unexpected eslint checker: %+v", eslint)
+	}
+	if len(eslint.YellowExitCodes) != 1 || eslint.YellowExitCodes[0] != 1 {
+		t.Errorf("YellowExitCodes = %v, want [1]", eslint.YellowExitCodes)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.QualityControl.Checkers["eslint"] = QCCheckerConfig{IssuePattern: "^x"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected checker without a command to be rejected")
+	}
+	cfg.QualityControl.Checkers["eslint"] = QCCheckerConfig{Command: "eslint", IssuePattern: "("}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected invalid issue_pattern to be rejected")
+	}
+}
+
+func TestLoadConfigQCDiffReview(t *testing.T) {
+	defaults := DefaultConfig().QualityControl.DiffReview
+	if defaults.Enabled || defaults.ChunkBytes != 40000 || defaults.MaxChunks != 5 {
+		t.Errorf("unexpected diff_review defaults: %+v", defaults)
+	}
+
+	content := `quality_control:
+  enabled: true
+  diff_review:
+    enabled: true
+    chunk_bytes: 20000
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	diff := cfg.QualityControl.DiffReview
+	if !diff.Enabled || diff.ChunkBytes != 20000 || diff.MaxChunks != 5 {
+		t.Errorf("unexpected diff_review config: %+v", diff)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.QualityControl.DiffReview.MaxChunks = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected max_chunks 0 to be rejected")
+	}
+}
+
+func TestLoadConfigQCCalibration(t *testing.T) {
+	defaults := DefaultConfig().QualityControl.Calibration
+	if !defaults.Enabled || defaults.MinSamples != 10 || defaults.MinAccuracy != 0.5 {
+		t.Errorf("unexpected calibration defaults: %+v", defaults)
+	}
+
+	content := `quality_control:
+  enabled: true
+  calibration:
+    enabled: false
+    min_accuracy: 0.7
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	cal := cfg.QualityControl.Calibration
+	if cal.Enabled || cal.MinSamples != 10 || cal.MinAccuracy != 0.7 {
+		t.Errorf("unexpected calibration config: %+v", cal)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.QualityControl.Calibration.MinAccuracy = 1.5
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected min_accuracy above 1 to be rejected")
+	}
+}
@@ -0,0 +1,226 @@
+// Package control exposes live run control over a Unix domain socket (v3.6+).
+//
+// A running `conductor run` serves the socket; `conductor ctl` connects to it to
+// pause and resume new task launches, cancel an in-flight task, skip a pending
+// task or change max concurrency. The protocol is one JSON Request per
+// connection answered by one JSON Response.
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// ActionStatus requests a status snapshot without changing anything.
+const ActionStatus = "status"
+
+// DefaultDialTimeout bounds how long a client waits for the socket.
+const DefaultDialTimeout = 5 * time.Second
+
+// Request is a control command sent by a client.
+type Request struct {
+	Action string `json:"action"`
+	Task   string `json:"task,omitempty"`
+	Value  int    `json:"value,omitempty"`
+	Actor  string `json:"actor,omitempty"`
+}
+
+// Response is the server's answer to a Request.
+type Response struct {
+	OK     bool                  `json:"ok"`
+	Error  string                `json:"error,omitempty"`
+	Status *models.ControlStatus `json:"status,omitempty"`
+}
+
+// Controller is the run state a Server operates on.
+// executor.RunController implements it.
+type Controller interface {
+	Pause(actor string) error
+	Resume(actor string) error
+	CancelTask(taskNumber, actor string) error
+	SkipTask(taskNumber, actor string) error
+	SetMaxConcurrency(limit int, actor string) error
+	Status() models.ControlStatus
+}
+
+// Server serves control requests on a Unix domain socket.
+type Server struct {
+	path       string
+	controller Controller
+
+	mu       sync.Mutex
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewServer creates a Server for the socket path.
+func NewServer(path string, controller Controller) *Server {
+	return &Server{path: path, controller: controller}
+}
+
+// Path returns the socket path.
+func (s *Server) Path() string {
+	return s.path
+}
+
+// Start listens on the socket and serves requests in the background.
+// A stale socket file left by a crashed run is replaced; a socket owned by
+// another live run is an error.
+func (s *Server) Start() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create control socket directory: %w", err)
+	}
+	if _, err := os.Stat(s.path); err == nil {
+		if conn, dialErr := net.DialTimeout("unix", s.path, time.Second); dialErr == nil {
+			conn.Close()
+			return fmt.Errorf("control socket %s is in use by another run", s.path)
+		}
+		if err := os.Remove(s.path); err != nil {
+			return fmt.Errorf("failed to remove stale control socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+	if err := os.Chmod(s.path, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to restrict control socket permissions: %w", err)
+	}
+
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.serve(listener)
+	return nil
+}
+
+// Close stops serving and removes the socket file.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	listener := s.listener
+	s.listener = nil
+	s.mu.Unlock()
+
+	if listener == nil {
+		return nil
+	}
+	err := listener.Close()
+	s.wg.Wait()
+	os.Remove(s.path)
+	return err
+}
+
+// serve accepts connections until the listener is closed.
+func (s *Server) serve(listener net.Listener) {
+	defer s.wg.Done()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+// handle answers a single request.
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(DefaultDialTimeout))
+
+	var req Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		json.NewEncoder(conn).Encode(Response{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+	json.NewEncoder(conn).Encode(s.Dispatch(req))
+}
+
+// Dispatch applies a request to the controller and returns the response.
+func (s *Server) Dispatch(req Request) Response {
+	actor := req.Actor
+	if actor == "" {
+		actor = "unknown"
+	}
+
+	var err error
+	switch req.Action {
+	case ActionStatus:
+	case models.ControlActionPause:
+		err = s.controller.Pause(actor)
+	case models.ControlActionResume:
+		err = s.controller.Resume(actor)
+	case models.ControlActionCancel:
+		err = requireTask(req)
+		if err == nil {
+			err = s.controller.CancelTask(req.Task, actor)
+		}
+	case models.ControlActionSkip:
+		err = requireTask(req)
+		if err == nil {
+			err = s.controller.SkipTask(req.Task, actor)
+		}
+	case models.ControlActionConcurrency:
+		err = s.controller.SetMaxConcurrency(req.Value, actor)
+	default:
+		err = fmt.Errorf("unknown action %q", req.Action)
+	}
+
+	status := s.controller.Status()
+	if err != nil {
+		return Response{Error: err.Error(), Status: &status}
+	}
+	return Response{OK: true, Status: &status}
+}
+
+// requireTask checks that a task-scoped request names a task.
+func requireTask(req Request) error {
+	if req.Task == "" {
+		return fmt.Errorf("%s requires a task number", req.Action)
+	}
+	return nil
+}
+
+// Send delivers a request to the server at path and returns its response.
+// A response with OK=false is returned as an error alongside the response.
+func Send(ctx context.Context, path string, req Request) (*Response, error) {
+	dialer := net.Dialer{Timeout: DefaultDialTimeout}
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("no conductor run is listening on %s: %w", path, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(DefaultDialTimeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send control request: %w", err)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read control response: %w", err)
+	}
+	if !resp.OK {
+		return &resp, errors.New(resp.Error)
+	}
+	return &resp, nil
+}
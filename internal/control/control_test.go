@@ -0,0 +1,167 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// fakeController records the calls made by the server.
+type fakeController struct {
+	mu     sync.Mutex
+	calls  []string
+	paused bool
+}
+
+func (f *fakeController) record(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeController) Pause(actor string) error {
+	f.record("pause:" + actor)
+	f.paused = true
+	return nil
+}
+
+func (f *fakeController) Resume(actor string) error {
+	f.record("resume:" + actor)
+	f.paused = false
+	return nil
+}
+
+func (f *fakeController) CancelTask(taskNumber, actor string) error {
+	if taskNumber == "9" {
+		return fmt.Errorf("task 9 is not part of this run")
+	}
+	f.record("cancel:" + taskNumber + ":" + actor)
+	return nil
+}
+
+func (f *fakeController) SkipTask(taskNumber, actor string) error {
+	f.record("skip:" + taskNumber + ":" + actor)
+	return nil
+}
+
+func (f *fakeController) SetMaxConcurrency(limit int, actor string) error {
+	f.record(fmt.Sprintf("concurrency:%d:%s", limit, actor))
+	return nil
+}
+
+func (f *fakeController) Status() models.ControlStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return models.ControlStatus{Paused: f.paused, Pending: []string{"2"}, Running: []string{"1"}}
+}
+
+func startTestServer(t *testing.T, controller Controller) *Server {
+	t.Helper()
+	server := NewServer(filepath.Join(t.TempDir(), "ctl.sock"), controller)
+	if err := server.Start(); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+func TestServer_RoundTrip(t *testing.T) {
+	controller := &fakeController{}
+	server := startTestServer(t, controller)
+	ctx := context.Background()
+
+	requests := []Request{
+		{Action: models.ControlActionPause, Actor: "alice"},
+		{Action: models.ControlActionCancel, Task: "1", Actor: "alice"},
+		{Action: models.ControlActionSkip, Task: "2"},
+		{Action: models.ControlActionConcurrency, Value: 2, Actor: "bob"},
+		{Action: models.ControlActionResume, Actor: "bob"},
+	}
+	for _, req := range requests {
+		resp, err := Send(ctx, server.Path(), req)
+		if err != nil {
+			t.Fatalf("Send(%s) returned error: %v", req.Action, err)
+		}
+		if !resp.OK || resp.Status == nil {
+			t.Errorf("Send(%s) response = %+v", req.Action, resp)
+		}
+	}
+
+	want := []string{"pause:alice", "cancel:1:alice", "skip:2:unknown", "concurrency:2:bob", "resume:bob"}
+	if strings.Join(controller.calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", controller.calls, want)
+	}
+
+	resp, err := Send(ctx, server.Path(), Request{Action: ActionStatus})
+	if err != nil {
+		t.Fatalf("status returned error: %v", err)
+	}
+	if len(resp.Status.Running) != 1 || resp.Status.Running[0] != "1" {
+		t.Errorf("status = %+v", resp.Status)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	server := startTestServer(t, &fakeController{})
+	ctx := context.Background()
+
+	tests := []struct {
+		req  Request
+		want string
+	}{
+		{Request{Action: "reboot"}, "unknown action"},
+		{Request{Action: models.ControlActionCancel}, "requires a task number"},
+		{Request{Action: models.ControlActionCancel, Task: "9"}, "not part of this run"},
+	}
+	for _, tt := range tests {
+		resp, err := Send(ctx, server.Path(), tt.req)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Send(%+v) error = %v, want %q", tt.req, err, tt.want)
+		}
+		if resp == nil || resp.OK {
+			t.Errorf("Send(%+v) response = %+v, want not OK", tt.req, resp)
+		}
+	}
+
+	if _, err := Send(ctx, filepath.Join(t.TempDir(), "missing.sock"), Request{Action: ActionStatus}); err == nil {
+		t.Error("expected error when no server is listening")
+	}
+}
+
+func TestServer_SocketLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ctl.sock")
+
+	// A stale socket file from a crashed run is replaced
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("failed to create stale socket: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	server := NewServer(path, &fakeController{})
+	if err := server.Start(); err != nil {
+		t.Fatalf("Start over stale socket returned error: %v", err)
+	}
+
+	// A live socket belongs to another run
+	other := NewServer(path, &fakeController{})
+	if err := other.Start(); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("Start on live socket error = %v, want in use", err)
+	}
+
+	if err := server.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket file should be removed on Close, stat err = %v", err)
+	}
+}
@@ -0,0 +1,348 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harrison/conductor/internal/approval"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// Defaults for ApprovalGate values left at zero.
+const (
+	defaultApprovalPollInterval = 2 * time.Second
+	maxApprovalDiffBytes        = 200000
+	maxApprovalFeedbackChars    = 300
+)
+
+// ApprovalGate holds `type: approval` tasks until someone runs
+// `conductor approve` or `conductor reject`, so their dependents wait for a
+// human sign-off (v3.6+). Requests are persisted in Store, so a gate survives
+// restarts: a run reaching a decided gate uses the decision right away.
+type ApprovalGate struct {
+	Store        *approval.Store
+	PollInterval time.Duration // How often a waiting gate checks for a decision (default 2s)
+
+	runs runRecorder // What the upstream tasks did in this run
+}
+
+// NewApprovalGate creates an ApprovalGate persisting requests in store.
+func NewApprovalGate(store *approval.Store) *ApprovalGate {
+	return &ApprovalGate{Store: store}
+}
+
+// recordStart remembers HEAD before a task runs, the base of the diff its gates show.
+func (g *ApprovalGate) recordStart(ctx context.Context, task models.Task, workDir string) {
+	g.runs.start(ctx, task, workDir)
+}
+
+// recordResult remembers a task's QC outcome for the gates downstream of it.
+func (g *ApprovalGate) recordResult(ctx context.Context, result models.TaskResult, workDir string) {
+	g.runs.finish(ctx, result, workDir)
+}
+
+// upstreamOf returns the numbers of the tasks gate depends on, directly or
+// through other tasks, in plan order.
+func upstreamOf(plan *models.Plan, gate models.Task) []string {
+	if plan == nil {
+		return dependencyNumbers(gate.DependsOn)
+	}
+	byNumber := make(map[string]models.Task, len(plan.Tasks))
+	for _, t := range plan.Tasks {
+		byNumber[t.Number] = t
+	}
+
+	seen := make(map[string]bool)
+	queue := dependencyNumbers(gate.DependsOn)
+	for len(queue) > 0 {
+		number := queue[0]
+		queue = queue[1:]
+		if seen[number] {
+			continue
+		}
+		seen[number] = true
+		queue = append(queue, dependencyNumbers(byNumber[number].DependsOn)...)
+	}
+
+	var numbers []string
+	for _, t := range plan.Tasks {
+		if seen[t.Number] {
+			numbers = append(numbers, t.Number)
+		}
+	}
+	return numbers
+}
+
+// dependencyNumbers returns the task numbers of dependencies, resolving
+// cross-file dependencies to the task they name.
+func dependencyNumbers(deps []string) []string {
+	numbers := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if models.IsCrossFileDep(dep) {
+			if cfd, err := models.ParseCrossFileDep(dep); err == nil {
+				dep = cfd.TaskID
+			}
+		}
+		numbers = append(numbers, dep)
+	}
+	return numbers
+}
+
+// buildApprovalRequest summarizes the QC results and the accumulated diff of
+// the gate's upstream tasks.
+func (te *DefaultTaskExecutor) buildApprovalRequest(ctx context.Context, task models.Task, planFile string) *approval.Request {
+	g := te.ApprovalGate
+	plan := te.currentPlan()
+	upstream := upstreamOf(plan, task)
+	names := make(map[string]string)
+	if plan != nil {
+		for _, t := range plan.Tasks {
+			names[t.Number] = t.Name
+		}
+	}
+
+	var summary strings.Builder
+	summary.WriteString("Upstream tasks:\n")
+	if len(upstream) == 0 {
+		summary.WriteString("- none\n")
+	}
+
+	base, baseOrder := "", 0
+	for _, number := range upstream {
+		label := fmt.Sprintf("Task %s", number)
+		if name := names[number]; name != "" {
+			label += " (" + name + ")"
+		}
+
+		u, ranHere := g.runs.get(number)
+		switch {
+		case ranHere && u.finished:
+			line := fmt.Sprintf("- %s: %s", label, u.status)
+			if u.agent != "" {
+				line += " by " + u.agent
+			}
+			if feedback := oneLine(u.feedback, maxApprovalFeedbackChars); feedback != "" {
+				line += " - " + feedback
+			}
+			summary.WriteString(line + "\n")
+		case ranHere:
+			summary.WriteString(fmt.Sprintf("- %s: still running\n", label))
+		default:
+			summary.WriteString(fmt.Sprintf("- %s: %s\n", label, te.earlierQCSummary(ctx, planFile, number)))
+		}
+		if ranHere && u.base != "" && (baseOrder == 0 || u.order < baseOrder) {
+			base, baseOrder = u.base, u.order
+		}
+	}
+
+	req := &approval.Request{
+		PlanFile:    planFile,
+		TaskNumber:  task.Number,
+		TaskName:    task.Name,
+		Status:      approval.StatusPending,
+		RequestedAt: time.Now(),
+	}
+
+	if base == "" {
+		summary.WriteString("\nNo diff: the upstream tasks ran before this run.\n")
+		req.Summary = summary.String()
+		return req
+	}
+	diffTask := models.Task{Metadata: map[string]interface{}{qcDiffBaseKey: base}}
+	diff, err := CollectTaskDiff(ctx, NewShellCommandRunner(te.workDirFor(task)), diffTask, nil)
+	if err != nil {
+		summary.WriteString(fmt.Sprintf("\nNo diff: %v\n", err))
+		req.Summary = summary.String()
+		return req
+	}
+
+	summary.WriteString(fmt.Sprintf("\n%s, %d files:\n", upperFirst(diff.Source), len(diff.Files)))
+	var patch strings.Builder
+	for _, f := range diff.Files {
+		added, deleted := diffLineCounts(f.Patch)
+		summary.WriteString(fmt.Sprintf("  %s +%d -%d\n", f.Path, added, deleted))
+		patch.WriteString(f.Patch)
+		if !strings.HasSuffix(f.Patch, "\n") {
+			patch.WriteString("\n")
+		}
+	}
+	req.Summary = summary.String()
+	req.Diff = truncateOutput(patch.String(), maxApprovalDiffBytes)
+	return req
+}
+
+// earlierQCSummary describes the last QC verdict of a task that completed
+// before this run, from the learning database when there is one.
+func (te *DefaultTaskExecutor) earlierQCSummary(ctx context.Context, planFile, number string) string {
+	if te.LearningStore != nil {
+		if history, err := te.LearningStore.GetExecutionHistory(ctx, planFile, number); err == nil && len(history) > 0 && history[0].QCVerdict != "" {
+			line := history[0].QCVerdict + " in an earlier run"
+			if feedback := oneLine(history[0].QCFeedback, maxApprovalFeedbackChars); feedback != "" {
+				line += " - " + feedback
+			}
+			return line
+		}
+	}
+	return "completed in an earlier run"
+}
+
+// diffLineCounts returns the lines a unified diff adds and removes.
+func diffLineCounts(patch string) (added, deleted int) {
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			deleted++
+		}
+	}
+	return added, deleted
+}
+
+// oneLine collapses s to a single line of at most max characters.
+func oneLine(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > max {
+		s = s[:max] + "..."
+	}
+	return s
+}
+
+// upperFirst capitalizes the first letter of s.
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// executeApproval runs an approval gate: it uses a decision already recorded
+// for the gate, or saves a pending request and waits for one. A cancelled run
+// leaves the request pending for the next run.
+func (te *DefaultTaskExecutor) executeApproval(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	result := models.TaskResult{Task: task}
+	start := te.clock()
+	fail := func(err error) (models.TaskResult, error) {
+		result.Status = models.StatusFailed
+		result.Error = err
+		result.Duration = te.clock().Sub(start)
+		te.updatePlanStatusLocked(task, StatusFailed, false)
+		return result, err
+	}
+
+	g := te.ApprovalGate
+	if g == nil || g.Store == nil {
+		return fail(fmt.Errorf("task %s is an approval gate but approval gates are not configured", task.Number))
+	}
+	planFile := te.planFileFor(task)
+
+	req, err := g.Store.Load(planFile, task.Number)
+	if errors.Is(err, approval.ErrNoRequest) {
+		req = te.buildApprovalRequest(ctx, task, planFile)
+		err = g.Store.Create(req)
+		if errors.Is(err, approval.ErrRequestExists) {
+			// Decided while the request was being built; use the decision
+			req, err = g.Store.Load(planFile, task.Number)
+		}
+	}
+	if err != nil {
+		return fail(fmt.Errorf("approval gate %s: %w", task.Number, err))
+	}
+
+	if !req.Decided() {
+		te.updatePlanStatusLocked(task, StatusInProgress, false)
+		if te.Logger != nil {
+			te.Logger.Infof("[Task %s] Waiting for approval: %s\n%s\nApprove: conductor approve %s %s\nReject:  conductor reject %s %s --reason \"...\"",
+				task.Number, task.Name, strings.TrimRight(req.Summary, "\n"), planFile, task.Number, planFile, task.Number)
+		}
+		req, err = te.waitForDecision(ctx, planFile, task.Number)
+		if err != nil {
+			return fail(fmt.Errorf("approval gate %s: %w", task.Number, err))
+		}
+	}
+
+	// A decision is used once; the next run reaching the gate asks again
+	if err := g.Store.Delete(planFile, task.Number); err != nil && te.Logger != nil {
+		te.Logger.Warnf("Approval gate %s: %v", task.Number, err)
+	}
+
+	result.Duration = te.clock().Sub(start)
+	if req.Status == approval.StatusRejected {
+		err := fmt.Errorf("rejected by %s: %s", req.DecidedBy, req.Reason)
+		result.Status = models.StatusFailed
+		result.Error = err
+		result.ReviewFeedback = err.Error()
+		te.recordApprovalDecision(task, req.Summary, result.ReviewFeedback, models.StatusRed, StatusFailed)
+		return result, err
+	}
+
+	result.Status = models.StatusGreen
+	result.ReviewFeedback = "Approved by " + req.DecidedBy
+	if req.Reason != "" {
+		result.ReviewFeedback += ": " + req.Reason
+	}
+	te.recordApprovalDecision(task, req.Summary, result.ReviewFeedback, models.StatusGreen, StatusCompleted)
+	return result, nil
+}
+
+// waitForDecision polls the store until the gate is approved or rejected.
+func (te *DefaultTaskExecutor) waitForDecision(ctx context.Context, planFile, taskNumber string) (*approval.Request, error) {
+	interval := te.ApprovalGate.PollInterval
+	if interval <= 0 {
+		interval = defaultApprovalPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+			req, err := te.ApprovalGate.Store.Load(planFile, taskNumber)
+			if err != nil {
+				// Deleted or half-written by hand: keep waiting for a decision
+				continue
+			}
+			if req.Decided() {
+				return req, nil
+			}
+		}
+	}
+}
+
+// updatePlanStatusLocked updates a task that runs no agent, such as an approval
+// gate or a skipped task, in the plan file under the plan's file lock.
+func (te *DefaultTaskExecutor) updatePlanStatusLocked(task models.Task, status string, markComplete bool) {
+	if te.FileLockManager != nil {
+		unlock := te.FileLockManager.Lock(te.planFileFor(task))
+		defer unlock()
+	}
+	_ = te.updatePlanStatus(task, status, markComplete)
+}
+
+// recordApprovalDecision writes the decision to the gate's execution history
+// and status in the plan file.
+func (te *DefaultTaskExecutor) recordApprovalDecision(task models.Task, summary, decision, verdict, status string) {
+	if te.FileLockManager != nil {
+		unlock := te.FileLockManager.Lock(te.planFileFor(task))
+		defer unlock()
+	}
+	_ = te.updateFeedback(task, 1, summary, decision, verdict)
+	_ = te.updatePlanStatus(task, status, status == StatusCompleted)
+}
+
+// planFileFor returns the plan file task belongs to (same priority as updatePlanStatus).
+func (te *DefaultTaskExecutor) planFileFor(task models.Task) string {
+	if task.SourceFile != "" {
+		return task.SourceFile
+	}
+	if te.SourceFile != "" {
+		return te.SourceFile
+	}
+	return te.cfg.PlanPath
+}
@@ -0,0 +1,147 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/approval"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func newApprovalTestExecutor(t *testing.T, invoker InvokerInterface, workDir string) (*DefaultTaskExecutor, *recordingUpdater, *approval.Store) {
+	t.Helper()
+	updater := &recordingUpdater{}
+	te, err := NewTaskExecutor(invoker, nil, updater, TaskExecutorConfig{PlanPath: "plan.md"})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	store := approval.NewStore(filepath.Join(t.TempDir(), "approvals"))
+	te.ApprovalGate = NewApprovalGate(store)
+	te.ApprovalGate.PollInterval = 10 * time.Millisecond
+	te.WorkDir = workDir
+	te.Plan = &models.Plan{Tasks: []models.Task{
+		{Number: "1", Name: "Add migration", DependsOn: nil},
+		{Number: "2", Name: "Update handlers", DependsOn: []string{"1"}},
+		{Number: "3", Name: "Sign off schema", Type: "approval", DependsOn: []string{"2"}},
+	}}
+	return te, updater, store
+}
+
+func TestExecuteApproval_WaitsForDecision(t *testing.T) {
+	dir := setupGitRepo(t)
+	invoker := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			if task.Number == "3" {
+				t.Error("an approval gate must not invoke an agent")
+			}
+			if err := os.WriteFile(filepath.Join(dir, "migration.sql"), []byte("ALTER TABLE users ADD email TEXT;\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			return &agent.InvocationResult{Output: `{"content":"done"}`}, nil
+		},
+	}
+	te, updater, store := newApprovalTestExecutor(t, invoker, dir)
+
+	// Task 1 runs in this run; task 2 completed in an earlier one
+	if _, err := te.Execute(context.Background(), models.Task{Number: "1", Name: "Add migration", Prompt: "Add it", Agent: "golang-pro"}); err != nil {
+		t.Fatalf("upstream task failed: %v", err)
+	}
+
+	gate := te.Plan.Tasks[2]
+	go func() {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if req, err := store.Load("plan.md", "3"); err == nil && req.Status == approval.StatusPending {
+				if _, err := store.Decide("plan.md", "3", gate.Name, approval.StatusApproved, "alice", "reviewed"); err != nil {
+					t.Error(err)
+				}
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	updater.calls = nil
+	result, err := te.Execute(context.Background(), gate)
+	if err != nil {
+		t.Fatalf("approved gate failed: %v", err)
+	}
+	if result.Status != models.StatusGreen || result.ReviewFeedback != "Approved by alice: reviewed" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(updater.calls) != 2 || updater.calls[0].status != StatusInProgress || updater.calls[1].status != StatusCompleted {
+		t.Errorf("gate should be in progress while waiting, then completed: %+v", updater.calls)
+	}
+	if _, err := store.Load("plan.md", "3"); !errors.Is(err, approval.ErrNoRequest) {
+		t.Errorf("a used decision should be removed, got %v", err)
+	}
+}
+
+func TestBuildApprovalRequest(t *testing.T) {
+	dir := setupGitRepo(t)
+	te, _, _ := newApprovalTestExecutor(t, &mockInvoker{}, dir)
+
+	te.ApprovalGate.recordStart(context.Background(), models.Task{Number: "2"}, dir)
+	if err := os.WriteFile(filepath.Join(dir, "handler.go"), []byte("package api\n\nfunc Handle() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	te.ApprovalGate.recordResult(context.Background(), models.TaskResult{
+		Task:           models.Task{Number: "2", Agent: "golang-pro"},
+		Status:         models.StatusGreen,
+		ReviewFeedback: "Handlers look good.\nTests pass.",
+	}, dir)
+
+	req := te.buildApprovalRequest(context.Background(), te.Plan.Tasks[2], "plan.md")
+	for _, want := range []string{
+		"- Task 1 (Add migration): completed in an earlier run",
+		"- Task 2 (Update handlers): GREEN by golang-pro - Handlers look good. Tests pass.",
+		"handler.go +3 -0",
+	} {
+		if !strings.Contains(req.Summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, req.Summary)
+		}
+	}
+	if !strings.Contains(req.Diff, "+func Handle() {}") {
+		t.Errorf("diff should hold the upstream changes:\n%s", req.Diff)
+	}
+}
+
+func TestExecuteApproval_DecidedBeforeReached(t *testing.T) {
+	te, updater, store := newApprovalTestExecutor(t, &mockInvoker{}, t.TempDir())
+	gate := te.Plan.Tasks[2]
+
+	if _, err := store.Decide("plan.md", "3", gate.Name, approval.StatusRejected, "bob", "breaks the public API"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := te.Execute(context.Background(), gate)
+	if err == nil || result.Status != models.StatusFailed || !strings.Contains(err.Error(), "rejected by bob: breaks the public API") {
+		t.Fatalf("rejected gate should fail with the reason, got %+v, %v", result, err)
+	}
+	if last := updater.calls[len(updater.calls)-1]; last.status != StatusFailed {
+		t.Errorf("gate should be marked failed, got %s", last.status)
+	}
+
+	// The rejection was used; the next run asks again and can be stopped while waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := te.Execute(ctx, gate); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the gate to wait for a new decision, got %v", err)
+	}
+	if req, err := store.Load("plan.md", "3"); err != nil || req.Status != approval.StatusPending {
+		t.Errorf("a stopped run should leave the request pending: %+v, %v", req, err)
+	}
+}
+
+func TestExecuteApproval_NotConfigured(t *testing.T) {
+	te, _, _ := newApprovalTestExecutor(t, &mockInvoker{}, t.TempDir())
+	te.ApprovalGate = nil
+	if _, err := te.Execute(context.Background(), models.Task{Number: "3", Type: "approval"}); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("expected an error without an approval gate, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/harrison/conductor/internal/condition"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// defaultProbeTimeout bounds a probe() command when TaskConditions.ProbeTimeout is zero.
+const defaultProbeTimeout = time.Minute
+
+// TaskConditions evaluates the `when:` expressions of tasks just before they
+// launch (v3.6+). A task whose expression is false is reported as SKIPPED
+// without running, and its dependents still run. Expressions see the status,
+// QC verdict and changed files of the upstream tasks in this run; upstream
+// tasks completed in an earlier run count as GREEN with their declared files.
+type TaskConditions struct {
+	Runner       CommandRunner       // Runs probe() commands (default: shell in the task's work dir)
+	ProbeTimeout time.Duration       // Limit for one probe() command (default 1m)
+	Getenv       func(string) string // Reads env() variables (default os.Getenv)
+
+	runs runRecorder
+}
+
+// NewTaskConditions creates a TaskConditions evaluator.
+func NewTaskConditions() *TaskConditions {
+	return &TaskConditions{runs: runRecorder{trackFiles: true}}
+}
+
+// ValidateConditions checks that every `when:` expression parses and only
+// refers to tasks it depends on, directly or through other tasks. Results of
+// other tasks may not exist yet when the expression is evaluated.
+func ValidateConditions(tasks []models.Task) error {
+	plan := &models.Plan{Tasks: tasks}
+	for _, task := range tasks {
+		if task.When == "" {
+			continue
+		}
+		expr, err := condition.Parse(task.When)
+		if err != nil {
+			return fmt.Errorf("task %s (%s): invalid when expression %q: %w", task.Number, task.Name, task.When, err)
+		}
+		upstream := make(map[string]bool)
+		for _, number := range upstreamOf(plan, task) {
+			upstream[number] = true
+		}
+		for _, ref := range expr.Tasks() {
+			if !upstream[ref] {
+				return fmt.Errorf("task %s (%s): when expression refers to task %s, which it does not depend on", task.Number, task.Name, ref)
+			}
+		}
+	}
+	return nil
+}
+
+// evaluateWhen decides whether a task with a `when:` expression runs. It
+// returns skipped=true with a SKIPPED result when the expression is false,
+// and a FAILED result when it cannot be evaluated.
+func (te *DefaultTaskExecutor) evaluateWhen(ctx context.Context, task models.Task) (models.TaskResult, bool, error) {
+	result := models.TaskResult{Task: task}
+	fail := func(err error) (models.TaskResult, bool, error) {
+		result.Status = models.StatusFailed
+		result.Error = err
+		te.updatePlanStatusLocked(task, StatusFailed, false)
+		return result, false, err
+	}
+
+	if te.Conditions == nil {
+		return fail(fmt.Errorf("task %s has a when expression but conditions are not configured", task.Number))
+	}
+	expr, err := condition.Parse(task.When)
+	if err != nil {
+		return fail(fmt.Errorf("task %s: invalid when expression %q: %w", task.Number, task.When, err))
+	}
+	env := &conditionEnv{ctx: ctx, te: te, task: task, upstream: upstreamOf(te.currentPlan(), task)}
+	ok, err := expr.Eval(ctx, env)
+	if err != nil {
+		return fail(fmt.Errorf("task %s: when %s: %w", task.Number, expr, err))
+	}
+	if ok {
+		return result, false, nil
+	}
+
+	result.Status = models.StatusSkipped
+	result.Output = fmt.Sprintf("Skipped: when %s is false", expr)
+	if te.Logger != nil {
+		te.Logger.Infof("[Task %s] %s", task.Number, result.Output)
+	}
+	// The plan file is left alone: a skipped status would make --skip-completed
+	// treat the task as done and never evaluate the expression again
+	te.Conditions.runs.finish(ctx, result, te.workDirFor(task))
+	return result, true, nil
+}
+
+// conditionEnv answers a `when:` expression for one task.
+type conditionEnv struct {
+	ctx      context.Context
+	te       *DefaultTaskExecutor
+	task     models.Task
+	upstream []string
+}
+
+// planTask returns the plan's task with the given number.
+func (e *conditionEnv) planTask(number string) (models.Task, bool) {
+	plan := e.te.currentPlan()
+	if plan == nil {
+		return models.Task{}, false
+	}
+	for _, t := range plan.Tasks {
+		if t.Number == number {
+			return t, true
+		}
+	}
+	return models.Task{}, false
+}
+
+func (e *conditionEnv) Status(task string) string {
+	if run, ok := e.te.Conditions.runs.get(task); ok && run.finished {
+		return run.status
+	}
+	if t, ok := e.planTask(task); ok {
+		switch {
+		case t.IsCompleted():
+			return models.StatusGreen
+		case t.Status == StatusSkipped:
+			return models.StatusSkipped
+		}
+	}
+	return ""
+}
+
+func (e *conditionEnv) Verdict(task string) string {
+	if run, ok := e.te.Conditions.runs.get(task); ok && run.finished {
+		return run.verdict
+	}
+	if e.te.LearningStore != nil {
+		history, err := e.te.LearningStore.GetExecutionHistory(e.ctx, e.te.planFileFor(e.task), task)
+		if err == nil && len(history) > 0 {
+			return history[0].QCVerdict
+		}
+	}
+	return ""
+}
+
+func (e *conditionEnv) Changed(pattern string, tasks []string) bool {
+	if len(tasks) == 0 {
+		tasks = e.upstream
+	}
+	for _, number := range tasks {
+		var files []string
+		if run, ok := e.te.Conditions.runs.get(number); ok && run.finished {
+			files = run.files
+		} else if t, ok := e.planTask(number); ok && t.IsCompleted() {
+			files = t.Files
+		}
+		for _, file := range files {
+			if condition.Match(pattern, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *conditionEnv) Getenv(name string) string {
+	if e.te.Conditions.Getenv != nil {
+		return e.te.Conditions.Getenv(name)
+	}
+	return os.Getenv(name)
+}
+
+func (e *conditionEnv) Probe(ctx context.Context, command string) (bool, error) {
+	c := e.te.Conditions
+	runner := c.Runner
+	if runner == nil {
+		runner = NewShellCommandRunner(e.te.workDirFor(e.task))
+	}
+	timeout := c.ProbeTimeout
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err := runner.Run(probeCtx, command)
+	if err == nil {
+		return true, nil
+	}
+	if ctxErr := probeCtx.Err(); ctxErr != nil {
+		if errors.Is(ctxErr, context.DeadlineExceeded) && ctx.Err() == nil {
+			return false, fmt.Errorf("timed out after %s", timeout)
+		}
+		return false, ctxErr
+	}
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return false, nil
+	}
+	return false, err
+}
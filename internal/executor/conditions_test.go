@@ -0,0 +1,252 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func newConditionTestExecutor(t *testing.T, invoker InvokerInterface, workDir string, tasks ...models.Task) (*DefaultTaskExecutor, *recordingUpdater) {
+	t.Helper()
+	updater := &recordingUpdater{}
+	te, err := NewTaskExecutor(invoker, nil, updater, TaskExecutorConfig{PlanPath: "plan.md"})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	te.Conditions = NewTaskConditions()
+	te.Conditions.Getenv = func(name string) string {
+		if name == "REGENERATE" {
+			return "yes"
+		}
+		return ""
+	}
+	te.WorkDir = workDir
+	te.Plan = &models.Plan{Tasks: tasks}
+	return te, updater
+}
+
+func TestExecute_WhenExpressions(t *testing.T) {
+	dir := setupGitRepo(t)
+	var mu sync.Mutex
+	invoked := make(map[string]bool)
+	invoker := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			mu.Lock()
+			invoked[task.Number] = true
+			mu.Unlock()
+			if task.Number == "1" {
+				if err := os.MkdirAll(filepath.Join(dir, "db", "schema"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "db", "schema", "users.sql"), []byte("ALTER TABLE users ADD email TEXT;\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return &agent.InvocationResult{Output: `{"content":"done"}`}, nil
+		},
+	}
+	tasks := []models.Task{
+		{Number: "1", Name: "Migrate schema", Prompt: "Add the column", Agent: "golang-pro"},
+		{Number: "2", Name: "Regenerate client", Prompt: "Regenerate", DependsOn: []string{"1"}, When: `changed("db/schema/**") && status("1") == "GREEN"`},
+		{Number: "3", Name: "Update API docs", Prompt: "Document", DependsOn: []string{"1"}, When: `changed("api/**", "1")`},
+		{Number: "4", Name: "Publish client", Prompt: "Publish", DependsOn: []string{"2", "3"}, When: `status("3") == "SKIPPED" && env("REGENERATE") == "yes" && probe("test -f db/schema/users.sql")`},
+	}
+	te, updater := newConditionTestExecutor(t, invoker, dir, tasks...)
+
+	for _, task := range tasks {
+		if _, err := te.Execute(context.Background(), task); err != nil {
+			t.Fatalf("task %s: %v", task.Number, err)
+		}
+	}
+
+	if !invoked["2"] || !invoked["4"] {
+		t.Errorf("tasks whose when: expression is true should run, invoked %v", invoked)
+	}
+	if invoked["3"] {
+		t.Error("task 3 should be skipped: task 1 changed no api/ files")
+	}
+
+	run, ok := te.Conditions.runs.get("3")
+	if !ok || run.status != models.StatusSkipped {
+		t.Errorf("skipped task should be recorded for the expressions downstream of it: %+v", run)
+	}
+	for _, call := range updater.calls {
+		if call.status == StatusSkipped {
+			t.Errorf("a condition skip must not be written to the plan, so reruns evaluate it again: %+v", updater.calls)
+		}
+	}
+}
+
+func TestExecute_WhenFalseReturnsSkippedResult(t *testing.T) {
+	invoker := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			t.Error("a skipped task must not invoke an agent")
+			return &agent.InvocationResult{}, nil
+		},
+	}
+	task := models.Task{Number: "2", Name: "Deploy", Prompt: "Deploy", When: `env("DEPLOY") == "true"`}
+	te, _ := newConditionTestExecutor(t, invoker, t.TempDir(), task)
+
+	result, err := te.Execute(context.Background(), task)
+	if err != nil {
+		t.Fatalf("a skipped task is not an error: %v", err)
+	}
+	if result.Status != models.StatusSkipped || result.Output != `Skipped: when env("DEPLOY") == "true" is false` {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+// hangingRunner is a CommandRunner whose commands run until they are cancelled.
+type hangingRunner struct{}
+
+func (hangingRunner) Run(ctx context.Context, command string) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestExecute_WhenErrors(t *testing.T) {
+	task := models.Task{Number: "1", Name: "Codegen", Prompt: "Generate", When: `probe("make check-schema")`}
+
+	t.Run("probe timeout fails the task", func(t *testing.T) {
+		te, updater := newConditionTestExecutor(t, &mockInvoker{}, t.TempDir(), task)
+		te.Conditions.Runner = hangingRunner{}
+		te.Conditions.ProbeTimeout = 20 * time.Millisecond
+		result, err := te.Execute(context.Background(), task)
+		if err == nil || result.Status != models.StatusFailed || !strings.Contains(err.Error(), "timed out") {
+			t.Errorf("expected a probe timeout failure, got %+v, %v", result, err)
+		}
+		if len(updater.calls) == 0 || updater.calls[len(updater.calls)-1].status != StatusFailed {
+			t.Errorf("task should be marked failed, got %+v", updater.calls)
+		}
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		te, _ := newConditionTestExecutor(t, &mockInvoker{}, t.TempDir(), task)
+		te.Conditions = nil
+		if _, err := te.Execute(context.Background(), task); err == nil || !strings.Contains(err.Error(), "not configured") {
+			t.Errorf("expected an error without conditions, got %v", err)
+		}
+	})
+}
+
+func TestConditionEnv_EarlierRun(t *testing.T) {
+	tasks := []models.Task{
+		{Number: "1", Name: "Migrate", Status: "completed", Files: []string{"db/schema/users.sql"}},
+		{Number: "2", Name: "Optional", Status: "skipped"},
+		{Number: "3", Name: "Client", DependsOn: []string{"1", "2"}},
+	}
+	te, _ := newConditionTestExecutor(t, &mockInvoker{}, t.TempDir(), tasks...)
+	env := &conditionEnv{ctx: context.Background(), te: te, task: tasks[2], upstream: upstreamOf(te.Plan, tasks[2])}
+
+	if got := env.Status("1"); got != models.StatusGreen {
+		t.Errorf("Status(1) = %q, want GREEN for a task completed in an earlier run", got)
+	}
+	if got := env.Status("2"); got != models.StatusSkipped {
+		t.Errorf("Status(2) = %q, want SKIPPED", got)
+	}
+	if !env.Changed("*.sql", nil) {
+		t.Error("a task completed in an earlier run should count its declared files as changed")
+	}
+	if env.Changed("*.sql", []string{"2"}) {
+		t.Error("only the named tasks' files should be matched")
+	}
+}
+
+func TestValidateConditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		tasks   []models.Task
+		wantErr string
+	}{
+		{
+			name: "valid",
+			tasks: []models.Task{
+				{Number: "1", Name: "Migrate"},
+				{Number: "2", Name: "Handlers", DependsOn: []string{"1"}},
+				{Number: "3", Name: "Client", DependsOn: []string{"2"}, When: `changed("db/**", "1") || status("2") == "YELLOW"`},
+			},
+		},
+		{
+			name: "cross-file dependency",
+			tasks: []models.Task{
+				{Number: "1", Name: "Migrate"},
+				{Number: "2", Name: "Client", DependsOn: []string{"file:plan-01.yaml:task:1"}, When: `status("1") == "GREEN"`},
+			},
+		},
+		{
+			name: "syntax error",
+			tasks: []models.Task{
+				{Number: "1", Name: "Client", When: `changed("db/**"`},
+			},
+			wantErr: "task 1 (Client): invalid when expression",
+		},
+		{
+			name: "refers to a task it does not depend on",
+			tasks: []models.Task{
+				{Number: "1", Name: "Migrate"},
+				{Number: "2", Name: "Docs"},
+				{Number: "3", Name: "Client", DependsOn: []string{"1"}, When: `status("2") == "GREEN"`},
+			},
+			wantErr: "refers to task 2, which it does not depend on",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTasks(tt.tasks)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOwnChangedFiles(t *testing.T) {
+	changed := []string{"api/users.go", "api/orders.go", "README.md"}
+	tests := []struct {
+		name string
+		task models.Task
+		want []string
+	}{
+		{
+			name: "declared files only",
+			task: models.Task{Files: []string{"./api/users.go", "README.md"}},
+			want: []string{"api/users.go", "README.md"},
+		},
+		{
+			name: "no declared files",
+			task: models.Task{},
+			want: changed,
+		},
+		{
+			name: "own worktree",
+			task: models.Task{Files: []string{"api/users.go"}, Metadata: map[string]interface{}{"worktree": &WorktreeInfo{Name: "task-2"}}},
+			want: changed,
+		},
+		{
+			name: "shared group worktree",
+			task: models.Task{Files: []string{"api/users.go"}, Metadata: map[string]interface{}{"worktree": &WorktreeInfo{Name: "group-api"}}},
+			want: []string{"api/users.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ownChangedFiles(tt.task, changed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ownChangedFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package executor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/prompts"
+)
+
+// contextTruncatedMarker ends a section that was summarized to fit the budget.
+const contextTruncatedMarker = "\n[... truncated to fit the prompt context budget]"
+
+// contextSection is the text one hook wrapped around the prompt.
+type contextSection struct {
+	name   string // config.ContextSection* key, used for its priority
+	label  string // name with detail, e.g. "qc_feedback (attempt 2)"
+	prefix string
+	suffix string
+}
+
+func (s contextSection) tokens() int {
+	return prompts.EstimateTokens(s.prefix + s.suffix)
+}
+
+// promptContext tracks the context hooks add around a task prompt, so the
+// prompt can be re-assembled within executor.context_budget (v3.6+).
+type promptContext struct {
+	base     string
+	sections []contextSection
+}
+
+func newPromptContext(base string) *promptContext {
+	return &promptContext{base: base}
+}
+
+// record adds the text a hook wrapped around before to produce after.
+// A hook that rewrote the prompt instead of wrapping it makes after the new,
+// uncuttable base.
+func (pc *promptContext) record(name, detail, before, after string) {
+	if pc == nil || before == after {
+		return
+	}
+	i := strings.Index(after, before)
+	if before == "" || i < 0 {
+		pc.base = after
+		pc.sections = nil
+		return
+	}
+	label := name
+	if detail != "" {
+		label = fmt.Sprintf("%s (%s)", name, detail)
+	}
+	pc.sections = append(pc.sections, contextSection{
+		name:   name,
+		label:  label,
+		prefix: after[:i],
+		suffix: after[i+len(before):],
+	})
+}
+
+// assembleContext wraps the base prompt in sections, in the order they were recorded.
+func assembleContext(base string, sections []contextSection) string {
+	prompt := base
+	for _, s := range sections {
+		prompt = s.prefix + prompt + s.suffix
+	}
+	return prompt
+}
+
+// fit returns the prompt cut down to budget.MaxTokens and the truncations applied.
+// Sections are first summarized to budget.SummaryTokens, then dropped, lowest
+// priority first and older before newer within a priority. The base prompt is
+// never cut, so the result can still exceed the budget.
+func (pc *promptContext) fit(budget config.ContextBudgetConfig) (string, []models.ContextTruncation) {
+	sections := append([]contextSection(nil), pc.sections...)
+	prompt := assembleContext(pc.base, sections)
+	if budget.MaxTokens <= 0 || prompts.EstimateTokens(prompt) <= budget.MaxTokens {
+		return prompt, nil
+	}
+
+	priority := func(name string) int {
+		if p, ok := budget.Priorities[name]; ok {
+			return p
+		}
+		return config.DefaultContextPriorities()[name]
+	}
+	order := make([]int, len(sections))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return priority(sections[order[a]].name) < priority(sections[order[b]].name)
+	})
+
+	original := make([]int, len(sections))
+	for i, s := range sections {
+		original[i] = s.tokens()
+	}
+	truncated := make(map[int]*models.ContextTruncation)
+	over := func() bool {
+		return prompts.EstimateTokens(assembleContext(pc.base, sections)) > budget.MaxTokens
+	}
+
+	if budget.SummaryTokens > 0 {
+		for _, i := range order {
+			if !over() {
+				break
+			}
+			if original[i] <= budget.SummaryTokens {
+				continue
+			}
+			sections[i] = summarizeSection(sections[i], budget.SummaryTokens)
+			truncated[i] = &models.ContextTruncation{
+				Section:    sections[i].label,
+				Action:     models.ContextSummarized,
+				Tokens:     original[i],
+				KeptTokens: sections[i].tokens(),
+			}
+		}
+	}
+
+	for _, i := range order {
+		if !over() {
+			break
+		}
+		sections[i].prefix, sections[i].suffix = "", ""
+		truncated[i] = &models.ContextTruncation{
+			Section: sections[i].label,
+			Action:  models.ContextDropped,
+			Tokens:  original[i],
+		}
+	}
+
+	var truncations []models.ContextTruncation
+	for _, i := range order {
+		if t, ok := truncated[i]; ok {
+			truncations = append(truncations, *t)
+		}
+	}
+	return assembleContext(pc.base, sections), truncations
+}
+
+// summarizeSection cuts a section to about maxTokens, sharing them between
+// its prefix and suffix by size.
+func summarizeSection(s contextSection, maxTokens int) contextSection {
+	prefixTokens := prompts.EstimateTokens(s.prefix)
+	total := prefixTokens + prompts.EstimateTokens(s.suffix)
+	if total == 0 {
+		return s
+	}
+	prefixShare := maxTokens * prefixTokens / total
+	s.prefix = truncateToTokens(s.prefix, prefixShare)
+	s.suffix = truncateToTokens(s.suffix, maxTokens-prefixShare)
+	return s
+}
+
+// truncateToTokens keeps the start of text within about maxTokens, preserving
+// the surrounding whitespace that separates it from the prompt.
+func truncateToTokens(text string, maxTokens int) string {
+	core := strings.TrimSpace(text)
+	if core == "" || prompts.EstimateTokens(core) <= maxTokens {
+		return text
+	}
+	lead := text[:strings.Index(text, core)]
+	trail := text[len(lead)+len(core):]
+	runes := []rune(core)
+	keep := maxTokens * 4
+	if keep > len(runes) {
+		keep = len(runes)
+	}
+	return lead + strings.TrimSpace(string(runes[:keep])) + contextTruncatedMarker + trail
+}
+
+// fitPromptContext applies executor.context_budget to the task prompt before
+// an attempt and logs each truncation (v3.6+). Without a budget the prompt is
+// left as the hooks built it.
+func (te *DefaultTaskExecutor) fitPromptContext(task *models.Task, pc *promptContext) []models.ContextTruncation {
+	if te.ContextBudget == nil || te.ContextBudget.MaxTokens <= 0 {
+		return nil
+	}
+	prompt, truncations := pc.fit(*te.ContextBudget)
+	task.Prompt = prompt
+	if te.Logger != nil {
+		for _, t := range truncations {
+			te.Logger.Warnf("Task %s: context budget %s %s (~%d -> ~%d tokens)", task.Number, t.Action, t.Section, t.Tokens, t.KeptTokens)
+		}
+	}
+	return truncations
+}
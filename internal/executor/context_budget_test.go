@@ -0,0 +1,167 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func testContextBudget(maxTokens, summaryTokens int) config.ContextBudgetConfig {
+	return config.ContextBudgetConfig{
+		MaxTokens:     maxTokens,
+		SummaryTokens: summaryTokens,
+		Priorities:    config.DefaultContextPriorities(),
+	}
+}
+
+func TestPromptContext_RecordsWrappedSections(t *testing.T) {
+	pc := newPromptContext("base")
+	pc.record(config.ContextSectionWarmUp, "", "base", "warm\n\nbase")
+	pc.record(config.ContextSectionIntegration, "", "warm\n\nbase", "warm\n\nbase\n\nintegration")
+
+	if len(pc.sections) != 2 || pc.sections[0].prefix != "warm\n\n" || pc.sections[1].suffix != "\n\nintegration" {
+		t.Fatalf("unexpected sections: %+v", pc.sections)
+	}
+	if got := assembleContext(pc.base, pc.sections); got != "warm\n\nbase\n\nintegration" {
+		t.Errorf("assembled prompt = %q", got)
+	}
+
+	// A hook that rewrites the prompt makes it the new base
+	pc.record(config.ContextSectionPattern, "", "warm\n\nbase\n\nintegration", "rewritten")
+	if pc.base != "rewritten" || len(pc.sections) != 0 {
+		t.Errorf("rewrite should reset the context: base=%q sections=%d", pc.base, len(pc.sections))
+	}
+}
+
+func TestPromptContext_FitWithinBudgetIsUnchanged(t *testing.T) {
+	pc := newPromptContext("Build the parser")
+	pc.record(config.ContextSectionLearning, "", "Build the parser", "Build the parser\n\npast failures")
+
+	prompt, truncations := pc.fit(testContextBudget(1000, 300))
+	if prompt != "Build the parser\n\npast failures" || truncations != nil {
+		t.Errorf("prompt within budget should be unchanged: %q %v", prompt, truncations)
+	}
+}
+
+func TestPromptContext_FitSummarizesThenDropsLowestPriority(t *testing.T) {
+	base := "Build the parser"
+	warmUp := strings.Repeat("w", 400)       // ~100 tokens, priority 30
+	architecture := strings.Repeat("a", 400) // ~100 tokens, priority 50
+	qcFeedback := strings.Repeat("q", 400)   // ~100 tokens, priority 90
+
+	pc := newPromptContext(base)
+	prompt := base
+	for _, s := range []struct{ name, text string }{
+		{config.ContextSectionWarmUp, warmUp},
+		{config.ContextSectionArchitecture, architecture},
+		{config.ContextSectionQCFeedback, qcFeedback},
+	} {
+		next := prompt + "\n\n" + s.text
+		pc.record(s.name, "", prompt, next)
+		prompt = next
+	}
+
+	// Summarizing warm-up and architecture to ~20 tokens (plus marker) each is enough
+	fitted, truncations := pc.fit(testContextBudget(180, 20))
+	if len(truncations) != 2 || truncations[0].Section != config.ContextSectionWarmUp || truncations[1].Section != config.ContextSectionArchitecture {
+		t.Fatalf("expected warm_up then architecture summarized, got %+v", truncations)
+	}
+	for _, tr := range truncations {
+		if tr.Action != models.ContextSummarized || tr.Tokens <= tr.KeptTokens {
+			t.Errorf("unexpected truncation: %+v", tr)
+		}
+	}
+	if !strings.Contains(fitted, qcFeedback) || !strings.Contains(fitted, contextTruncatedMarker) || !strings.HasPrefix(fitted, base) {
+		t.Errorf("QC feedback and the base prompt should be kept whole:\n%s", fitted)
+	}
+
+	// Without summaries the low-priority sections are dropped entirely
+	fitted, truncations = pc.fit(testContextBudget(120, 0))
+	dropped := 0
+	for _, tr := range truncations {
+		if tr.Action == models.ContextDropped {
+			dropped++
+			if tr.Section == config.ContextSectionQCFeedback {
+				t.Error("QC feedback should be dropped last")
+			}
+		}
+	}
+	if dropped == 0 || strings.Contains(fitted, "wwww") || !strings.Contains(fitted, qcFeedback) {
+		t.Errorf("expected warm-up dropped and QC feedback kept, got %+v:\n%s", truncations, fitted)
+	}
+}
+
+func TestPromptContext_FitHonorsConfiguredPriorities(t *testing.T) {
+	pc := newPromptContext("base")
+	pc.record(config.ContextSectionWarmUp, "", "base", "base "+strings.Repeat("w", 400))
+	pc.record(config.ContextSectionLearning, "", "base "+strings.Repeat("w", 400), "base "+strings.Repeat("w", 400)+" "+strings.Repeat("l", 400))
+
+	budget := testContextBudget(110, 0)
+	budget.Priorities[config.ContextSectionWarmUp] = 99
+	fitted, truncations := pc.fit(budget)
+	if len(truncations) != 1 || truncations[0].Section != config.ContextSectionLearning || truncations[0].Action != models.ContextDropped {
+		t.Fatalf("expected learning dropped before the higher-priority warm-up, got %+v", truncations)
+	}
+	if !strings.Contains(fitted, "wwww") {
+		t.Errorf("warm-up should be kept: %q", fitted)
+	}
+}
+
+func TestTaskExecutor_ContextBudgetRecordedInHistory(t *testing.T) {
+	invoker := newStubInvoker(
+		&agent.InvocationResult{Output: `{"content":"first"}`},
+		&agent.InvocationResult{Output: `{"content":"second"}`},
+	)
+	reviewer := &stubReviewer{
+		results: []*ReviewResult{
+			{Flag: models.StatusRed, Feedback: strings.Repeat("fix the parser ", 100)},
+			{Flag: models.StatusGreen},
+		},
+		retryDecisions: map[int]bool{0: true},
+	}
+	executor := newSpendTestExecutor(t, invoker, reviewer)
+	executor.Plan = &models.Plan{Tasks: []models.Task{
+		{Number: "1", Name: "Lexer", Files: []string{"lexer.go"}, Prompt: "Build the lexer"},
+	}}
+	budget := testContextBudget(450, 0)
+	executor.ContextBudget = &budget
+
+	task := models.Task{Number: "2", Name: "Parser", Prompt: "Build the parser", DependsOn: []string{"1"}}
+	result, err := executor.Execute(context.Background(), task)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if len(result.ExecutionHistory) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(result.ExecutionHistory))
+	}
+	if got := result.ExecutionHistory[0].ContextTruncations; len(got) != 0 {
+		t.Errorf("first attempt fits the budget, got truncations %+v", got)
+	}
+
+	// The retry adds ~375 tokens of QC feedback, pushing integration context out first
+	got := result.ExecutionHistory[1].ContextTruncations
+	if len(got) == 0 || got[0].Section != config.ContextSectionIntegration || got[0].Action != models.ContextDropped {
+		t.Fatalf("expected integration context dropped on retry, got %+v", got)
+	}
+	if prompt := invoker.calls[1].Prompt; strings.Contains(prompt, "lexer.go") || !strings.Contains(prompt, "Build the parser") {
+		t.Errorf("retry prompt should keep the base prompt without integration context:\n%s", prompt)
+	}
+}
+
+func TestTaskExecutor_NoContextBudgetLeavesPrompt(t *testing.T) {
+	invoker := newStubInvoker(&agent.InvocationResult{Output: `{"content":"done"}`})
+	executor := newSpendTestExecutor(t, invoker, &stubReviewer{})
+	executor.Plan = &models.Plan{Tasks: []models.Task{{Number: "1", Name: "Lexer", Files: []string{"lexer.go"}}}}
+
+	result, err := executor.Execute(context.Background(), models.Task{Number: "2", Name: "Parser", Prompt: "Build the parser", DependsOn: []string{"1"}})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if !strings.Contains(invoker.calls[0].Prompt, "lexer.go") || result.ExecutionHistory[0].ContextTruncations != nil {
+		t.Errorf("without a budget the integration context should be sent as built")
+	}
+}
@@ -0,0 +1,252 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/prompts"
+)
+
+// PromptSection is one part of an assembled prompt and where it came from (v3.6+).
+type PromptSection struct {
+	Name    string
+	Source  string
+	Content string
+	Tokens  int    // Approximate, see prompts.EstimateTokens
+	Note    string // Set when the section is not sent as shown
+}
+
+// PromptExplanation is the prompt an agent would receive for a task,
+// broken down by the hook that contributed each part (v3.6+).
+type PromptExplanation struct {
+	Task     models.Task     // Task after all pre-task hooks (agent, model, prompt)
+	Sections []PromptSection // Agent prompt parts in the order they are applied
+	Prompt   string          // Final -p prompt
+	Tokens   int             // Approximate tokens of Prompt plus system prompt and schema
+	Notes    []string        // Hooks that were not replayed or would stop the task
+	QC       *QCExplanation  // Set when QC prompts were requested
+}
+
+// QCExplanation is the review prompt QC agents would receive for a task (v3.6+).
+type QCExplanation struct {
+	Agents   []string // Selected reviewers; empty for intelligent selection
+	Sections []PromptSection
+	Prompt   string // Review prompt with a placeholder for the agent output
+	Tokens   int
+	Notes    []string
+}
+
+// qcOutputPlaceholder stands in for agent output in explained QC prompts.
+const qcOutputPlaceholder = "<agent output goes here>"
+
+func newPromptSection(name, source, content string) PromptSection {
+	return PromptSection{Name: name, Source: source, Content: content, Tokens: prompts.EstimateTokens(content)}
+}
+
+// injectedText returns what a hook added around before to produce after.
+func injectedText(before, after string) string {
+	if i := strings.Index(after, before); before != "" && i >= 0 {
+		return strings.TrimSpace(after[:i] + after[i+len(before):])
+	}
+	return strings.TrimSpace(after)
+}
+
+// dryRun collects what prepareTask would have done to a task that is explained
+// rather than run (v3.6+). A nil *dryRun means a real run.
+type dryRun struct {
+	notes []string
+}
+
+// note records an outcome that is not applied in a dry run. No-op on a real run.
+func (d *dryRun) note(format string, args ...interface{}) {
+	if d == nil {
+		return
+	}
+	d.notes = append(d.notes, fmt.Sprintf(format, args...))
+}
+
+// contextSectionTitles names the prompt context each hook adds, by config.ContextSection* key.
+var contextSectionTitles = map[string]struct{ name, source string }{
+	config.ContextSectionLearning:     {"Past failures", "learning store (retry_learning template)"},
+	config.ContextSectionWarmUp:       {"Warm-up context", "learning store (similar successful tasks)"},
+	config.ContextSectionPattern:      {"Pattern intelligence (STOP)", "pattern hook"},
+	config.ContextSectionArchitecture: {"Architecture checkpoint", "architecture hook"},
+	config.ContextSectionIntegration:  {"Integration context", "dependencies"},
+}
+
+// Explain assembles the prompt the agent would receive for task by running the
+// pre-task hooks of a real run in dry-run mode: the agent is not invoked, and
+// no commands, checkpoints or plan updates are made (v3.6+).
+// With includeQC the review prompt is assembled as well.
+func (te *DefaultTaskExecutor) Explain(ctx context.Context, task models.Task, includeQC bool) (*PromptExplanation, error) {
+	if task.IsApproval() {
+		return nil, fmt.Errorf("task %s is an approval gate: it waits for conductor approve and sends no prompt", task.Number)
+	}
+
+	exp := &PromptExplanation{}
+	if task.When != "" {
+		exp.Notes = append(exp.Notes, fmt.Sprintf("the task only runs if when %s is true at launch; it is not evaluated here", task.When))
+	}
+
+	dry := &dryRun{}
+	promptCtx := newPromptContext(task.Prompt)
+	task, err := te.prepareTask(ctx, task, promptCtx, dry)
+	if err != nil {
+		return nil, err
+	}
+	exp.Notes = append(exp.Notes, dry.notes...)
+
+	source := task.SourceFile
+	if source == "" {
+		source = te.PlanFile
+	}
+	exp.Sections = append(exp.Sections, newPromptSection("Task prompt", "plan "+source, promptCtx.base))
+
+	// Sections the context budget may cut, by label
+	budgeted := make(map[string]int)
+	for _, section := range promptCtx.sections {
+		title := contextSectionTitles[section.name]
+		if section.name == config.ContextSectionIntegration {
+			title.source = "dependencies " + strings.Join(task.DependsOn, ", ")
+		}
+		budgeted[section.label] = len(exp.Sections)
+		exp.Sections = append(exp.Sections, newPromptSection(title.name, title.source, strings.TrimSpace(section.prefix+section.suffix)))
+	}
+
+	if te.ContextBudget != nil && te.ContextBudget.MaxTokens > 0 {
+		var truncations []models.ContextTruncation
+		task.Prompt, truncations = promptCtx.fit(*te.ContextBudget)
+		for _, t := range truncations {
+			i, ok := budgeted[t.Section]
+			if !ok {
+				continue
+			}
+			if t.Action == models.ContextDropped {
+				exp.Sections[i].Note = fmt.Sprintf("dropped by the context budget (max_tokens %d)", te.ContextBudget.MaxTokens)
+			} else {
+				exp.Sections[i].Note = fmt.Sprintf("summarized to ~%d tokens by the context budget (max_tokens %d)", t.KeptTokens, te.ContextBudget.MaxTokens)
+			}
+		}
+	}
+
+	// Prompt blocks are parsed from runtime metadata but no hook injects them
+	if task.RuntimeMetadata != nil {
+		for _, block := range task.RuntimeMetadata.PromptBlocks {
+			section := newPromptSection("Prompt block ("+block.Type+")", "runtime_metadata.prompt_blocks", block.Content)
+			section.Note = "parsed from the plan but not sent to the agent"
+			exp.Sections = append(exp.Sections, section)
+		}
+	}
+
+	exp.Prompt = agent.PrepareAgentPrompt(task.Prompt)
+	exp.Sections = append(exp.Sections, newPromptSection("Response format", "agent_prompt template", injectedText(task.Prompt, exp.Prompt)))
+
+	schema := task.JSONSchema
+	schemaSource := "default agent response schema"
+	if schema != "" {
+		schemaSource = "task json_schema"
+	} else {
+		schema = models.AgentResponseSchemaWithOptions(te.Subtasks)
+	}
+	exp.Sections = append(exp.Sections,
+		newPromptSection("JSON schema", schemaSource+" (--json-schema)", schema),
+		newPromptSection("System prompt", "conductor (--system-prompt)", agent.SystemPrompt),
+	)
+	exp.Task = task
+	exp.Tokens = prompts.EstimateTokens(exp.Prompt) + prompts.EstimateTokens(schema) + prompts.EstimateTokens(agent.SystemPrompt)
+
+	if includeQC {
+		exp.QC = te.explainQC(ctx, task, te.lastPatternResult)
+	}
+	return exp, nil
+}
+
+// explainQC assembles the review prompt with a placeholder for the agent output.
+func (te *DefaultTaskExecutor) explainQC(ctx context.Context, task models.Task, patternResult *PreTaskCheckResult) *QCExplanation {
+	exp := &QCExplanation{}
+	qc, ok := te.reviewer.(*QualityController)
+	if !te.qcEnabled || !ok || qc == nil {
+		exp.Notes = append(exp.Notes, "quality control is disabled for this plan")
+		return exp
+	}
+
+	// Work on a copy so the explained run leaves the controller untouched
+	preview := *qc
+	preview.TestCommandResults = nil
+	preview.CriterionVerifyResults = nil
+	preview.DocTargetResults = nil
+	preview.CommitVerification = nil
+	preview.STOPSummary = ""
+	if patternResult != nil && patternResult.STOPResult != nil {
+		preview.STOPSummary = BuildSTOPSummaryFromSTOPResult(patternResult.STOPResult)
+		preview.RequireJustification = te.PatternHook.RequireJustification()
+	}
+
+	switch {
+	case !preview.shouldUseMultiAgent():
+		exp.Agents = []string{preview.getDefaultAgent()}
+	case preview.AgentConfig.Mode == "intelligent":
+		exp.Notes = append(exp.Notes, "reviewers are chosen by intelligent selection at review time")
+	default:
+		calibration := preview.reviewerCalibration(ctx)
+		exp.Agents = SelectQCAgentsWithContext(ctx, task, preview.AgentConfig, preview.Registry, &SelectionContext{Calibration: calibration})
+		for _, name := range SelectQCAgents(task, preview.AgentConfig, preview.Registry) {
+			if calibration.Poor(name) && !containsAgent(exp.Agents, name) {
+				exp.Notes = append(exp.Notes, fmt.Sprintf("%s is left out because its past verdicts were poorly calibrated", name))
+			}
+		}
+	}
+	for _, name := range riskQCAgents(task) {
+		if !containsAgent(exp.Agents, name) {
+			exp.Agents = appendUniqueAgents(exp.Agents, name)
+			exp.Notes = append(exp.Notes, fmt.Sprintf("%s is added by the risk gate", name))
+		}
+	}
+	for _, name := range exp.Agents {
+		if checker := preview.Checkers[name]; checker != nil {
+			note := fmt.Sprintf("%s runs `%s` locally and receives no prompt", name, checker.command(checker.matchingFiles(task.Files)))
+			if len(checker.Config.Extensions) > 0 && len(checker.matchingFiles(task.Files)) == 0 {
+				note = fmt.Sprintf("%s does not apply to this task's files", name)
+			}
+			exp.Notes = append(exp.Notes, note)
+		}
+	}
+	if preview.shouldUseMultiAgent() && len(exp.Agents) != 1 {
+		exp.Notes = append(exp.Notes, fmt.Sprintf("verdicts are combined with the %s aggregation strategy", preview.aggregationStrategy()))
+	}
+
+	if preview.DiffReview.Enabled {
+		exp.Notes = append(exp.Notes, fmt.Sprintf("reviewers also receive the git diff of the task's commit or of its changes since it started, split by file into up to %d parts over %d bytes",
+			preview.DiffReview.MaxChunks, preview.DiffReview.ChunkBytes))
+	}
+
+	hasCriteria := len(getCombinedCriteria(task)) > 0
+	templateName := prompts.QCReview
+	if hasCriteria {
+		templateName = prompts.QCStructuredReview
+		exp.Prompt = preview.BuildStructuredReviewPrompt(ctx, task, qcOutputPlaceholder)
+	} else {
+		exp.Prompt = preview.BuildReviewPrompt(ctx, task, qcOutputPlaceholder)
+	}
+	exp.Sections = append(exp.Sections, newPromptSection("Review prompt", templateName+" + qc_prompt templates", exp.Prompt))
+
+	if preview.STOPSummary != "" {
+		exp.Sections = append(exp.Sections, newPromptSection("Prior art (STOP)", "pattern hook", FormatSTOPPriorArt(preview.STOPSummary, preview.RequireJustification)))
+	}
+	if preview.LearningStore != nil {
+		if history, err := preview.LoadContext(ctx, task, preview.LearningStore); err == nil {
+			exp.Sections = append(exp.Sections, newPromptSection("Historical attempts", "learning store", history))
+		}
+	}
+
+	schema := models.QCResponseSchemaWithOptions(hasCriteria, preview.RequireJustification && preview.STOPSummary != "")
+	exp.Sections = append(exp.Sections, newPromptSection("JSON schema", "QC response schema (--json-schema)", schema))
+	exp.Tokens = prompts.EstimateTokens(exp.Prompt) + prompts.EstimateTokens(schema) + prompts.EstimateTokens(agent.SystemPrompt)
+
+	exp.Notes = append(exp.Notes, "agent output, test command results, criteria verification, documentation targets, commit verification, the task diff and behavior context are only known after the agent runs")
+	return exp
+}
@@ -0,0 +1,158 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func sectionNames(sections []PromptSection) []string {
+	names := make([]string, 0, len(sections))
+	for _, s := range sections {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestTaskExecutor_ExplainAgentPrompt(t *testing.T) {
+	invoker := newStubInvoker()
+	updater := &recordingUpdater{}
+	executor, err := NewTaskExecutor(invoker, nil, updater, TaskExecutorConfig{PlanPath: "plan.md", DefaultAgent: "golang-pro"})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	executor.PlanFile = "plan.md"
+	executor.LearningStore = &failureRateStore{analysis: &learning.FailureAnalysis{FailedAttempts: 2, TriedAgents: []string{"general-purpose"}}}
+	executor.Plan = &models.Plan{Tasks: []models.Task{
+		{Number: "1", Name: "Lexer", Files: []string{"lexer.go"}},
+	}}
+
+	task := models.Task{
+		Number:    "2",
+		Name:      "Parser",
+		Prompt:    "Build the parser",
+		DependsOn: []string{"1"},
+		RuntimeMetadata: &models.TaskMetadataRuntime{
+			PromptBlocks: []models.PromptBlock{{Type: "constraint", Content: "No regex"}},
+		},
+	}
+	exp, err := executor.Explain(context.Background(), task, false)
+	if err != nil {
+		t.Fatalf("Explain returned error: %v", err)
+	}
+
+	want := []string{"Task prompt", "Past failures", "Integration context", "Prompt block (constraint)", "Response format", "JSON schema", "System prompt"}
+	if got := sectionNames(exp.Sections); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("sections = %v, want %v", got, want)
+	}
+	if !strings.Contains(exp.Sections[1].Content, "2 past failures") || strings.Contains(exp.Sections[1].Content, "Build the parser") {
+		t.Errorf("past failures section should hold only the injected text: %q", exp.Sections[1].Content)
+	}
+	if !strings.Contains(exp.Sections[2].Content, "<file>lexer.go</file>") {
+		t.Errorf("integration section missing dependency files: %q", exp.Sections[2].Content)
+	}
+	if exp.Sections[3].Note == "" || strings.Contains(exp.Prompt, "No regex") {
+		t.Error("prompt blocks are not injected and should be flagged as such")
+	}
+	if exp.Task.Agent != "golang-pro" || !strings.Contains(exp.Prompt, "Build the parser") || !strings.Contains(exp.Prompt, "<response_format>") {
+		t.Errorf("unexpected final task/prompt: agent=%q\n%s", exp.Task.Agent, exp.Prompt)
+	}
+	for _, s := range exp.Sections {
+		if s.Content != "" && s.Tokens == 0 {
+			t.Errorf("section %s has no token estimate", s.Name)
+		}
+	}
+	if exp.QC != nil {
+		t.Error("QC should only be explained on request")
+	}
+
+	// Explaining must not invoke the agent or touch the plan
+	if len(invoker.calls) != 0 || len(updater.calls) != 0 {
+		t.Errorf("explain had side effects: %d invocations, %d plan updates", len(invoker.calls), len(updater.calls))
+	}
+}
+
+func TestTaskExecutor_ExplainQCPrompt(t *testing.T) {
+	qc := NewQualityController(newStubInvoker())
+	qc.AgentConfig = models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"code-reviewer"}}
+	qc.STOPSummary = "stale summary from a previous task"
+	executor := newSpendTestExecutor(t, newStubInvoker(), qc)
+
+	task := models.Task{Number: "3", Name: "API", Prompt: "Add the endpoint", SuccessCriteria: []string{"Returns 200"}}
+	exp, err := executor.Explain(context.Background(), task, true)
+	if err != nil {
+		t.Fatalf("Explain returned error: %v", err)
+	}
+	if exp.QC == nil {
+		t.Fatal("expected a QC explanation")
+	}
+	if len(exp.QC.Agents) != 1 || exp.QC.Agents[0] != "code-reviewer" {
+		t.Errorf("reviewers = %v, want [code-reviewer]", exp.QC.Agents)
+	}
+	if !strings.Contains(exp.QC.Prompt, "0. [ ] Returns 200") || !strings.Contains(exp.QC.Prompt, qcOutputPlaceholder) {
+		t.Errorf("QC prompt should list criteria and the output placeholder:\n%s", exp.QC.Prompt)
+	}
+	if strings.Contains(exp.QC.Prompt, "stale summary") || qc.STOPSummary != "stale summary from a previous task" {
+		t.Error("explain should neither use nor reset the controller's per-task state")
+	}
+	if exp.QC.Tokens <= exp.QC.Sections[0].Tokens {
+		t.Errorf("QC total %d should include the schema and system prompt", exp.QC.Tokens)
+	}
+}
+
+func TestTaskExecutor_ExplainContextBudget(t *testing.T) {
+	executor, err := NewTaskExecutor(newStubInvoker(), nil, &recordingUpdater{}, TaskExecutorConfig{PlanPath: "plan.md"})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	executor.Plan = &models.Plan{Tasks: []models.Task{{Number: "1", Name: "Lexer", Files: []string{"lexer.go"}}}}
+	budget := config.ContextBudgetConfig{MaxTokens: 5, Priorities: config.DefaultContextPriorities()}
+	executor.ContextBudget = &budget
+
+	exp, err := executor.Explain(context.Background(), models.Task{Number: "2", Name: "Parser", Prompt: "Build the parser", DependsOn: []string{"1"}}, false)
+	if err != nil {
+		t.Fatalf("Explain returned error: %v", err)
+	}
+	if exp.Sections[1].Name != "Integration context" || !strings.Contains(exp.Sections[1].Note, "dropped by the context budget") {
+		t.Errorf("integration section should be flagged as dropped: %+v", exp.Sections[1])
+	}
+	if strings.Contains(exp.Prompt, "lexer.go") {
+		t.Errorf("dropped section should not be in the prompt:\n%s", exp.Prompt)
+	}
+}
+
+func TestTaskExecutor_ExplainRunsHooksInDryRun(t *testing.T) {
+	executor, err := NewTaskExecutor(newStubInvoker(), nil, &recordingUpdater{}, TaskExecutorConfig{PlanPath: "plan.md"})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	var commands []string
+	executor.EnforceDependencyChecks = true
+	executor.CommandRunner = &customRunner{runFunc: func(_ context.Context, command string) (string, error) {
+		commands = append(commands, command)
+		return "", nil
+	}}
+
+	task := models.Task{
+		Number: "1",
+		Name:   "Build",
+		Prompt: "Build it",
+		RuntimeMetadata: &models.TaskMetadataRuntime{
+			DependencyChecks: []models.DependencyCheck{{Command: "go version", Description: "Go installed"}},
+		},
+	}
+	exp, err := executor.Explain(context.Background(), task, false)
+	if err != nil {
+		t.Fatalf("Explain returned error: %v", err)
+	}
+	if len(commands) != 0 {
+		t.Errorf("dependency checks should not run in a dry run, ran %v", commands)
+	}
+	if !strings.Contains(strings.Join(exp.Notes, "\n"), "1 dependency check(s) are not run") {
+		t.Errorf("notes should mention the skipped dependency check: %v", exp.Notes)
+	}
+}
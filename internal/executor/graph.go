@@ -90,7 +90,8 @@ func ValidateTasks(tasks []models.Task) error {
 		}
 	}
 
-	return nil
+	// Validate when: expressions (v3.6+)
+	return ValidateConditions(tasks)
 }
 
 // ValidateFileOverlaps checks that tasks within the same wave do not modify
@@ -310,6 +311,29 @@ func (g *DependencyGraph) HasCycle() bool {
 	return false
 }
 
+// TransitiveDependents returns the set of tasks that directly or indirectly depend on
+// the given task. Used by keep-going mode to block only the downstream subgraph of a
+// failed task.
+func (g *DependencyGraph) TransitiveDependents(taskNum string) map[string]bool {
+	dependents := make(map[string]bool)
+	queue := []string{taskNum}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range g.Edges[current] {
+			if dependent == taskNum {
+				continue
+			}
+			if dependents[dependent] {
+				continue
+			}
+			dependents[dependent] = true
+			queue = append(queue, dependent)
+		}
+	}
+	return dependents
+}
+
 // ValidateRegistryPrerequisites validates that tasks respect DataFlowRegistry constraints.
 // This is a graph-level validation that ensures:
 // 1. No task is scheduled before its registry prerequisites are ready
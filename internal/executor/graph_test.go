@@ -1328,3 +1328,32 @@ func TestCalculateWaves_PackageConflictDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestTransitiveDependents(t *testing.T) {
+	tasks := []models.Task{
+		{Number: "1", Name: "Root"},
+		{Number: "2", Name: "Child", DependsOn: []string{"1"}},
+		{Number: "3", Name: "Grandchild", DependsOn: []string{"2"}},
+		{Number: "4", Name: "Diamond", DependsOn: []string{"1", "3"}},
+		{Number: "5", Name: "Independent"},
+		{Number: "6", Name: "Depends on independent", DependsOn: []string{"5"}},
+	}
+
+	graph := BuildDependencyGraph(tasks)
+
+	dependents := graph.TransitiveDependents("1")
+	for _, want := range []string{"2", "3", "4"} {
+		if !dependents[want] {
+			t.Errorf("expected task %s to be a transitive dependent of task 1", want)
+		}
+	}
+	for _, unwanted := range []string{"1", "5", "6"} {
+		if dependents[unwanted] {
+			t.Errorf("task %s should not be a transitive dependent of task 1", unwanted)
+		}
+	}
+
+	if leaf := graph.TransitiveDependents("4"); len(leaf) != 0 {
+		t.Errorf("expected no dependents for leaf task 4, got %v", leaf)
+	}
+}
@@ -0,0 +1,110 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// ModelDecision is the model chosen for a task invocation and why (v3.6+).
+type ModelDecision struct {
+	Model  string
+	Reason string
+}
+
+// ModelRouter picks the Claude model for each task invocation from task signals
+// and escalates to stronger models on RED verdicts (v3.6+).
+// Signals are checked in order: task type, past failure rate, size, then Default.
+type ModelRouter struct {
+	Config config.ModelRoutingConfig
+
+	// Store provides past failure rates (optional)
+	Store LearningStore
+}
+
+// NewModelRouter creates a ModelRouter for the given routing config.
+func NewModelRouter(cfg config.ModelRoutingConfig, store LearningStore) *ModelRouter {
+	return &ModelRouter{Config: cfg, Store: store}
+}
+
+// Route picks the starting model for a task.
+// planFile scopes the failure history lookup.
+func (r *ModelRouter) Route(ctx context.Context, task models.Task, planFile string) ModelDecision {
+	cfg := r.Config
+
+	if model, ok := cfg.TaskTypes[task.Type]; ok && task.Type != "" && model != "" {
+		return ModelDecision{Model: model, Reason: fmt.Sprintf("task type %s", task.Type)}
+	}
+
+	if rate, ok := r.failureRate(ctx, task, planFile); ok && cfg.FailureRate > 0 && rate >= cfg.FailureRate {
+		return ModelDecision{Model: r.strongest(), Reason: fmt.Sprintf("past failure rate %.0f%%", rate*100)}
+	}
+
+	files := len(task.Files)
+	if cfg.ComplexMinFiles > 0 && files >= cfg.ComplexMinFiles {
+		return ModelDecision{Model: r.strongest(), Reason: fmt.Sprintf("%d files", files)}
+	}
+	if cfg.ComplexMinEstimate > 0 && task.EstimatedTime >= cfg.ComplexMinEstimate {
+		return ModelDecision{Model: r.strongest(), Reason: fmt.Sprintf("estimated %s", task.EstimatedTime)}
+	}
+	if files <= cfg.SimpleMaxFiles && task.EstimatedTime > 0 && task.EstimatedTime <= cfg.SimpleMaxEstimate {
+		return ModelDecision{Model: r.weakest(), Reason: fmt.Sprintf("%d file(s), estimated %s", files, task.EstimatedTime)}
+	}
+
+	return ModelDecision{Model: cfg.Default, Reason: "default"}
+}
+
+// Escalate returns the next stronger tier after current.
+// Models outside the tier list escalate from Default's position.
+// Returns false when current is already the strongest tier or no position is known.
+func (r *ModelRouter) Escalate(current string) (string, bool) {
+	tiers := r.Config.Tiers
+	idx := tierIndex(tiers, current)
+	if idx < 0 {
+		idx = tierIndex(tiers, r.Config.Default)
+	}
+	if idx < 0 || idx+1 >= len(tiers) {
+		return "", false
+	}
+	return tiers[idx+1], true
+}
+
+// failureRate returns the fraction of recorded attempts for the task that failed.
+func (r *ModelRouter) failureRate(ctx context.Context, task models.Task, planFile string) (float64, bool) {
+	if r.Store == nil {
+		return 0, false
+	}
+	analysis, err := r.Store.AnalyzeFailures(ctx, planFile, task.Number, 1)
+	if err != nil || analysis == nil || analysis.TotalAttempts == 0 {
+		return 0, false
+	}
+	return float64(analysis.FailedAttempts) / float64(analysis.TotalAttempts), true
+}
+
+// weakest returns the first tier, or Default when no tiers are configured.
+func (r *ModelRouter) weakest() string {
+	if len(r.Config.Tiers) == 0 {
+		return r.Config.Default
+	}
+	return r.Config.Tiers[0]
+}
+
+// strongest returns the last tier, or Default when no tiers are configured.
+func (r *ModelRouter) strongest() string {
+	if len(r.Config.Tiers) == 0 {
+		return r.Config.Default
+	}
+	return r.Config.Tiers[len(r.Config.Tiers)-1]
+}
+
+// tierIndex returns the position of value in list, or -1.
+func tierIndex(list []string, value string) int {
+	for i, item := range list {
+		if item == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,145 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func TestModelRouter_Route(t *testing.T) {
+	cfg := config.DefaultModelRoutingConfig()
+	cfg.Enabled = true
+	cfg.TaskTypes = map[string]string{"integration": "opus"}
+
+	tests := []struct {
+		name  string
+		task  models.Task
+		want  string
+		store bool
+	}{
+		{
+			name: "small quick task uses weakest tier",
+			task: models.Task{Number: "1", Files: []string{"a.go"}, EstimatedTime: 5 * time.Minute},
+			want: "haiku",
+		},
+		{
+			name: "many files use strongest tier",
+			task: models.Task{Number: "1", Files: []string{"a", "b", "c", "d", "e", "f"}},
+			want: "opus",
+		},
+		{
+			name: "long estimate uses strongest tier",
+			task: models.Task{Number: "1", Files: []string{"a.go"}, EstimatedTime: 2 * time.Hour},
+			want: "opus",
+		},
+		{
+			name: "no estimate uses default",
+			task: models.Task{Number: "1", Files: []string{"a.go"}},
+			want: "sonnet",
+		},
+		{
+			name: "task type overrides size",
+			task: models.Task{Number: "1", Type: "integration", Files: []string{"a.go"}, EstimatedTime: time.Minute},
+			want: "opus",
+		},
+		{
+			name:  "past failures use strongest tier",
+			task:  models.Task{Number: "2", Files: []string{"a.go"}, EstimatedTime: 5 * time.Minute},
+			want:  "opus",
+			store: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewModelRouter(cfg, nil)
+			if tt.store {
+				router.Store = &failureRateStore{analysis: &learning.FailureAnalysis{TotalAttempts: 3, FailedAttempts: 2}}
+			}
+			if got := router.Route(context.Background(), tt.task, "plan.md"); got.Model != tt.want {
+				t.Errorf("Route() = %+v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModelRouter_Escalate(t *testing.T) {
+	router := NewModelRouter(config.DefaultModelRoutingConfig(), nil)
+
+	if next, ok := router.Escalate("haiku"); !ok || next != "sonnet" {
+		t.Errorf("Escalate(haiku) = %q, %v; want sonnet", next, ok)
+	}
+	if next, ok := router.Escalate("claude-custom"); !ok || next != "opus" {
+		t.Errorf("Escalate(unknown) = %q, %v; want opus (one above default)", next, ok)
+	}
+	if _, ok := router.Escalate("opus"); ok {
+		t.Error("Escalate(opus) should report no stronger tier")
+	}
+}
+
+func TestTaskExecutor_EscalatesModelBeforeAgentSwap(t *testing.T) {
+	invoker := newStubInvoker(
+		&agent.InvocationResult{Output: `{"content":"first"}`},
+		&agent.InvocationResult{Output: `{"content":"second"}`},
+		&agent.InvocationResult{Output: `{"content":"third"}`},
+	)
+	reviewer := &stubReviewer{
+		results: []*ReviewResult{
+			{Flag: models.StatusRed, Feedback: "Try again"},
+			{Flag: models.StatusRed, Feedback: "Still wrong"},
+			{Flag: models.StatusGreen},
+		},
+		retryDecisions: map[int]bool{0: true, 1: true},
+	}
+	executor := newSpendTestExecutor(t, invoker, reviewer)
+	executor.SwapDuringRetries = true
+	executor.ModelRouter = NewModelRouter(config.DefaultModelRoutingConfig(), nil)
+
+	task := models.Task{Number: "1", Name: "Demo", Prompt: "Do it", Agent: "golang-pro", Files: []string{"a.go"}, EstimatedTime: time.Minute}
+	result, err := executor.Execute(context.Background(), task)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	var got []string
+	for _, call := range invoker.calls {
+		got = append(got, call.Model)
+		if call.Agent != "golang-pro" {
+			t.Errorf("agent changed to %q while the model could still escalate", call.Agent)
+		}
+	}
+	want := []string{"haiku", "sonnet", "opus"}
+	if len(got) != len(want) {
+		t.Fatalf("models = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("attempt %d model = %s, want %s", i+1, got[i], want[i])
+		}
+		if result.ExecutionHistory[i].Model != want[i] {
+			t.Errorf("history[%d].Model = %s, want %s", i, result.ExecutionHistory[i].Model, want[i])
+		}
+	}
+}
+
+// failureRateStore returns a fixed failure analysis.
+type failureRateStore struct {
+	analysis *learning.FailureAnalysis
+}
+
+func (s *failureRateStore) AnalyzeFailures(context.Context, string, string, int) (*learning.FailureAnalysis, error) {
+	return s.analysis, nil
+}
+
+func (s *failureRateStore) RecordExecution(context.Context, *learning.TaskExecution) error {
+	return nil
+}
+
+func (s *failureRateStore) GetExecutionHistory(context.Context, string, string) ([]*learning.TaskExecution, error) {
+	return nil, nil
+}
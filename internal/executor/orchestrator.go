@@ -53,6 +53,12 @@ type Logger interface {
 	LogRateLimitResume()                                  // Log when resuming after rate limit pause
 	LogRateLimitCountdown(remaining, total time.Duration) // Log live countdown (every 1s, console only)
 	LogRateLimitAnnounce(remaining, total time.Duration)  // Log TTS announcement (at announce_interval)
+
+	// Run control (v3.6+)
+	LogControlEvent(event models.ControlEvent) // Log an operator change (pause, cancel, skip, concurrency)
+
+	// Streaming agent output (v3.6+)
+	LogTaskActivity(activity models.AgentActivity) // Log a live tool call or text event from a running agent
 }
 
 // WaveExecutorInterface defines the behavior required to execute waves.
@@ -368,6 +374,11 @@ func (o *Orchestrator) aggregateResults(plan *models.Plan, results []models.Task
 	// This is important when some tasks are skipped or execution is interrupted
 	executionResult.TotalTasks = len(plan.Tasks)
 
+	// Include helper calls (agent selection, similarity, setup) in the run's cost (v3.6+)
+	if o.claudeInvoker != nil {
+		executionResult.Usage.Add(o.claudeInvoker.Usage())
+	}
+
 	return executionResult
 }
 
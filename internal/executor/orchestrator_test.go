@@ -72,6 +72,8 @@ func (m *mockLogger) LogRateLimitPause(delay time.Duration)
 func (m *mockLogger) LogRateLimitResume()                                              {}
 func (m *mockLogger) LogRateLimitCountdown(remaining, total time.Duration)             {}
 func (m *mockLogger) LogRateLimitAnnounce(remaining, total time.Duration)              {}
+func (m *mockLogger) LogControlEvent(event models.ControlEvent)                        {}
+func (m *mockLogger) LogTaskActivity(activity models.AgentActivity)                    {}
 
 func TestOrchestratorExecutePlan(t *testing.T) {
 	tests := []struct {
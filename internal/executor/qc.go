@@ -14,9 +14,11 @@ import (
 	"github.com/harrison/conductor/internal/architecture"
 	"github.com/harrison/conductor/internal/behavioral"
 	"github.com/harrison/conductor/internal/claude"
+	"github.com/harrison/conductor/internal/config"
 	"github.com/harrison/conductor/internal/learning"
 	"github.com/harrison/conductor/internal/models"
 	"github.com/harrison/conductor/internal/pattern"
+	"github.com/harrison/conductor/internal/prompts"
 )
 
 // MaxAgentOutputLen is the maximum length of agent output to include in QC prompts.
@@ -89,6 +91,13 @@ type QualityController struct {
 	BehavioralMetrics   *BehavioralMetricsProvider // Provider for behavioral metrics context (v2.7+)
 	LLMTimeout          time.Duration              // Timeout for LLM calls (from timeouts.llm)
 	ClaudeInvoker       *claude.Invoker            // Shared Claude CLI invoker for intelligent selection (v3.1+)
+	SelectionModel      string                     // Model for intelligent selection calls (v3.6+, empty = CLI default)
+	Aggregation         config.QCAggregationConfig // How multi-agent verdicts are combined (v3.6+, empty = strictest)
+	Checkers            map[string]*CommandChecker // Local checker reviewers by name, e.g. "check:go-vet" (v3.6+)
+	CheckerGate         bool                       // A RED checker fails the review before QC agents run (v3.6+)
+	CheckerRunner       CommandRunner              // Runs checker commands (optional, default: shell in task.WorkDir)
+	DiffReview          config.QCDiffReviewConfig  // Review the git diff of the task's changes (v3.6+)
+	Calibration         config.QCCalibrationConfig // Pick poorly calibrated agents less often (v3.6+)
 
 	// Test/verification results for QC prompt injection (v2.9+)
 	TestCommandResults     []TestCommandResult           // Results from RunTestCommands
@@ -148,6 +157,9 @@ type ReviewResult struct {
 	SuggestedAgent  string                   // Alternative agent suggestion for retry
 	AgentName       string                   // Name of agent that produced this result (v2.2+)
 	CriteriaResults []models.CriterionResult // Per-criterion verification results (v2.2+)
+	Issues          []models.Issue           // Specific issues found by the reviewer (v3.6+)
+	Cached          bool                     // Reused from the QC result cache instead of a fresh review (v3.6+)
+	Usage           models.Usage             // Tokens and cost of the review, summed across agents (v3.6+)
 }
 
 // NewQualityController creates a new QualityController with default settings
@@ -170,32 +182,22 @@ func NewQualityController(invoker InvokerInterface) *QualityController {
 }
 
 // BuildReviewPrompt creates a comprehensive review prompt for the QC agent
+// Rendered from the qc_review prompt template (v3.6+)
 func (qc *QualityController) BuildReviewPrompt(ctx context.Context, task models.Task, output string) string {
-	basePrompt := fmt.Sprintf(`Review the following task execution:
-
-Task: %s
-
-Requirements:
-%s
-
-Agent Output:
-%s
-`, task.Name, task.Prompt, output)
+	data := prompts.QCReviewData{Task: task, Output: output, Diff: FormatTaskDiff(diffChunkFor(task))}
 
 	// Load historical context if learning enabled
 	if qc.LearningStore != nil {
-		if historicalContext, err := qc.LoadContext(ctx, task, qc.LearningStore); err == nil && historicalContext != "" {
-			basePrompt += "\n\n" + historicalContext
+		if historicalContext, err := qc.LoadContext(ctx, task, qc.LearningStore); err == nil {
+			data.HistoricalContext = historicalContext
 		}
 	}
 
 	// Inject behavioral metrics context if provider configured
-	if behaviorContext := qc.injectBehaviorContext(ctx, task); behaviorContext != "" {
-		basePrompt += "\n\n" + behaviorContext
-	}
+	data.BehaviorContext = qc.injectBehaviorContext(ctx, task)
 
 	// Add formatting instructions (QC-specific JSON format)
-	return agent.PrepareQCPrompt(basePrompt)
+	return agent.PrepareQCPrompt(prompts.Render(prompts.QCReview, data))
 }
 
 // getCombinedCriteria merges success_criteria and integration_criteria into single array
@@ -211,159 +213,85 @@ func getCombinedCriteria(task models.Task) []string {
 
 // BuildStructuredReviewPrompt creates a QC prompt with numbered success criteria for verification.
 // Falls back to legacy prompt structure for tasks without explicit criteria.
+// Rendered from the qc_structured_review prompt template (v3.6+)
 func (qc *QualityController) BuildStructuredReviewPrompt(ctx context.Context, task models.Task, output string) string {
-	var sb strings.Builder
-
-	sb.WriteString(fmt.Sprintf("<qc_review task=\"%s\">\n\n", task.Name))
-	sb.WriteString(agent.XMLSection("task_requirements", task.Prompt))
-	sb.WriteString("\n\n")
+	data := prompts.StructuredReviewData{Task: task}
 
 	// Get combined criteria (success + integration)
 	allCriteria := getCombinedCriteria(task)
-
-	if len(allCriteria) > 0 {
-		var criteriaContent strings.Builder
-		for i, criterion := range allCriteria {
-			criteriaContent.WriteString(fmt.Sprintf("%d. [ ] %s\n", i, criterion))
-		}
-		sb.WriteString(agent.XMLSection("success_criteria", criteriaContent.String()))
-		sb.WriteString("\n\n")
-
-		// Add integration note if this is an integration task with integration criteria
-		if task.Type == "integration" && len(task.IntegrationCriteria) > 0 {
-			var integrationContent strings.Builder
-			startIdx := len(task.SuccessCriteria)
-			for i, criterion := range task.IntegrationCriteria {
-				integrationContent.WriteString(fmt.Sprintf("%d. [ ] %s\n", startIdx+i, criterion))
-			}
-			sb.WriteString(agent.XMLSection("integration_criteria", integrationContent.String()))
-			sb.WriteString("\n\n")
-		}
+	for i, criterion := range allCriteria {
+		data.Criteria = append(data.Criteria, prompts.Criterion{Index: i, Text: criterion})
 	}
 
-	if len(task.KeyPoints) > 0 {
-		var keyPointsContent strings.Builder
-		keyPointCount := len(task.KeyPoints)
-		criteriaCount := len(allCriteria)
-		if criteriaCount < keyPointCount {
-			keyPointsContent.WriteString(fmt.Sprintf("⚠️ %d key point(s) do not have explicit success criteria above. Treat these as context while scoring only against listed criteria.\n\n", keyPointCount-criteriaCount))
-		} else {
-			keyPointsContent.WriteString("These implementation key points are provided for context. Score strictly against the success criteria above.\n\n")
-		}
-		for _, kp := range task.KeyPoints {
-			line := kp.Point
-			if kp.Details != "" {
-				line += fmt.Sprintf(": %s", kp.Details)
-			}
-			if kp.Reference != "" {
-				line += fmt.Sprintf(" (ref: %s)", kp.Reference)
-			}
-			keyPointsContent.WriteString(fmt.Sprintf("- %s\n", line))
+	// Integration criteria are listed again on their own for integration tasks
+	if len(allCriteria) > 0 && task.Type == "integration" {
+		startIdx := len(task.SuccessCriteria)
+		for i, criterion := range task.IntegrationCriteria {
+			data.IntegrationCriteria = append(data.IntegrationCriteria, prompts.Criterion{Index: startIdx + i, Text: criterion})
 		}
-		sb.WriteString("<key_points guidance=\"true\">\n")
-		sb.WriteString(keyPointsContent.String())
-		sb.WriteString("</key_points>\n\n")
 	}
 
-	if len(task.TestCommands) > 0 {
-		var testContent strings.Builder
-		for _, cmd := range task.TestCommands {
-			testContent.WriteString(fmt.Sprintf("- `%s`\n", cmd))
-		}
-		sb.WriteString(agent.XMLSection("test_commands", testContent.String()))
-		sb.WriteString("\n\n")
-	}
-
-	// Add expected file paths for verification - CRITICAL for agent compliance
-	if len(task.Files) > 0 {
-		var filesContent strings.Builder
-		filesContent.WriteString("⚠️ MANDATORY: Agent MUST create/modify these EXACT file paths:\n")
-		for _, file := range task.Files {
-			filesContent.WriteString(fmt.Sprintf("- [ ] `%s`\n", file))
-		}
-		filesContent.WriteString("\nVerdict Rules:\n")
-		filesContent.WriteString("- If agent created files with DIFFERENT paths/names → RED (wrong files)\n")
-		filesContent.WriteString("- If expected files are missing → RED (incomplete)\n")
-		filesContent.WriteString("- Only GREEN if ALL expected files exist at EXACT paths")
-		sb.WriteString("<expected_files critical=\"true\">\n")
-		sb.WriteString(filesContent.String())
-		sb.WriteString("\n</expected_files>\n\n")
+	if len(task.KeyPoints) > len(allCriteria) {
+		data.UncoveredKeyPoints = len(task.KeyPoints) - len(allCriteria)
 	}
 
 	// Add domain-specific review criteria based on file extensions
-	if domainCriteria := inferDomainChecks(task.Files); domainCriteria != "" {
-		sb.WriteString(domainCriteria)
-		sb.WriteString("\n")
-	}
+	data.DomainChecks = inferDomainChecks(task.Files)
 
 	// Truncate agent output to prevent exceeding context window (max ~50KB to leave room for other sections)
 	// Claude's context window is ~200K tokens (~800KB chars), but QC prompt has many other sections
-	truncatedOutput := truncateOutput(output, MaxAgentOutputLen)
-	sb.WriteString(agent.XMLSection("agent_output", truncatedOutput))
-	sb.WriteString("\n\n")
+	data.Output = truncateOutput(output, MaxAgentOutputLen)
+
+	// Inject the git diff of the task's changes (v3.6+)
+	data.Diff = FormatTaskDiff(diffChunkFor(task))
 
 	// Inject test command results (v2.9+)
 	if len(qc.TestCommandResults) > 0 {
-		sb.WriteString(FormatTestResults(qc.TestCommandResults))
-		sb.WriteString("\n")
+		data.TestResults = FormatTestResults(qc.TestCommandResults)
 	}
 
 	// Inject criterion verification results (v2.9+)
 	if len(qc.CriterionVerifyResults) > 0 {
-		sb.WriteString(FormatCriterionResults(qc.CriterionVerifyResults))
-		sb.WriteString("\n")
+		data.CriterionResults = FormatCriterionResults(qc.CriterionVerifyResults)
 	}
 
 	// Inject error classification context (v2.12+)
 	if detectedErrors := getDetectedErrors(&task); len(detectedErrors) > 0 {
-		sb.WriteString(FormatDetectedErrors(detectedErrors))
-		sb.WriteString("\n")
+		data.DetectedErrors = FormatDetectedErrors(detectedErrors)
 	}
 
 	// Inject documentation target verification results (v2.9+)
 	if len(qc.DocTargetResults) > 0 {
-		sb.WriteString(FormatDocTargetResults(qc.DocTargetResults))
-		sb.WriteString("\n")
+		data.DocTargets = FormatDocTargetResults(qc.DocTargetResults)
 	}
 
 	// Inject STOP protocol prior art context (v2.24+)
 	if qc.STOPSummary != "" {
-		sb.WriteString(FormatSTOPPriorArt(qc.STOPSummary, qc.RequireJustification))
-		sb.WriteString("\n")
+		data.PriorArt = FormatSTOPPriorArt(qc.STOPSummary, qc.RequireJustification)
 	}
 
 	// Inject Architecture Checkpoint context (v2.27+)
 	if qc.ArchitectureSummary != "" {
-		sb.WriteString(FormatArchitectureContext(qc.ArchitectureSummary, qc.RequireArchitectureJustification))
-		sb.WriteString("\n")
+		data.Architecture = FormatArchitectureContext(qc.ArchitectureSummary, qc.RequireArchitectureJustification)
 	}
 
 	// Inject commit verification results (v2.30+)
 	// Shows whether agent created the expected commit as specified in task
 	hasCommitSpec := task.CommitSpec != nil && !task.CommitSpec.IsEmpty()
-	if commitSection := FormatCommitVerification(qc.CommitVerification, hasCommitSpec); commitSection != "" {
-		sb.WriteString(commitSection)
-		sb.WriteString("\n")
-	}
+	data.CommitVerification = FormatCommitVerification(qc.CommitVerification, hasCommitSpec)
 
 	// Load historical context if learning enabled
 	if qc.LearningStore != nil {
-		if historicalContext, err := qc.LoadContext(ctx, task, qc.LearningStore); err == nil && historicalContext != "" {
-			sb.WriteString(historicalContext)
-			sb.WriteString("\n\n")
+		if historicalContext, err := qc.LoadContext(ctx, task, qc.LearningStore); err == nil {
+			data.HistoricalContext = historicalContext
 		}
 	}
 
 	// Inject behavioral metrics context if provider configured
-	if behaviorContext := qc.injectBehaviorContext(ctx, task); behaviorContext != "" {
-		sb.WriteString(behaviorContext)
-		sb.WriteString("\n")
-	}
-
-	sb.WriteString("</qc_review>\n")
+	data.BehaviorContext = qc.injectBehaviorContext(ctx, task)
 
 	// Add formatting instructions (QC-specific JSON format)
-	return agent.PrepareQCPrompt(sb.String())
+	return agent.PrepareQCPrompt(prompts.Render(prompts.QCStructuredReview, data))
 }
 
 // ParseReviewResponse extracts the QC flag and feedback from agent output
@@ -433,11 +361,24 @@ func ParseReviewResponse(output string) (flag string, feedback string) {
 // Review executes a quality control review of a task output
 // If multi-agent QC is configured (AgentConfig.Mode != "" and not single explicit agent),
 // this automatically delegates to ReviewMultiAgent for parallel review.
+// With diff review enabled, the git diff of the task's changes is reviewed
+// alongside the output, in per-file parts when it is large (v3.6+).
 func (qc *QualityController) Review(ctx context.Context, task models.Task, output string) (*ReviewResult, error) {
-	// Check if multi-agent QC is enabled
-	if qc.shouldUseMultiAgent() {
+	if chunks := qc.diffChunks(task); len(chunks) > 0 {
+		return qc.reviewDiffChunks(ctx, task, output, chunks)
+	}
+	return qc.review(ctx, task, output)
+}
+
+// review runs one QC review of task, with a single agent or several.
+func (qc *QualityController) review(ctx context.Context, task models.Task, output string) (*ReviewResult, error) {
+	// Check if multi-agent QC is enabled, or the risk gate added a reviewer (v3.6+)
+	if qc.shouldUseMultiAgent() || len(riskQCAgents(task)) > 0 {
 		return qc.ReviewMultiAgent(ctx, task, output)
 	}
+	if qcAgent := qc.getDefaultAgent(); agent.IsQCChecker(qcAgent) {
+		return qc.reviewSingleAgent(ctx, task, output, qcAgent)
+	}
 
 	// Single agent review (legacy behavior)
 	var basePrompt string
@@ -464,10 +405,11 @@ func (qc *QualityController) Review(ctx context.Context, task models.Task, outpu
 		Prompt:     basePrompt,
 		Agent:      qcAgent,
 		JSONSchema: models.QCResponseSchemaWithOptions(hasSuccessCriteria, requireSTOPJustification), // Enforce QC response structure via schema
+		WorkDir:    task.WorkDir,
 	}
 
 	// Invoke the QC agent and parse response
-	qcResp, jsonErr := qc.invokeAndParseQCAgent(ctx, reviewTask, qcAgent)
+	qcResp, usage, jsonErr := qc.invokeAndParseQCAgent(ctx, reviewTask, qcAgent)
 	if jsonErr != nil {
 		// Invocation or parsing failed
 		return nil, fmt.Errorf("QC review failed: %w", jsonErr)
@@ -480,6 +422,8 @@ func (qc *QualityController) Review(ctx context.Context, task models.Task, outpu
 		SuggestedAgent:  qcResp.SuggestedAgent,
 		AgentName:       qcAgent,
 		CriteriaResults: qcResp.CriteriaResults,
+		Issues:          qcResp.Issues,
+		Usage:           usage,
 	}
 
 	// Apply criteria aggregation if task has success criteria or integration criteria
@@ -503,6 +447,8 @@ func (qc *QualityController) Review(ctx context.Context, task models.Task, outpu
 		}
 	}
 
+	// A lone reviewer's verdict feeds qc-stats and calibration too (v3.6+)
+	qc.recordAgentVerdicts(ctx, task, []*ReviewResult{reviewResult}, reviewResult)
 	return reviewResult, nil
 }
 
@@ -546,6 +492,7 @@ func (qc *QualityController) ReviewMultiAgent(ctx context.Context, task models.T
 	// Select which agents to use based on configuration
 	var agents []string
 	var selCtx *SelectionContext
+	calibration := qc.reviewerCalibration(ctx)
 
 	// Use intelligent selection if mode is "intelligent" and selector is available
 	if qc.AgentConfig.Mode == "intelligent" {
@@ -557,16 +504,27 @@ func (qc *QualityController) ReviewMultiAgent(ctx context.Context, task models.T
 			} else {
 				qc.IntelligentSelector = NewIntelligentSelector(qc.Registry, qc.AgentConfig.CacheTTLSeconds, qc.LLMTimeout, nil)
 			}
+			qc.IntelligentSelector.Model = qc.SelectionModel
+			qc.IntelligentSelector.Calibration = calibration
 		}
 
 		selCtx = &SelectionContext{
 			ExecutingAgent:      task.Agent,
 			IntelligentSelector: qc.IntelligentSelector,
 			LLMTimeout:          qc.LLMTimeout,
+			Calibration:         calibration,
 		}
 		agents = SelectQCAgentsWithContext(ctx, task, qc.AgentConfig, qc.Registry, selCtx)
+	} else if qc.AgentConfig.Mode == "" {
+		// Legacy single-agent mode reached only through risk gate reviewers
+		agents = []string{qc.getDefaultAgent()}
 	} else {
-		agents = SelectQCAgents(task, qc.AgentConfig, qc.Registry)
+		agents = SelectQCAgentsWithContext(ctx, task, qc.AgentConfig, qc.Registry, &SelectionContext{Calibration: calibration})
+	}
+
+	// Reviewers added by the risk gate join whatever the mode selected (v3.6+)
+	if extra := riskQCAgents(task); len(extra) > 0 {
+		agents = filterBlockedAgents(appendUniqueAgents(append([]string(nil), agents...), extra...), qc.AgentConfig.BlockedAgents)
 	}
 
 	// Check for empty agent list (all agents blocked)
@@ -590,8 +548,24 @@ func (qc *QualityController) ReviewMultiAgent(ctx context.Context, task models.T
 		return qc.reviewSingleAgent(ctx, task, output, agents[0])
 	}
 
-	// Invoke all agents in parallel
-	results := qc.invokeAgentsParallel(ctx, task, output, agents)
+	// Invoke all agents in parallel. With the checker gate, local checkers run
+	// first and a RED from one fails the review without invoking agents (v3.6+).
+	checkers, qcAgents := splitCheckers(agents)
+	var results []*ReviewResult
+	if qc.CheckerGate && len(checkers) > 0 && len(qcAgents) > 0 {
+		results = qc.invokeAgentsParallel(ctx, task, output, checkers)
+		if gated := qc.aggregateVerdicts(results, checkers); gated.Flag == models.StatusRed {
+			gated.Feedback += fmt.Sprintf("\nQC agents (%s) were not invoked because a checker failed", strings.Join(qcAgents, ", "))
+			if qc.Logger != nil {
+				qc.Logger.LogQCAggregatedResult(gated.Flag, "checker-gate")
+			}
+			qc.recordAgentVerdicts(ctx, task, results, gated)
+			return gated, nil
+		}
+		results = append(results, qc.invokeAgentsParallel(ctx, task, output, qcAgents)...)
+	} else {
+		results = qc.invokeAgentsParallel(ctx, task, output, agents)
+	}
 
 	// Log individual verdicts from each agent
 	if qc.Logger != nil {
@@ -604,11 +578,22 @@ func (qc *QualityController) ReviewMultiAgent(ctx context.Context, task models.T
 		qc.Logger.LogQCIndividualVerdicts(verdictMap)
 	}
 
-	// Aggregate results: use per-criterion consensus for tasks with success or integration criteria,
-	// otherwise use strictest-wins for legacy tasks
+	// Aggregate results: a configured voting strategy (v3.6+), else per-criterion consensus
+	// for tasks with success or integration criteria and strictest-wins for legacy tasks
 	var final *ReviewResult
 	allCriteria := getCombinedCriteria(task)
-	if len(allCriteria) > 0 {
+	if len(qcAgents) == 0 {
+		// Checkers cannot judge criteria; only their verdicts count
+		allCriteria = nil
+	}
+	if strategy := qc.aggregationStrategy(); strategy != config.QCAggregationStrictest {
+		final = qc.aggregateByStrategy(ctx, results, agents, len(allCriteria))
+
+		// Log aggregated result
+		if qc.Logger != nil {
+			qc.Logger.LogQCAggregatedResult(final.Flag, strategy)
+		}
+	} else if len(allCriteria) > 0 {
 		// Use multi-agent criteria aggregation (unanimous consensus)
 		final = qc.aggregateMultiAgentCriteria(results, task, len(allCriteria))
 		final.AgentName = fmt.Sprintf("multi-agent(%s)", strings.Join(agents, ","))
@@ -627,11 +612,31 @@ func (qc *QualityController) ReviewMultiAgent(ctx context.Context, task models.T
 		}
 	}
 
+	// Every agent's invocation counts towards the review's cost (v3.6+)
+	for _, result := range results {
+		if result != nil {
+			final.Usage.Add(result.Usage)
+		}
+	}
+	qc.recordAgentVerdicts(ctx, task, results, final)
+
 	return final, nil
 }
 
 // reviewSingleAgent performs QC review with a single specified agent
 func (qc *QualityController) reviewSingleAgent(ctx context.Context, task models.Task, output string, agentName string) (*ReviewResult, error) {
+	if agent.IsQCChecker(agentName) {
+		result, ok := qc.runChecker(ctx, task, agentName)
+		if !ok {
+			return &ReviewResult{Flag: models.StatusGreen, AgentName: agentName, Feedback: fmt.Sprintf("%s does not apply to the task's files", agentName)}, nil
+		}
+		if result.Flag == "" {
+			return nil, fmt.Errorf("QC review failed: %s %s", agentName, result.Feedback)
+		}
+		qc.recordAgentVerdicts(ctx, task, []*ReviewResult{result}, result)
+		return result, nil
+	}
+
 	var basePrompt string
 	allCriteria := getCombinedCriteria(task)
 	hasSuccessCriteria := len(allCriteria) > 0
@@ -650,9 +655,10 @@ func (qc *QualityController) reviewSingleAgent(ctx context.Context, task models.
 		Prompt:     basePrompt,
 		Agent:      agentName,
 		JSONSchema: models.QCResponseSchemaWithOptions(hasSuccessCriteria, requireSTOPJustification), // Enforce QC response structure via schema
+		WorkDir:    task.WorkDir,
 	}
 
-	qcResp, jsonErr := qc.invokeAndParseQCAgent(ctx, reviewTask, agentName)
+	qcResp, usage, jsonErr := qc.invokeAndParseQCAgent(ctx, reviewTask, agentName)
 	if jsonErr != nil {
 		// Invocation or parsing failed - return error
 		return nil, fmt.Errorf("QC review failed: %w", jsonErr)
@@ -664,6 +670,8 @@ func (qc *QualityController) reviewSingleAgent(ctx context.Context, task models.
 		SuggestedAgent:  qcResp.SuggestedAgent,
 		AgentName:       agentName,
 		CriteriaResults: qcResp.CriteriaResults,
+		Issues:          qcResp.Issues,
+		Usage:           usage,
 	}
 
 	// Apply STOP justification check if required (v2.24+)
@@ -674,6 +682,7 @@ func (qc *QualityController) reviewSingleAgent(ctx context.Context, task models.
 		}
 	}
 
+	qc.recordAgentVerdicts(ctx, task, []*ReviewResult{reviewResult}, reviewResult)
 	return reviewResult, nil
 }
 
@@ -692,6 +701,20 @@ func (qc *QualityController) invokeAgentsParallel(ctx context.Context, task mode
 
 	for i, agentName := range agents {
 		wg.Add(1)
+
+		// Local checkers run their command instead of invoking an agent (v3.6+)
+		if agent.IsQCChecker(agentName) {
+			go func(idx int, name string) {
+				defer wg.Done()
+				if result, ok := qc.runChecker(ctx, task, name); ok {
+					mu.Lock()
+					results[idx] = result
+					mu.Unlock()
+				}
+			}(i, agentName)
+			continue
+		}
+
 		go func(idx int, agent string) {
 			defer wg.Done()
 
@@ -710,16 +733,18 @@ func (qc *QualityController) invokeAgentsParallel(ctx context.Context, task mode
 				Prompt:     basePrompt,
 				Agent:      agent,
 				JSONSchema: models.QCResponseSchemaWithOptions(hasSuccessCriteria, requireSTOPJustification), // Enforce QC response structure via schema
+				WorkDir:    task.WorkDir,
 			}
 
 			// Invoke agent and parse response
-			qcResp, jsonErr := qc.invokeAndParseQCAgent(ctx, reviewTask, agent)
+			qcResp, usage, jsonErr := qc.invokeAndParseQCAgent(ctx, reviewTask, agent)
 			if jsonErr != nil {
 				mu.Lock()
 				results[idx] = &ReviewResult{
 					Flag:      "",
 					Feedback:  fmt.Sprintf("Agent %s failed: %v", agent, jsonErr),
 					AgentName: agent,
+					Usage:     usage,
 				}
 				mu.Unlock()
 				return
@@ -733,6 +758,8 @@ func (qc *QualityController) invokeAgentsParallel(ctx context.Context, task mode
 				SuggestedAgent:  qcResp.SuggestedAgent,
 				AgentName:       agent,
 				CriteriaResults: qcResp.CriteriaResults,
+				Issues:          qcResp.Issues,
+				Usage:           usage,
 			}
 			// Log criteria results if available
 			if qc.Logger != nil && len(qcResp.CriteriaResults) > 0 {
@@ -778,6 +805,7 @@ func (qc *QualityController) aggregateVerdicts(results []*ReviewResult, agents [
 		if result.Feedback != "" {
 			feedbackParts = append(feedbackParts, fmt.Sprintf("[%s] %s", result.AgentName, result.Feedback))
 		}
+		combined.Issues = append(combined.Issues, result.Issues...)
 
 		// Track agent suggestions (use first non-empty)
 		if result.SuggestedAgent != "" && combined.SuggestedAgent == "" {
@@ -850,13 +878,19 @@ func parseQCJSON(output string) (*models.QCResponse, error) {
 
 // invokeAndParseQCAgent invokes a QC agent and parses the response with schema enforcement.
 // With --json-schema flag, invalid JSON is prevented at the CLI level, eliminating need for retries.
-func (qc *QualityController) invokeAndParseQCAgent(ctx context.Context, task models.Task, agentName string) (*models.QCResponse, error) {
+// The invocation's usage is returned even when parsing fails (v3.6+).
+func (qc *QualityController) invokeAndParseQCAgent(ctx context.Context, task models.Task, agentName string) (*models.QCResponse, models.Usage, error) {
 	result, err := qc.Invoker.Invoke(ctx, task)
 	if err != nil {
-		return nil, fmt.Errorf("QC review failed: %w", err)
+		var usage models.Usage
+		if result != nil {
+			usage = result.Usage
+		}
+		return nil, usage, fmt.Errorf("QC review failed: %w", err)
 	}
 
-	return parseQCJSON(result.Output)
+	resp, err := parseQCJSON(result.Output)
+	return resp, result.Usage, err
 }
 
 // aggregateCriteriaResults combines per-criterion verdicts using unanimous consensus.
@@ -968,7 +1002,11 @@ func (qc *QualityController) aggregateMultiAgentCriteria(results []*ReviewResult
 
 	// Preserve individual agent feedback instead of generic message
 	var feedbackBuilder strings.Builder
+	var issues []models.Issue
 	for _, result := range results {
+		if result != nil {
+			issues = append(issues, result.Issues...)
+		}
 		if result != nil && result.Feedback != "" {
 			if result.AgentName != "" {
 				feedbackBuilder.WriteString(fmt.Sprintf("[%s] %s\n", result.AgentName, result.Feedback))
@@ -986,6 +1024,7 @@ func (qc *QualityController) aggregateMultiAgentCriteria(results []*ReviewResult
 	return &ReviewResult{
 		Flag:     verdict,
 		Feedback: feedback,
+		Issues:   issues,
 	}
 }
 
@@ -0,0 +1,317 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// qcVote is one agent's verdict on the review or on a single criterion.
+type qcVote struct {
+	agent string
+	flag  string // GREEN, YELLOW or RED
+}
+
+// aggregationStrategy returns the configured strategy, strictest when unset.
+func (qc *QualityController) aggregationStrategy() string {
+	if qc.Aggregation.Strategy == "" {
+		return config.QCAggregationStrictest
+	}
+	return qc.Aggregation.Strategy
+}
+
+// isVetoAgent reports whether a RED from agent fails the review on its own.
+func (qc *QualityController) isVetoAgent(agent string) bool {
+	for _, veto := range qc.Aggregation.VetoAgents {
+		if veto == agent {
+			return true
+		}
+	}
+	return false
+}
+
+// unscoredAgentWeight is the weighted strategy's vote weight for an agent
+// without min_samples reviews with a known outcome: even odds of being right.
+const unscoredAgentWeight = 0.5
+
+// agentWeights returns each agent's vote weight for the weighted strategy:
+// its historical agreement with review outcomes once it has Aggregation.MinSamples
+// reviews with a known outcome. Agents without enough history get the neutral
+// unscoredAgentWeight, so a new reviewer does not outweigh a proven one.
+// Without a learning store every agent weighs 1.
+func (qc *QualityController) agentWeights(ctx context.Context, agents []string) map[string]float64 {
+	weights := make(map[string]float64, len(agents))
+	for _, agent := range agents {
+		weights[agent] = 1
+	}
+	if qc.aggregationStrategy() != config.QCAggregationWeighted || qc.LearningStore == nil {
+		return weights
+	}
+
+	agreement, err := qc.LearningStore.GetQCAgentAgreement(ctx, agents)
+	if err != nil {
+		return weights
+	}
+	for _, agent := range agents {
+		weights[agent] = unscoredAgentWeight
+		if a, ok := agreement[agent]; ok && a.Reviews >= qc.Aggregation.MinSamples {
+			weights[agent] = a.Rate()
+		}
+	}
+	return weights
+}
+
+// decideVotes combines votes with the configured strategy. reviewers is the
+// number of agents that could have voted: agents that returned no verdict do
+// not vote, but the quorum is capped at reviewers rather than at the votes
+// cast, so a failed agent never lowers it. A RED from a veto agent decides
+// the result.
+func (qc *QualityController) decideVotes(votes []qcVote, weights map[string]float64, reviewers int) string {
+	if len(votes) == 0 && qc.aggregationStrategy() != config.QCAggregationQuorum {
+		return models.StatusYellow
+	}
+	for _, v := range votes {
+		if v.flag == models.StatusRed && qc.isVetoAgent(v.agent) {
+			return models.StatusRed
+		}
+	}
+
+	var greens, yellows, reds int
+	var passWeight, redWeight float64
+	for _, v := range votes {
+		weight := weights[v.agent]
+		switch v.flag {
+		case models.StatusGreen:
+			greens++
+			passWeight += weight
+		case models.StatusYellow:
+			yellows++
+			passWeight += weight
+		default:
+			reds++
+			redWeight += weight
+		}
+	}
+
+	switch qc.aggregationStrategy() {
+	case config.QCAggregationQuorum:
+		need := qc.Aggregation.Quorum
+		if need > reviewers {
+			need = reviewers
+		}
+		if greens >= need {
+			return models.StatusGreen
+		}
+		if greens+yellows >= need {
+			return models.StatusYellow
+		}
+		return models.StatusRed
+	case config.QCAggregationMajority:
+		if reds >= greens+yellows {
+			return models.StatusRed
+		}
+	case config.QCAggregationWeighted:
+		// Agents that never agree carry no weight; fall back to a plain majority
+		if passWeight+redWeight == 0 {
+			passWeight, redWeight = float64(greens+yellows), float64(reds)
+		}
+		if redWeight >= passWeight {
+			return models.StatusRed
+		}
+	default:
+		if reds > 0 {
+			return models.StatusRed
+		}
+	}
+	if yellows > 0 {
+		return models.StatusYellow
+	}
+	return models.StatusGreen
+}
+
+// quorumReviewers returns how many agents could have voted on the review and
+// on each criterion. Every QC agent counts, including ones that failed.
+// Checkers count for the review only when they applied to the task, and
+// never for criteria, which they cannot judge.
+func quorumReviewers(results []*ReviewResult, agents []string) (review, criteria int) {
+	for _, name := range agents {
+		if !agent.IsQCChecker(name) {
+			criteria++
+		}
+	}
+	review = criteria
+	for _, result := range results {
+		if result != nil && agent.IsQCChecker(result.AgentName) {
+			review++
+		}
+	}
+	return review, criteria
+}
+
+// aggregateByStrategy combines multi-agent results with a voting strategy (v3.6+).
+// For tasks with criteria each criterion is decided by the same vote and any
+// failed criterion makes the review RED; otherwise the overall verdicts decide.
+func (qc *QualityController) aggregateByStrategy(ctx context.Context, results []*ReviewResult, agents []string, criteriaCount int) *ReviewResult {
+	weights := qc.agentWeights(ctx, agents)
+	reviewers, criterionReviewers := quorumReviewers(results, agents)
+
+	var votes []qcVote
+	for _, result := range results {
+		if result != nil && result.Flag != "" {
+			votes = append(votes, qcVote{agent: result.AgentName, flag: result.Flag})
+		}
+	}
+	verdict := qc.decideVotes(votes, weights, reviewers)
+
+	if criteriaCount > 0 {
+		criterionVotes := make(map[int][]qcVote)
+		for _, result := range results {
+			if result == nil {
+				continue
+			}
+			for _, cr := range result.CriteriaResults {
+				if cr.Index < 0 || cr.Index >= criteriaCount {
+					continue
+				}
+				flag := models.StatusGreen
+				if !cr.Passed {
+					flag = models.StatusRed
+				}
+				criterionVotes[cr.Index] = append(criterionVotes[cr.Index], qcVote{agent: result.AgentName, flag: flag})
+			}
+		}
+
+		var consensus []models.CriterionResult
+		allPassed := true
+		for idx := 0; idx < criteriaCount; idx++ {
+			// A criterion no agent voted on cannot pass
+			passed := len(criterionVotes[idx]) > 0 && qc.decideVotes(criterionVotes[idx], weights, criterionReviewers) != models.StatusRed
+			if !passed {
+				allPassed = false
+			}
+			consensus = append(consensus, models.CriterionResult{Index: idx, Passed: passed})
+		}
+		if qc.Logger != nil {
+			qc.Logger.LogQCCriteriaResults("consensus", consensus)
+		}
+		if !allPassed {
+			verdict = models.StatusRed
+		}
+	}
+
+	combined := &ReviewResult{
+		Flag:      verdict,
+		AgentName: fmt.Sprintf("multi-agent(%s)", strings.Join(agents, ",")),
+	}
+	var feedbackParts []string
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		combined.Issues = append(combined.Issues, result.Issues...)
+		if result.SuggestedAgent != "" && combined.SuggestedAgent == "" {
+			combined.SuggestedAgent = result.SuggestedAgent
+		}
+		if result.Feedback == "" {
+			continue
+		}
+		// Mark reviewers the vote went against, so a retry knows which feedback decided
+		outvoted := ""
+		if result.Flag != "" && (result.Flag == models.StatusRed) != (verdict == models.StatusRed) {
+			outvoted = " (outvoted)"
+		}
+		feedbackParts = append(feedbackParts, fmt.Sprintf("[%s]%s %s", result.AgentName, outvoted, result.Feedback))
+	}
+	combined.Feedback = strings.Join(feedbackParts, "\n")
+	return combined
+}
+
+// recordAgentVerdicts stores each agent's verdict with the review's final
+// verdict; a single-reviewer review records its one verdict as both. Once the review's outcome is known, these build the agreement
+// history the weighted strategy uses and the calibration history agent
+// selection uses (v3.6+). The review of a diff part only collects its
+// verdicts, so a diff reviewed in parts is recorded once.
+func (qc *QualityController) recordAgentVerdicts(ctx context.Context, task models.Task, results []*ReviewResult, final *ReviewResult) {
+	if qc.LearningStore == nil || final == nil {
+		return
+	}
+	if parts, ok := task.Metadata[qcPartVerdicts].(*partVerdicts); ok {
+		parts.add(results)
+		return
+	}
+	outcome, source := qc.reviewOutcome(results)
+	fileTypes := taskFileTypes(task)
+	var verdicts []learning.QCAgentVerdict
+	for _, result := range results {
+		if result == nil || result.Flag == "" {
+			continue
+		}
+		verdicts = append(verdicts, learning.QCAgentVerdict{
+			PlanFile:      task.SourceFile,
+			TaskNumber:    task.Number,
+			Agent:         result.AgentName,
+			Verdict:       result.Flag,
+			FinalVerdict:  final.Flag,
+			Strategy:      qc.aggregationStrategy(),
+			FileTypes:     fileTypes,
+			Outcome:       outcome,
+			OutcomeSource: source,
+		})
+	}
+	// History is best-effort; a failed write must not fail the review
+	if err := qc.LearningStore.RecordQCVerdicts(ctx, verdicts); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record QC verdicts for task %s: %v\n", task.Number, err)
+	}
+}
+
+// reviewOutcome returns the outcome known at review time: when a RED is
+// contradicted by another reviewer and every test command passed, the work is
+// taken to be sound. Otherwise the outcome is left for a fix task or a human
+// override to settle.
+func (qc *QualityController) reviewOutcome(results []*ReviewResult) (outcome, source string) {
+	if len(qc.TestCommandResults) == 0 {
+		return "", ""
+	}
+	for _, test := range qc.TestCommandResults {
+		if !test.Passed {
+			return "", ""
+		}
+	}
+	var red, passed bool
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		switch result.Flag {
+		case models.StatusRed:
+			red = true
+		case models.StatusGreen, models.StatusYellow:
+			passed = true
+		}
+	}
+	if red && passed {
+		return learning.QCOutcomeGood, learning.QCOutcomeSourceTests
+	}
+	return "", ""
+}
+
+// taskFileTypes returns the distinct extensions of the task's files.
+func taskFileTypes(task models.Task) []string {
+	var types []string
+	seen := make(map[string]bool)
+	for _, file := range task.Files {
+		ext := strings.ToLower(filepath.Ext(file))
+		if ext != "" && !seen[ext] {
+			seen[ext] = true
+			types = append(types, ext)
+		}
+	}
+	return types
+}
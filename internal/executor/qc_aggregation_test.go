@@ -0,0 +1,372 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// oneStrictReviewer is three approvals and one RED, the case strictest-wins retries.
+func oneStrictReviewer() []*ReviewResult {
+	return []*ReviewResult{
+		{AgentName: "code-reviewer", Flag: models.StatusGreen, Feedback: "looks good"},
+		{AgentName: "golang-pro", Flag: models.StatusGreen, Feedback: "idiomatic"},
+		{AgentName: "test-automator", Flag: models.StatusYellow, Feedback: "one more test would help"},
+		{AgentName: "strict-reviewer", Flag: models.StatusRed, Feedback: "rename everything"},
+	}
+}
+
+func TestAggregateByStrategy(t *testing.T) {
+	agents := []string{"code-reviewer", "golang-pro", "test-automator", "strict-reviewer"}
+	tests := []struct {
+		name        string
+		aggregation config.QCAggregationConfig
+		agents      []string // Defaults to agents
+		criteria    int
+		results     []*ReviewResult
+		want        string
+	}{
+		{
+			name:        "majority outvotes one strict reviewer",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationMajority},
+			results:     oneStrictReviewer(),
+			want:        models.StatusYellow,
+		},
+		{
+			name:        "majority tie is RED",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationMajority},
+			results: []*ReviewResult{
+				{AgentName: "code-reviewer", Flag: models.StatusGreen},
+				{AgentName: "strict-reviewer", Flag: models.StatusRed},
+			},
+			want: models.StatusRed,
+		},
+		{
+			name:        "majority ignores agents without a verdict",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationMajority},
+			results: []*ReviewResult{
+				{AgentName: "code-reviewer", Flag: models.StatusGreen},
+				{AgentName: "golang-pro", Flag: "", Feedback: "Agent golang-pro failed"},
+			},
+			want: models.StatusGreen,
+		},
+		{
+			name:        "quorum of two GREEN met",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationQuorum, Quorum: 2},
+			results:     oneStrictReviewer(),
+			want:        models.StatusGreen,
+		},
+		{
+			name:        "quorum counts YELLOW as a weaker approval",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationQuorum, Quorum: 3},
+			results:     oneStrictReviewer(),
+			want:        models.StatusYellow,
+		},
+		{
+			name:        "quorum not met",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationQuorum, Quorum: 4},
+			results:     oneStrictReviewer(),
+			want:        models.StatusRed,
+		},
+		{
+			name:        "quorum is capped at the selected agents",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationQuorum, Quorum: 5},
+			results: []*ReviewResult{
+				{AgentName: "code-reviewer", Flag: models.StatusGreen},
+				{AgentName: "golang-pro", Flag: models.StatusGreen},
+				{AgentName: "test-automator", Flag: models.StatusGreen},
+				{AgentName: "strict-reviewer", Flag: models.StatusGreen},
+			},
+			want: models.StatusGreen,
+		},
+		{
+			name:        "agents without a verdict do not lower the quorum",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationQuorum, Quorum: 3},
+			results: []*ReviewResult{
+				{AgentName: "code-reviewer", Flag: models.StatusGreen},
+				{AgentName: "golang-pro", Flag: models.StatusGreen},
+				{AgentName: "test-automator", Flag: "", Feedback: "Agent test-automator failed"},
+			},
+			want: models.StatusRed,
+		},
+		{
+			name:        "checkers that did not apply do not count towards the quorum",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationQuorum, Quorum: 3},
+			agents:      []string{"code-reviewer", "golang-pro", "check:go-vet"},
+			results: []*ReviewResult{
+				{AgentName: "code-reviewer", Flag: models.StatusGreen},
+				{AgentName: "golang-pro", Flag: models.StatusGreen},
+				nil,
+			},
+			want: models.StatusGreen,
+		},
+		{
+			name:        "checkers do not count towards the criteria quorum",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationQuorum, Quorum: 3},
+			agents:      []string{"code-reviewer", "golang-pro", "check:go-vet"},
+			criteria:    1,
+			results: []*ReviewResult{
+				{AgentName: "code-reviewer", Flag: models.StatusGreen, CriteriaResults: []models.CriterionResult{{Index: 0, Passed: true}}},
+				{AgentName: "golang-pro", Flag: models.StatusGreen, CriteriaResults: []models.CriterionResult{{Index: 0, Passed: true}}},
+				{AgentName: "check:go-vet", Flag: models.StatusGreen},
+			},
+			want: models.StatusGreen,
+		},
+		{
+			name:        "a checker that applied counts towards the quorum",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationQuorum, Quorum: 3},
+			agents:      []string{"code-reviewer", "golang-pro", "check:go-vet"},
+			results: []*ReviewResult{
+				{AgentName: "code-reviewer", Flag: models.StatusGreen},
+				{AgentName: "golang-pro", Flag: models.StatusGreen},
+				{AgentName: "check:go-vet", Flag: models.StatusRed, Feedback: "go vet failed"},
+			},
+			want: models.StatusRed,
+		},
+		{
+			name:        "veto agent overrides the majority",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationMajority, VetoAgents: []string{"strict-reviewer"}},
+			results:     oneStrictReviewer(),
+			want:        models.StatusRed,
+		},
+		{
+			name:        "veto agent approving does not override",
+			aggregation: config.QCAggregationConfig{Strategy: config.QCAggregationMajority, VetoAgents: []string{"code-reviewer"}},
+			results:     oneStrictReviewer(),
+			want:        models.StatusYellow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qc := NewQualityController(nil)
+			qc.Aggregation = tt.aggregation
+			reviewers := agents
+			if tt.agents != nil {
+				reviewers = tt.agents
+			}
+			got := qc.aggregateByStrategy(context.Background(), tt.results, reviewers, tt.criteria)
+			if got.Flag != tt.want {
+				t.Errorf("Flag = %s, want %s", got.Flag, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateByStrategy_Criteria(t *testing.T) {
+	qc := NewQualityController(nil)
+	qc.Aggregation = config.QCAggregationConfig{Strategy: config.QCAggregationMajority}
+
+	criteria := func(passed ...bool) []models.CriterionResult {
+		var results []models.CriterionResult
+		for i, p := range passed {
+			results = append(results, models.CriterionResult{Index: i, Passed: p})
+		}
+		return results
+	}
+	results := []*ReviewResult{
+		{AgentName: "a", Flag: models.StatusGreen, CriteriaResults: criteria(true, true)},
+		{AgentName: "b", Flag: models.StatusGreen, CriteriaResults: criteria(true, true)},
+		{AgentName: "c", Flag: models.StatusRed, CriteriaResults: criteria(true, false), Feedback: "criterion 1 fails"},
+	}
+
+	got := qc.aggregateByStrategy(context.Background(), results, []string{"a", "b", "c"}, 2)
+	if got.Flag != models.StatusGreen {
+		t.Errorf("two of three passing each criterion should be GREEN, got %s", got.Flag)
+	}
+	if !strings.Contains(got.Feedback, "[c] (outvoted) criterion 1 fails") {
+		t.Errorf("outvoted feedback should be marked: %q", got.Feedback)
+	}
+
+	// A criterion no agent voted on cannot pass
+	got = qc.aggregateByStrategy(context.Background(), results, []string{"a", "b", "c"}, 3)
+	if got.Flag != models.StatusRed {
+		t.Errorf("unvoted criterion should fail the review, got %s", got.Flag)
+	}
+}
+
+func TestAggregateByStrategy_WeightedUsesAgreementHistory(t *testing.T) {
+	store, err := learning.NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	// strict-reviewer failed sound work in 5 of its 6 past reviews; code-reviewer was always right
+	ctx := context.Background()
+	for i := 0; i < 6; i++ {
+		strictVerdict := models.StatusRed
+		if i == 0 {
+			strictVerdict = models.StatusGreen
+		}
+		if err := store.RecordQCVerdicts(ctx, []learning.QCAgentVerdict{
+			{TaskNumber: "1", Agent: "code-reviewer", Verdict: models.StatusGreen, FinalVerdict: strictVerdict, Outcome: learning.QCOutcomeGood},
+			{TaskNumber: "1", Agent: "strict-reviewer", Verdict: strictVerdict, FinalVerdict: strictVerdict, Outcome: learning.QCOutcomeGood},
+		}); err != nil {
+			t.Fatalf("RecordQCVerdicts: %v", err)
+		}
+	}
+
+	qc := NewQualityController(nil)
+	qc.LearningStore = store
+	qc.Aggregation = config.QCAggregationConfig{Strategy: config.QCAggregationWeighted, MinSamples: 5}
+
+	weights := qc.agentWeights(ctx, []string{"code-reviewer", "strict-reviewer", "new-reviewer"})
+	if weights["code-reviewer"] != 1 || weights["strict-reviewer"] > 0.2 {
+		t.Errorf("unexpected weights: %v", weights)
+	}
+	// An agent without history gets a neutral weight
+	if weights["new-reviewer"] != unscoredAgentWeight {
+		t.Errorf("new-reviewer weight = %v, want %v", weights["new-reviewer"], unscoredAgentWeight)
+	}
+
+	// A new reviewer's RED does not outweigh an approval from a proven one
+	newcomer := []*ReviewResult{
+		{AgentName: "code-reviewer", Flag: models.StatusGreen},
+		{AgentName: "new-reviewer", Flag: models.StatusRed},
+	}
+	if got := qc.aggregateByStrategy(ctx, newcomer, []string{"code-reviewer", "new-reviewer"}, 0); got.Flag != models.StatusGreen {
+		t.Errorf("weighted verdict with a new reviewer = %s, want GREEN", got.Flag)
+	}
+
+	// One reliable approval outweighs one unreliable RED
+	results := []*ReviewResult{
+		{AgentName: "code-reviewer", Flag: models.StatusGreen},
+		{AgentName: "strict-reviewer", Flag: models.StatusRed},
+	}
+	if got := qc.aggregateByStrategy(ctx, results, []string{"code-reviewer", "strict-reviewer"}, 0); got.Flag != models.StatusGreen {
+		t.Errorf("weighted verdict = %s, want GREEN", got.Flag)
+	}
+
+	// Below min_samples every agent weighs the same, so the same votes tie
+	qc.Aggregation.MinSamples = 10
+	if got := qc.aggregateByStrategy(ctx, results, []string{"code-reviewer", "strict-reviewer"}, 0); got.Flag != models.StatusRed {
+		t.Errorf("unweighted tie = %s, want RED", got.Flag)
+	}
+}
+
+func TestReviewMultiAgent_AggregationStrategyAndHistory(t *testing.T) {
+	store, err := learning.NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	mock := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			verdict := "GREEN"
+			if task.Agent == "strict-reviewer" {
+				verdict = "RED"
+			}
+			return &agent.InvocationResult{
+				Output: `{"verdict":"` + verdict + `","feedback":"from ` + task.Agent + `","issues":[],"recommendations":[],"should_retry":false,"suggested_agent":""}`,
+			}, nil
+		},
+	}
+	qc := NewQualityController(mock)
+	qc.LearningStore = store
+	qc.AgentConfig = models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"code-reviewer", "golang-pro", "strict-reviewer"}}
+
+	task := models.Task{Number: "4", Name: "Task", Prompt: "p"}
+	result, err := qc.ReviewMultiAgent(context.Background(), task, "output")
+	if err != nil {
+		t.Fatalf("ReviewMultiAgent() error = %v", err)
+	}
+	if result.Flag != models.StatusRed {
+		t.Errorf("default strictest-wins should be RED, got %s", result.Flag)
+	}
+
+	qc.Aggregation = config.QCAggregationConfig{Strategy: config.QCAggregationMajority}
+	result, err = qc.ReviewMultiAgent(context.Background(), task, "output")
+	if err != nil {
+		t.Fatalf("ReviewMultiAgent() error = %v", err)
+	}
+	if result.Flag != models.StatusGreen {
+		t.Errorf("majority should approve 2 of 3, got %s", result.Flag)
+	}
+
+	// Agreement is scored against the outcome, not the verdict the review settled on
+	agreement, err := store.GetQCAgentAgreement(context.Background(), []string{"code-reviewer", "strict-reviewer"})
+	if err != nil {
+		t.Fatalf("GetQCAgentAgreement: %v", err)
+	}
+	if len(agreement) != 0 {
+		t.Errorf("reviews without an outcome should not be scored, got %+v", agreement)
+	}
+
+	// The latest review turns out to have passed sound work
+	if _, err := store.SetQCReviewOutcome(context.Background(), "", "4", learning.QCOutcomeGood, learning.QCOutcomeSourceOverride); err != nil {
+		t.Fatalf("SetQCReviewOutcome: %v", err)
+	}
+	agreement, err = store.GetQCAgentAgreement(context.Background(), []string{"code-reviewer", "strict-reviewer"})
+	if err != nil {
+		t.Fatalf("GetQCAgentAgreement: %v", err)
+	}
+	if got := agreement["strict-reviewer"]; got.Reviews != 1 || got.Agreed != 0 {
+		t.Errorf("strict-reviewer agreement = %+v, want 0 of 1", got)
+	}
+	if got := agreement["code-reviewer"]; got.Reviews != 1 || got.Agreed != 1 {
+		t.Errorf("code-reviewer agreement = %+v, want 1 of 1", got)
+	}
+}
+
+func TestReview_SingleReviewerRecordsVerdict(t *testing.T) {
+	store, err := learning.NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	mock := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			return &agent.InvocationResult{
+				Output: `{"verdict":"RED","feedback":"missing tests","issues":[],"recommendations":[],"should_retry":true,"suggested_agent":""}`,
+			}, nil
+		},
+	}
+	ctx := context.Background()
+	for taskNum, agentConfig := range map[string]models.QCAgentConfig{
+		"6": {},
+		"7": {Mode: "explicit", ExplicitList: []string{"golang-pro"}},
+	} {
+		qc := NewQualityController(mock)
+		qc.LearningStore = store
+		qc.AgentConfig = agentConfig
+		if _, err := qc.Review(ctx, models.Task{Number: taskNum, Name: "Task", Prompt: "p"}, "output"); err != nil {
+			t.Fatalf("Review() error = %v", err)
+		}
+
+		// A fix task went on to confirm the RED
+		if _, err := store.SetQCReviewOutcome(ctx, "", taskNum, learning.QCOutcomeBad, learning.QCOutcomeSourceFixTask); err != nil {
+			t.Fatalf("SetQCReviewOutcome: %v", err)
+		}
+	}
+	agreement, err := store.GetQCAgentAgreement(ctx, []string{"quality-control", "golang-pro"})
+	if err != nil {
+		t.Fatalf("GetQCAgentAgreement: %v", err)
+	}
+	for _, name := range []string{"quality-control", "golang-pro"} {
+		if got := agreement[name]; got.Reviews != 1 || got.Agreed != 1 {
+			t.Errorf("%s agreement = %+v, want its single-reviewer verdict recorded", name, got)
+		}
+	}
+}
+
+func TestQCResultCacheKey_WithAggregation(t *testing.T) {
+	key := QCResultCacheKey{TaskHash: "t", FilesHash: "f", CriteriaHash: "c", AgentSet: "mode=explicit"}
+	key.combine()
+
+	if got := key.WithAggregation(config.QCAggregationConfig{Strategy: config.QCAggregationStrictest}); got.Key != key.Key {
+		t.Error("strictest-wins should keep existing cache keys")
+	}
+	majority := key.WithAggregation(config.QCAggregationConfig{Strategy: config.QCAggregationMajority})
+	vetoed := key.WithAggregation(config.QCAggregationConfig{Strategy: config.QCAggregationMajority, VetoAgents: []string{"security-auditor"}})
+	if majority.Key == key.Key || vetoed.Key == majority.Key {
+		t.Error("each aggregation setting should get its own cache key")
+	}
+}
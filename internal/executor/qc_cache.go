@@ -1,15 +1,24 @@
 package executor
 
 import (
+	"context"
+	"crypto/sha1"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
 	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/pattern"
+	"github.com/harrison/conductor/internal/prompts"
 )
 
 // QCSelectionCache caches intelligent agent selection results to avoid redundant API calls
@@ -121,3 +130,206 @@ func (c *QCSelectionCache) Clear() {
 	defer c.mu.Unlock()
 	c.entries = make(map[string]*cacheEntry)
 }
+
+// QCResultStore persists QC review results across runs (implemented by learning.Store).
+type QCResultStore interface {
+	GetQCResult(ctx context.Context, cacheKey string) (*learning.QCCacheEntry, error)
+	StoreQCResult(ctx context.Context, entry *learning.QCCacheEntry) error
+}
+
+// QCResultCache reuses QC verdicts for tasks whose definition, file contents,
+// criteria and QC agent set are unchanged since an earlier review (v3.6+).
+// Unlike QCSelectionCache it caches the review itself and persists across runs,
+// so --retry-failed and rate-limit resumes skip re-reviewing unchanged output.
+type QCResultCache struct {
+	Store QCResultStore
+	TTL   time.Duration // Entries older than TTL are ignored (0 = never expire)
+
+	hasher *pattern.TaskHasher
+	now    func() time.Time
+}
+
+// QCResultCacheKey identifies one cacheable QC review.
+type QCResultCacheKey struct {
+	Key          string // Combined cache key
+	TaskHash     string // Task definition hash (pattern.TaskHasher)
+	FilesHash    string // Hash of the task files' git blob hashes
+	CriteriaHash string // Hash of criteria and test commands
+	AgentSet     string // Canonical QC agent configuration
+}
+
+// NewQCResultCache creates a QC result cache backed by store.
+func NewQCResultCache(store QCResultStore, ttl time.Duration) *QCResultCache {
+	return &QCResultCache{
+		Store:  store,
+		TTL:    ttl,
+		hasher: pattern.NewTaskHasher(),
+		now:    time.Now,
+	}
+}
+
+// KeyFor computes the cache key for a task as defined in the plan (before any
+// prompt enrichment), with files resolved relative to workDir.
+// Returns false for tasks without files, since their output can't be content-addressed.
+func (c *QCResultCache) KeyFor(task models.Task, workDir string, agents models.QCAgentConfig) (QCResultCacheKey, bool) {
+	if len(task.Files) == 0 {
+		return QCResultCacheKey{}, false
+	}
+
+	filesHash, err := hashTaskFiles(workDir, task.Files)
+	if err != nil {
+		return QCResultCacheKey{}, false
+	}
+
+	key := QCResultCacheKey{
+		TaskHash:     c.hasher.Hash(task.Name+"\n"+task.Prompt, task.Files).FullHash,
+		FilesHash:    filesHash,
+		CriteriaHash: hashCriteria(task),
+		AgentSet:     canonicalAgentSet(agents),
+	}
+	key.combine()
+	return key, true
+}
+
+// WithAggregation returns key scoped to a non-default verdict aggregation, so
+// verdicts reached by one strategy are not reused under another (v3.6+).
+// Strictest-wins keys are left unchanged.
+func (key QCResultCacheKey) WithAggregation(aggregation config.QCAggregationConfig) QCResultCacheKey {
+	if aggregation.Strategy == "" || aggregation.Strategy == config.QCAggregationStrictest {
+		return key
+	}
+	veto := append([]string(nil), aggregation.VetoAgents...)
+	sort.Strings(veto)
+	key.AgentSet += fmt.Sprintf(";aggregation=%s;quorum=%d;veto=%s", aggregation.Strategy, aggregation.Quorum, strings.Join(veto, ","))
+	key.combine()
+	return key
+}
+
+func (key *QCResultCacheKey) combine() {
+	combined := sha256.Sum256([]byte(strings.Join([]string{key.TaskHash, key.FilesHash, key.CriteriaHash, key.AgentSet}, "|")))
+	key.Key = hex.EncodeToString(combined[:])
+}
+
+// Lookup returns the cached review for key if one exists and hasn't expired.
+func (c *QCResultCache) Lookup(ctx context.Context, key QCResultCacheKey) (*ReviewResult, bool) {
+	entry, err := c.Store.GetQCResult(ctx, key.Key)
+	if err != nil || entry == nil {
+		return nil, false
+	}
+	if c.TTL > 0 && c.now().Sub(entry.CreatedAt) > c.TTL {
+		return nil, false
+	}
+
+	var review ReviewResult
+	if err := json.Unmarshal([]byte(entry.Result), &review); err != nil || review.Flag == "" {
+		return nil, false
+	}
+	review.Cached = true
+	return &review, true
+}
+
+// Save stores a review under key.
+func (c *QCResultCache) Save(ctx context.Context, key QCResultCacheKey, taskNumber string, review *ReviewResult) error {
+	if review == nil || review.Flag == "" {
+		return nil
+	}
+
+	stored := *review
+	stored.Cached = false
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("encode review for cache: %w", err)
+	}
+
+	return c.Store.StoreQCResult(ctx, &learning.QCCacheEntry{
+		CacheKey:     key.Key,
+		TaskNumber:   taskNumber,
+		TaskHash:     key.TaskHash,
+		FilesHash:    key.FilesHash,
+		CriteriaHash: key.CriteriaHash,
+		AgentSet:     key.AgentSet,
+		Verdict:      review.Flag,
+		Result:       string(data),
+	})
+}
+
+// hashTaskFiles hashes the current contents of the task's files.
+// Each file contributes its git blob hash (as `git hash-object` computes it),
+// so the result changes exactly when a file's tracked content changes.
+// Missing files are recorded as such rather than treated as errors.
+func hashTaskFiles(workDir string, files []string) (string, error) {
+	sortedFiles := make([]string, len(files))
+	copy(sortedFiles, files)
+	sort.Strings(sortedFiles)
+
+	var sb strings.Builder
+	for _, file := range sortedFiles {
+		path := file
+		if !filepath.IsAbs(path) && workDir != "" {
+			path = filepath.Join(workDir, path)
+		}
+
+		content, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			fmt.Fprintf(&sb, "%s:%s\n", file, gitBlobHash(content))
+		case os.IsNotExist(err):
+			fmt.Fprintf(&sb, "%s:missing\n", file)
+		default:
+			return "", err
+		}
+	}
+
+	hash := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// gitBlobHash returns the git object id of content stored as a blob.
+func gitBlobHash(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashCriteria hashes everything QC verifies a task against, including
+// project overrides of the QC prompt templates (v3.6+).
+func hashCriteria(task models.Task) string {
+	data, _ := json.Marshal(struct {
+		Type                string
+		SuccessCriteria     []string
+		IntegrationCriteria []string
+		StructuredCriteria  []models.SuccessCriterion
+		TestCommands        []string
+		Templates           string `json:",omitempty"`
+	}{
+		Type:                task.Type,
+		SuccessCriteria:     task.SuccessCriteria,
+		IntegrationCriteria: task.IntegrationCriteria,
+		StructuredCriteria:  task.StructuredCriteria,
+		TestCommands:        task.TestCommands,
+		Templates:           prompts.Active().Fingerprint(prompts.Claude4Enhancements, prompts.QCPrompt, prompts.QCReview, prompts.QCStructuredReview),
+	})
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// canonicalAgentSet renders the QC agent configuration in a stable form.
+func canonicalAgentSet(agents models.QCAgentConfig) string {
+	sortedCopy := func(list []string) string {
+		sorted := make([]string, len(list))
+		copy(sorted, list)
+		sort.Strings(sorted)
+		return strings.Join(sorted, ",")
+	}
+
+	return fmt.Sprintf("mode=%s;explicit=%s;additional=%s;blocked=%s;default=%s;max=%d;code_review=%t",
+		agents.Mode,
+		sortedCopy(agents.ExplicitList),
+		sortedCopy(agents.AdditionalAgents),
+		sortedCopy(agents.BlockedAgents),
+		agents.DefaultAgent,
+		agents.MaxAgents,
+		agents.RequireCodeReview,
+	)
+}
@@ -0,0 +1,279 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/prompts"
+)
+
+// memoryQCResultStore is an in-memory QCResultStore for tests.
+type memoryQCResultStore struct {
+	entries map[string]*learning.QCCacheEntry
+	gets    int
+}
+
+func newMemoryQCResultStore() *memoryQCResultStore {
+	return &memoryQCResultStore{entries: make(map[string]*learning.QCCacheEntry)}
+}
+
+func (m *memoryQCResultStore) GetQCResult(_ context.Context, cacheKey string) (*learning.QCCacheEntry, error) {
+	m.gets++
+	return m.entries[cacheKey], nil
+}
+
+func (m *memoryQCResultStore) StoreQCResult(_ context.Context, entry *learning.QCCacheEntry) error {
+	stored := *entry
+	stored.CreatedAt = time.Now()
+	m.entries[entry.CacheKey] = &stored
+	return nil
+}
+
+func TestQCResultCache_KeyFor(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewQCResultCache(newMemoryQCResultStore(), time.Hour)
+	task := models.Task{
+		Number:          "1",
+		Name:            "Add main",
+		Prompt:          "Create main.go",
+		Files:           []string{"main.go", "missing.go"},
+		SuccessCriteria: []string{"Compiles"},
+	}
+	agents := models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"code-reviewer", "golang-pro"}}
+
+	base, ok := cache.KeyFor(task, dir, agents)
+	if !ok || base.Key == "" {
+		t.Fatalf("KeyFor() = %+v, %v; want a key", base, ok)
+	}
+
+	t.Run("stable for identical input", func(t *testing.T) {
+		again, _ := cache.KeyFor(task, dir, agents)
+		if again != base {
+			t.Errorf("key changed between calls: %+v vs %+v", again, base)
+		}
+	})
+
+	t.Run("agent order does not matter", func(t *testing.T) {
+		reordered := agents
+		reordered.ExplicitList = []string{"golang-pro", "code-reviewer"}
+		key, _ := cache.KeyFor(task, dir, reordered)
+		if key.Key != base.Key {
+			t.Error("expected agent set to be order-insensitive")
+		}
+	})
+
+	t.Run("changes when a QC template is overridden", func(t *testing.T) {
+		templatesDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(templatesDir, "qc_prompt.tmpl"), []byte("House rules\n{{.Prompt}}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		templates, err := prompts.Load(templatesDir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		prompts.SetActive(templates)
+		defer prompts.SetActive(prompts.Default())
+
+		if key := mustKey(t, cache, task, dir, agents); key.CriteriaHash == base.CriteriaHash {
+			t.Error("key should change when the QC prompt template changes")
+		}
+	})
+
+	t.Run("changes when inputs change", func(t *testing.T) {
+		changedCriteria := task
+		changedCriteria.SuccessCriteria = []string{"Compiles", "Has tests"}
+		otherAgents := models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"code-reviewer"}}
+		changedPrompt := task
+		changedPrompt.Prompt = "Create main.go with a flag"
+
+		for name, key := range map[string]QCResultCacheKey{
+			"criteria": mustKey(t, cache, changedCriteria, dir, agents),
+			"agents":   mustKey(t, cache, task, dir, otherAgents),
+			"prompt":   mustKey(t, cache, changedPrompt, dir, agents),
+		} {
+			if key.Key == base.Key {
+				t.Errorf("key should change when %s changes", name)
+			}
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		changedFile := mustKey(t, cache, task, dir, agents)
+		if changedFile.FilesHash == base.FilesHash || changedFile.TaskHash != base.TaskHash {
+			t.Errorf("only the files hash should change when file content changes: %+v vs %+v", changedFile, base)
+		}
+	})
+
+	t.Run("tasks without files are not cached", func(t *testing.T) {
+		noFiles := task
+		noFiles.Files = nil
+		if _, ok := cache.KeyFor(noFiles, dir, agents); ok {
+			t.Error("expected no key for a task without files")
+		}
+	})
+}
+
+func mustKey(t *testing.T, cache *QCResultCache, task models.Task, dir string, agents models.QCAgentConfig) QCResultCacheKey {
+	t.Helper()
+	key, ok := cache.KeyFor(task, dir, agents)
+	if !ok {
+		t.Fatalf("KeyFor(%+v) returned no key", task)
+	}
+	return key
+}
+
+func TestQCResultCache_LookupAndSave(t *testing.T) {
+	store := newMemoryQCResultStore()
+	cache := NewQCResultCache(store, time.Hour)
+	key := QCResultCacheKey{Key: "k", TaskHash: "t", FilesHash: "f", CriteriaHash: "c", AgentSet: "a"}
+	ctx := context.Background()
+
+	if _, hit := cache.Lookup(ctx, key); hit {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	review := &ReviewResult{
+		Flag:      models.StatusRed,
+		Feedback:  "Missing error handling",
+		AgentName: "code-reviewer",
+		Issues:    []models.Issue{{Severity: "critical", Description: "err ignored", Location: "main.go:3"}},
+	}
+	if err := cache.Save(ctx, key, "1", review); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if store.entries["k"].Verdict != models.StatusRed || store.entries["k"].TaskNumber != "1" {
+		t.Errorf("unexpected stored entry: %+v", store.entries["k"])
+	}
+
+	cached, hit := cache.Lookup(ctx, key)
+	if !hit {
+		t.Fatal("expected hit after Save")
+	}
+	if !cached.Cached || cached.Flag != models.StatusRed || cached.Feedback != "Missing error handling" ||
+		len(cached.Issues) != 1 || cached.Issues[0].Location != "main.go:3" {
+		t.Errorf("unexpected cached review: %+v", cached)
+	}
+
+	t.Run("expired entries are ignored", func(t *testing.T) {
+		cache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		defer func() { cache.now = time.Now }()
+		if _, hit := cache.Lookup(ctx, key); hit {
+			t.Error("expected expired entry to miss")
+		}
+	})
+
+	t.Run("zero TTL never expires", func(t *testing.T) {
+		forever := NewQCResultCache(store, 0)
+		forever.now = func() time.Time { return time.Now().Add(1000 * time.Hour) }
+		if _, hit := forever.Lookup(ctx, key); !hit {
+			t.Error("expected hit with zero TTL")
+		}
+	})
+}
+
+func TestTaskExecutor_QCResultCacheSkipsReviewForUnchangedTask(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewQCResultCache(newMemoryQCResultStore(), time.Hour)
+	reviewer := &stubReviewer{
+		results: []*ReviewResult{{Flag: models.StatusGreen, Feedback: "Looks good"}},
+	}
+	task := models.Task{Number: "1", Name: "Demo", Prompt: "Do the thing", Files: []string{"main.go"}}
+
+	run := func() models.TaskResult {
+		invoker := newStubInvoker(&agent.InvocationResult{Output: `{"content":"done"}`, ExitCode: 0})
+		executor, err := NewTaskExecutor(invoker, reviewer, &recordingUpdater{}, TaskExecutorConfig{
+			PlanPath:       "plan.md",
+			QualityControl: models.QualityControlConfig{Enabled: true},
+		})
+		if err != nil {
+			t.Fatalf("NewTaskExecutor returned error: %v", err)
+		}
+		executor.WorkDir = dir
+		executor.QCResultCache = cache
+
+		result, err := executor.Execute(context.Background(), task)
+		if err != nil {
+			t.Fatalf("Execute returned error: %v", err)
+		}
+		return result
+	}
+
+	first := run()
+	second := run()
+
+	if len(reviewer.outputs) != 1 {
+		t.Errorf("reviewer called %d times, want 1 (second run should hit the cache)", len(reviewer.outputs))
+	}
+	if first.Status != models.StatusGreen || second.Status != models.StatusGreen {
+		t.Errorf("statuses = %s/%s, want GREEN/GREEN", first.Status, second.Status)
+	}
+	if second.ReviewFeedback != "Looks good" {
+		t.Errorf("cached feedback = %q, want the original review's feedback", second.ReviewFeedback)
+	}
+
+	// Changing a task file invalidates the cached verdict
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run()
+	if len(reviewer.outputs) != 2 {
+		t.Errorf("reviewer called %d times, want 2 after a file change", len(reviewer.outputs))
+	}
+}
+
+func TestTaskExecutor_QCResultCacheBypassedOnRetry(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewQCResultCache(newMemoryQCResultStore(), time.Hour)
+	reviewer := &stubReviewer{
+		results: []*ReviewResult{
+			{Flag: models.StatusRed, Feedback: "Missing handler"},
+			{Flag: models.StatusGreen, Feedback: "Fixed"},
+		},
+		retryDecisions: map[int]bool{0: true},
+	}
+	invoker := newStubInvoker(
+		&agent.InvocationResult{Output: `{"content":"first"}`, ExitCode: 0},
+		&agent.InvocationResult{Output: `{"content":"second"}`, ExitCode: 0},
+	)
+	executor, err := NewTaskExecutor(invoker, reviewer, &recordingUpdater{}, TaskExecutorConfig{
+		PlanPath:       "plan.md",
+		QualityControl: models.QualityControlConfig{Enabled: true, RetryOnRed: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	executor.WorkDir = dir
+	executor.QCResultCache = cache
+
+	// The declared file is unchanged between attempts, so the key matches the RED entry
+	result, err := executor.Execute(context.Background(), models.Task{Number: "1", Name: "Demo", Prompt: "Do the thing", Files: []string{"main.go"}})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if len(reviewer.outputs) != 2 {
+		t.Errorf("reviewer called %d times, want 2 (the retry must not reuse the cached RED)", len(reviewer.outputs))
+	}
+	if result.Status != models.StatusGreen {
+		t.Errorf("status = %s, want GREEN", result.Status)
+	}
+}
@@ -0,0 +1,97 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harrison/conductor/internal/learning"
+)
+
+// ReviewerCalibration is the recorded accuracy of QC agents against task
+// outcomes, used to pick poorly calibrated agents less often (v3.6+).
+// A nil *ReviewerCalibration treats every agent as well calibrated.
+type ReviewerCalibration struct {
+	Stats       map[string]learning.QCCalibration // By agent name
+	MinSamples  int                               // Labeled reviews needed before accuracy counts
+	MinAccuracy float64                           // Accuracy below which an agent is poorly calibrated
+}
+
+// Poor reports whether agent has enough labeled reviews and too low an accuracy.
+func (c *ReviewerCalibration) Poor(agent string) bool {
+	if c == nil {
+		return false
+	}
+	stats, ok := c.Stats[agent]
+	return ok && stats.Labeled > 0 && stats.Labeled >= c.MinSamples && stats.Accuracy() < c.MinAccuracy
+}
+
+// Drop removes poorly calibrated agents, except those in keep. When every
+// agent would be removed the list is returned unchanged.
+func (c *ReviewerCalibration) Drop(agents []string, keep ...string) []string {
+	if c == nil {
+		return agents
+	}
+	var kept []string
+	for _, agent := range agents {
+		if c.Poor(agent) && !containsAgent(keep, agent) {
+			continue
+		}
+		kept = append(kept, agent)
+	}
+	if len(kept) == 0 {
+		return agents
+	}
+	return kept
+}
+
+// Summary describes the accuracy of those of agents with enough labeled
+// reviews, one line each, for an agent selection prompt. Returns an empty
+// string when no agent has enough.
+func (c *ReviewerCalibration) Summary(agents []string) string {
+	if c == nil {
+		return ""
+	}
+	var lines []string
+	for _, agent := range agents {
+		stats, ok := c.Stats[agent]
+		if !ok || stats.Labeled == 0 || stats.Labeled < c.MinSamples {
+			continue
+		}
+		line := fmt.Sprintf("- %s: %.0f%% accurate over %d reviews (precision %.0f%%, recall %.0f%%)",
+			agent, stats.Accuracy()*100, stats.Labeled, stats.Precision()*100, stats.Recall()*100)
+		if c.Poor(agent) {
+			line += " - poorly calibrated"
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// reviewerCalibration loads agent calibration from the learning store, or
+// returns nil when calibration is off or no history is available.
+func (qc *QualityController) reviewerCalibration(ctx context.Context) *ReviewerCalibration {
+	if !qc.Calibration.Enabled || qc.LearningStore == nil {
+		return nil
+	}
+	byAgent, _, err := qc.LearningStore.GetQCCalibration(ctx, "")
+	if err != nil || len(byAgent) == 0 {
+		return nil
+	}
+	return &ReviewerCalibration{
+		Stats:       byAgent,
+		MinSamples:  qc.Calibration.MinSamples,
+		MinAccuracy: qc.Calibration.MinAccuracy,
+	}
+}
+
+func containsAgent(agents []string, name string) bool {
+	for _, agent := range agents {
+		if agent == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// poorGolangPro is calibration where golang-pro was right in 2 of 10 reviews.
+func poorGolangPro() *ReviewerCalibration {
+	return &ReviewerCalibration{
+		Stats: map[string]learning.QCCalibration{
+			"golang-pro":      {Name: "golang-pro", Reviews: 12, Labeled: 10, TruePositives: 1, TrueNegatives: 1, FalsePositives: 8},
+			"quality-control": {Name: "quality-control", Reviews: 12, Labeled: 10, FalseNegatives: 9, TrueNegatives: 1},
+			"code-reviewer":   {Name: "code-reviewer", Reviews: 10, Labeled: 10, TruePositives: 4, TrueNegatives: 5, FalseNegatives: 1},
+			"python-pro":      {Name: "python-pro", Reviews: 3, Labeled: 3},
+		},
+		MinSamples:  10,
+		MinAccuracy: 0.5,
+	}
+}
+
+func TestReviewerCalibration(t *testing.T) {
+	cal := poorGolangPro()
+	if !cal.Poor("golang-pro") || cal.Poor("code-reviewer") || cal.Poor("python-pro") || cal.Poor("unknown") {
+		t.Error("only golang-pro has enough samples and low accuracy")
+	}
+	if got := strings.Join(cal.Drop([]string{"quality-control", "golang-pro", "code-reviewer"}, "quality-control"), ","); got != "quality-control,code-reviewer" {
+		t.Errorf("Drop() = %s", got)
+	}
+	if got := cal.Drop([]string{"golang-pro"}); len(got) != 1 {
+		t.Error("Drop() should never leave no reviewers")
+	}
+
+	var none *ReviewerCalibration
+	if none.Poor("golang-pro") || len(none.Drop([]string{"golang-pro"})) != 1 || none.Summary([]string{"golang-pro"}) != "" {
+		t.Error("nil calibration should change nothing")
+	}
+
+	summary := cal.Summary([]string{"golang-pro", "code-reviewer", "python-pro"})
+	if !strings.Contains(summary, "- code-reviewer: 90% accurate over 10 reviews (precision 100%, recall 80%)") ||
+		!strings.Contains(summary, "golang-pro: 20% accurate over 10 reviews (precision 11%, recall 100%) - poorly calibrated") ||
+		strings.Contains(summary, "python-pro") {
+		t.Errorf("unexpected summary:\n%s", summary)
+	}
+}
+
+func TestSelectQCAgents_Calibration(t *testing.T) {
+	registry := createTestRegistry(t, []string{"golang-pro", "code-reviewer"})
+	task := models.Task{Files: []string{"main.go"}}
+	selCtx := &SelectionContext{Calibration: poorGolangPro()}
+
+	// Auto mode leaves out golang-pro but keeps the poorly calibrated baseline
+	agents := SelectQCAgentsWithContext(context.Background(), task, models.QCAgentConfig{Mode: "auto"}, registry, selCtx)
+	if got := strings.Join(agents, ","); got != "quality-control" {
+		t.Errorf("auto agents = %s, want quality-control", got)
+	}
+
+	// Additional and explicit agents are the user's choice and are kept
+	agents = SelectQCAgentsWithContext(context.Background(), task, models.QCAgentConfig{Mode: "mixed", AdditionalAgents: []string{"golang-pro"}}, registry, selCtx)
+	if got := strings.Join(agents, ","); got != "quality-control,golang-pro" {
+		t.Errorf("mixed agents = %s", got)
+	}
+	agents = SelectQCAgentsWithContext(context.Background(), task, models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"golang-pro"}}, registry, selCtx)
+	if got := strings.Join(agents, ","); got != "golang-pro" {
+		t.Errorf("explicit agents = %s", got)
+	}
+}
+
+func TestIntelligentSelector_Calibration(t *testing.T) {
+	registry := createTestRegistry(t, []string{"golang-pro", "code-reviewer", "security-auditor"})
+	selector := NewIntelligentSelector(registry, 3600, 90*time.Second, nil)
+	selector.Calibration = poorGolangPro()
+
+	prompt := selector.buildSelectionPrompt(models.Task{Number: "1", Files: []string{"main.go"}}, "golang-pro",
+		[]string{"golang-pro", "code-reviewer", "security-auditor"}, models.QCAgentConfig{})
+	if !strings.Contains(prompt, "REVIEWER ACCURACY") || !strings.Contains(prompt, "golang-pro: 20% accurate") {
+		t.Errorf("selection prompt should carry reviewer accuracy:\n%s", prompt)
+	}
+
+	result := selector.applyGuardrails(&IntelligentAgentRecommendation{Agents: []string{"golang-pro", "security-auditor"}},
+		models.QCAgentConfig{MaxAgents: 4, RequireCodeReview: true})
+	if got := strings.Join(result.Agents, ","); got != "code-reviewer,security-auditor" {
+		t.Errorf("guardrails agents = %s, want poorly calibrated golang-pro dropped", got)
+	}
+}
+
+func TestRecordAgentVerdicts_Outcomes(t *testing.T) {
+	store, err := learning.NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	qc := NewQualityController(nil)
+	qc.LearningStore = store
+	task := models.Task{Number: "1", SourceFile: "/work/plan.yaml", Files: []string{"api/handler.go", "api/routes.go", "README.md"}}
+	results := []*ReviewResult{
+		{AgentName: "code-reviewer", Flag: models.StatusGreen},
+		{AgentName: "strict-reviewer", Flag: models.StatusRed},
+	}
+
+	// Passing tests and an approving reviewer contradict the RED
+	qc.TestCommandResults = []TestCommandResult{{Command: "go test ./...", Passed: true}}
+	qc.recordAgentVerdicts(ctx, task, results, &ReviewResult{Flag: models.StatusRed})
+
+	// Failing tests settle nothing
+	qc.TestCommandResults = []TestCommandResult{{Command: "go test ./...", Passed: false}}
+	qc.recordAgentVerdicts(ctx, models.Task{Number: "2", SourceFile: "/work/plan.yaml"}, results, &ReviewResult{Flag: models.StatusRed})
+
+	// Task 3 fixes task 2: the review that let it through missed a problem
+	te := &DefaultTaskExecutor{LearningStore: store}
+	te.recordFixOutcomes(ctx, models.Task{Number: "3", SourceFile: "/work/plan.yaml", Fixes: []string{"2"}})
+
+	byAgent, byFileType, err := store.GetQCCalibration(ctx, "plan.yaml")
+	if err != nil {
+		t.Fatalf("GetQCCalibration: %v", err)
+	}
+	if got := byAgent["strict-reviewer"]; got.FalsePositives != 1 || got.TruePositives != 1 {
+		t.Errorf("strict-reviewer calibration = %+v, want one false and one true RED", got)
+	}
+	if got := byAgent["code-reviewer"]; got.TrueNegatives != 1 || got.FalseNegatives != 1 {
+		t.Errorf("code-reviewer calibration = %+v", got)
+	}
+	if got := byFileType[".go"]; got.Reviews != 2 || byFileType[".md"].Reviews != 2 {
+		t.Errorf("file types should be recorded once per verdict: %v", byFileType)
+	}
+}
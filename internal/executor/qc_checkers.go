@@ -0,0 +1,269 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// maxCheckerFeedback caps the checker output quoted in QC feedback.
+const maxCheckerFeedback = 4 * 1024
+
+// goIssuePattern matches file:line[:col]: message diagnostics from Go tools.
+const goIssuePattern = `^(?:vet: )?(?P<file>[^\s:]+\.go):(?P<line>\d+)(?::\d+)?: (?P<message>.+)$`
+
+// builtinQCCheckers are the checkers available without configuration (v3.6+).
+var builtinQCCheckers = map[string]config.QCCheckerConfig{
+	"go-vet": {
+		Command:      "go vet {packages}",
+		Extensions:   []string{".go"},
+		IssuePattern: goIssuePattern,
+	},
+	"gofmt": {
+		Command:      "gofmt -l {files}",
+		Extensions:   []string{".go"},
+		FailOnOutput: true,
+		IssuePattern: `^(?P<file>\S+\.go)$`,
+		IssueMessage: "file is not gofmt-formatted",
+	},
+	"staticcheck": {
+		Command:      "staticcheck {packages}",
+		Extensions:   []string{".go"},
+		IssuePattern: goIssuePattern,
+	},
+}
+
+// CommandChecker is a deterministic QC reviewer that runs a local command
+// instead of invoking an agent (v3.6+).
+type CommandChecker struct {
+	Name   string // Reviewer name, including agent.QCCheckerPrefix
+	Config config.QCCheckerConfig
+	issues *regexp.Regexp
+}
+
+// NewQCCheckers builds the built-in checkers plus those defined in custom,
+// keyed by reviewer name (e.g. "check:go-vet").
+func NewQCCheckers(custom map[string]config.QCCheckerConfig) (map[string]*CommandChecker, error) {
+	defs := make(map[string]config.QCCheckerConfig, len(builtinQCCheckers)+len(custom))
+	for name, def := range builtinQCCheckers {
+		defs[name] = def
+	}
+	for name, def := range custom {
+		defs[name] = def
+	}
+
+	checkers := make(map[string]*CommandChecker, len(defs))
+	for name, def := range defs {
+		checker := &CommandChecker{Name: agent.QCCheckerPrefix + name, Config: def}
+		if def.IssuePattern != "" {
+			re, err := regexp.Compile(def.IssuePattern)
+			if err != nil {
+				return nil, fmt.Errorf("checker %s: invalid issue_pattern: %w", name, err)
+			}
+			checker.issues = re
+		}
+		if checker.Config.Timeout == 0 {
+			checker.Config.Timeout = config.DefaultQCCheckerTimeout
+		}
+		checkers[checker.Name] = checker
+	}
+	return checkers, nil
+}
+
+// UnknownQCCheckers returns the checker names in reviewers that checkers does not define.
+func UnknownQCCheckers(reviewers []string, checkers map[string]*CommandChecker) []string {
+	var unknown []string
+	for _, name := range reviewers {
+		if agent.IsQCChecker(name) && checkers[name] == nil {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// matchingFiles returns the task files the checker applies to.
+func (c *CommandChecker) matchingFiles(files []string) []string {
+	if len(c.Config.Extensions) == 0 {
+		return files
+	}
+	var matched []string
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file))
+		for _, want := range c.Config.Extensions {
+			if ext == strings.ToLower(want) {
+				matched = append(matched, file)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// command expands {files} and {packages} for files.
+func (c *CommandChecker) command(files []string) string {
+	quoted := make([]string, len(files))
+	seen := make(map[string]bool)
+	var packages []string
+	for i, file := range files {
+		quoted[i] = shellQuote(file)
+		dir := filepath.ToSlash(filepath.Dir(file))
+		if dir != "." && !strings.HasPrefix(dir, "/") && !strings.HasPrefix(dir, "./") {
+			dir = "./" + dir
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			packages = append(packages, shellQuote(dir))
+		}
+	}
+	sort.Strings(packages)
+
+	command := strings.ReplaceAll(c.Config.Command, "{files}", strings.Join(quoted, " "))
+	return strings.ReplaceAll(command, "{packages}", strings.Join(packages, " "))
+}
+
+// Check runs the checker for task. It returns false when the task has no
+// files the checker applies to. A command that cannot run gives a result
+// without a verdict, like an agent whose review failed.
+func (c *CommandChecker) Check(ctx context.Context, runner CommandRunner, task models.Task) (*ReviewResult, bool) {
+	files := c.matchingFiles(task.Files)
+	if len(c.Config.Extensions) > 0 && len(files) == 0 {
+		return nil, false
+	}
+	command := c.command(files)
+
+	if c.Config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Config.Timeout)
+		defer cancel()
+	}
+	output, err := runner.Run(ctx, command)
+
+	result := &ReviewResult{AgentName: c.Name}
+	exitCode := 0
+	if err != nil {
+		var exitErr interface{ ExitCode() int }
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() < 0 || exitErr.ExitCode() == 127 {
+			result.Feedback = fmt.Sprintf("could not run `%s`: %v", command, err)
+			return result, true
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	trimmed := strings.TrimSpace(output)
+	switch {
+	case exitCode == 0 && (!c.Config.FailOnOutput || trimmed == ""):
+		result.Flag = models.StatusGreen
+		result.Feedback = fmt.Sprintf("`%s` passed", command)
+		return result, true
+	case containsInt(c.Config.YellowExitCodes, exitCode):
+		result.Flag = models.StatusYellow
+	default:
+		result.Flag = models.StatusRed
+	}
+
+	result.Issues = c.parseIssues(trimmed, result.Flag)
+	result.Feedback = fmt.Sprintf("`%s` exited %d:\n%s", command, exitCode, truncateOutput(trimmed, maxCheckerFeedback))
+	return result, true
+}
+
+// parseIssues turns output lines matching the issue pattern into issues.
+// Output that matches nothing becomes a single issue for the whole run.
+func (c *CommandChecker) parseIssues(output, flag string) []models.Issue {
+	severity := "critical"
+	if flag == models.StatusYellow {
+		severity = "warning"
+	}
+
+	var issues []models.Issue
+	if c.issues != nil {
+		for _, line := range strings.Split(output, "\n") {
+			match := c.issues.FindStringSubmatch(strings.TrimSpace(line))
+			if match == nil {
+				continue
+			}
+			issue := models.Issue{Severity: severity, Description: c.Config.IssueMessage}
+			var file, lineNo string
+			for i, group := range c.issues.SubexpNames() {
+				switch group {
+				case "file":
+					file = match[i]
+				case "line":
+					lineNo = match[i]
+				case "message":
+					issue.Description = match[i]
+				}
+			}
+			issue.Location = file
+			if file != "" && lineNo != "" {
+				issue.Location = file + ":" + lineNo
+			}
+			if issue.Description == "" {
+				issue.Description = strings.TrimSpace(line)
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	if len(issues) == 0 {
+		description := fmt.Sprintf("%s reported problems", c.Name)
+		if output != "" {
+			description = strings.SplitN(output, "\n", 2)[0]
+		}
+		issues = append(issues, models.Issue{Severity: severity, Description: description, Location: c.Name})
+	}
+	return issues
+}
+
+// runChecker reviews task with the named checker. The second result is false
+// when the checker does not apply to the task.
+func (qc *QualityController) runChecker(ctx context.Context, task models.Task, name string) (*ReviewResult, bool) {
+	checker := qc.Checkers[name]
+	if checker == nil {
+		return &ReviewResult{AgentName: name, Feedback: fmt.Sprintf("unknown QC checker %s", name)}, true
+	}
+	runner := qc.CheckerRunner
+	if runner == nil {
+		runner = NewShellCommandRunner(task.WorkDir)
+	}
+	return checker.Check(ctx, runner, task)
+}
+
+// splitCheckers separates local checkers from QC agents, keeping their order.
+func splitCheckers(reviewers []string) (checkers, agents []string) {
+	for _, name := range reviewers {
+		if agent.IsQCChecker(name) {
+			checkers = append(checkers, name)
+		} else {
+			agents = append(agents, name)
+		}
+	}
+	return checkers, agents
+}
+
+// shellQuote quotes s for sh -c.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '/' || r == '.' || r == '_' || r == '-' || r == '+' || r == ':' || r == '@' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// exitCodeError mimics *exec.ExitError for MockCommandRunner.
+type exitCodeError int
+
+func (e exitCodeError) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e exitCodeError) ExitCode() int { return int(e) }
+
+func newTestCheckers(t *testing.T, custom map[string]config.QCCheckerConfig) map[string]*CommandChecker {
+	t.Helper()
+	checkers, err := NewQCCheckers(custom)
+	if err != nil {
+		t.Fatalf("NewQCCheckers() error = %v", err)
+	}
+	return checkers
+}
+
+func TestCommandChecker_BuiltinGoChecks(t *testing.T) {
+	checkers := newTestCheckers(t, nil)
+	task := models.Task{Number: "1", Files: []string{"internal/lexer/lexer.go", "internal/lexer/token.go", "cmd/main.go", "README.md"}}
+
+	runner := NewMockCommandRunner()
+	runner.Outputs["go vet ./cmd ./internal/lexer"] = "# internal/lexer\ninternal/lexer/lexer.go:42:3: unreachable code\n"
+	runner.Errors["go vet ./cmd ./internal/lexer"] = exitCodeError(1)
+
+	result, ok := checkers["check:go-vet"].Check(context.Background(), runner, task)
+	if !ok || result.Flag != models.StatusRed {
+		t.Fatalf("go vet failure should be RED, got %+v (applies=%v)", result, ok)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Location != "internal/lexer/lexer.go:42" || result.Issues[0].Description != "unreachable code" {
+		t.Errorf("unexpected issues: %+v", result.Issues)
+	}
+
+	// gofmt -l exits 0 but lists unformatted files
+	runner.Outputs["gofmt -l internal/lexer/lexer.go internal/lexer/token.go cmd/main.go"] = "cmd/main.go\n"
+	result, _ = checkers["check:gofmt"].Check(context.Background(), runner, task)
+	if result.Flag != models.StatusRed || len(result.Issues) != 1 || result.Issues[0].Location != "cmd/main.go" || result.Issues[0].Description != "file is not gofmt-formatted" {
+		t.Errorf("unexpected gofmt result: %+v", result)
+	}
+
+	// No Go files: the checker does not apply
+	if _, ok := checkers["check:gofmt"].Check(context.Background(), runner, models.Task{Files: []string{"docs/guide.md"}}); ok {
+		t.Error("gofmt should not apply to a task without Go files")
+	}
+}
+
+func TestCommandChecker_GenericCommand(t *testing.T) {
+	checkers := newTestCheckers(t, map[string]config.QCCheckerConfig{
+		"lint": {
+			Command:         "echo 'src/app.py:7: unused import os'; exit 3",
+			YellowExitCodes: []int{3},
+			IssuePattern:    `^(?P<file>[^:]+):(?P<line>\d+): (?P<message>.+)$`,
+		},
+		"missing": {Command: "conductor-test-no-such-tool"},
+	})
+
+	// Run through the real shell to exercise exit code handling
+	runner := NewShellCommandRunner(t.TempDir())
+	result, ok := checkers["check:lint"].Check(context.Background(), runner, models.Task{Files: []string{"src/app.py"}})
+	if !ok || result.Flag != models.StatusYellow {
+		t.Fatalf("exit code 3 should be YELLOW, got %+v", result)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Severity != "warning" || result.Issues[0].Location != "src/app.py:7" {
+		t.Errorf("unexpected issues: %+v", result.Issues)
+	}
+
+	result, ok = checkers["check:missing"].Check(context.Background(), runner, models.Task{})
+	if !ok || result.Flag != "" || !strings.Contains(result.Feedback, "could not run") {
+		t.Errorf("a missing tool should give no verdict, got %+v", result)
+	}
+}
+
+func TestUnknownQCCheckers(t *testing.T) {
+	checkers := newTestCheckers(t, map[string]config.QCCheckerConfig{"lint": {Command: "make lint"}})
+	unknown := UnknownQCCheckers([]string{"code-reviewer", "check:go-vet", "check:lint", "check:typo"}, checkers)
+	if len(unknown) != 1 || unknown[0] != "check:typo" {
+		t.Errorf("UnknownQCCheckers() = %v, want [check:typo]", unknown)
+	}
+	if _, err := NewQCCheckers(map[string]config.QCCheckerConfig{"bad": {Command: "x", IssuePattern: "("}}); err == nil {
+		t.Error("expected invalid issue_pattern to be rejected")
+	}
+}
+
+// checkerTestQC returns a QC with two agents and a go vet checker whose
+// result depends on vetErr.
+func checkerTestQC(t *testing.T, vetErr error, calls *[]string) *QualityController {
+	t.Helper()
+	var mu sync.Mutex
+	mock := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			mu.Lock()
+			*calls = append(*calls, task.Agent)
+			mu.Unlock()
+			return &agent.InvocationResult{
+				Output: `{"verdict":"GREEN","feedback":"fine","issues":[],"recommendations":[],"should_retry":false,"suggested_agent":""}`,
+			}, nil
+		},
+	}
+	runner := NewMockCommandRunner()
+	runner.Outputs["go vet ./api"] = "api/handler.go:10:2: printf call has arguments but no formatting directives"
+	runner.Errors["go vet ./api"] = vetErr
+
+	qc := NewQualityController(mock)
+	qc.AgentConfig = models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"check:go-vet", "code-reviewer", "golang-pro"}}
+	qc.Checkers = newTestCheckers(t, nil)
+	qc.CheckerRunner = runner
+	return qc
+}
+
+func TestReviewMultiAgent_CheckerGate(t *testing.T) {
+	task := models.Task{Number: "2", Name: "API", Prompt: "p", Files: []string{"api/handler.go"}}
+
+	var calls []string
+	qc := checkerTestQC(t, exitCodeError(1), &calls)
+	qc.CheckerGate = true
+	result, err := qc.ReviewMultiAgent(context.Background(), task, "output")
+	if err != nil {
+		t.Fatalf("ReviewMultiAgent() error = %v", err)
+	}
+	if result.Flag != models.StatusRed || len(calls) != 0 {
+		t.Errorf("a failing checker should end the review before agents run: flag=%s calls=%v", result.Flag, calls)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Feedback, "were not invoked") {
+		t.Errorf("gated review should carry the checker's issues and say why: %+v", result)
+	}
+
+	// Without the gate the checker votes alongside the agents
+	calls = nil
+	qc = checkerTestQC(t, exitCodeError(1), &calls)
+	qc.Aggregation = config.QCAggregationConfig{Strategy: config.QCAggregationMajority}
+	result, err = qc.ReviewMultiAgent(context.Background(), task, "output")
+	if err != nil {
+		t.Fatalf("ReviewMultiAgent() error = %v", err)
+	}
+	if result.Flag != models.StatusGreen || len(calls) != 2 {
+		t.Errorf("majority should outvote the checker: flag=%s calls=%v", result.Flag, calls)
+	}
+	if !strings.Contains(result.Feedback, "[check:go-vet] (outvoted)") {
+		t.Errorf("checker feedback should be kept: %q", result.Feedback)
+	}
+
+	// A passing checker lets the agents review
+	calls = nil
+	qc = checkerTestQC(t, nil, &calls)
+	qc.CheckerGate = true
+	result, err = qc.ReviewMultiAgent(context.Background(), task, "output")
+	if err != nil {
+		t.Fatalf("ReviewMultiAgent() error = %v", err)
+	}
+	if result.Flag != models.StatusGreen || len(calls) != 2 {
+		t.Errorf("passing checker should not gate: flag=%s calls=%v", result.Flag, calls)
+	}
+}
+
+func TestReview_SingleChecker(t *testing.T) {
+	runner := NewMockCommandRunner()
+	qc := NewQualityController(nil)
+	qc.AgentConfig = models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"check:gofmt"}}
+	qc.Checkers = newTestCheckers(t, nil)
+	qc.CheckerRunner = runner
+
+	// Task with success criteria: a checker alone still decides by its verdict
+	task := models.Task{Number: "3", Files: []string{"main.go"}, SuccessCriteria: []string{"Compiles"}}
+	result, err := qc.Review(context.Background(), task, "output")
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if result.Flag != models.StatusGreen || result.AgentName != "check:gofmt" {
+		t.Errorf("formatted files should be GREEN from the checker, got %+v", result)
+	}
+}
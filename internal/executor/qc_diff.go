@@ -0,0 +1,414 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// Task metadata keys for diff-based QC review (v3.6+)
+const (
+	qcDiffBaseKey  = "qc_diff_base"     // HEAD before the agent ran, when no rollback checkpoint exists
+	qcTaskDiffKey  = "qc_task_diff"     // *TaskDiff of the task's work, set before its review
+	qcDiffChunkKey = "qc_diff_chunk"    // *TaskDiff part a review is looking at
+	qcPartVerdicts = "qc_part_verdicts" // *partVerdicts collecting the verdicts of a diff's parts
+)
+
+// Defaults for QCDiffReviewConfig values left at zero by configs built in code.
+const (
+	defaultDiffChunkBytes = 40000
+	defaultDiffMaxChunks  = 5
+)
+
+// FileDiff is the unified diff of one file.
+type FileDiff struct {
+	Path  string
+	Patch string
+}
+
+// TaskDiff is the git diff of the changes a task made, reviewed by QC in
+// place of trusting the agent's own account of its work (v3.6+).
+type TaskDiff struct {
+	Source  string // What was diffed, e.g. "commit abc1234"
+	Files   []FileDiff
+	Part    int      // 1-based part number when the diff is split for review, 0 otherwise
+	Parts   int      // Number of parts the diff was split into
+	Omitted []string // Files left out because the diff exceeded the part limit
+}
+
+// Paths returns the files in the diff.
+func (d *TaskDiff) Paths() []string {
+	paths := make([]string, len(d.Files))
+	for i, f := range d.Files {
+		paths[i] = f.Path
+	}
+	return paths
+}
+
+// Chunks splits the diff by file into parts of at most maxBytes, each reviewed
+// on its own. A single file larger than maxBytes is truncated. After maxChunks
+// parts the remaining files are only listed in the last part's Omitted.
+func (d *TaskDiff) Chunks(maxBytes, maxChunks int) []*TaskDiff {
+	if d == nil {
+		return nil
+	}
+	if len(d.Files) == 0 {
+		return []*TaskDiff{d}
+	}
+
+	var chunks []*TaskDiff
+	current := &TaskDiff{Source: d.Source}
+	size := 0
+	for _, f := range d.Files {
+		patch := truncateOutput(f.Patch, maxBytes)
+		if len(current.Files) > 0 && size+len(patch) > maxBytes {
+			if len(chunks)+1 >= maxChunks {
+				current.Omitted = append(current.Omitted, f.Path)
+				continue
+			}
+			chunks = append(chunks, current)
+			current = &TaskDiff{Source: d.Source}
+			size = 0
+		}
+		current.Files = append(current.Files, FileDiff{Path: f.Path, Patch: patch})
+		size += len(patch)
+	}
+	chunks = append(chunks, current)
+
+	if len(chunks) > 1 {
+		for i, chunk := range chunks {
+			chunk.Part = i + 1
+			chunk.Parts = len(chunks)
+		}
+	}
+	return chunks
+}
+
+// CollectTaskDiff returns the git diff of the task's work: the commit
+// CommitVerifier matched, or else everything changed since the task's rollback
+// checkpoint or captured base commit, including new untracked files.
+// Unless the task runs in a worktree of its own, changes are limited to the
+// task's files, so work by parallel tasks and earlier edits are left out.
+// Returns nil when there is nothing to diff against.
+func CollectTaskDiff(ctx context.Context, runner CommandRunner, task models.Task, cv *CommitVerification) (*TaskDiff, error) {
+	if cv != nil && cv.Found && (cv.FullHash != "" || cv.CommitHash != "") {
+		hash := cv.FullHash
+		if hash == "" {
+			hash = cv.CommitHash
+		}
+		output, err := runner.Run(ctx, "git show --no-color --no-ext-diff --format= "+shellQuote(hash))
+		if err != nil {
+			return nil, fmt.Errorf("git show %s: %w", hash, err)
+		}
+		return &TaskDiff{Source: "commit " + shortHash(hash), Files: parseUnifiedDiff(output)}, nil
+	}
+
+	base := diffBase(task)
+	if base == "" {
+		return nil, nil
+	}
+	pathspec := diffPathspec(task)
+	output, err := runner.Run(ctx, "git diff --no-color --no-ext-diff "+shellQuote(base)+pathspec)
+	if err != nil {
+		return nil, fmt.Errorf("git diff %s: %w", base, err)
+	}
+	diff := &TaskDiff{Source: "changes since " + shortHash(base), Files: parseUnifiedDiff(output)}
+
+	// git diff leaves out files the agent created but did not add
+	untracked, err := runner.Run(ctx, "git ls-files --others --exclude-standard"+pathspec)
+	if err != nil {
+		return diff, nil
+	}
+	for _, path := range strings.Split(strings.TrimSpace(untracked), "\n") {
+		if path == "" {
+			continue
+		}
+		// --no-index exits 1 when the files differ, which they always do here
+		output, err := runner.Run(ctx, "git diff --no-color --no-index -- /dev/null "+shellQuote(path))
+		var exitErr interface{ ExitCode() int }
+		if err != nil && (!errors.As(err, &exitErr) || exitErr.ExitCode() != 1) {
+			continue
+		}
+		diff.Files = append(diff.Files, parseUnifiedDiff(output)...)
+	}
+	return diff, nil
+}
+
+// diffPathspec returns the git pathspec limiting a task's diff to its files,
+// or "" when the task has no files or runs in a worktree of its own.
+func diffPathspec(task models.Task) string {
+	if len(task.Files) == 0 || ownsWorktree(task) {
+		return ""
+	}
+	quoted := make([]string, len(task.Files))
+	for i, file := range task.Files {
+		quoted[i] = shellQuote(file)
+	}
+	return " -- " + strings.Join(quoted, " ")
+}
+
+// diffBase returns the commit the task's changes are diffed against.
+func diffBase(task models.Task) string {
+	if task.Metadata == nil {
+		return ""
+	}
+	if cp, ok := task.Metadata["rollback_checkpoint"].(*CheckpointInfo); ok && cp != nil && cp.CommitHash != "" {
+		return cp.CommitHash
+	}
+	base, _ := task.Metadata[qcDiffBaseKey].(string)
+	return base
+}
+
+// parseUnifiedDiff splits git diff output into per-file diffs.
+func parseUnifiedDiff(output string) []FileDiff {
+	var files []FileDiff
+	var current *FileDiff
+	var patch strings.Builder
+	flush := func() {
+		if current != nil {
+			current.Patch = strings.TrimRight(patch.String(), "\n")
+			files = append(files, *current)
+		}
+		patch.Reset()
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+			current = &FileDiff{}
+			if idx := strings.LastIndex(line, " b/"); idx >= 0 {
+				current.Path = line[idx+3:]
+			}
+		}
+		if current == nil {
+			continue
+		}
+		if strings.HasPrefix(line, "+++ b/") {
+			current.Path = strings.TrimPrefix(line, "+++ b/")
+		}
+		patch.WriteString(line)
+		patch.WriteString("\n")
+	}
+	flush()
+	return files
+}
+
+// FormatTaskDiff renders a diff, or one part of it, for a QC prompt.
+// Returns an empty string for a nil diff.
+func FormatTaskDiff(d *TaskDiff) string {
+	if d == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	if d.Parts > 1 {
+		sb.WriteString(fmt.Sprintf("<task_diff source=%q part=\"%d of %d\">\n", d.Source, d.Part, d.Parts))
+	} else {
+		sb.WriteString(fmt.Sprintf("<task_diff source=%q>\n", d.Source))
+	}
+	sb.WriteString("This is the change the task actually made, taken from git. Base your verdict on it: the agent output is only the agent's own account. Work the agent describes that does not appear in this diff was not done.\n")
+	if d.Parts > 1 {
+		sb.WriteString("The change is reviewed in parts; other files are reviewed separately. Fail a criterion only when the files below violate it, not because its implementation is in another part.\n")
+	}
+
+	if len(d.Files) == 0 {
+		sb.WriteString("\nThe task made no changes to any file.\n")
+	}
+	for _, f := range d.Files {
+		sb.WriteString(fmt.Sprintf("\n<file path=%q>\n%s\n</file>\n", f.Path, f.Patch))
+	}
+	if len(d.Omitted) > 0 {
+		sb.WriteString(fmt.Sprintf("\n<omitted_files>Also changed, not shown because the diff is too large: %s</omitted_files>\n", strings.Join(d.Omitted, ", ")))
+	}
+	sb.WriteString("</task_diff>")
+	return sb.String()
+}
+
+// withTaskDiff returns a copy of task carrying the diff QC reviews. The diff
+// travels with the task because the QualityController is shared by parallel tasks.
+func withTaskDiff(task models.Task, diff *TaskDiff) models.Task {
+	metadata := make(map[string]interface{}, len(task.Metadata)+1)
+	for k, v := range task.Metadata {
+		metadata[k] = v
+	}
+	metadata[qcTaskDiffKey] = diff
+	task.Metadata = metadata
+	return task
+}
+
+// diffChunkFor returns the diff part attached to a review task.
+func diffChunkFor(task models.Task) *TaskDiff {
+	if task.Metadata == nil {
+		return nil
+	}
+	chunk, _ := task.Metadata[qcDiffChunkKey].(*TaskDiff)
+	return chunk
+}
+
+// diffChunks returns the parts of the task's diff to review, or nil when diff
+// review is off or there is no diff.
+func (qc *QualityController) diffChunks(task models.Task) []*TaskDiff {
+	diff, _ := task.Metadata[qcTaskDiffKey].(*TaskDiff)
+	if !qc.DiffReview.Enabled || diff == nil {
+		return nil
+	}
+	chunkBytes, maxChunks := qc.DiffReview.ChunkBytes, qc.DiffReview.MaxChunks
+	if chunkBytes <= 0 {
+		chunkBytes = defaultDiffChunkBytes
+	}
+	if maxChunks <= 0 {
+		maxChunks = defaultDiffMaxChunks
+	}
+	return diff.Chunks(chunkBytes, maxChunks)
+}
+
+// reviewDiffChunks reviews each part of the diff and merges the verdicts.
+// A part's review only sees the task files in that part, so expected-file
+// checks apply per part; expected files the diff never touches go to the
+// first part, where their absence shows.
+func (qc *QualityController) reviewDiffChunks(ctx context.Context, task models.Task, output string, chunks []*TaskDiff) (*ReviewResult, error) {
+	if len(chunks) == 1 {
+		return qc.review(ctx, withDiffChunk(task, chunks[0], task.Files), output)
+	}
+
+	inDiff := make(map[string]int)
+	for i, chunk := range chunks {
+		for _, path := range chunk.Paths() {
+			inDiff[filepath.Clean(path)] = i
+		}
+	}
+	chunkFiles := make([][]string, len(chunks))
+	for _, file := range task.Files {
+		idx := inDiff[filepath.Clean(file)] // Absent files map to part 0
+		chunkFiles[idx] = append(chunkFiles[idx], file)
+	}
+
+	// Parts record nothing themselves; the merged review is recorded once
+	verdicts := &partVerdicts{}
+	results := make([]*ReviewResult, 0, len(chunks))
+	for i, chunk := range chunks {
+		part := withDiffChunk(task, chunk, chunkFiles[i])
+		part.Metadata[qcPartVerdicts] = verdicts
+		result, err := qc.review(ctx, part, output)
+		if err != nil {
+			return nil, fmt.Errorf("diff part %d of %d: %w", chunk.Part, chunk.Parts, err)
+		}
+		results = append(results, result)
+	}
+
+	merged := mergeDiffChunkResults(results, chunks)
+	if qc.Logger != nil {
+		qc.Logger.LogQCAggregatedResult(merged.Flag, "diff-parts")
+	}
+	qc.recordAgentVerdicts(ctx, task, verdicts.results, merged)
+	return merged, nil
+}
+
+// verdictRank orders verdicts from most to least lenient.
+var verdictRank = map[string]int{models.StatusGreen: 1, models.StatusYellow: 2, models.StatusRed: 3}
+
+// partVerdicts keeps each agent's strictest verdict over the parts of a diff.
+type partVerdicts struct {
+	results []*ReviewResult
+}
+
+// add merges one part's agent verdicts.
+func (p *partVerdicts) add(results []*ReviewResult) {
+	for _, result := range results {
+		if result == nil || result.Flag == "" {
+			continue
+		}
+		found := false
+		for i, prev := range p.results {
+			if prev.AgentName != result.AgentName {
+				continue
+			}
+			found = true
+			if verdictRank[result.Flag] > verdictRank[prev.Flag] {
+				p.results[i] = result
+			}
+		}
+		if !found {
+			p.results = append(p.results, result)
+		}
+	}
+}
+
+// withDiffChunk returns a copy of task that reviews chunk and lists files as
+// the expected files.
+func withDiffChunk(task models.Task, chunk *TaskDiff, files []string) models.Task {
+	metadata := make(map[string]interface{}, len(task.Metadata)+1)
+	for k, v := range task.Metadata {
+		metadata[k] = v
+	}
+	metadata[qcDiffChunkKey] = chunk
+	task.Metadata = metadata
+	task.Files = files
+	return task
+}
+
+// mergeDiffChunkResults combines the reviews of a diff's parts. The strictest
+// verdict wins and a criterion passes only if every part that judged it
+// passed it.
+func mergeDiffChunkResults(results []*ReviewResult, chunks []*TaskDiff) *ReviewResult {
+	merged := &ReviewResult{Flag: models.StatusGreen}
+	criteria := make(map[int]models.CriterionResult)
+	var criteriaOrder []int
+	var feedbackParts []string
+
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		switch result.Flag {
+		case models.StatusRed:
+			merged.Flag = models.StatusRed
+		case models.StatusYellow:
+			if merged.Flag != models.StatusRed {
+				merged.Flag = models.StatusYellow
+			}
+		}
+		if merged.AgentName == "" {
+			merged.AgentName = result.AgentName
+		}
+		if merged.SuggestedAgent == "" {
+			merged.SuggestedAgent = result.SuggestedAgent
+		}
+		merged.Issues = append(merged.Issues, result.Issues...)
+		merged.Usage.Add(result.Usage)
+
+		// Keep the first judgement of each criterion, or the first failure
+		for _, cr := range result.CriteriaResults {
+			prev, seen := criteria[cr.Index]
+			if !seen {
+				criteriaOrder = append(criteriaOrder, cr.Index)
+			}
+			if !seen || (prev.Passed && !cr.Passed) {
+				criteria[cr.Index] = cr
+			}
+		}
+
+		if result.Feedback != "" {
+			feedbackParts = append(feedbackParts, fmt.Sprintf("[diff part %d/%d: %s] %s",
+				i+1, len(chunks), strings.Join(chunks[i].Paths(), ", "), result.Feedback))
+		}
+	}
+
+	for _, idx := range criteriaOrder {
+		merged.CriteriaResults = append(merged.CriteriaResults, criteria[idx])
+	}
+	merged.Feedback = strings.Join(feedbackParts, "\n")
+	return merged
+}
+
+// shortHash abbreviates a commit hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
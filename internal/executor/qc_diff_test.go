@@ -0,0 +1,208 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func TestCollectTaskDiff(t *testing.T) {
+	dir := setupGitRepo(t)
+	runner := NewShellCommandRunner(dir)
+	ctx := context.Background()
+	base := gitOutput(t, dir, "rev-parse", "HEAD")
+
+	// No commit and no base: nothing to diff
+	diff, err := CollectTaskDiff(ctx, runner, models.Task{}, nil)
+	if err != nil || diff != nil {
+		t.Fatalf("expected no diff without a base, got %+v, %v", diff, err)
+	}
+
+	// Committed work: the verified commit is diffed
+	if err := os.WriteFile(filepath.Join(dir, "lexer.go"), []byte("package lexer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitOutput(t, dir, "add", ".")
+	gitOutput(t, dir, "commit", "-m", "feat: add lexer")
+	head := gitOutput(t, dir, "rev-parse", "HEAD")
+
+	diff, err = CollectTaskDiff(ctx, runner, models.Task{}, &CommitVerification{Found: true, FullHash: head, CommitHash: head[:7]})
+	if err != nil {
+		t.Fatalf("CollectTaskDiff() error = %v", err)
+	}
+	if diff.Source != "commit "+head[:7] || len(diff.Files) != 1 || diff.Files[0].Path != "lexer.go" || !strings.Contains(diff.Files[0].Patch, "+package lexer") {
+		t.Errorf("unexpected commit diff: %+v", diff)
+	}
+
+	// Uncommitted and untracked work since the base
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Lexer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "token.go"), []byte("package lexer\n\ntype Token int\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	task := models.Task{Metadata: map[string]interface{}{qcDiffBaseKey: base}}
+	diff, err = CollectTaskDiff(ctx, runner, task, &CommitVerification{Found: false})
+	if err != nil {
+		t.Fatalf("CollectTaskDiff() error = %v", err)
+	}
+	paths := strings.Join(diff.Paths(), ",")
+	if diff.Source != "changes since "+base[:7] || paths != "README.md,lexer.go,token.go" {
+		t.Errorf("unexpected base diff: source=%q paths=%s", diff.Source, paths)
+	}
+	if !strings.Contains(diff.Files[2].Patch, "+type Token int") {
+		t.Errorf("untracked file should be diffed in full: %q", diff.Files[2].Patch)
+	}
+
+	// Only the task's own files are diffed, unless it has a worktree to itself
+	task.Files = []string{"token.go"}
+	diff, err = CollectTaskDiff(ctx, runner, task, nil)
+	if err != nil {
+		t.Fatalf("CollectTaskDiff() error = %v", err)
+	}
+	if paths := strings.Join(diff.Paths(), ","); paths != "token.go" {
+		t.Errorf("diff should be limited to the task's files, got %s", paths)
+	}
+	task.Metadata["worktree"] = &WorktreeInfo{Name: "task-1", Path: dir}
+	diff, err = CollectTaskDiff(ctx, runner, task, nil)
+	if err != nil {
+		t.Fatalf("CollectTaskDiff() error = %v", err)
+	}
+	if paths := strings.Join(diff.Paths(), ","); paths != "README.md,lexer.go,token.go" {
+		t.Errorf("a task's own worktree should be diffed in full, got %s", paths)
+	}
+
+	// A rollback checkpoint takes precedence over the captured base
+	task.Metadata["rollback_checkpoint"] = &CheckpointInfo{CommitHash: head}
+	if got := diffBase(task); got != head {
+		t.Errorf("diffBase() = %s, want checkpoint %s", got, head)
+	}
+}
+
+func TestTaskDiffChunks(t *testing.T) {
+	diff := &TaskDiff{Source: "commit abc1234", Files: []FileDiff{
+		{Path: "a.go", Patch: strings.Repeat("a", 30)},
+		{Path: "b.go", Patch: strings.Repeat("b", 30)},
+		{Path: "c.go", Patch: strings.Repeat("c", 30)},
+	}}
+
+	if chunks := diff.Chunks(100, 5); len(chunks) != 1 || chunks[0].Parts != 0 {
+		t.Errorf("a diff under the limit should be one unnumbered part, got %d", len(chunks))
+	}
+
+	chunks := diff.Chunks(70, 5)
+	if len(chunks) != 2 || chunks[0].Part != 1 || chunks[1].Parts != 2 {
+		t.Fatalf("expected 2 numbered parts, got %+v", chunks)
+	}
+	if got := strings.Join(chunks[0].Paths(), ","); got != "a.go,b.go" {
+		t.Errorf("part 1 files = %s, want a.go,b.go", got)
+	}
+
+	chunks = diff.Chunks(40, 2)
+	if len(chunks) != 2 || len(chunks[1].Omitted) != 1 || chunks[1].Omitted[0] != "c.go" {
+		t.Errorf("files beyond max_chunks should be omitted, got %+v", chunks)
+	}
+	if !strings.Contains(FormatTaskDiff(chunks[1]), "not shown because the diff is too large: c.go") {
+		t.Error("omitted files should be listed in the prompt")
+	}
+
+	empty := &TaskDiff{Source: "changes since abc1234"}
+	if chunks := empty.Chunks(100, 5); len(chunks) != 1 || !strings.Contains(FormatTaskDiff(chunks[0]), "made no changes") {
+		t.Error("an empty diff should still be reviewed and say nothing changed")
+	}
+}
+
+func TestReview_DiffParts(t *testing.T) {
+	var mu sync.Mutex
+	var prompts []string
+	mock := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			mu.Lock()
+			prompts = append(prompts, task.Prompt)
+			mu.Unlock()
+			if strings.Contains(task.Prompt, `<file path="handler.go">`) {
+				return &agent.InvocationResult{Output: `{"verdict":"RED","feedback":"handler ignores errors","issues":[{"severity":"critical","description":"error dropped","location":"handler.go:12"}],"recommendations":[],"should_retry":true,"suggested_agent":"","criteria_results":[{"index":0,"criterion":"Errors handled","passed":false,"fail_reason":"dropped"}]}`}, nil
+			}
+			return &agent.InvocationResult{Output: `{"verdict":"GREEN","feedback":"fine","issues":[],"recommendations":[],"should_retry":false,"suggested_agent":"","criteria_results":[{"index":0,"criterion":"Errors handled","passed":true}]}`}, nil
+		},
+	}
+
+	store, err := learning.NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	qc := NewQualityController(mock)
+	qc.LearningStore = store
+	qc.AgentConfig = models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"code-reviewer"}}
+	qc.DiffReview = config.QCDiffReviewConfig{Enabled: true, ChunkBytes: 60, MaxChunks: 5}
+	diff := &TaskDiff{Source: "commit abc1234", Files: []FileDiff{
+		{Path: "model.go", Patch: "+type Model struct{}" + strings.Repeat(" ", 30)},
+		{Path: "handler.go", Patch: "+func Handle() { _ = save() }" + strings.Repeat(" ", 30)},
+	}}
+
+	task := withTaskDiff(models.Task{
+		Number:          "5",
+		Name:            "Handler",
+		Prompt:          "Add the handler",
+		Files:           []string{"model.go", "handler.go", "routes.go"},
+		SuccessCriteria: []string{"Errors handled"},
+	}, diff)
+	result, err := qc.Review(context.Background(), task, "Added model, handler and routes")
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	if len(prompts) != 2 {
+		t.Fatalf("expected one review per part, got %d", len(prompts))
+	}
+	first, second := prompts[0], prompts[1]
+	if !strings.Contains(first, `part="1 of 2"`) || !strings.Contains(first, "model.go") || strings.Contains(first, "func Handle") {
+		t.Errorf("part 1 should show only model.go:\n%s", first)
+	}
+	// routes.go is expected but was never changed; part 1 lists it so its absence shows
+	if !strings.Contains(first, "`routes.go`") || strings.Contains(second, "`routes.go`") || strings.Contains(second, "`model.go`") {
+		t.Error("expected files should be split between parts, untouched ones in part 1")
+	}
+
+	if result.Flag != models.StatusRed || len(result.Issues) != 1 {
+		t.Errorf("RED part should fail the review: %+v", result)
+	}
+	if len(result.CriteriaResults) != 1 || result.CriteriaResults[0].Passed || result.CriteriaResults[0].FailReason != "dropped" {
+		t.Errorf("criterion failed in one part should fail: %+v", result.CriteriaResults)
+	}
+	if !strings.Contains(result.Feedback, "[diff part 2/2: handler.go] handler ignores errors") {
+		t.Errorf("feedback should name the part: %q", result.Feedback)
+	}
+
+	// The parts are recorded as one review, with the agent's strictest verdict
+	if n, err := store.SetQCReviewOutcome(context.Background(), "", "5", learning.QCOutcomeBad, learning.QCOutcomeSourceFixTask); err != nil || n != 1 {
+		t.Fatalf("SetQCReviewOutcome() = %d, %v; want 1 verdict labeled", n, err)
+	}
+	byAgent, _, err := store.GetQCCalibration(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetQCCalibration: %v", err)
+	}
+	if got := byAgent["code-reviewer"]; got.Reviews != 1 || got.Labeled != 1 || got.TruePositives != 1 {
+		t.Errorf("code-reviewer calibration = %+v, want one labeled RED", got)
+	}
+
+	// Diff review off: the prompt has no diff
+	prompts = nil
+	qc.DiffReview.Enabled = false
+	if _, err := qc.Review(context.Background(), task, "output"); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if len(prompts) != 1 || strings.Contains(prompts[0], "<task_diff") {
+		t.Error("without diff review QC should see the output only")
+	}
+}
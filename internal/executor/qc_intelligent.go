@@ -22,8 +22,9 @@ type IntelligentAgentRecommendation struct {
 // Embeds BaseSelector for shared registry access and Claude invocation.
 type IntelligentSelector struct {
 	BaseSelector
-	Cache     *QCSelectionCache
-	MaxAgents int
+	Cache       *QCSelectionCache
+	MaxAgents   int
+	Calibration *ReviewerCalibration // Past reviewer accuracy, shown to Claude and enforced by guardrails (v3.6+, optional)
 }
 
 // NewIntelligentSelector creates a new intelligent selector with configurable timeout.
@@ -119,6 +120,13 @@ func (is *IntelligentSelector) buildSelectionPrompt(
 		integrationStr = "\n\nThis is an INTEGRATION task verifying cross-component interactions and wiring."
 	}
 
+	// Add reviewer accuracy against past task outcomes (v3.6+)
+	calibrationStr := ""
+	if summary := is.Calibration.Summary(availableAgents); summary != "" {
+		calibrationStr = "\n\nREVIEWER ACCURACY (verdicts scored against later task outcomes):\n" + summary +
+			"\nPrefer accurate reviewers. Poorly calibrated reviewers will be dropped from your selection."
+	}
+
 	prompt := fmt.Sprintf(`You are selecting QC (Quality Control) reviewer agents for a completed task.
 
 TASK CONTEXT:
@@ -129,7 +137,7 @@ TASK CONTEXT:
 - Task Description: %s%s%s
 
 AVAILABLE QC AGENTS (from registry):
-%s
+%s%s
 
 INSTRUCTIONS:
 Analyze the task context and recommend the best QC agents to review this work. Consider:
@@ -165,6 +173,7 @@ RESPONSE FORMAT (JSON only):
 		criteriaStr,
 		integrationStr,
 		agentsStr,
+		calibrationStr,
 		maxAgents,
 		maxAgents,
 	)
@@ -221,6 +230,11 @@ func (is *IntelligentSelector) applyGuardrails(
 			continue
 		}
 
+		// Skip reviewers whose verdicts have often been wrong (v3.6+)
+		if is.Calibration.Poor(agentName) {
+			continue
+		}
+
 		// Add if under cap
 		if len(result.Agents) < maxAgents {
 			result.Agents = append(result.Agents, agentName)
@@ -15,7 +15,8 @@ import (
 type SelectionContext struct {
 	ExecutingAgent      string
 	IntelligentSelector *IntelligentSelector
-	LLMTimeout          time.Duration // Timeout for LLM calls (from timeouts.llm)
+	LLMTimeout          time.Duration        // Timeout for LLM calls (from timeouts.llm)
+	Calibration         *ReviewerCalibration // Drops poorly calibrated auto-selected agents (v3.6+, optional)
 	// Output fields (populated after selection)
 	UsedMode        string // Actual mode used (may differ from config if fallback occurred)
 	FallbackOccured bool   // True if intelligent selection fell back to auto
@@ -23,6 +24,14 @@ type SelectionContext struct {
 	Rationale       string // Rationale from intelligent selection (if successful)
 }
 
+// calibration returns the selection's reviewer calibration, nil without one.
+func (sc *SelectionContext) calibration() *ReviewerCalibration {
+	if sc == nil {
+		return nil
+	}
+	return sc.Calibration
+}
+
 // SelectQCAgents determines which QC agents to use based on configuration and task context
 func SelectQCAgents(task models.Task, agentConfig models.QCAgentConfig, registry *agent.Registry) []string {
 	// For backward compatibility, use non-intelligent modes
@@ -79,7 +88,7 @@ func SelectQCAgentsWithContext(
 			selCtx.FallbackReason = "intelligent selector not initialized"
 		}
 		// Fallback to auto-selection
-		agents = AutoSelectQCAgents(task, registry)
+		agents = selCtx.calibration().Drop(AutoSelectQCAgents(task, registry), "quality-control")
 		if selCtx != nil && selCtx.UsedMode == "" {
 			selCtx.UsedMode = "auto (fallback from intelligent)"
 		}
@@ -88,13 +97,13 @@ func SelectQCAgentsWithContext(
 		agents = agentConfig.ExplicitList
 	case "mixed":
 		// Start with auto-selected agents, then add additional
-		agents = AutoSelectQCAgents(task, registry)
+		agents = selCtx.calibration().Drop(AutoSelectQCAgents(task, registry), "quality-control")
 		agents = appendUniqueAgents(agents, agentConfig.AdditionalAgents...)
 	case "auto":
 		fallthrough
 	default:
-		// Auto-select based on task context
-		agents = AutoSelectQCAgents(task, registry)
+		// Auto-select based on task context, leaving out poorly calibrated agents (v3.6+)
+		agents = selCtx.calibration().Drop(AutoSelectQCAgents(task, registry), "quality-control")
 	}
 
 	// Remove blocked agents (intelligent mode already handles this, but double-check)
@@ -1044,6 +1044,31 @@ func TestReviewMultiAgent(t *testing.T) {
 	}
 }
 
+func TestReviewMultiAgent_SumsUsage(t *testing.T) {
+	mock := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			if task.Agent == "golang-pro" {
+				return &agent.InvocationResult{Output: "not json", Usage: models.Usage{OutputTokens: 3, CostUSD: 0.02}}, nil
+			}
+			return &agent.InvocationResult{
+				Output: `{"verdict":"GREEN","feedback":"ok","issues":[],"recommendations":[],"should_retry":false,"suggested_agent":""}`,
+				Usage:  models.Usage{InputTokens: 40, CostUSD: 0.03},
+			}, nil
+		},
+	}
+	qc := NewQualityController(mock)
+	qc.AgentConfig = models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"quality-control", "golang-pro"}}
+
+	result, err := qc.ReviewMultiAgent(context.Background(), models.Task{Number: "1", Name: "Task", Prompt: "p"}, "output")
+	if err != nil {
+		t.Fatalf("ReviewMultiAgent() error = %v", err)
+	}
+	// Failed agents cost money too
+	if result.Usage.InputTokens != 40 || result.Usage.OutputTokens != 3 || result.Usage.CostUSD < 0.0499 || result.Usage.CostUSD > 0.0501 {
+		t.Errorf("Usage = %+v, want both agents summed", result.Usage)
+	}
+}
+
 func TestAggregateVerdicts(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1168,6 +1193,25 @@ func TestAggregateVerdicts(t *testing.T) {
 	}
 }
 
+func TestAggregateVerdicts_CollectsIssues(t *testing.T) {
+	qc := NewQualityController(nil)
+	results := []*ReviewResult{
+		{Flag: models.StatusGreen, AgentName: "agent1", Issues: []models.Issue{{Severity: "info", Description: "naming", Location: "a.go:1"}}},
+		nil,
+		{Flag: models.StatusRed, AgentName: "agent2", Issues: []models.Issue{{Severity: "critical", Description: "panic", Location: "b.go:2"}}},
+	}
+
+	combined := qc.aggregateVerdicts(results, []string{"agent1", "agent2"})
+	if len(combined.Issues) != 2 || combined.Issues[0].Location != "a.go:1" || combined.Issues[1].Location != "b.go:2" {
+		t.Errorf("aggregateVerdicts() issues = %+v, want issues from every agent", combined.Issues)
+	}
+
+	consensus := qc.aggregateMultiAgentCriteria(results, models.Task{}, 1)
+	if len(consensus.Issues) != 2 {
+		t.Errorf("aggregateMultiAgentCriteria() issues = %+v, want issues from every agent", consensus.Issues)
+	}
+}
+
 // mockQCLogger for testing QC logging calls
 type mockQCLogger struct {
 	agentSelectionCalls []struct {
@@ -2472,7 +2516,7 @@ func TestInvokeAndParseQCAgent_FailsOnPersistentInvalidJSON(t *testing.T) {
 	}
 
 	// Test that invokeAndParseQCAgent fails on invalid JSON (single attempt with schema enforcement)
-	resp, err := qc.invokeAndParseQCAgent(context.Background(), task, "test-agent")
+	resp, _, err := qc.invokeAndParseQCAgent(context.Background(), task, "test-agent")
 
 	if err == nil {
 		t.Error("Expected error on persistent invalid JSON, got nil")
@@ -2512,7 +2556,7 @@ func TestInvokeAndParseQCAgent_SucceedsWithValidJSONFirstAttempt(t *testing.T) {
 		Prompt: "original prompt",
 	}
 
-	resp, err := qc.invokeAndParseQCAgent(context.Background(), task, "test-agent")
+	resp, _, err := qc.invokeAndParseQCAgent(context.Background(), task, "test-agent")
 
 	if err != nil {
 		t.Fatalf("Expected success on valid JSON, got error: %v", err)
@@ -2555,7 +2599,7 @@ func TestInvokeAndParseQCAgent_HandlesContextCancellation(t *testing.T) {
 		Prompt: "original prompt",
 	}
 
-	resp, err := qc.invokeAndParseQCAgent(context.Background(), task, "test-agent")
+	resp, _, err := qc.invokeAndParseQCAgent(context.Background(), task, "test-agent")
 
 	if err == nil {
 		t.Error("Expected error on context cancellation, got nil")
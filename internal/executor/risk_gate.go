@@ -0,0 +1,511 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/behavioral"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// riskQCAgentsKey is the task metadata key for reviewers the risk gate adds to QC (v3.6+).
+const riskQCAgentsKey = "risk_qc_agents"
+
+// anomalyStdDevThreshold is the deviation at which PatternDetector flags a session.
+const anomalyStdDevThreshold = 2.0
+
+// SessionHistoryStore provides the recorded and ingested session history.
+type SessionHistoryStore interface {
+	GetSessionHistory(ctx context.Context, limit int) ([]learning.SessionHistory, error)
+}
+
+// BehavioralHistory is the session history loaded at run start, converted for
+// the behavioral FailurePredictor, PerformanceScorer and PatternDetector (v3.6+).
+// It is safe for concurrent use.
+type BehavioralHistory struct {
+	history   []learning.SessionHistory
+	rankings  []learning.AgentRanking
+	anomalous map[string][]string // Session ID -> anomaly types
+
+	mu        sync.Mutex // Guards predictor, which caches tool failure rates on first use
+	predictor *behavioral.FailurePredictor
+}
+
+// LoadBehavioralHistory loads the most recent limit sessions from store.
+func LoadBehavioralHistory(ctx context.Context, store SessionHistoryStore, limit int) (*BehavioralHistory, error) {
+	history, err := store.GetSessionHistory(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("load session history: %w", err)
+	}
+	return NewBehavioralHistory(history), nil
+}
+
+// NewBehavioralHistory scores agents and indexes anomalous sessions in history.
+func NewBehavioralHistory(history []learning.SessionHistory) *BehavioralHistory {
+	sessions := make([]behavioral.Session, len(history))
+	metrics := make([]behavioral.BehavioralMetrics, len(history))
+	for i, h := range history {
+		sessions[i], metrics[i] = toBehavioralSession(h)
+	}
+
+	h := &BehavioralHistory{
+		history:   history,
+		anomalous: make(map[string][]string),
+		predictor: behavioral.NewFailurePredictor(sessions, metrics),
+	}
+	h.rankings = rankAgents(behavioral.NewPerformanceScorer(sessions, metrics))
+	for _, a := range behavioral.NewPatternDetector(sessions, metrics).IdentifyAnomalies(anomalyStdDevThreshold) {
+		h.anomalous[a.SessionID] = append(h.anomalous[a.SessionID], a.Type)
+	}
+	return h
+}
+
+// toBehavioralSession converts a stored session for the behavioral package.
+func toBehavioralSession(h learning.SessionHistory) (behavioral.Session, behavioral.BehavioralMetrics) {
+	status := "completed"
+	if !h.Success {
+		status = "failed"
+	}
+	session := behavioral.Session{
+		ID:         strconv.FormatInt(h.ID, 10),
+		Project:    h.Project,
+		Timestamp:  h.Start,
+		Status:     status,
+		AgentName:  h.Agent,
+		Duration:   h.DurationSecs * 1000,
+		Success:    h.Success,
+		ErrorCount: h.ErrorCount,
+	}
+
+	metrics := behavioral.BehavioralMetrics{
+		TotalSessions: 1,
+		TotalErrors:   h.ErrorCount,
+		TokenUsage: behavioral.TokenUsage{
+			InputTokens:  h.InputTokens,
+			OutputTokens: h.OutputTokens,
+			CostUSD:      behavioral.CalculateCost(h.Model, h.InputTokens, h.OutputTokens),
+			ModelName:    h.Model,
+		},
+		AgentPerformance: make(map[string]int),
+	}
+	if h.Success {
+		metrics.SuccessRate = 1.0
+		metrics.AgentPerformance[h.Agent] = 1
+	} else {
+		metrics.AgentPerformance[h.Agent] = 0
+	}
+	for _, tool := range h.Tools {
+		te := behavioral.ToolExecution{
+			Name:         tool.Name,
+			Count:        tool.Count,
+			TotalErrors:  tool.Errors,
+			TotalSuccess: tool.Count - tool.Errors,
+		}
+		if tool.Count > 0 {
+			te.ErrorRate = float64(tool.Errors) / float64(tool.Count)
+			te.SuccessRate = 1 - te.ErrorRate
+		}
+		metrics.ToolExecutions = append(metrics.ToolExecutions, te)
+	}
+	return session, metrics
+}
+
+// rankAgents combines the overall and per-domain rankings of scorer.
+func rankAgents(scorer *behavioral.PerformanceScorer) []learning.AgentRanking {
+	domainRank := make(map[string]int)
+	domainOf := make(map[string]string)
+	for domain, ranked := range scorer.CompareWithinDomain() {
+		for _, r := range ranked {
+			domainRank[r.AgentName] = r.Rank
+			domainOf[r.AgentName] = domain
+		}
+	}
+
+	ranked := scorer.RankAgents()
+	rankings := make([]learning.AgentRanking, 0, len(ranked))
+	for _, r := range ranked {
+		rankings = append(rankings, learning.AgentRanking{
+			Agent:      r.AgentName,
+			Score:      r.Score,
+			Rank:       r.Rank,
+			Domain:     domainOf[r.AgentName],
+			DomainRank: domainRank[r.AgentName],
+			Sessions:   scorer.ScoreAgent(r.AgentName).SampleSize,
+		})
+	}
+	return rankings
+}
+
+// Sessions returns the number of sessions in the history.
+func (h *BehavioralHistory) Sessions() int {
+	if h == nil {
+		return 0
+	}
+	return len(h.history)
+}
+
+// AgentRankings returns the agents ranked by PerformanceScorer, best first.
+func (h *BehavioralHistory) AgentRankings() []learning.AgentRanking {
+	if h == nil {
+		return nil
+	}
+	return h.rankings
+}
+
+// BestAgentLike returns the best-scored agent in the domain of current that
+// scores higher than current, or "" if there is none. When exists is non-nil,
+// agents it rejects are skipped.
+func (h *BehavioralHistory) BestAgentLike(current string, exists func(string) bool) string {
+	var own *learning.AgentRanking
+	for i := range h.AgentRankings() {
+		if h.rankings[i].Agent == current {
+			own = &h.rankings[i]
+			break
+		}
+	}
+	if own == nil {
+		return ""
+	}
+
+	var candidates []learning.AgentRanking
+	for _, r := range h.rankings {
+		if r.Domain == own.Domain && r.Agent != current && r.Score > own.Score {
+			candidates = append(candidates, r)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].DomainRank < candidates[j].DomainRank })
+	for _, r := range candidates {
+		if exists == nil || exists(r.Agent) {
+			return r.Agent
+		}
+	}
+	return ""
+}
+
+// RiskPrediction is a task's predicted failure probability and what it is based on.
+type RiskPrediction struct {
+	*behavioral.PredictionResult
+	Basis string // e.g. "4 past sessions of task 3"
+}
+
+// Predict scores the failure probability of task from the tool usage of its
+// own past sessions in planFile, or else of its agent's past sessions.
+// Returns nil when neither has any history.
+func (h *BehavioralHistory) Predict(task models.Task, planFile string) *RiskPrediction {
+	if h == nil {
+		return nil
+	}
+
+	var basis []learning.SessionHistory
+	for _, s := range h.history {
+		if s.TaskNumber == task.Number && s.PlanFile != "" && filepath.Base(s.PlanFile) == filepath.Base(planFile) {
+			basis = append(basis, s)
+		}
+	}
+	description := fmt.Sprintf("%d past sessions of task %s", len(basis), task.Number)
+	if len(basis) == 0 && task.Agent != "" {
+		for _, s := range h.history {
+			if s.Agent == task.Agent {
+				basis = append(basis, s)
+			}
+		}
+		description = fmt.Sprintf("%d past sessions of %s", len(basis), task.Agent)
+	}
+	if len(basis) == 0 {
+		return nil
+	}
+
+	h.mu.Lock()
+	result, err := h.predictor.PredictFailure(&behavioral.Session{AgentName: task.Agent}, typicalToolUsage(basis))
+	h.mu.Unlock()
+	if err != nil {
+		return nil
+	}
+
+	// PatternDetector: past sessions that stood out from the baseline
+	anomalous := 0
+	types := make(map[string]bool)
+	for _, s := range basis {
+		if found := h.anomalous[strconv.FormatInt(s.ID, 10)]; len(found) > 0 {
+			anomalous++
+			for _, t := range found {
+				types[t] = true
+			}
+		}
+	}
+	if anomalous > 0 {
+		names := make([]string, 0, len(types))
+		for t := range types {
+			names = append(names, t)
+		}
+		sort.Strings(names)
+		result.RiskFactors = append(result.RiskFactors, fmt.Sprintf("%d of %d past sessions were anomalous (%s)", anomalous, len(basis), strings.Join(names, ", ")))
+	}
+
+	return &RiskPrediction{PredictionResult: result, Basis: description}
+}
+
+// typicalToolUsage returns the tools an average session in sessions used, one
+// entry per use, in name order.
+func typicalToolUsage(sessions []learning.SessionHistory) []string {
+	totals := make(map[string]int)
+	for _, s := range sessions {
+		for _, tool := range s.Tools {
+			totals[tool.Name] += tool.Count
+		}
+	}
+	names := make([]string, 0, len(totals))
+	for name := range totals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var usage []string
+	for _, name := range names {
+		count := (totals[name] + len(sessions)/2) / len(sessions)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			usage = append(usage, name)
+		}
+	}
+	return usage
+}
+
+// TaskApprover asks a human whether a task may run (v3.6+).
+type TaskApprover interface {
+	Approve(ctx context.Context, task models.Task, reason string) (bool, error)
+}
+
+// TerminalApprover asks for approval on a terminal. Concurrent tasks ask one at a time.
+type TerminalApprover struct {
+	in     *bufio.Reader
+	out    io.Writer
+	mu     sync.Mutex
+	start  sync.Once
+	prompt atomic.Uint64       // Sequence number of the latest prompt
+	lines  chan terminalAnswer // Answers from the reader goroutine; closed at end of input
+}
+
+// terminalAnswer is an input line and the prompt showing when it was read.
+type terminalAnswer struct {
+	line   string
+	prompt uint64
+}
+
+// NewTerminalApprover creates a TerminalApprover reading answers from in.
+func NewTerminalApprover(in io.Reader, out io.Writer) *TerminalApprover {
+	return &TerminalApprover{in: bufio.NewReader(in), out: out, lines: make(chan terminalAnswer)}
+}
+
+// Approve prints reason and waits for a yes or no answer. Anything but y or yes rejects.
+func (a *TerminalApprover) Approve(ctx context.Context, task models.Task, reason string) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prompt := a.prompt.Add(1)
+
+	// One goroutine reads every answer, so an abandoned prompt leaves no reader behind
+	a.start.Do(func() { go a.readLines() })
+
+	fmt.Fprintf(a.out, "\nTask %s (%s) needs approval: %s\nRun it? [y/N]: ", task.Number, task.Name, reason)
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case answer, ok := <-a.lines:
+			if !ok {
+				return false, nil
+			}
+			if answer.prompt != prompt {
+				continue // Typed for an abandoned prompt, before this one showed
+			}
+			line := strings.ToLower(strings.TrimSpace(answer.line))
+			return line == "y" || line == "yes", nil
+		}
+	}
+}
+
+// readLines feeds input lines to Approve until the input ends.
+func (a *TerminalApprover) readLines() {
+	defer close(a.lines)
+	for {
+		line, err := a.in.ReadString('\n')
+		if line != "" {
+			a.lines <- terminalAnswer{line: line, prompt: a.prompt.Load()}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// RiskGate predicts each task's failure probability from session history
+// before it runs and escalates high-risk tasks as configured (v3.6+).
+type RiskGate struct {
+	Config   config.RiskGateConfig
+	History  *BehavioralHistory
+	Registry *agent.Registry // Optional: escalation agents must exist in it
+	Approver TaskApprover    // Nil fails tasks that need approval
+}
+
+// NewRiskGate creates a RiskGate. Returns nil when the gate is disabled or
+// there is no history to predict from.
+func NewRiskGate(cfg config.RiskGateConfig, history *BehavioralHistory) *RiskGate {
+	if !cfg.Enabled || history == nil {
+		return nil
+	}
+	return &RiskGate{Config: cfg, History: history}
+}
+
+// RiskAssessment is the risk gate's decision for one task.
+type RiskAssessment struct {
+	Prediction    *RiskPrediction
+	HighRisk      bool   // At or above the threshold with enough confidence
+	Agent         string // Stronger agent to run the task with, "" to keep its agent
+	QCAgent       string // Reviewer to add to QC, "" for none
+	NeedsApproval bool
+}
+
+// Assess predicts the failure risk of task and decides the actions for it.
+// Returns nil when there is not enough history to predict.
+func (g *RiskGate) Assess(task models.Task, planFile string) *RiskAssessment {
+	if g == nil || g.History.Sessions() < g.Config.MinSessions {
+		return nil
+	}
+	prediction := g.History.Predict(task, planFile)
+	if prediction == nil {
+		return nil
+	}
+
+	assessment := &RiskAssessment{Prediction: prediction}
+	if prediction.Probability < g.Config.Threshold || prediction.Confidence < g.Config.MinConfidence {
+		return assessment
+	}
+	assessment.HighRisk = true
+
+	if g.Config.HasAction(config.RiskActionStrongerAgent) {
+		escalation := g.Config.EscalationAgent
+		if escalation == "" {
+			escalation = g.History.BestAgentLike(task.Agent, g.agentExists)
+		}
+		if escalation != task.Agent {
+			assessment.Agent = escalation
+		}
+	}
+	if g.Config.HasAction(config.RiskActionExtraQC) {
+		assessment.QCAgent = g.Config.QCAgent
+	}
+	assessment.NeedsApproval = g.Config.HasAction(config.RiskActionApproval)
+	return assessment
+}
+
+// agentExists reports whether name is a known agent; without a registry every name is.
+func (g *RiskGate) agentExists(name string) bool {
+	return g.Registry == nil || g.Registry.Exists(name)
+}
+
+// Summary describes the prediction in one line.
+func (a *RiskAssessment) Summary() string {
+	p := a.Prediction
+	summary := fmt.Sprintf("predicted failure probability %.0f%% (confidence %.0f%%, from %s)", p.Probability*100, p.Confidence*100, p.Basis)
+	if len(p.RiskFactors) > 0 {
+		summary += "; " + strings.Join(p.RiskFactors, "; ")
+	}
+	return summary
+}
+
+// Anomaly reports the assessment through Logger.LogAnomaly. Its severity is
+// the predictor's risk level, raised to high when the gate acts on it.
+func (a *RiskAssessment) Anomaly(taskNumber string) WaveAnomaly {
+	severity := a.Prediction.RiskLevel
+	description := a.Summary()
+	if a.HighRisk {
+		severity = "high"
+		var actions []string
+		if a.Agent != "" {
+			actions = append(actions, "running with "+a.Agent)
+		}
+		if a.QCAgent != "" {
+			actions = append(actions, "adding QC reviewer "+a.QCAgent)
+		}
+		if a.NeedsApproval {
+			actions = append(actions, "waiting for approval")
+		}
+		if len(actions) > 0 {
+			description += " - " + strings.Join(actions, ", ")
+		}
+	}
+	return WaveAnomaly{
+		Type:        "failure_risk",
+		Description: description,
+		Severity:    severity,
+		TaskNumber:  taskNumber,
+	}
+}
+
+// Escalate gives a high-risk task the stronger agent and the extra QC reviewer.
+func (a *RiskAssessment) Escalate(task *models.Task) {
+	if !a.HighRisk {
+		return
+	}
+	if a.Agent != "" {
+		task.Agent = a.Agent
+	}
+	if a.QCAgent != "" {
+		metadata := make(map[string]interface{}, len(task.Metadata)+1)
+		for k, v := range task.Metadata {
+			metadata[k] = v
+		}
+		metadata[riskQCAgentsKey] = []string{a.QCAgent}
+		task.Metadata = metadata
+	}
+}
+
+// applyRiskAssessment escalates a high-risk task and waits for approval when
+// configured. Returns an error when the task must not run.
+func (te *DefaultTaskExecutor) applyRiskAssessment(ctx context.Context, task *models.Task, assessment *RiskAssessment) error {
+	if !assessment.HighRisk {
+		return nil
+	}
+	if assessment.Agent != "" && te.Logger != nil {
+		te.Logger.Warnf("[Task %s] High failure risk: running with %s instead of %s", task.Number, assessment.Agent, task.Agent)
+	}
+	assessment.Escalate(task)
+
+	if !assessment.NeedsApproval {
+		return nil
+	}
+	if te.RiskGate.Approver == nil {
+		return fmt.Errorf("task %s needs approval at the risk gate (%s) but there is no terminal to approve it on", task.Number, assessment.Summary())
+	}
+	approved, err := te.RiskGate.Approver.Approve(ctx, *task, assessment.Summary())
+	if err != nil {
+		return fmt.Errorf("risk gate approval for task %s: %w", task.Number, err)
+	}
+	if !approved {
+		return fmt.Errorf("task %s was not approved at the risk gate", task.Number)
+	}
+	return nil
+}
+
+// riskQCAgents returns the reviewers the risk gate added to task's QC.
+func riskQCAgents(task models.Task) []string {
+	if task.Metadata == nil {
+		return nil
+	}
+	agents, _ := task.Metadata[riskQCAgentsKey].([]string)
+	return agents
+}
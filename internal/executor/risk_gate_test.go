@@ -0,0 +1,229 @@
+package executor
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// riskHistory returns sessions where backend-developer keeps failing task 3
+// of plan.md with Bash errors while api-specialist succeeds with Edit.
+func riskHistory() []learning.SessionHistory {
+	start := time.Now().Add(-24 * time.Hour)
+	var history []learning.SessionHistory
+	for i := 0; i < 8; i++ {
+		history = append(history, learning.SessionHistory{
+			ID: int64(i + 1), PlanFile: "/work/plan.md", TaskNumber: "3", Agent: "backend-developer",
+			Start: start.Add(time.Duration(i) * time.Minute), DurationSecs: 300, Success: false, ErrorCount: 3,
+			Tools: []learning.SessionToolUsage{{Name: "Bash", Count: 4, Errors: 3}},
+		})
+	}
+	for i := 0; i < 6; i++ {
+		history = append(history, learning.SessionHistory{
+			ID: int64(i + 9), PlanFile: "/work/plan.md", TaskNumber: "1", Agent: "api-specialist",
+			Start: start.Add(time.Duration(i) * time.Minute), DurationSecs: 60, Success: true,
+			Tools: []learning.SessionToolUsage{{Name: "Edit", Count: 2}},
+		})
+	}
+	return history
+}
+
+func TestBehavioralHistory_Rankings(t *testing.T) {
+	history := NewBehavioralHistory(riskHistory())
+	if history.Sessions() != 14 {
+		t.Fatalf("Sessions() = %d, want 14", history.Sessions())
+	}
+
+	rankings := history.AgentRankings()
+	if len(rankings) != 2 || rankings[0].Agent != "api-specialist" || rankings[0].Rank != 1 {
+		t.Fatalf("api-specialist should rank first: %+v", rankings)
+	}
+	if rankings[1].Domain != "backend" || rankings[1].DomainRank != 2 || rankings[1].Sessions != 8 {
+		t.Errorf("unexpected backend-developer ranking: %+v", rankings[1])
+	}
+
+	if got := history.BestAgentLike("backend-developer", nil); got != "api-specialist" {
+		t.Errorf("BestAgentLike() = %q, want api-specialist", got)
+	}
+	if got := history.BestAgentLike("backend-developer", func(string) bool { return false }); got != "" {
+		t.Errorf("unknown agents should be skipped, got %q", got)
+	}
+	if got := history.BestAgentLike("api-specialist", nil); got != "" {
+		t.Errorf("the best agent has nothing stronger, got %q", got)
+	}
+
+	var nilHistory *BehavioralHistory
+	if nilHistory.Sessions() != 0 || nilHistory.AgentRankings() != nil || nilHistory.Predict(models.Task{Number: "3"}, "plan.md") != nil {
+		t.Error("a nil history should predict nothing")
+	}
+}
+
+func TestRiskGate_Assess(t *testing.T) {
+	history := NewBehavioralHistory(riskHistory())
+	cfg := config.DefaultRiskGateConfig()
+	cfg.Enabled = true
+	cfg.Actions = []string{config.RiskActionStrongerAgent, config.RiskActionExtraQC, config.RiskActionApproval}
+
+	if NewRiskGate(config.DefaultRiskGateConfig(), history) != nil || NewRiskGate(cfg, nil) != nil {
+		t.Fatal("the gate should be off when disabled or without history")
+	}
+	gate := NewRiskGate(cfg, history)
+
+	task := models.Task{Number: "3", Name: "Migrate schema", Agent: "backend-developer"}
+	assessment := gate.Assess(task, "plan.md")
+	if assessment == nil || !assessment.HighRisk {
+		t.Fatalf("task 3 keeps failing and should be high risk: %+v", assessment)
+	}
+	if !strings.Contains(assessment.Prediction.Basis, "8 past sessions of task 3") {
+		t.Errorf("prediction should be based on the task's own sessions: %q", assessment.Prediction.Basis)
+	}
+	if assessment.Agent != "api-specialist" || assessment.QCAgent != "code-reviewer" || !assessment.NeedsApproval {
+		t.Errorf("unexpected actions: %+v", assessment)
+	}
+
+	anomaly := assessment.Anomaly("3")
+	if anomaly.Type != "failure_risk" || anomaly.Severity != "high" || !strings.Contains(anomaly.Description, "running with api-specialist") {
+		t.Errorf("unexpected anomaly: %+v", anomaly)
+	}
+
+	// A configured escalation agent wins over the rankings
+	gate.Config.EscalationAgent = "golang-pro"
+	if a := gate.Assess(task, "plan.md"); a.Agent != "golang-pro" {
+		t.Errorf("Agent = %q, want golang-pro", a.Agent)
+	}
+
+	// A task with a good track record passes
+	if a := gate.Assess(models.Task{Number: "1", Agent: "api-specialist"}, "plan.md"); a == nil || a.HighRisk {
+		t.Errorf("task 1 always succeeded and should pass: %+v", a)
+	}
+
+	// Not enough history: no prediction
+	gate.Config.MinSessions = 100
+	if a := gate.Assess(task, "plan.md"); a != nil {
+		t.Errorf("expected no assessment below min_sessions, got %+v", a)
+	}
+	if (*RiskGate)(nil).Assess(task, "plan.md") != nil {
+		t.Error("a nil gate should assess nothing")
+	}
+}
+
+type recordingApprover struct {
+	approve bool
+	reasons []string
+}
+
+func (a *recordingApprover) Approve(ctx context.Context, task models.Task, reason string) (bool, error) {
+	a.reasons = append(a.reasons, reason)
+	return a.approve, nil
+}
+
+func TestApplyRiskAssessment(t *testing.T) {
+	te := &DefaultTaskExecutor{RiskGate: &RiskGate{}}
+	assessment := &RiskAssessment{
+		Prediction:    NewBehavioralHistory(riskHistory()).Predict(models.Task{Number: "3", Agent: "backend-developer"}, "plan.md"),
+		HighRisk:      true,
+		Agent:         "api-specialist",
+		QCAgent:       "security-auditor",
+		NeedsApproval: true,
+	}
+
+	metadata := map[string]interface{}{"owner": "plan"}
+	task := models.Task{Number: "3", Agent: "backend-developer", Metadata: metadata}
+	err := te.applyRiskAssessment(context.Background(), &task, assessment)
+	if err == nil || !strings.Contains(err.Error(), "needs approval") {
+		t.Fatalf("without an approver the task must not run, got %v", err)
+	}
+	if task.Agent != "api-specialist" || riskQCAgents(task)[0] != "security-auditor" {
+		t.Errorf("task should be escalated: agent=%s reviewers=%v", task.Agent, riskQCAgents(task))
+	}
+	if _, shared := metadata[riskQCAgentsKey]; shared {
+		t.Error("escalation must not write into the plan's metadata map")
+	}
+
+	approver := &recordingApprover{}
+	te.RiskGate.Approver = approver
+	if err := te.applyRiskAssessment(context.Background(), &task, assessment); err == nil || !strings.Contains(err.Error(), "not approved") {
+		t.Errorf("a rejected task must not run, got %v", err)
+	}
+	approver.approve = true
+	if err := te.applyRiskAssessment(context.Background(), &task, assessment); err != nil {
+		t.Errorf("an approved task should run, got %v", err)
+	}
+	if len(approver.reasons) != 2 || !strings.Contains(approver.reasons[0], "predicted failure probability") {
+		t.Errorf("approver should be told why: %v", approver.reasons)
+	}
+}
+
+func TestTerminalApprover(t *testing.T) {
+	var out strings.Builder
+	approver := NewTerminalApprover(strings.NewReader("yes\nn\n"), &out)
+	task := models.Task{Number: "3", Name: "Migrate schema"}
+
+	if ok, err := approver.Approve(context.Background(), task, "risky"); err != nil || !ok {
+		t.Errorf("yes should approve, got %v, %v", ok, err)
+	}
+	if ok, err := approver.Approve(context.Background(), task, "risky"); err != nil || ok {
+		t.Errorf("n should reject, got %v, %v", ok, err)
+	}
+	if !strings.Contains(out.String(), "Task 3 (Migrate schema) needs approval: risky") {
+		t.Errorf("unexpected prompt: %q", out.String())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pending, answer := io.Pipe()
+	blocked := NewTerminalApprover(pending, &out)
+	if _, err := blocked.Approve(ctx, task, "risky"); err == nil {
+		t.Error("a cancelled context should stop waiting")
+	}
+	answer.Close()
+	if ok, err := blocked.Approve(context.Background(), task, "risky"); err != nil || ok {
+		t.Errorf("end of input should reject, got %v, %v", ok, err)
+	}
+
+	// An answer read while an abandoned prompt showed is not taken for the next one
+	stale := NewTerminalApprover(strings.NewReader(""), &out)
+	stale.start.Do(func() {})
+	go func() {
+		stale.lines <- terminalAnswer{line: "y\n", prompt: 0}
+		stale.lines <- terminalAnswer{line: "n\n", prompt: 1}
+	}()
+	if ok, err := stale.Approve(context.Background(), task, "risky"); err != nil || ok {
+		t.Errorf("y typed before the prompt should be ignored, got %v, %v", ok, err)
+	}
+}
+
+func TestReviewMultiAgent_RiskQCAgent(t *testing.T) {
+	var mu sync.Mutex
+	var reviewers []string
+	mock := &mockInvoker{
+		mockInvoke: func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+			mu.Lock()
+			reviewers = append(reviewers, task.Agent)
+			mu.Unlock()
+			return &agent.InvocationResult{Output: `{"verdict":"GREEN","feedback":"fine","issues":[],"recommendations":[],"should_retry":false,"suggested_agent":""}`}, nil
+		},
+	}
+
+	qc := NewQualityController(mock)
+	task := models.Task{
+		Number:   "3",
+		Name:     "Migrate schema",
+		Prompt:   "Add the migration",
+		Metadata: map[string]interface{}{riskQCAgentsKey: []string{"security-auditor"}},
+	}
+	if _, err := qc.Review(context.Background(), task, "Added migration"); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if len(reviewers) != 2 || !containsAgent(reviewers, "security-auditor") {
+		t.Errorf("risk gate reviewer should join the default reviewer, got %v", reviewers)
+	}
+}
@@ -0,0 +1,325 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// ErrTaskCancelled indicates a task was cancelled through run control (v3.6+).
+// It is an ordinary task failure, not a run-wide cancellation.
+var ErrTaskCancelled = errors.New("task cancelled via run control")
+
+// controlTaskState tracks a task's lifecycle as seen by run control.
+type controlTaskState int
+
+const (
+	controlTaskPending controlTaskState = iota
+	controlTaskRunning
+	controlTaskDone
+)
+
+// RunController lets an operator steer a running plan (v3.6+).
+// It pauses and resumes new launches, cancels a single in-flight task,
+// skips a pending task and overrides max_concurrency. Schedulers consult it
+// before every launch; every change is reported through Logger.LogControlEvent.
+// RunController is safe for concurrent use.
+type RunController struct {
+	logger Logger
+
+	mu             sync.Mutex
+	paused         bool
+	maxConcurrency int // -1 when not overridden, 0 = unlimited
+	tasks          map[string]controlTaskState
+	cancels        map[string]context.CancelFunc
+	cancelledBy    map[string]string
+	skippedBy      map[string]string
+	notify         chan struct{}
+}
+
+// NewRunController creates a RunController. The logger is optional.
+func NewRunController(logger Logger) *RunController {
+	return &RunController{
+		logger:         logger,
+		maxConcurrency: -1,
+		tasks:          make(map[string]controlTaskState),
+		cancels:        make(map[string]context.CancelFunc),
+		cancelledBy:    make(map[string]string),
+		skippedBy:      make(map[string]string),
+		notify:         make(chan struct{}),
+	}
+}
+
+// Register marks tasks as pending so they can be skipped before launch.
+func (c *RunController) Register(taskNumbers []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, taskNum := range taskNumbers {
+		if _, exists := c.tasks[taskNum]; !exists {
+			c.tasks[taskNum] = controlTaskPending
+		}
+	}
+}
+
+// Pause stops new task launches. Running tasks continue.
+func (c *RunController) Pause(actor string) error {
+	c.mu.Lock()
+	if c.paused {
+		c.mu.Unlock()
+		return fmt.Errorf("run is already paused")
+	}
+	c.paused = true
+	c.broadcastLocked()
+	c.mu.Unlock()
+
+	c.logEvent(models.ControlEvent{Action: models.ControlActionPause, Actor: actor})
+	return nil
+}
+
+// Resume allows new task launches again.
+func (c *RunController) Resume(actor string) error {
+	c.mu.Lock()
+	if !c.paused {
+		c.mu.Unlock()
+		return fmt.Errorf("run is not paused")
+	}
+	c.paused = false
+	c.broadcastLocked()
+	c.mu.Unlock()
+
+	c.logEvent(models.ControlEvent{Action: models.ControlActionResume, Actor: actor})
+	return nil
+}
+
+// CancelTask cancels the context of one in-flight task. Other tasks are unaffected;
+// the cancelled task is reported as FAILED with ErrTaskCancelled.
+func (c *RunController) CancelTask(taskNumber, actor string) error {
+	c.mu.Lock()
+	cancel, running := c.cancels[taskNumber]
+	if !running {
+		state, known := c.tasks[taskNumber]
+		c.mu.Unlock()
+		if !known {
+			return fmt.Errorf("task %s is not part of this run", taskNumber)
+		}
+		if state == controlTaskPending {
+			return fmt.Errorf("task %s has not started (use skip for pending tasks)", taskNumber)
+		}
+		return fmt.Errorf("task %s has already finished", taskNumber)
+	}
+	c.cancelledBy[taskNumber] = actor
+	c.mu.Unlock()
+
+	cancel()
+	c.logEvent(models.ControlEvent{Action: models.ControlActionCancel, TaskNumber: taskNumber, Actor: actor})
+	return nil
+}
+
+// SkipTask prevents a pending task from launching. The scheduler reports it as
+// skipped (GREEN), exactly like --skip-completed, so its dependents still run.
+func (c *RunController) SkipTask(taskNumber, actor string) error {
+	c.mu.Lock()
+	state, known := c.tasks[taskNumber]
+	switch {
+	case !known:
+		c.mu.Unlock()
+		return fmt.Errorf("task %s is not part of this run", taskNumber)
+	case state == controlTaskRunning:
+		c.mu.Unlock()
+		return fmt.Errorf("task %s is already running (use cancel for in-flight tasks)", taskNumber)
+	case state == controlTaskDone:
+		c.mu.Unlock()
+		return fmt.Errorf("task %s has already finished", taskNumber)
+	}
+	if _, already := c.skippedBy[taskNumber]; already {
+		c.mu.Unlock()
+		return fmt.Errorf("task %s is already skipped", taskNumber)
+	}
+	c.skippedBy[taskNumber] = actor
+	c.broadcastLocked()
+	c.mu.Unlock()
+
+	c.logEvent(models.ControlEvent{Action: models.ControlActionSkip, TaskNumber: taskNumber, Actor: actor})
+	return nil
+}
+
+// SetMaxConcurrency overrides the concurrency limit for new launches (0 = unlimited).
+// Running tasks are never stopped when the limit is lowered.
+func (c *RunController) SetMaxConcurrency(limit int, actor string) error {
+	if limit < 0 {
+		return fmt.Errorf("max concurrency must be >= 0 (0 = unlimited), got %d", limit)
+	}
+	c.mu.Lock()
+	c.maxConcurrency = limit
+	c.broadcastLocked()
+	c.mu.Unlock()
+
+	c.logEvent(models.ControlEvent{Action: models.ControlActionConcurrency, Value: limit, Actor: actor})
+	return nil
+}
+
+// Status returns a snapshot of the controller state.
+func (c *RunController) Status() models.ControlStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := models.ControlStatus{
+		Paused:              c.paused,
+		ConcurrencyOverride: c.maxConcurrency >= 0,
+		Pending:             []string{},
+		Running:             []string{},
+		Done:                []string{},
+	}
+	if status.ConcurrencyOverride {
+		status.MaxConcurrency = c.maxConcurrency
+	}
+	for taskNum, state := range c.tasks {
+		switch state {
+		case controlTaskPending:
+			if _, skipped := c.skippedBy[taskNum]; !skipped {
+				status.Pending = append(status.Pending, taskNum)
+			}
+		case controlTaskRunning:
+			status.Running = append(status.Running, taskNum)
+		case controlTaskDone:
+			status.Done = append(status.Done, taskNum)
+		}
+	}
+	for taskNum := range c.skippedBy {
+		status.Skipped = append(status.Skipped, taskNum)
+	}
+	for taskNum := range c.cancelledBy {
+		status.Cancelled = append(status.Cancelled, taskNum)
+	}
+	for _, list := range [][]string{status.Pending, status.Running, status.Done, status.Skipped, status.Cancelled} {
+		sort.Strings(list)
+	}
+	return status
+}
+
+// isPaused reports whether new launches are paused.
+func (c *RunController) isPaused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// limit returns the effective concurrency limit given the scheduler's own limit.
+// A result <= 0 means unlimited.
+func (c *RunController) limit(schedulerLimit int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.maxConcurrency >= 0 {
+		return c.maxConcurrency
+	}
+	return schedulerLimit
+}
+
+// changed returns a channel that is closed on the next state change.
+func (c *RunController) changed() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.notify
+}
+
+// awaitLaunch blocks until a new task may launch: the run is not paused and
+// fewer than the effective limit of tasks are active.
+func (c *RunController) awaitLaunch(ctx context.Context, active func() int, schedulerLimit int) error {
+	for {
+		c.mu.Lock()
+		limit := schedulerLimit
+		if c.maxConcurrency >= 0 {
+			limit = c.maxConcurrency
+		}
+		if !c.paused && (limit <= 0 || active() < limit) {
+			c.mu.Unlock()
+			return nil
+		}
+		ch := c.notify
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+}
+
+// takeSkip reports whether a pending task was skipped, marking it done.
+func (c *RunController) takeSkip(taskNumber string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	actor, skipped := c.skippedBy[taskNumber]
+	if !skipped || c.tasks[taskNumber] != controlTaskPending {
+		return "", false
+	}
+	c.tasks[taskNumber] = controlTaskDone
+	return actor, true
+}
+
+// startTask marks a task running and returns a context that CancelTask can cancel.
+// The returned finish function must be called when the task ends.
+func (c *RunController) startTask(ctx context.Context, taskNumber string) (context.Context, func()) {
+	taskCtx, cancel := context.WithCancel(ctx)
+
+	c.mu.Lock()
+	c.tasks[taskNumber] = controlTaskRunning
+	c.cancels[taskNumber] = cancel
+	c.mu.Unlock()
+
+	return taskCtx, func() {
+		cancel()
+		c.mu.Lock()
+		delete(c.cancels, taskNumber)
+		c.tasks[taskNumber] = controlTaskDone
+		c.broadcastLocked()
+		c.mu.Unlock()
+	}
+}
+
+// cancelledActor returns who cancelled a task through run control, if anyone.
+func (c *RunController) cancelledActor(taskNumber string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	actor, cancelled := c.cancelledBy[taskNumber]
+	return actor, cancelled
+}
+
+// wake notifies waiting schedulers that a launch slot may have been freed.
+func (c *RunController) wake() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.broadcastLocked()
+}
+
+// broadcastLocked wakes every goroutine waiting on changed(). Caller holds c.mu.
+func (c *RunController) broadcastLocked() {
+	close(c.notify)
+	c.notify = make(chan struct{})
+}
+
+// logEvent reports a control change through the logger.
+func (c *RunController) logEvent(event models.ControlEvent) {
+	if event.Actor == "" {
+		event.Actor = "unknown"
+	}
+	event.Time = time.Now()
+	if c.logger != nil {
+		c.logger.LogControlEvent(event)
+	}
+}
+
+// newControlSkippedResult creates the synthetic result for a task skipped through run control.
+func newControlSkippedResult(task models.Task, actor string) models.TaskResult {
+	return models.TaskResult{
+		Task:   task,
+		Status: models.StatusGreen,
+		Output: fmt.Sprintf("Skipped by %s via run control", actor),
+	}
+}
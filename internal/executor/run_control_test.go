@@ -0,0 +1,295 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// controlEventRecorder records run control events.
+type controlEventRecorder struct {
+	*mockLogger
+	mu     sync.Mutex
+	events []models.ControlEvent
+}
+
+func (r *controlEventRecorder) LogControlEvent(event models.ControlEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+// blockingMockExecutor runs tasks until their context is cancelled or they are released.
+type blockingMockExecutor struct {
+	mu       sync.Mutex
+	started  []string
+	blocking map[string]bool
+	current  int
+	maxSeen  int
+}
+
+func (m *blockingMockExecutor) Execute(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	m.mu.Lock()
+	m.started = append(m.started, task.Number)
+	m.current++
+	if m.current > m.maxSeen {
+		m.maxSeen = m.current
+	}
+	blocks := m.blocking[task.Number]
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		m.current--
+		m.mu.Unlock()
+	}()
+
+	if blocks {
+		<-ctx.Done()
+		return models.TaskResult{Task: task, Status: models.StatusFailed, Error: ctx.Err()}, ctx.Err()
+	}
+	time.Sleep(20 * time.Millisecond)
+	return models.TaskResult{Task: task, Status: models.StatusGreen}, nil
+}
+
+func (m *blockingMockExecutor) startedTasks() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.started...)
+}
+
+// waitFor polls until cond is true or fails the test after a timeout.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func controlTestPlan() *models.Plan {
+	return &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1", Name: "First"},
+			{Number: "2", Name: "Second"},
+			{Number: "3", Name: "Third", DependsOn: []string{"2"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1", "2"}, MaxConcurrency: 3},
+			{Name: "Wave 2", TaskNumbers: []string{"3"}, MaxConcurrency: 3},
+		},
+	}
+}
+
+func TestRunController_Errors(t *testing.T) {
+	recorder := &controlEventRecorder{mockLogger: &mockLogger{}}
+	c := NewRunController(recorder)
+	c.Register([]string{"1", "2"})
+
+	if err := c.CancelTask("9", "alice"); err == nil || !strings.Contains(err.Error(), "not part of this run") {
+		t.Errorf("CancelTask(unknown) error = %v", err)
+	}
+	if err := c.CancelTask("1", "alice"); err == nil || !strings.Contains(err.Error(), "has not started") {
+		t.Errorf("CancelTask(pending) error = %v", err)
+	}
+	if err := c.SetMaxConcurrency(-1, "alice"); err == nil {
+		t.Error("expected error for negative concurrency")
+	}
+	if err := c.Resume("alice"); err == nil {
+		t.Error("expected error resuming a run that is not paused")
+	}
+	if err := c.Pause("alice"); err != nil {
+		t.Fatalf("Pause returned error: %v", err)
+	}
+	if err := c.Pause("alice"); err == nil {
+		t.Error("expected error pausing twice")
+	}
+
+	_, finish := c.startTask(context.Background(), "2")
+	if err := c.SkipTask("2", "bob"); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("SkipTask(running) error = %v", err)
+	}
+	finish()
+	if err := c.CancelTask("2", "bob"); err == nil || !strings.Contains(err.Error(), "already finished") {
+		t.Errorf("CancelTask(finished) error = %v", err)
+	}
+
+	status := c.Status()
+	if !status.Paused || len(status.Pending) != 1 || len(status.Done) != 1 {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	if len(recorder.events) != 1 || recorder.events[0].Action != models.ControlActionPause || recorder.events[0].Actor != "alice" {
+		t.Errorf("expected one pause event by alice, got %+v", recorder.events)
+	}
+}
+
+func TestWaveExecutor_RunControlPauseSkipResume(t *testing.T) {
+	for _, mode := range []config.SchedulerMode{config.SchedulerModeWave, config.SchedulerModeDependency} {
+		t.Run(string(mode), func(t *testing.T) {
+			mock := &blockingMockExecutor{}
+			recorder := &controlEventRecorder{mockLogger: &mockLogger{}}
+			controller := NewRunController(recorder)
+			if err := controller.Pause("alice"); err != nil {
+				t.Fatalf("Pause returned error: %v", err)
+			}
+
+			w := NewWaveExecutor(mock, nil)
+			w.SetSchedulerMode(mode)
+			w.SetRunController(controller)
+
+			type outcome struct {
+				results []models.TaskResult
+				err     error
+			}
+			done := make(chan outcome, 1)
+			go func() {
+				results, err := w.ExecutePlan(context.Background(), controlTestPlan())
+				done <- outcome{results, err}
+			}()
+
+			waitFor(t, "tasks to register", func() bool { return len(controller.Status().Pending) == 3 })
+			time.Sleep(30 * time.Millisecond)
+			if started := mock.startedTasks(); len(started) != 0 {
+				t.Fatalf("paused run launched tasks: %v", started)
+			}
+
+			if err := controller.SkipTask("2", "bob"); err != nil {
+				t.Fatalf("SkipTask returned error: %v", err)
+			}
+			if err := controller.Resume("alice"); err != nil {
+				t.Fatalf("Resume returned error: %v", err)
+			}
+
+			var out outcome
+			select {
+			case out = <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("run did not finish after resume")
+			}
+			if out.err != nil {
+				t.Fatalf("ExecutePlan returned error: %v", out.err)
+			}
+			if len(out.results) != 3 {
+				t.Fatalf("expected 3 results, got %d", len(out.results))
+			}
+			for _, result := range out.results {
+				if result.Status != models.StatusGreen {
+					t.Errorf("task %s status = %s, want GREEN", result.Task.Number, result.Status)
+				}
+				if result.Task.Number == "2" && !strings.Contains(result.Output, "Skipped by bob") {
+					t.Errorf("task 2 output = %q, want control skip", result.Output)
+				}
+			}
+			for _, taskNum := range mock.startedTasks() {
+				if taskNum == "2" {
+					t.Error("skipped task 2 was executed")
+				}
+			}
+			if len(recorder.events) != 3 {
+				t.Errorf("expected pause, skip and resume events, got %+v", recorder.events)
+			}
+		})
+	}
+}
+
+func TestWaveExecutor_RunControlCancelTask(t *testing.T) {
+	for _, mode := range []config.SchedulerMode{config.SchedulerModeWave, config.SchedulerModeDependency} {
+		t.Run(string(mode), func(t *testing.T) {
+			mock := &blockingMockExecutor{blocking: map[string]bool{"1": true}}
+			controller := NewRunController(nil)
+
+			w := NewWaveExecutor(mock, nil)
+			w.SetSchedulerMode(mode)
+			w.SetKeepGoing(true)
+			w.SetRunController(controller)
+
+			done := make(chan []models.TaskResult, 1)
+			errCh := make(chan error, 1)
+			go func() {
+				results, err := w.ExecutePlan(context.Background(), controlTestPlan())
+				done <- results
+				errCh <- err
+			}()
+
+			waitFor(t, "task 1 to start", func() bool {
+				for _, taskNum := range controller.Status().Running {
+					if taskNum == "1" {
+						return true
+					}
+				}
+				return false
+			})
+			if err := controller.CancelTask("1", "carol"); err != nil {
+				t.Fatalf("CancelTask returned error: %v", err)
+			}
+
+			var results []models.TaskResult
+			select {
+			case results = <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("run did not finish after cancel")
+			}
+			if err := <-errCh; err != nil {
+				t.Fatalf("cancelling one task must not fail the run: %v", err)
+			}
+
+			statuses := make(map[string]models.TaskResult)
+			for _, result := range results {
+				statuses[result.Task.Number] = result
+			}
+			cancelled := statuses["1"]
+			if cancelled.Status != models.StatusFailed || !errors.Is(cancelled.Error, ErrTaskCancelled) {
+				t.Errorf("task 1 = %s (%v), want FAILED with ErrTaskCancelled", cancelled.Status, cancelled.Error)
+			}
+			if !strings.Contains(cancelled.Error.Error(), "carol") {
+				t.Errorf("cancel error should name the actor, got %v", cancelled.Error)
+			}
+			for _, taskNum := range []string{"2", "3"} {
+				if statuses[taskNum].Status != models.StatusGreen {
+					t.Errorf("task %s status = %s, want GREEN", taskNum, statuses[taskNum].Status)
+				}
+			}
+		})
+	}
+}
+
+func TestWaveExecutor_RunControlConcurrencyOverride(t *testing.T) {
+	for _, mode := range []config.SchedulerMode{config.SchedulerModeWave, config.SchedulerModeDependency} {
+		t.Run(string(mode), func(t *testing.T) {
+			plan := &models.Plan{
+				Tasks: []models.Task{{Number: "1"}, {Number: "2"}, {Number: "3"}, {Number: "4"}},
+				Waves: []models.Wave{{Name: "Wave 1", TaskNumbers: []string{"1", "2", "3", "4"}, MaxConcurrency: 4}},
+			}
+			mock := &blockingMockExecutor{}
+			controller := NewRunController(nil)
+			if err := controller.SetMaxConcurrency(1, "dave"); err != nil {
+				t.Fatalf("SetMaxConcurrency returned error: %v", err)
+			}
+
+			w := NewWaveExecutor(mock, nil)
+			w.SetSchedulerMode(mode)
+			w.SetRunController(controller)
+
+			results, err := w.ExecutePlan(context.Background(), plan)
+			if err != nil {
+				t.Fatalf("ExecutePlan returned error: %v", err)
+			}
+			if len(results) != 4 {
+				t.Fatalf("expected 4 results, got %d", len(results))
+			}
+			if mock.maxSeen != 1 {
+				t.Errorf("max concurrent tasks = %d, want 1 (override)", mock.maxSeen)
+			}
+		})
+	}
+}
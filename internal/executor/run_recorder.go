@@ -0,0 +1,165 @@
+package executor
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// taskRun is what a task did in this run, for the approval gates and `when:`
+// expressions downstream of it (v3.6+).
+type taskRun struct {
+	order     int    // Start order, to find the earliest base commit
+	base      string // HEAD when the task started
+	untracked map[string]bool
+	finished  bool
+	status    string
+	verdict   string // Last QC verdict
+	agent     string
+	feedback  string
+	files     []string // Files changed while the task ran, relative to the work dir
+}
+
+// runRecorder remembers the tasks of this run as they start and finish.
+// With trackFiles it also lists the files each task changed.
+type runRecorder struct {
+	trackFiles bool
+
+	mu      sync.Mutex
+	runs    map[string]*taskRun
+	started int
+}
+
+// start remembers HEAD, and with trackFiles the untracked files, before a task runs.
+func (r *runRecorder) start(ctx context.Context, task models.Task, workDir string) {
+	runner := NewShellCommandRunner(workDir)
+	base, err := runner.Run(ctx, "git rev-parse HEAD")
+	if err != nil {
+		base = ""
+	}
+	run := &taskRun{base: strings.TrimSpace(base)}
+	if r.trackFiles && run.base != "" {
+		run.untracked = make(map[string]bool)
+		for _, path := range untrackedFiles(ctx, runner) {
+			run.untracked[path] = true
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.runs == nil {
+		r.runs = make(map[string]*taskRun)
+	}
+	r.started++
+	run.order = r.started
+	r.runs[task.Number] = run
+}
+
+// finish remembers a task's outcome. A task that never started, such as one
+// skipped by its `when:` expression, is recorded with its outcome only.
+func (r *runRecorder) finish(ctx context.Context, result models.TaskResult, workDir string) {
+	r.mu.Lock()
+	run, ok := r.runs[result.Task.Number]
+	var base string
+	var untracked map[string]bool
+	if ok {
+		base, untracked = run.base, run.untracked
+	}
+	r.mu.Unlock()
+
+	var files []string
+	if r.trackFiles && base != "" {
+		files = ownChangedFiles(result.Task, changedFiles(ctx, NewShellCommandRunner(workDir), base, untracked))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.runs == nil {
+		r.runs = make(map[string]*taskRun)
+	}
+	if !ok {
+		run = &taskRun{}
+		r.runs[result.Task.Number] = run
+	}
+	run.finished = true
+	run.status = result.Status
+	run.agent = result.Task.Agent
+	run.feedback = result.ReviewFeedback
+	if run.feedback == "" && result.Error != nil {
+		run.feedback = result.Error.Error()
+	}
+	for _, attempt := range result.ExecutionHistory {
+		if attempt.Verdict != "" {
+			run.verdict = attempt.Verdict
+		}
+	}
+	run.files = files
+}
+
+// get returns a copy of what task did in this run.
+func (r *runRecorder) get(number string) (taskRun, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.runs[number]
+	if !ok {
+		return taskRun{}, false
+	}
+	return *run, true
+}
+
+// changedFiles lists the files changed since base, in commits or in the work
+// tree, plus the untracked files that were not there when the task started.
+func changedFiles(ctx context.Context, runner CommandRunner, base string, untrackedBefore map[string]bool) []string {
+	var files []string
+	if output, err := runner.Run(ctx, "git diff --name-only --relative "+shellQuote(base)); err == nil {
+		for _, path := range strings.Split(strings.TrimSpace(output), "\n") {
+			if path != "" {
+				files = append(files, path)
+			}
+		}
+	}
+	for _, path := range untrackedFiles(ctx, runner) {
+		if !untrackedBefore[path] {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// ownChangedFiles keeps the changed files task declared. Tasks running in
+// parallel share the work tree, so the rest may be theirs. A task with a
+// worktree of its own, or without declared files, keeps them all.
+func ownChangedFiles(task models.Task, changed []string) []string {
+	if len(task.Files) == 0 || ownsWorktree(task) {
+		return changed
+	}
+	declared := make(map[string]bool, len(task.Files))
+	for _, file := range task.Files {
+		declared[filepath.Clean(file)] = true
+	}
+	var files []string
+	for _, path := range changed {
+		if declared[filepath.Clean(path)] {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// untrackedFiles lists the files git does not track and does not ignore.
+func untrackedFiles(ctx context.Context, runner CommandRunner) []string {
+	output, err := runner.Run(ctx, "git ls-files --others --exclude-standard")
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, path := range strings.Split(strings.TrimSpace(output), "\n") {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	return files
+}
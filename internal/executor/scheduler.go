@@ -0,0 +1,457 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// dependencyScheduler holds the bookkeeping for dependency-driven execution (v3.6+).
+// Instead of waiting at wave boundaries, a task becomes ready as soon as every
+// prerequisite in the DependencyGraph finished GREEN/YELLOW or was SKIPPED by its
+// when: expression. Waves are still used to decide which tasks are scheduled,
+// their relative launch order and for wave-level logging.
+type dependencyScheduler struct {
+	order     []string            // scheduled tasks in wave order (launch priority)
+	waveIndex map[string]int      // task number -> index into plan.Waves
+	remaining map[string]int      // task number -> unfinished prerequisites
+	edges     map[string][]string // prerequisite -> dependents (from DependencyGraph)
+	ready     []string            // tasks whose prerequisites are satisfied, in launch order
+	running   map[string][]string // running task number -> normalized files
+	results   map[string]models.TaskResult
+	graph     *DependencyGraph
+}
+
+// newDependencyScheduler builds scheduler state for the tasks referenced by the plan's waves.
+// Prerequisites outside the scheduled set (e.g. filtered out by --task, or satisfied by
+// another plan file) are treated as already satisfied, matching wave mode semantics.
+func newDependencyScheduler(plan *models.Plan) *dependencyScheduler {
+	s := &dependencyScheduler{
+		waveIndex: make(map[string]int),
+		remaining: make(map[string]int),
+		edges:     make(map[string][]string),
+		running:   make(map[string][]string),
+		results:   make(map[string]models.TaskResult),
+	}
+
+	for i, wave := range plan.Waves {
+		for _, taskNum := range wave.TaskNumbers {
+			if _, seen := s.waveIndex[taskNum]; seen {
+				continue
+			}
+			s.waveIndex[taskNum] = i
+			s.order = append(s.order, taskNum)
+			s.remaining[taskNum] = 0
+		}
+	}
+
+	graph := BuildDependencyGraph(plan.Tasks)
+	s.graph = graph
+	for prereq, dependents := range graph.Edges {
+		if _, scheduled := s.waveIndex[prereq]; !scheduled {
+			continue
+		}
+		for _, dependent := range dependents {
+			if _, scheduled := s.waveIndex[dependent]; !scheduled {
+				continue
+			}
+			s.edges[prereq] = append(s.edges[prereq], dependent)
+			s.remaining[dependent]++
+		}
+	}
+
+	return s
+}
+
+// markDone records a finished task and, when it succeeded or was skipped by
+// its when: expression, returns the dependents whose prerequisites are now all satisfied.
+func (s *dependencyScheduler) markDone(taskNum string, result models.TaskResult) []string {
+	s.results[taskNum] = result
+	delete(s.running, taskNum)
+
+	if result.Status != models.StatusGreen && result.Status != models.StatusYellow && result.Status != models.StatusSkipped {
+		return nil
+	}
+	var released []string
+	for _, dependent := range s.edges[taskNum] {
+		s.remaining[dependent]--
+		if s.remaining[dependent] == 0 {
+			released = append(released, dependent)
+		}
+	}
+	return released
+}
+
+// sortReady keeps the ready queue in the original wave/launch order so
+// earlier waves keep priority when concurrency slots are scarce.
+func (s *dependencyScheduler) sortReady() {
+	position := make(map[string]int, len(s.order))
+	for i, taskNum := range s.order {
+		position[taskNum] = i
+	}
+	for i := 1; i < len(s.ready); i++ {
+		for j := i; j > 0 && position[s.ready[j]] < position[s.ready[j-1]]; j-- {
+			s.ready[j], s.ready[j-1] = s.ready[j-1], s.ready[j]
+		}
+	}
+}
+
+// overlapsRunning reports whether any of the files is being modified by a running task.
+func (s *dependencyScheduler) overlapsRunning(files []string) bool {
+	if len(files) == 0 {
+		return false
+	}
+	for _, runningFiles := range s.running {
+		for _, f := range runningFiles {
+			for _, candidate := range files {
+				if f == candidate {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// normalizeTaskFiles returns the cleaned file paths declared by a task.
+func normalizeTaskFiles(task models.Task) []string {
+	files := make([]string, 0, len(task.Files))
+	for _, f := range task.Files {
+		files = append(files, filepath.Clean(f))
+	}
+	return files
+}
+
+// controlSkip reports whether run control skipped a task, and by whom.
+func (w *WaveExecutor) controlSkip(taskNum string) (string, bool) {
+	if w.control == nil {
+		return "", false
+	}
+	return w.control.takeSkip(taskNum)
+}
+
+// dependencyConcurrencyLimit derives the global concurrency limit for dependency mode.
+// Waves carry max_concurrency (from config or DefaultMaxConcurrency), so the largest
+// wave limit is used. Zero means unlimited, as in wave mode.
+func dependencyConcurrencyLimit(plan *models.Plan, taskCount int) int {
+	limit := 0
+	for _, wave := range plan.Waves {
+		if wave.MaxConcurrency > limit {
+			limit = wave.MaxConcurrency
+		}
+	}
+	if limit <= 0 || limit > taskCount {
+		limit = taskCount
+	}
+	if limit == 0 {
+		limit = 1
+	}
+	return limit
+}
+
+// executeDependencyDriven runs the plan without wave barriers (scheduler: dependency).
+// A task launches once its prerequisites are GREEN/YELLOW/SKIPPED, a concurrency slot is free,
+// none of its files are being modified by a running task, and its Go packages can be
+// locked by the PackageGuard. Tasks whose prerequisites failed are never launched and
+// are reported as BLOCKED.
+// As in wave mode, the first execution error stops new launches while running tasks finish,
+// unless keep-going is enabled, in which case only the failed task's dependents are blocked.
+func (w *WaveExecutor) executeDependencyDriven(ctx context.Context, plan *models.Plan, taskMap map[string]models.Task) ([]models.TaskResult, error) {
+	sched := newDependencyScheduler(plan)
+
+	// Validate every scheduled task exists before launching anything
+	for _, taskNum := range sched.order {
+		if _, ok := taskMap[taskNum]; !ok {
+			wave := plan.Waves[sched.waveIndex[taskNum]]
+			return nil, NewTaskError(taskNum, "task not found in "+wave.Name, nil)
+		}
+	}
+
+	// Wave bookkeeping for logging and anomaly detection
+	waveStarted := make([]bool, len(plan.Waves))
+	waveStartTime := make([]time.Time, len(plan.Waves))
+	waveOutstanding := make([]int, len(plan.Waves))
+	waveMonitors := make([]*AnomalyMonitor, len(plan.Waves))
+	for _, taskNum := range sched.order {
+		waveOutstanding[sched.waveIndex[taskNum]]++
+	}
+
+	waveResults := func(idx int) []models.TaskResult {
+		var results []models.TaskResult
+		for _, taskNum := range plan.Waves[idx].TaskNumbers {
+			if result, ok := sched.results[taskNum]; ok {
+				results = append(results, result)
+			}
+		}
+		return results
+	}
+
+	orderedResults := func() []models.TaskResult {
+		results := make([]models.TaskResult, 0, len(sched.results))
+		for _, taskNum := range sched.order {
+			if result, ok := sched.results[taskNum]; ok {
+				results = append(results, result)
+			}
+		}
+		return results
+	}
+
+	finishWaveTask := func(taskNum string) {
+		idx := sched.waveIndex[taskNum]
+		waveOutstanding[idx]--
+		if waveOutstanding[idx] == 0 && waveStarted[idx] && w.logger != nil {
+			w.logger.LogWaveComplete(plan.Waves[idx], time.Since(waveStartTime[idx]), waveResults(idx))
+		}
+	}
+
+	// resolveSkips completes skippable ready tasks with a synthetic GREEN result:
+	// completed tasks with --skip-completed, and tasks skipped through run control.
+	// Completing a task can release dependents that are skippable too, so repeat until stable.
+	resolveSkips := func() {
+		if !w.skipCompleted && w.control == nil {
+			return
+		}
+		for {
+			var launchable []string
+			skipped := false
+			for _, taskNum := range sched.ready {
+				task := taskMap[taskNum]
+				var skippedResult models.TaskResult
+				if w.skipCompleted && task.CanSkip() {
+					skippedResult = models.TaskResult{
+						Task:   task,
+						Status: models.StatusGreen,
+						Output: "Skipped",
+					}
+				} else if actor, ok := w.controlSkip(taskNum); ok {
+					skippedResult = newControlSkippedResult(task, actor)
+				} else {
+					launchable = append(launchable, taskNum)
+					continue
+				}
+				if w.logger != nil {
+					if logErr := w.logger.LogTaskResult(skippedResult); logErr != nil {
+						// Log error but don't fail execution
+					}
+				}
+				launchable = append(launchable, sched.markDone(taskNum, skippedResult)...)
+				finishWaveTask(taskNum)
+				skipped = true
+			}
+			sched.ready = launchable
+			sched.sortReady()
+			if !skipped {
+				return
+			}
+		}
+	}
+
+	for _, taskNum := range sched.order {
+		if sched.remaining[taskNum] == 0 {
+			sched.ready = append(sched.ready, taskNum)
+		}
+	}
+	resolveSkips()
+
+	// satisfied reports whether a task an accepted subtask depends on can no longer hold it back
+	satisfied := func(taskNum string) bool {
+		if _, scheduled := sched.waveIndex[taskNum]; !scheduled {
+			return true
+		}
+		result, done := sched.results[taskNum]
+		return done && (result.Status == models.StatusGreen || result.Status == models.StatusYellow || result.Status == models.StatusSkipped)
+	}
+
+	limit := dependencyConcurrencyLimit(plan, len(sched.order))
+	resultsCh := make(chan taskExecutionResult, len(sched.order))
+
+	var execErr error
+	var launchErr error
+	stopping := false
+
+	for {
+		// Run control changes (resume, skip, concurrency, finished tasks) wake the loop.
+		// The channel is taken before evaluating launches so no change is missed.
+		var controlChanged <-chan struct{}
+		if w.control != nil {
+			controlChanged = w.control.changed()
+			resolveSkips()
+		}
+
+		// Launch as many ready tasks as the constraints allow
+		if !stopping {
+			if err := ctx.Err(); err != nil {
+				launchErr = err
+				stopping = true
+			}
+		}
+		paused := w.control != nil && w.control.isPaused()
+		if !stopping && !paused {
+			launchLimit := limit
+			if w.control != nil {
+				launchLimit = w.control.limit(limit)
+			}
+			var deferred []string
+			for _, taskNum := range sched.ready {
+				task := taskMap[taskNum]
+				files := normalizeTaskFiles(task)
+
+				if (launchLimit > 0 && len(sched.running) >= launchLimit) || sched.overlapsRunning(files) {
+					deferred = append(deferred, taskNum)
+					continue
+				}
+
+				// Acquire package locks without blocking a concurrency slot (v2.9+)
+				var releasePackages func()
+				if w.enforcePackageGuard && w.packageGuard != nil {
+					packages := GetTaskPackages(task)
+					if len(packages) > 0 {
+						ok, release := w.packageGuard.TryAcquire(task.Number, packages)
+						if !ok {
+							deferred = append(deferred, taskNum)
+							continue
+						}
+						releasePackages = release
+					}
+				}
+
+				idx := sched.waveIndex[taskNum]
+				if !waveStarted[idx] {
+					waveStarted[idx] = true
+					waveStartTime[idx] = time.Now()
+					if w.logger != nil {
+						w.logger.LogWaveStart(plan.Waves[idx])
+					}
+					if w.anomalyConfig != nil && w.anomalyConfig.ConsecutiveFailureThreshold > 0 {
+						waveMonitors[idx] = NewAnomalyMonitorWithConfig(plan.Waves[idx].Name, *w.anomalyConfig)
+					}
+				}
+
+				sched.running[taskNum] = files
+				go func(task models.Task, release func()) {
+					result, err := w.runTask(ctx, task)
+					if release != nil {
+						release()
+					}
+					resultsCh <- taskExecutionResult{taskNumber: task.Number, result: result, err: err}
+				}(task, releasePackages)
+			}
+			sched.ready = deferred
+		}
+
+		if len(sched.running) == 0 {
+			if paused && !stopping && len(sched.ready) > 0 {
+				// Paused with work left: wait for resume, skip or cancellation
+				select {
+				case <-controlChanged:
+				case <-ctx.Done():
+				}
+				continue
+			}
+			// Nothing running: either everything finished, we are stopping,
+			// or the remaining tasks are blocked by failed prerequisites.
+			break
+		}
+
+		var executionResult taskExecutionResult
+		select {
+		case executionResult = <-resultsCh:
+		case <-controlChanged:
+			continue
+		}
+		if executionResult.result.Task.Number == "" {
+			executionResult.result.Task = taskMap[executionResult.taskNumber]
+		}
+		// Schedule accepted subtasks before releasing the dependents they hold back (v3.6+)
+		if !stopping {
+			if ins := w.acceptSubtasks(plan, taskMap, executionResult.result, satisfied); ins != nil {
+				sched.insertSubtasks(ins)
+				idx := sched.waveIndex[ins.parent]
+				waveOutstanding[idx] += len(ins.subtasks)
+				w.updatePlan(func() {
+					waves := append([]models.Wave(nil), plan.Waves...)
+					waves[idx].TaskNumbers = append(append([]string(nil), waves[idx].TaskNumbers...), ins.numbers()...)
+					plan.Waves = waves
+				})
+			}
+		}
+		released := sched.markDone(executionResult.taskNumber, executionResult.result)
+		if executionResult.err != nil {
+			if !w.keepGoing {
+				if execErr == nil {
+					execErr = executionResult.err
+				}
+				// Stop launching new tasks once an error is encountered
+				stopping = true
+			} else if isFatalExecutionError(executionResult.err) {
+				if execErr == nil {
+					execErr = executionResult.err
+				}
+				stopping = true
+			}
+		}
+
+		if w.logger != nil {
+			if logErr := w.logger.LogTaskResult(executionResult.result); logErr != nil {
+				// Log error but don't fail execution
+			}
+			w.logger.LogProgress(orderedResults())
+		}
+
+		// ========== REAL-TIME ANOMALY DETECTION ==========
+		if monitor := waveMonitors[sched.waveIndex[executionResult.taskNumber]]; monitor != nil {
+			for _, anomaly := range monitor.RecordResult(executionResult.result) {
+				if w.logger != nil {
+					w.logger.LogAnomaly(anomaly)
+				}
+			}
+		}
+		// ========== END ANOMALY DETECTION ==========
+
+		finishWaveTask(executionResult.taskNumber)
+
+		// Report the failed task's downstream subgraph as blocked, so every
+		// scheduled task has a result whether or not keep-going is enabled
+		if executionResult.result.Status == models.StatusRed || executionResult.result.Status == models.StatusFailed {
+			dependents := sched.graph.TransitiveDependents(executionResult.taskNumber)
+			for _, taskNum := range sched.order {
+				if _, done := sched.results[taskNum]; done || !dependents[taskNum] {
+					continue
+				}
+				blockedResult := newBlockedResult(taskMap[taskNum], executionResult.taskNumber)
+				sched.markDone(taskNum, blockedResult)
+				if w.logger != nil {
+					if logErr := w.logger.LogTaskResult(blockedResult); logErr != nil {
+						// Log error but don't fail execution
+					}
+				}
+				finishWaveTask(taskNum)
+			}
+		}
+
+		sched.ready = append(sched.ready, released...)
+		sched.sortReady()
+		resolveSkips()
+	}
+
+	// Close out waves that started but could not finish (stopped or blocked tasks)
+	for idx, started := range waveStarted {
+		if started && waveOutstanding[idx] > 0 && w.logger != nil {
+			w.logger.LogWaveComplete(plan.Waves[idx], time.Since(waveStartTime[idx]), waveResults(idx))
+		}
+	}
+
+	if launchErr != nil {
+		if execErr == nil {
+			execErr = launchErr
+		}
+	} else if execErr != nil && errors.Is(execErr, context.Canceled) {
+		// Propagate context cancellation explicitly
+		execErr = context.Canceled
+	}
+
+	return orderedResults(), execErr
+}
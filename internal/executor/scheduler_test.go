@@ -0,0 +1,355 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// timelineMockExecutor records start/end times per task and supports
+// per-task durations, statuses and errors.
+type timelineMockExecutor struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+	statuses  map[string]string
+	errs      map[string]error
+	starts    map[string]time.Time
+	ends      map[string]time.Time
+	current   int
+	maxSeen   int
+}
+
+func newTimelineMockExecutor() *timelineMockExecutor {
+	return &timelineMockExecutor{
+		durations: make(map[string]time.Duration),
+		statuses:  make(map[string]string),
+		errs:      make(map[string]error),
+		starts:    make(map[string]time.Time),
+		ends:      make(map[string]time.Time),
+	}
+}
+
+func (m *timelineMockExecutor) Execute(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	m.mu.Lock()
+	m.starts[task.Number] = time.Now()
+	m.current++
+	if m.current > m.maxSeen {
+		m.maxSeen = m.current
+	}
+	duration := m.durations[task.Number]
+	m.mu.Unlock()
+
+	if duration == 0 {
+		duration = 10 * time.Millisecond
+	}
+	time.Sleep(duration)
+
+	m.mu.Lock()
+	m.current--
+	m.ends[task.Number] = time.Now()
+	status := m.statuses[task.Number]
+	err := m.errs[task.Number]
+	m.mu.Unlock()
+
+	if status == "" {
+		status = models.StatusGreen
+	}
+	return models.TaskResult{Task: task, Status: status}, err
+}
+
+func (m *timelineMockExecutor) executed(taskNum string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.starts[taskNum]
+	return ok
+}
+
+func (m *timelineMockExecutor) overlapped(a, b string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.starts[a].Before(m.ends[b]) && m.starts[b].Before(m.ends[a])
+}
+
+func newDependencyWaveExecutor(te TaskExecutor) *WaveExecutor {
+	w := NewWaveExecutor(te, nil)
+	w.SetSchedulerMode(config.SchedulerModeDependency)
+	return w
+}
+
+func TestDependencyScheduler_StartsTaskBeforeWaveBarrier(t *testing.T) {
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1", Name: "Slow"},
+			{Number: "2", Name: "Fast"},
+			{Number: "3", Name: "Depends on fast", DependsOn: []string{"2"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1", "2"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"3"}, MaxConcurrency: 10},
+		},
+	}
+
+	mock := newTimelineMockExecutor()
+	mock.durations["1"] = 200 * time.Millisecond
+
+	results, err := newDependencyWaveExecutor(mock).ExecutePlan(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	if !mock.starts["3"].Before(mock.ends["1"]) {
+		t.Errorf("task 3 should start before slow task 1 finishes (no wave barrier)")
+	}
+	if mock.starts["3"].Before(mock.ends["2"]) {
+		t.Errorf("task 3 started before its dependency task 2 finished")
+	}
+
+	// Results are returned in wave order
+	expected := []string{"1", "2", "3"}
+	for i, result := range results {
+		if result.Task.Number != expected[i] {
+			t.Errorf("results[%d] = task %s, want %s", i, result.Task.Number, expected[i])
+		}
+	}
+}
+
+func TestDependencyScheduler_RespectsMaxConcurrency(t *testing.T) {
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1"}, {Number: "2"}, {Number: "3"}, {Number: "4"}, {Number: "5"},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1", "2", "3", "4", "5"}, MaxConcurrency: 2},
+		},
+	}
+
+	mock := newTimelineMockExecutor()
+	for _, task := range plan.Tasks {
+		mock.durations[task.Number] = 30 * time.Millisecond
+	}
+
+	results, err := newDependencyWaveExecutor(mock).ExecutePlan(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+	if mock.maxSeen > 2 {
+		t.Errorf("max concurrent tasks = %d, want <= 2", mock.maxSeen)
+	}
+}
+
+func TestDependencyScheduler_SerializesFileOverlaps(t *testing.T) {
+	// Tasks 1 and 3 share a file but sit in different waves without a direct
+	// dependency, so dependency mode could otherwise run them concurrently.
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1", Files: []string{"docs/shared.md"}},
+			{Number: "2", Files: []string{"docs/other.md"}},
+			{Number: "3", Files: []string{"./docs/shared.md"}, DependsOn: []string{"2"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1", "2"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"3"}, MaxConcurrency: 10},
+		},
+	}
+
+	mock := newTimelineMockExecutor()
+	mock.durations["1"] = 100 * time.Millisecond
+
+	if _, err := newDependencyWaveExecutor(mock).ExecutePlan(context.Background(), plan); err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+	if mock.overlapped("1", "3") {
+		t.Errorf("tasks 1 and 3 modify the same file and must not run concurrently")
+	}
+}
+
+func TestDependencyScheduler_RespectsPackageGuard(t *testing.T) {
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1", Files: []string{"internal/foo/a.go"}},
+			{Number: "2", Files: []string{"internal/bar/b.go"}},
+			{Number: "3", Files: []string{"internal/foo/c.go"}, DependsOn: []string{"2"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1", "2"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"3"}, MaxConcurrency: 10},
+		},
+	}
+
+	mock := newTimelineMockExecutor()
+	mock.durations["1"] = 100 * time.Millisecond
+
+	w := NewWaveExecutorWithPackageGuard(mock, nil, false, false, true)
+	w.SetSchedulerMode(config.SchedulerModeDependency)
+
+	if _, err := w.ExecutePlan(context.Background(), plan); err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+	if mock.overlapped("1", "3") {
+		t.Errorf("tasks 1 and 3 share a Go package and must not run concurrently")
+	}
+}
+
+func TestDependencyScheduler_DoesNotLaunchDependentsOfRedTask(t *testing.T) {
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1"},
+			{Number: "2", DependsOn: []string{"1"}},
+			{Number: "3"},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1", "3"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"2"}, MaxConcurrency: 10},
+		},
+	}
+
+	mock := newTimelineMockExecutor()
+	mock.statuses["1"] = models.StatusRed
+
+	results, err := newDependencyWaveExecutor(mock).ExecutePlan(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+	if mock.executed("2") {
+		t.Errorf("task 2 should not run when its dependency is RED")
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[2].Task.Number != "2" || results[2].Status != models.StatusBlocked {
+		t.Errorf("dependent of a RED task should be reported as BLOCKED, got %+v", results[2])
+	}
+}
+
+func TestDependencyScheduler_LaunchesDependentsOfSkippedTask(t *testing.T) {
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1", When: `env("REGENERATE") == "true"`},
+			{Number: "2", DependsOn: []string{"1"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"2"}, MaxConcurrency: 10},
+		},
+	}
+
+	mock := newTimelineMockExecutor()
+	mock.statuses["1"] = models.StatusSkipped
+
+	results, err := newDependencyWaveExecutor(mock).ExecutePlan(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+	if !mock.executed("2") {
+		t.Errorf("task 2 should run when its dependency was skipped by its when: expression")
+	}
+	if len(results) != 2 || results[0].Status != models.StatusSkipped {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestDependencyScheduler_StopsLaunchingAfterError(t *testing.T) {
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1"},
+			{Number: "2"},
+			{Number: "3", DependsOn: []string{"2"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1", "2"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"3"}, MaxConcurrency: 10},
+		},
+	}
+
+	mock := newTimelineMockExecutor()
+	mock.statuses["1"] = models.StatusFailed
+	mock.errs["1"] = errors.New("boom")
+	mock.durations["2"] = 50 * time.Millisecond
+
+	results, err := newDependencyWaveExecutor(mock).ExecutePlan(context.Background(), plan)
+	if err == nil {
+		t.Fatal("expected error from failing task")
+	}
+	if mock.executed("3") {
+		t.Errorf("task 3 should not launch after an execution error")
+	}
+	// Running task 2 is allowed to finish
+	if len(results) != 2 {
+		t.Errorf("expected 2 results, got %d", len(results))
+	}
+}
+
+func TestDependencyScheduler_SkipsCompletedTasks(t *testing.T) {
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1", Status: "completed"},
+			{Number: "2", DependsOn: []string{"1"}, Status: "completed"},
+			{Number: "3", DependsOn: []string{"2"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"2"}, MaxConcurrency: 10},
+			{Name: "Wave 3", TaskNumbers: []string{"3"}, MaxConcurrency: 10},
+		},
+	}
+
+	mock := newTimelineMockExecutor()
+	w := NewWaveExecutorWithConfig(mock, nil, true, false)
+	w.SetSchedulerMode(config.SchedulerModeDependency)
+
+	results, err := w.ExecutePlan(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if mock.executed("1") || mock.executed("2") {
+		t.Errorf("completed tasks should be skipped")
+	}
+	if !mock.executed("3") {
+		t.Errorf("task 3 should run once skipped dependencies are satisfied")
+	}
+	if results[0].Output != "Skipped" || results[1].Output != "Skipped" {
+		t.Errorf("expected skipped results for tasks 1 and 2")
+	}
+}
+
+func TestDependencyScheduler_LogsWaveStartAndComplete(t *testing.T) {
+	plan := &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1"},
+			{Number: "2", DependsOn: []string{"1"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"2"}, MaxConcurrency: 10},
+		},
+	}
+
+	logger := &mockLogger{}
+	w := NewWaveExecutor(newTimelineMockExecutor(), logger)
+	w.SetSchedulerMode(config.SchedulerModeDependency)
+
+	if _, err := w.ExecutePlan(context.Background(), plan); err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+
+	if len(logger.waveStartCalls) != 2 {
+		t.Errorf("expected 2 wave start logs, got %d", len(logger.waveStartCalls))
+	}
+	if len(logger.waveCompleteCalls) != 2 {
+		t.Errorf("expected 2 wave complete logs, got %d", len(logger.waveCompleteCalls))
+	}
+}
@@ -32,6 +32,7 @@ type SetupResult struct {
 type SetupIntrospector struct {
 	inv    *claude.Invoker     // Invoker handles CLI invocation and rate limit retry
 	Logger budget.WaiterLogger // For TTS + visual during rate limit wait (passed to Invoker)
+	Model  string              // Model for introspection calls (v3.6+, empty = CLI default)
 }
 
 // NewSetupIntrospector creates a setup introspector with the specified timeout.
@@ -111,6 +112,7 @@ func (si *SetupIntrospector) Introspect(ctx context.Context) (*SetupResult, erro
 	req := claude.Request{
 		Prompt: prompt,
 		Schema: SetupSchema(),
+		Model:  si.Model,
 	}
 
 	// Invoke Claude CLI (rate limit handling is in Invoker)
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// Spend cap scopes (v3.6+)
+const (
+	SpendScopeRun  = "run"  // budget.max_run_usd
+	SpendScopeTask = "task" // budget.max_task_usd
+)
+
+// ErrSpendCapExceeded indicates a run or task reached its configured spend cap (v3.6+).
+// Run caps are fatal: the run stops and its state is saved for resume.
+// Task caps stop further retries of that task only.
+type ErrSpendCapExceeded struct {
+	Scope      string  // SpendScopeRun or SpendScopeTask
+	TaskNumber string  // Task that was about to be invoked
+	SpentUSD   float64 // Cost so far within the scope
+	LimitUSD   float64 // Configured cap
+	StateID    string  // Saved execution state (run caps only, empty if not saved)
+}
+
+func (e *ErrSpendCapExceeded) Error() string {
+	if e.Scope == SpendScopeTask {
+		return fmt.Sprintf("task %s reached its spend cap: $%.4f spent of $%.2f max_task_usd", e.TaskNumber, e.SpentUSD, e.LimitUSD)
+	}
+	msg := fmt.Sprintf("run reached its spend cap: $%.4f spent of $%.2f max_run_usd", e.SpentUSD, e.LimitUSD)
+	if e.StateID != "" {
+		msg += fmt.Sprintf(" (state saved: %s)", e.StateID)
+	}
+	return msg
+}
+
+// UsageSource reports the accumulated usage of invocations made outside the
+// task executor, such as the helper Claude calls of a claude.Invoker (v3.6+).
+type UsageSource interface {
+	Usage() models.Usage
+}
+
+// SpendTracker accumulates the usage of every agent and QC invocation in a run
+// and enforces the run and task spend caps (v3.6+).
+// A zero cap means no limit. Safe for concurrent use by parallel tasks.
+type SpendTracker struct {
+	MaxRunUSD  float64
+	MaxTaskUSD float64
+
+	// Helpers adds helper invocations (agent selection, swaps, setup) to the
+	// run total, so they count towards the run cap (optional)
+	Helpers UsageSource
+
+	mu      sync.Mutex
+	total   models.Usage
+	paused  bool   // Run cap reached and state handled
+	stateID string // Saved execution state for the run cap
+}
+
+// NewSpendTracker creates a SpendTracker with the given caps.
+func NewSpendTracker(maxRunUSD, maxTaskUSD float64) *SpendTracker {
+	return &SpendTracker{MaxRunUSD: maxRunUSD, MaxTaskUSD: maxTaskUSD}
+}
+
+// Record adds invocation usage to the run total.
+func (s *SpendTracker) Record(usage models.Usage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total.Add(usage)
+}
+
+// Total returns the usage recorded so far, including helper invocations.
+func (s *SpendTracker) Total() models.Usage {
+	s.mu.Lock()
+	total := s.total
+	s.mu.Unlock()
+	if s.Helpers != nil {
+		total.Add(s.Helpers.Usage())
+	}
+	return total
+}
+
+// Check returns an *ErrSpendCapExceeded when the run total or the task's own
+// usage has reached its cap. The run cap is checked first.
+func (s *SpendTracker) Check(taskNumber string, taskUsage models.Usage) error {
+	if spent := s.Total().CostUSD; s.MaxRunUSD > 0 && spent >= s.MaxRunUSD {
+		return &ErrSpendCapExceeded{Scope: SpendScopeRun, TaskNumber: taskNumber, SpentUSD: spent, LimitUSD: s.MaxRunUSD}
+	}
+	if s.MaxTaskUSD > 0 && taskUsage.CostUSD >= s.MaxTaskUSD {
+		return &ErrSpendCapExceeded{Scope: SpendScopeTask, TaskNumber: taskNumber, SpentUSD: taskUsage.CostUSD, LimitUSD: s.MaxTaskUSD}
+	}
+	return nil
+}
+
+// pause runs save once when the run cap is first reached and returns the saved
+// state ID. Parallel tasks hitting the cap afterwards share the same state.
+func (s *SpendTracker) pause(save func() string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.paused {
+		s.paused = true
+		s.stateID = save()
+	}
+	return s.stateID
+}
@@ -0,0 +1,217 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/budget"
+	"github.com/harrison/conductor/internal/learning"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func TestSpendTracker_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxRun    float64
+		maxTask   float64
+		runSpent  float64
+		taskSpent float64
+		wantScope string
+	}{
+		{name: "no caps", runSpent: 100, taskSpent: 100},
+		{name: "under both caps", maxRun: 10, maxTask: 2, runSpent: 5, taskSpent: 1},
+		{name: "run cap reached", maxRun: 10, maxTask: 2, runSpent: 10, taskSpent: 1, wantScope: SpendScopeRun},
+		{name: "task cap reached", maxRun: 10, maxTask: 2, runSpent: 5, taskSpent: 2.5, wantScope: SpendScopeTask},
+		{name: "run cap wins over task cap", maxRun: 10, maxTask: 2, runSpent: 12, taskSpent: 3, wantScope: SpendScopeRun},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewSpendTracker(tt.maxRun, tt.maxTask)
+			tracker.Record(models.Usage{CostUSD: tt.runSpent})
+
+			err := tracker.Check("3", models.Usage{CostUSD: tt.taskSpent})
+			if tt.wantScope == "" {
+				if err != nil {
+					t.Errorf("Check() = %v, want nil", err)
+				}
+				return
+			}
+			var capErr *ErrSpendCapExceeded
+			if !errors.As(err, &capErr) || capErr.Scope != tt.wantScope || capErr.TaskNumber != "3" {
+				t.Errorf("Check() = %v, want %s cap error for task 3", err, tt.wantScope)
+			}
+		})
+	}
+}
+
+// fixedUsage is a UsageSource reporting a constant usage.
+type fixedUsage models.Usage
+
+func (u fixedUsage) Usage() models.Usage { return models.Usage(u) }
+
+func TestSpendTracker_CountsHelperUsage(t *testing.T) {
+	tracker := NewSpendTracker(10, 0)
+	tracker.Helpers = fixedUsage{CostUSD: 4, InputTokens: 100}
+	tracker.Record(models.Usage{CostUSD: 5, InputTokens: 50})
+
+	if total := tracker.Total(); total.CostUSD != 9 || total.InputTokens != 150 {
+		t.Errorf("Total() = %+v, want task and helper usage combined", total)
+	}
+	if err := tracker.Check("1", models.Usage{}); err != nil {
+		t.Errorf("Check() = %v, want nil under the cap", err)
+	}
+
+	tracker.Record(models.Usage{CostUSD: 1})
+	var capErr *ErrSpendCapExceeded
+	if err := tracker.Check("1", models.Usage{}); !errors.As(err, &capErr) || capErr.Scope != SpendScopeRun {
+		t.Errorf("Check() = %v, want run cap error once helper usage pushes the total over", err)
+	}
+}
+
+func TestSpendCapRunScopeIsFatal(t *testing.T) {
+	if !isFatalExecutionError(&ErrSpendCapExceeded{Scope: SpendScopeRun}) {
+		t.Error("run spend cap should stop the run")
+	}
+	if isFatalExecutionError(&ErrSpendCapExceeded{Scope: SpendScopeTask}) {
+		t.Error("task spend cap should only fail its task")
+	}
+}
+
+func newSpendTestExecutor(t *testing.T, invoker InvokerInterface, reviewer Reviewer) *DefaultTaskExecutor {
+	t.Helper()
+	executor, err := NewTaskExecutor(invoker, reviewer, &recordingUpdater{}, TaskExecutorConfig{
+		PlanPath: "plan.md",
+		QualityControl: models.QualityControlConfig{
+			Enabled:    true,
+			RetryOnRed: 3,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	return executor
+}
+
+func TestTaskExecutor_AccountsUsagePerAttempt(t *testing.T) {
+	invoker := newStubInvoker(
+		&agent.InvocationResult{Output: `{"content":"first"}`, Usage: models.Usage{InputTokens: 100, CostUSD: 0.30}},
+		&agent.InvocationResult{Output: `{"content":"second"}`, Usage: models.Usage{InputTokens: 80, CostUSD: 0.20}},
+	)
+	reviewer := &stubReviewer{
+		results: []*ReviewResult{
+			{Flag: models.StatusRed, Feedback: "Try again", Usage: models.Usage{OutputTokens: 10, CostUSD: 0.05}},
+			{Flag: models.StatusGreen, Usage: models.Usage{OutputTokens: 5, CostUSD: 0.05}},
+		},
+		retryDecisions: map[int]bool{0: true},
+	}
+	store, err := learning.NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	executor := newSpendTestExecutor(t, invoker, reviewer)
+	executor.LearningStore = store
+	executor.PlanFile = "plan.md"
+	executor.RunNumber = 1
+	executor.Spend = NewSpendTracker(0, 0)
+
+	result, err := executor.Execute(context.Background(), models.Task{Number: "1", Name: "Demo", Prompt: "Do it"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if len(result.ExecutionHistory) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(result.ExecutionHistory))
+	}
+	first := result.ExecutionHistory[0]
+	if first.Usage.CostUSD != 0.30 || first.QCUsage.CostUSD != 0.05 {
+		t.Errorf("first attempt usage = %+v / QC %+v", first.Usage, first.QCUsage)
+	}
+	if got := result.Usage.CostUSD; got < 0.5999 || got > 0.6001 {
+		t.Errorf("task cost = %v, want 0.60", got)
+	}
+	if result.Usage.InputTokens != 180 || result.Usage.OutputTokens != 15 {
+		t.Errorf("task tokens = %+v", result.Usage)
+	}
+	if executor.Spend.Total() != result.Usage {
+		t.Errorf("run total %+v should match the only task %+v", executor.Spend.Total(), result.Usage)
+	}
+
+	// Per-attempt records carry the attempt's agent + QC cost
+	history, err := store.GetExecutionHistory(context.Background(), "plan.md", "1")
+	if err != nil {
+		t.Fatalf("GetExecutionHistory: %v", err)
+	}
+	var recorded float64
+	for _, exec := range history {
+		recorded += exec.Usage.CostUSD
+	}
+	if recorded < 0.5999 || recorded > 0.6001 {
+		t.Errorf("recorded cost = %v, want 0.60 without double counting", recorded)
+	}
+}
+
+func TestTaskExecutor_TaskSpendCapStopsRetries(t *testing.T) {
+	invoker := newStubInvoker(
+		&agent.InvocationResult{Output: `{"content":"first"}`, Usage: models.Usage{CostUSD: 1.50}},
+		&agent.InvocationResult{Output: `{"content":"second"}`, Usage: models.Usage{CostUSD: 1.50}},
+	)
+	reviewer := &stubReviewer{
+		results:        []*ReviewResult{{Flag: models.StatusRed}, {Flag: models.StatusRed}},
+		retryDecisions: map[int]bool{0: true, 1: true, 2: true},
+	}
+	executor := newSpendTestExecutor(t, invoker, reviewer)
+	executor.Spend = NewSpendTracker(0, 2)
+
+	result, err := executor.Execute(context.Background(), models.Task{Number: "4", Name: "Demo", Prompt: "Do it"})
+
+	var capErr *ErrSpendCapExceeded
+	if !errors.As(err, &capErr) || capErr.Scope != SpendScopeTask {
+		t.Fatalf("expected task spend cap error, got %v", err)
+	}
+	if len(invoker.calls) != 2 {
+		t.Errorf("agent invoked %d times, want 2 (third attempt blocked by the cap)", len(invoker.calls))
+	}
+	if result.Status != models.StatusRed || result.RetryCount != 1 {
+		t.Errorf("result = %s retry %d, want RED after 1 retry", result.Status, result.RetryCount)
+	}
+}
+
+func TestTaskExecutor_RunSpendCapSavesState(t *testing.T) {
+	invoker := newStubInvoker(&agent.InvocationResult{Output: `{"content":"done"}`})
+	executor := newSpendTestExecutor(t, invoker, &stubReviewer{})
+	executor.PlanFile = "plan.md"
+	executor.Spend = NewSpendTracker(5, 0)
+	executor.Spend.Record(models.Usage{CostUSD: 5.25})
+	stateDir := t.TempDir()
+	executor.StateManager = budget.NewStateManager(stateDir)
+
+	_, err := executor.Execute(context.Background(), models.Task{Number: "2", Name: "Demo", Prompt: "Do it"})
+
+	var capErr *ErrSpendCapExceeded
+	if !errors.As(err, &capErr) || capErr.Scope != SpendScopeRun || capErr.StateID == "" {
+		t.Fatalf("expected run spend cap error with saved state, got %v", err)
+	}
+	if len(invoker.calls) != 0 {
+		t.Errorf("agent invoked %d times, want 0 once the run cap is reached", len(invoker.calls))
+	}
+
+	state, err := budget.NewStateManager(stateDir).Load(capErr.StateID)
+	if err != nil {
+		t.Fatalf("Load(%s): %v", capErr.StateID, err)
+	}
+	if state.Reason != budget.PauseReasonSpendCap || state.SpentUSD != 5.25 || state.Status != budget.StatusReady {
+		t.Errorf("unexpected saved state: %+v", state)
+	}
+
+	// A second task hitting the cap reuses the saved state
+	_, err = executor.Execute(context.Background(), models.Task{Number: "3", Name: "Other", Prompt: "Do it"})
+	var second *ErrSpendCapExceeded
+	if !errors.As(err, &second) || second.StateID != capErr.StateID {
+		t.Errorf("second cap error = %v, want same state %s", err, capErr.StateID)
+	}
+}
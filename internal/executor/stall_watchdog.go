@@ -0,0 +1,116 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// ErrAgentStalled indicates a streaming agent reported no events for the
+// configured stall timeout on every attempt (v3.6+).
+type ErrAgentStalled struct {
+	TaskNumber string
+	Timeout    time.Duration // executor.stall_timeout
+	Attempts   int           // Invocations killed by the watchdog
+}
+
+func (e *ErrAgentStalled) Error() string {
+	return fmt.Sprintf("task %s: agent stalled (no activity for %s, %d attempt(s))", e.TaskNumber, e.Timeout, e.Attempts)
+}
+
+// stallWatchdog cancels an invocation when no heartbeat arrives within timeout (v3.6+).
+// It arms on the first heartbeat, so backends that never stream are not killed.
+type stallWatchdog struct {
+	timeout time.Duration
+	cancel  context.CancelFunc
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	stalled bool
+	stopped bool
+}
+
+// newStallWatchdog creates a watchdog that calls cancel after timeout without heartbeats.
+func newStallWatchdog(timeout time.Duration, cancel context.CancelFunc) *stallWatchdog {
+	return &stallWatchdog{timeout: timeout, cancel: cancel}
+}
+
+// beat records activity and restarts the countdown.
+func (w *stallWatchdog) beat() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped || w.stalled {
+		return
+	}
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.timeout, w.fire)
+		return
+	}
+	w.timer.Reset(w.timeout)
+}
+
+func (w *stallWatchdog) fire() {
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		return
+	}
+	w.stalled = true
+	w.mu.Unlock()
+	w.cancel()
+}
+
+// stop disarms the watchdog and reports whether it killed the invocation.
+func (w *stallWatchdog) stop() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	return w.stalled
+}
+
+// invokeAgent invokes the task agent, forwarding streamed activity to the
+// EventLogger and restarting invocations that stall (v3.6+).
+// Without a StallTimeout the invoker is called once, as before.
+func (te *DefaultTaskExecutor) invokeAgent(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+	logActivity := func(activity models.AgentActivity) {
+		if te.EventLogger != nil && activity.IsVisible() {
+			activity.TaskNumber = task.Number
+			te.EventLogger.LogTaskActivity(activity)
+		}
+	}
+
+	if te.StallTimeout <= 0 {
+		return te.invoker.Invoke(agent.WithActivityHandler(ctx, logActivity), task)
+	}
+
+	for stalls := 0; ; {
+		invokeCtx, cancel := context.WithCancel(ctx)
+		watchdog := newStallWatchdog(te.StallTimeout, cancel)
+		invokeCtx = agent.WithActivityHandler(invokeCtx, func(activity models.AgentActivity) {
+			watchdog.beat()
+			logActivity(activity)
+		})
+
+		invocation, err := te.invoker.Invoke(invokeCtx, task)
+		stalled := watchdog.stop()
+		cancel()
+		if !stalled || ctx.Err() != nil {
+			return invocation, err
+		}
+
+		stalls++
+		if stalls > te.StallRetries {
+			return invocation, &ErrAgentStalled{TaskNumber: task.Number, Timeout: te.StallTimeout, Attempts: stalls}
+		}
+		if te.Logger != nil {
+			te.Logger.Warnf("Task %s: no agent activity for %s, restarting agent (%d/%d)", task.Number, te.StallTimeout, stalls, te.StallRetries)
+		}
+	}
+}
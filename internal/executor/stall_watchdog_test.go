@@ -0,0 +1,112 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// activityRecorder records live agent activity.
+type activityRecorder struct {
+	*mockLogger
+	mu         sync.Mutex
+	activities []models.AgentActivity
+}
+
+func (r *activityRecorder) LogTaskActivity(activity models.AgentActivity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.activities = append(r.activities, activity)
+}
+
+// stallingInvoker streams one tool call, then hangs until killed for the first
+// stalls invocations and succeeds afterwards.
+func stallingInvoker(stalls int) *stubInvoker {
+	calls := 0
+	invoker := newStubInvoker()
+	invoker.invokeFunc = func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+		calls++
+		emit := agent.ActivityHandlerFromContext(ctx)
+		emit(models.AgentActivity{Kind: models.ActivityToolUse, Tool: "Read", Detail: "a.go"})
+		if calls <= stalls {
+			<-ctx.Done()
+			return &agent.InvocationResult{ExitCode: -1}, nil
+		}
+		return &agent.InvocationResult{Output: `{"content":"done"}`}, nil
+	}
+	return invoker
+}
+
+func TestTaskExecutor_RestartsStalledAgent(t *testing.T) {
+	invoker := stallingInvoker(1)
+	executor := newSpendTestExecutor(t, invoker, &stubReviewer{results: []*ReviewResult{{Flag: models.StatusGreen}}})
+	recorder := &activityRecorder{mockLogger: &mockLogger{}}
+	executor.EventLogger = recorder
+	executor.StallTimeout = 20 * time.Millisecond
+	executor.StallRetries = 1
+
+	result, err := executor.Execute(context.Background(), models.Task{Number: "1", Name: "Demo", Prompt: "Do it"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if result.Status != models.StatusGreen {
+		t.Errorf("status = %s, want GREEN after restarting the stalled agent", result.Status)
+	}
+	if len(invoker.calls) != 2 {
+		t.Errorf("agent invoked %d times, want 2", len(invoker.calls))
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if len(recorder.activities) != 2 || recorder.activities[0].TaskNumber != "1" {
+		t.Errorf("activities = %+v, want one tool call per invocation tagged with task 1", recorder.activities)
+	}
+}
+
+func TestTaskExecutor_StalledAgentFailsAfterRetries(t *testing.T) {
+	invoker := stallingInvoker(5)
+	executor := newSpendTestExecutor(t, invoker, &stubReviewer{})
+	executor.StallTimeout = 20 * time.Millisecond
+	executor.StallRetries = 1
+
+	result, err := executor.Execute(context.Background(), models.Task{Number: "2", Name: "Demo", Prompt: "Do it"})
+
+	var stallErr *ErrAgentStalled
+	if !errors.As(err, &stallErr) || stallErr.Attempts != 2 {
+		t.Fatalf("expected stall error after 2 attempts, got %v", err)
+	}
+	if result.Status != models.StatusFailed {
+		t.Errorf("status = %s, want FAILED", result.Status)
+	}
+	if len(invoker.calls) != 2 {
+		t.Errorf("agent invoked %d times, want 2", len(invoker.calls))
+	}
+}
+
+func TestStallWatchdog_ArmsOnFirstHeartbeat(t *testing.T) {
+	cancelled := make(chan struct{})
+	watchdog := newStallWatchdog(10*time.Millisecond, func() { close(cancelled) })
+
+	// No heartbeat yet: a backend that never streams must not be killed
+	time.Sleep(30 * time.Millisecond)
+	select {
+	case <-cancelled:
+		t.Fatal("watchdog fired before the first heartbeat")
+	default:
+	}
+
+	watchdog.beat()
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("watchdog did not fire after the heartbeat stopped")
+	}
+	if !watchdog.stop() {
+		t.Error("stop() should report the stall")
+	}
+}
@@ -0,0 +1,427 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/updater"
+)
+
+// SubtaskPlanner accepts the subtasks agents propose in their responses and
+// turns them into plan tasks (v3.6+). Accepted subtasks run after the task
+// that proposed them and before its dependents, and are written back into the
+// plan file so later runs see them. MaxPerRun caps how many tasks agents may
+// add during one run; a proposal that would exceed it is rejected as a whole.
+type SubtaskPlanner struct {
+	MaxPerRun int                      // Maximum generated tasks per run (0 = reject all proposals)
+	Logger    RuntimeEnforcementLogger // Reports accepted and rejected proposals (optional)
+
+	// Writes the plan files; defaults to updater.InsertTasks and updater.AddTaskDependencies
+	insertTasks     func(planPath, after string, tasks []models.Task) error
+	addDependencies func(planPath, taskNumber string, deps []string) error
+
+	mu       sync.Mutex
+	accepted int
+}
+
+// NewSubtaskPlanner creates a SubtaskPlanner accepting up to maxPerRun generated tasks.
+func NewSubtaskPlanner(maxPerRun int) *SubtaskPlanner {
+	return &SubtaskPlanner{
+		MaxPerRun:       maxPerRun,
+		insertTasks:     updater.InsertTasks,
+		addDependencies: updater.AddTaskDependencies,
+	}
+}
+
+// subtaskInsertion describes subtasks accepted into the running plan.
+type subtaskInsertion struct {
+	parent     string        // Task that proposed the subtasks
+	subtasks   []models.Task // New tasks, in proposal order
+	dependents []string      // Tasks of the parent that now also wait for the subtasks
+	tasks      []models.Task // The plan's tasks with the subtasks inserted
+}
+
+// numbers returns the task numbers of the accepted subtasks.
+func (ins *subtaskInsertion) numbers() []string {
+	numbers := make([]string, len(ins.subtasks))
+	for i, task := range ins.subtasks {
+		numbers[i] = task.Number
+	}
+	return numbers
+}
+
+// accept validates the subtasks proposed in result against the plan's tasks.
+// A subtask may depend on earlier subtasks of the same proposal, by name, and
+// on plan tasks for which satisfied reports true; it always depends on the
+// task that proposed it. Subtasks are numbered after the highest task number.
+// A proposal named like a task that already depends on the parent, such as a
+// subtask written to the plan by an earlier run, refers to that task instead
+// of adding another. Returns nil when every proposal is already in the plan.
+func (p *SubtaskPlanner) accept(tasks []models.Task, result models.TaskResult, satisfied func(taskNumber string) bool) (*subtaskInsertion, error) {
+	parent := result.Task
+	proposals := result.ProposedSubtasks
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	dependents := BuildDependencyGraph(tasks).Edges[parent.Number]
+	planned := make(map[string]string, len(dependents)) // Name to number of tasks depending on the parent
+	for _, task := range tasks {
+		if slices.Contains(dependents, task.Number) {
+			planned[strings.TrimSpace(task.Name)] = task.Number
+		}
+	}
+
+	existing := make(map[string]bool, len(tasks))
+	next := 0
+	for _, task := range tasks {
+		existing[task.Number] = true
+		if n, err := strconv.Atoi(task.Number); err == nil && n > next {
+			next = n
+		}
+	}
+
+	byName := make(map[string]string)
+	subtasks := make([]models.Task, 0, len(proposals))
+	var merged []string
+	for i, proposal := range proposals {
+		name := strings.TrimSpace(proposal.Name)
+		prompt := strings.TrimSpace(proposal.Prompt)
+		if name == "" || prompt == "" {
+			return nil, fmt.Errorf("subtask %d: name and prompt are required", i+1)
+		}
+		if _, dup := byName[name]; dup {
+			return nil, fmt.Errorf("subtask %q: duplicate name", name)
+		}
+		if number, ok := planned[name]; ok {
+			byName[name] = number
+			merged = append(merged, number)
+			continue
+		}
+
+		deps := []string{parent.Number}
+		for _, dep := range proposal.DependsOn {
+			dep = strings.TrimSpace(dep)
+			number, sibling := byName[dep]
+			switch {
+			case dep == "" || dep == parent.Number:
+				continue
+			case sibling:
+				dep = number
+			case !existing[dep]:
+				return nil, fmt.Errorf("subtask %q: depends on unknown task %q", name, dep)
+			case !satisfied(dep):
+				return nil, fmt.Errorf("subtask %q: depends on task %s, which has not finished", name, dep)
+			}
+			if !slices.Contains(deps, dep) {
+				deps = append(deps, dep)
+			}
+		}
+
+		var files []string
+		for _, file := range proposal.Files {
+			if file = strings.TrimSpace(file); file != "" {
+				files = append(files, filepath.ToSlash(filepath.Clean(file)))
+			}
+		}
+
+		next++
+		for existing[strconv.Itoa(next)] {
+			next++
+		}
+		number := strconv.Itoa(next)
+		existing[number] = true
+		byName[name] = number
+		subtasks = append(subtasks, models.Task{
+			Number:        number,
+			Name:          name,
+			Prompt:        prompt,
+			Files:         files,
+			DependsOn:     deps,
+			Agent:         parent.Agent,
+			WorktreeGroup: parent.WorktreeGroup,
+			SourceFile:    parent.SourceFile,
+		})
+	}
+
+	if len(subtasks) == 0 {
+		return nil, nil
+	}
+	if p.accepted+len(subtasks) > p.MaxPerRun {
+		return nil, fmt.Errorf("%d subtask(s) would exceed executor.max_generated_tasks (%d, %d already generated)", len(subtasks), p.MaxPerRun, p.accepted)
+	}
+
+	ins := &subtaskInsertion{parent: parent.Number, subtasks: subtasks}
+	for _, dependent := range dependents {
+		// A merged proposal waits only for the subtasks it was proposed to depend on
+		if !slices.Contains(merged, dependent) {
+			ins.dependents = append(ins.dependents, dependent)
+		}
+	}
+	numbers := ins.numbers()
+	isDependent := make(map[string]bool, len(ins.dependents))
+	for _, dependent := range ins.dependents {
+		isDependent[dependent] = true
+	}
+
+	ins.tasks = make([]models.Task, 0, len(tasks)+len(subtasks))
+	inserted := false
+	for _, task := range tasks {
+		if isDependent[task.Number] {
+			task.DependsOn = append(append([]string(nil), task.DependsOn...), numbers...)
+		}
+		ins.tasks = append(ins.tasks, task)
+		if task.Number == parent.Number {
+			ins.tasks = append(ins.tasks, subtasks...)
+			inserted = true
+		}
+	}
+	if !inserted {
+		ins.tasks = append(ins.tasks, subtasks...)
+	}
+
+	if err := ValidateTasks(ins.tasks); err != nil {
+		return nil, err
+	}
+	if BuildDependencyGraph(ins.tasks).HasCycle() {
+		return nil, fmt.Errorf("subtasks would create a dependency cycle")
+	}
+
+	p.accepted += len(subtasks)
+	return ins, nil
+}
+
+// persist writes accepted subtasks into the plan files, after the task that
+// proposed them, and adds them to the dependencies of its dependents.
+// Failures are reported but do not stop the run.
+func (p *SubtaskPlanner) persist(plan *models.Plan, ins *subtaskInsertion) {
+	planFile := func(task models.Task) string {
+		if task.SourceFile != "" {
+			return task.SourceFile
+		}
+		return plan.FilePath
+	}
+	byNumber := make(map[string]models.Task, len(ins.tasks))
+	for _, task := range ins.tasks {
+		byNumber[task.Number] = task
+	}
+
+	if path := planFile(byNumber[ins.parent]); path != "" {
+		if err := p.insertTasks(path, ins.parent, ins.subtasks); err != nil {
+			p.warnf("Task %s: failed to write subtasks to %s: %v", ins.parent, path, err)
+		}
+	}
+	for _, dependent := range ins.dependents {
+		path := planFile(byNumber[dependent])
+		if path == "" {
+			continue
+		}
+		if err := p.addDependencies(path, dependent, ins.numbers()); err != nil {
+			p.warnf("Task %s: failed to add subtask dependencies in %s: %v", dependent, path, err)
+		}
+	}
+}
+
+func (p *SubtaskPlanner) warnf(format string, args ...interface{}) {
+	if p.Logger != nil {
+		p.Logger.Warnf(format, args...)
+	}
+}
+
+func (p *SubtaskPlanner) infof(format string, args ...interface{}) {
+	if p.Logger != nil {
+		p.Logger.Infof(format, args...)
+	}
+}
+
+// SetSubtaskPlanner lets agents add subtasks to the running plan (v3.6+).
+// Subtasks proposed by GREEN/YELLOW tasks are accepted by planner and
+// scheduled after the task that proposed them; without a planner, proposals
+// are ignored.
+func (w *WaveExecutor) SetSubtaskPlanner(planner *SubtaskPlanner) {
+	w.subtasks = planner
+}
+
+// acceptSubtasks inserts the subtasks a finished task proposed into the plan
+// and taskMap, and writes them to the plan file. It returns nil when nothing
+// was inserted.
+func (w *WaveExecutor) acceptSubtasks(plan *models.Plan, taskMap map[string]models.Task, result models.TaskResult, satisfied func(taskNumber string) bool) *subtaskInsertion {
+	if len(result.ProposedSubtasks) == 0 || (result.Status != models.StatusGreen && result.Status != models.StatusYellow) {
+		return nil
+	}
+	if w.subtasks == nil {
+		fmt.Fprintf(os.Stderr, "Warning: task %s proposed %d subtask(s), ignored because executor.max_generated_tasks is 0\n", result.Task.Number, len(result.ProposedSubtasks))
+		return nil
+	}
+
+	ins, err := w.subtasks.accept(plan.Tasks, result, satisfied)
+	if err != nil {
+		w.subtasks.warnf("Task %s: rejected proposed subtasks: %v", result.Task.Number, err)
+		return nil
+	}
+	if ins == nil {
+		w.subtasks.infof("Task %s: proposed subtasks are already in the plan", result.Task.Number)
+		return nil
+	}
+	w.updatePlan(func() {
+		plan.Tasks = ins.tasks
+	})
+	for _, task := range ins.tasks {
+		taskMap[task.Number] = task
+	}
+	if w.control != nil {
+		w.control.Register(ins.numbers())
+	}
+	w.subtasks.infof("Task %s: added subtasks %s", ins.parent, strings.Join(ins.numbers(), ", "))
+	w.subtasks.persist(plan, ins)
+	return ins
+}
+
+// updatePlan applies change to the plan shared with the task executor.
+func (w *WaveExecutor) updatePlan(change func()) {
+	if te, ok := w.taskExecutor.(*DefaultTaskExecutor); ok {
+		te.updatePlan(change)
+		return
+	}
+	change()
+}
+
+// insertSubtaskWaves adds the subtasks proposed by a finished wave as new
+// waves right after it (wave mode). Subtasks are layered so each runs after
+// the subtasks it depends on and apart from subtasks modifying the same files.
+func (w *WaveExecutor) insertSubtaskWaves(plan *models.Plan, waveIdx int, taskMap map[string]models.Task, waveResults, allResults []models.TaskResult) bool {
+	if w.subtasks == nil {
+		return false
+	}
+
+	scheduled := make(map[string]bool)
+	for _, wave := range plan.Waves {
+		for _, taskNum := range wave.TaskNumbers {
+			scheduled[taskNum] = true
+		}
+	}
+	finished := make(map[string]bool)
+	for _, result := range allResults {
+		switch result.Status {
+		case models.StatusGreen, models.StatusYellow, models.StatusSkipped:
+			finished[result.Task.Number] = true
+		}
+	}
+	satisfied := func(taskNumber string) bool {
+		return !scheduled[taskNumber] || finished[taskNumber]
+	}
+
+	var subtasks []models.Task
+	for _, result := range waveResults {
+		if ins := w.acceptSubtasks(plan, taskMap, result, satisfied); ins != nil {
+			subtasks = append(subtasks, ins.subtasks...)
+		}
+	}
+	if len(subtasks) == 0 {
+		return false
+	}
+
+	layerOf := make(map[string]int, len(subtasks))
+	var layers [][]models.Task
+	for _, task := range subtasks {
+		layer := 0
+		for _, dep := range task.DependsOn {
+			if depLayer, ok := layerOf[dep]; ok && depLayer+1 > layer {
+				layer = depLayer + 1
+			}
+		}
+		for layer < len(layers) && sharesFiles(task, layers[layer]) {
+			layer++
+		}
+		if layer == len(layers) {
+			layers = append(layers, nil)
+		}
+		layers[layer] = append(layers[layer], task)
+		layerOf[task.Number] = layer
+	}
+
+	current := plan.Waves[waveIdx]
+	waves := make([]models.Wave, 0, len(plan.Waves)+len(layers))
+	waves = append(waves, plan.Waves[:waveIdx+1]...)
+	for i, layer := range layers {
+		wave := models.Wave{
+			Name:           fmt.Sprintf("%s.%d", current.Name, i+1),
+			MaxConcurrency: current.MaxConcurrency,
+		}
+		for _, task := range layer {
+			wave.TaskNumbers = append(wave.TaskNumbers, task.Number)
+		}
+		waves = append(waves, wave)
+	}
+	waves = append(waves, plan.Waves[waveIdx+1:]...)
+	w.updatePlan(func() {
+		plan.Waves = waves
+	})
+	return true
+}
+
+// sharesFiles reports whether task modifies a file modified by any of others.
+func sharesFiles(task models.Task, others []models.Task) bool {
+	files := normalizeTaskFiles(task)
+	for _, other := range others {
+		for _, file := range normalizeTaskFiles(other) {
+			if slices.Contains(files, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// insertSubtasks schedules subtasks accepted while the plan runs
+// (dependency mode). They join the wave of the task that proposed them, right
+// after it in launch order, and hold back that task's unfinished dependents.
+func (s *dependencyScheduler) insertSubtasks(ins *subtaskInsertion) {
+	idx := s.waveIndex[ins.parent]
+	pos := len(s.order)
+	for i, taskNum := range s.order {
+		if taskNum == ins.parent {
+			pos = i + 1
+			break
+		}
+	}
+	numbers := ins.numbers()
+	order := make([]string, 0, len(s.order)+len(numbers))
+	order = append(order, s.order[:pos]...)
+	order = append(order, numbers...)
+	s.order = append(order, s.order[pos:]...)
+
+	for _, taskNum := range numbers {
+		s.waveIndex[taskNum] = idx
+		s.remaining[taskNum] = 0
+	}
+	pending := func(taskNum string) bool {
+		_, scheduled := s.waveIndex[taskNum]
+		_, done := s.results[taskNum]
+		return scheduled && !done
+	}
+	for _, task := range ins.subtasks {
+		for _, dep := range task.DependsOn {
+			if pending(dep) {
+				s.edges[dep] = append(s.edges[dep], task.Number)
+				s.remaining[task.Number]++
+			}
+		}
+	}
+	for _, dependent := range ins.dependents {
+		if !pending(dependent) {
+			continue
+		}
+		for _, taskNum := range numbers {
+			s.edges[taskNum] = append(s.edges[taskNum], dependent)
+			s.remaining[dependent]++
+		}
+	}
+	s.graph = BuildDependencyGraph(ins.tasks)
+}
@@ -0,0 +1,265 @@
+package executor
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// proposingExecutor completes every task GREEN, returning the configured
+// subtask proposals, and records the order tasks finish in.
+type proposingExecutor struct {
+	mu        sync.Mutex
+	proposals map[string][]models.ProposedSubtask
+	finished  []string
+}
+
+func (e *proposingExecutor) Execute(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.finished = append(e.finished, task.Number)
+	return models.TaskResult{Task: task, Status: models.StatusGreen, ProposedSubtasks: e.proposals[task.Number]}, nil
+}
+
+func (e *proposingExecutor) position(taskNum string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, finished := range e.finished {
+		if finished == taskNum {
+			return i
+		}
+	}
+	return -1
+}
+
+// recordingPlanWriter stands in for the updater calls that persist subtasks.
+type recordingPlanWriter struct {
+	inserted map[string][]models.Task
+	deps     map[string][]string
+}
+
+func newTestSubtaskPlanner(max int) (*SubtaskPlanner, *recordingPlanWriter) {
+	writer := &recordingPlanWriter{inserted: make(map[string][]models.Task), deps: make(map[string][]string)}
+	planner := NewSubtaskPlanner(max)
+	planner.insertTasks = func(planPath, after string, tasks []models.Task) error {
+		writer.inserted[planPath+"#"+after] = tasks
+		return nil
+	}
+	planner.addDependencies = func(planPath, taskNumber string, deps []string) error {
+		writer.deps[planPath+"#"+taskNumber] = deps
+		return nil
+	}
+	return planner, writer
+}
+
+func TestSubtaskPlanner_Accept(t *testing.T) {
+	tasks := []models.Task{
+		{Number: "1", Name: "Schema"},
+		{Number: "2", Name: "Handlers", DependsOn: []string{"1"}, Agent: "golang-pro", SourceFile: "plan-02.yaml"},
+		{Number: "3", Name: "Docs", DependsOn: []string{"2"}},
+	}
+	result := models.TaskResult{
+		Task:   tasks[1],
+		Status: models.StatusGreen,
+		ProposedSubtasks: []models.ProposedSubtask{
+			{Name: "Users handler", Prompt: "Add the users handler", Files: []string{"./api/users.go"}, DependsOn: []string{"1"}},
+			{Name: "Users tests", Prompt: "Test the users handler", DependsOn: []string{"Users handler", "2"}},
+		},
+	}
+	planner, _ := newTestSubtaskPlanner(5)
+
+	ins, err := planner.accept(tasks, result, func(string) bool { return true })
+	if err != nil {
+		t.Fatalf("accept returned error: %v", err)
+	}
+
+	if got := taskNumbers(ins.tasks); !reflect.DeepEqual(got, []string{"1", "2", "4", "5", "3"}) {
+		t.Errorf("plan tasks = %v, want the subtasks right after their parent", got)
+	}
+	handler, tests := ins.subtasks[0], ins.subtasks[1]
+	if !reflect.DeepEqual(handler.DependsOn, []string{"2", "1"}) || !reflect.DeepEqual(handler.Files, []string{"api/users.go"}) {
+		t.Errorf("unexpected first subtask: %+v", handler)
+	}
+	if !reflect.DeepEqual(tests.DependsOn, []string{"2", "4"}) {
+		t.Errorf("subtask should depend on its parent and on earlier subtasks by name, got %v", tests.DependsOn)
+	}
+	if handler.Agent != "golang-pro" || handler.SourceFile != "plan-02.yaml" {
+		t.Errorf("subtask should inherit agent and source file: %+v", handler)
+	}
+	if !reflect.DeepEqual(ins.tasks[4].DependsOn, []string{"2", "4", "5"}) {
+		t.Errorf("dependent should wait for the subtasks, got %v", ins.tasks[4].DependsOn)
+	}
+	if !reflect.DeepEqual(tasks[2].DependsOn, []string{"2"}) {
+		t.Errorf("the original tasks must not be modified, got %v", tasks[2].DependsOn)
+	}
+}
+
+func TestSubtaskPlanner_AcceptMergesEarlierSubtasks(t *testing.T) {
+	// Subtask 3 was written to the plan when task 2 last ran
+	tasks := []models.Task{
+		{Number: "1", Name: "Schema"},
+		{Number: "2", Name: "Handlers", DependsOn: []string{"1"}},
+		{Number: "3", Name: "Users handler", DependsOn: []string{"2"}},
+		{Number: "4", Name: "Docs", DependsOn: []string{"2", "3"}},
+	}
+	rerun := []models.ProposedSubtask{{Name: "Users handler", Prompt: "Add the users handler"}}
+	planner, _ := newTestSubtaskPlanner(1)
+
+	ins, err := planner.accept(tasks, models.TaskResult{Task: tasks[1], Status: models.StatusGreen, ProposedSubtasks: rerun}, func(string) bool { return true })
+	if err != nil || ins != nil {
+		t.Fatalf("a subtask already in the plan should not be added again, got %+v, %v", ins, err)
+	}
+
+	proposals := append(rerun, models.ProposedSubtask{Name: "Users tests", Prompt: "Test the users handler", DependsOn: []string{"Users handler"}})
+	ins, err = planner.accept(tasks, models.TaskResult{Task: tasks[1], Status: models.StatusGreen, ProposedSubtasks: proposals}, func(string) bool { return true })
+	if err != nil {
+		t.Fatalf("accept returned error: %v", err)
+	}
+	if got := taskNumbers(ins.tasks); !reflect.DeepEqual(got, []string{"1", "2", "5", "3", "4"}) {
+		t.Errorf("plan tasks = %v, want only the new subtask added", got)
+	}
+	if !reflect.DeepEqual(ins.subtasks[0].DependsOn, []string{"2", "3"}) {
+		t.Errorf("new subtask should depend on the earlier subtask by name, got %v", ins.subtasks[0].DependsOn)
+	}
+	if !reflect.DeepEqual(ins.dependents, []string{"4"}) || !reflect.DeepEqual(ins.tasks[3].DependsOn, []string{"2"}) {
+		t.Errorf("the earlier subtask must not wait for the new one: dependents %v, task 3 %v", ins.dependents, ins.tasks[3].DependsOn)
+	}
+	if planner.accepted != 1 {
+		t.Errorf("only new subtasks count against the cap, got %d", planner.accepted)
+	}
+}
+
+func TestSubtaskPlanner_Rejects(t *testing.T) {
+	tasks := []models.Task{
+		{Number: "1", Name: "Schema"},
+		{Number: "2", Name: "Handlers", DependsOn: []string{"1"}},
+		{Number: "3", Name: "Client"},
+	}
+	tests := []struct {
+		name      string
+		max       int
+		proposals []models.ProposedSubtask
+		wantErr   string
+	}{
+		{
+			name:      "over the cap",
+			max:       1,
+			proposals: []models.ProposedSubtask{{Name: "A", Prompt: "a"}, {Name: "B", Prompt: "b"}},
+			wantErr:   "would exceed executor.max_generated_tasks (1, 0 already generated)",
+		},
+		{
+			name:      "missing prompt",
+			max:       5,
+			proposals: []models.ProposedSubtask{{Name: "A"}},
+			wantErr:   "name and prompt are required",
+		},
+		{
+			name:      "duplicate name",
+			max:       5,
+			proposals: []models.ProposedSubtask{{Name: "A", Prompt: "a"}, {Name: "A", Prompt: "b"}},
+			wantErr:   "duplicate name",
+		},
+		{
+			name:      "unknown dependency",
+			max:       5,
+			proposals: []models.ProposedSubtask{{Name: "A", Prompt: "a", DependsOn: []string{"Later"}}},
+			wantErr:   `depends on unknown task "Later"`,
+		},
+		{
+			name:      "unfinished dependency",
+			max:       5,
+			proposals: []models.ProposedSubtask{{Name: "A", Prompt: "a", DependsOn: []string{"3"}}},
+			wantErr:   "depends on task 3, which has not finished",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planner, _ := newTestSubtaskPlanner(tt.max)
+			result := models.TaskResult{Task: tasks[1], Status: models.StatusGreen, ProposedSubtasks: tt.proposals}
+			_, err := planner.accept(tasks, result, func(taskNum string) bool { return taskNum != "3" })
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+			if planner.accepted != 0 {
+				t.Errorf("a rejected proposal must not count against the cap, got %d", planner.accepted)
+			}
+		})
+	}
+}
+
+func TestWaveExecutor_InsertsProposedSubtasks(t *testing.T) {
+	for _, mode := range []config.SchedulerMode{config.SchedulerModeWave, config.SchedulerModeDependency} {
+		t.Run(string(mode), func(t *testing.T) {
+			plan := &models.Plan{
+				FilePath: "plan.yaml",
+				Tasks: []models.Task{
+					{Number: "1", Name: "Handlers", Files: []string{"api/handlers.go"}},
+					{Number: "2", Name: "Docs", DependsOn: []string{"1"}},
+				},
+				Waves: []models.Wave{
+					{Name: "Wave 1", TaskNumbers: []string{"1"}, MaxConcurrency: 4},
+					{Name: "Wave 2", TaskNumbers: []string{"2"}, MaxConcurrency: 4},
+				},
+			}
+			mock := &proposingExecutor{proposals: map[string][]models.ProposedSubtask{
+				"1": {
+					{Name: "Users handler", Prompt: "Add the users handler", Files: []string{"api/users.go"}},
+					{Name: "Orders handler", Prompt: "Add the orders handler", Files: []string{"api/users.go"}},
+					{Name: "Handler tests", Prompt: "Test both handlers", DependsOn: []string{"Users handler", "Orders handler"}},
+				},
+				// Proposals past the cap are rejected
+				"3": {{Name: "More", Prompt: "More work"}},
+			}}
+			planner, writer := newTestSubtaskPlanner(3)
+			w := NewWaveExecutor(mock, nil)
+			w.SetSchedulerMode(mode)
+			w.SetSubtaskPlanner(planner)
+
+			results, err := w.ExecutePlan(context.Background(), plan)
+			if err != nil {
+				t.Fatalf("ExecutePlan returned error: %v", err)
+			}
+			if len(results) != 5 {
+				t.Fatalf("expected the 2 plan tasks and 3 subtasks to run, got %d results (%v)", len(results), mock.finished)
+			}
+			for _, subtask := range []string{"3", "4", "5"} {
+				if mock.position(subtask) < mock.position("1") || mock.position(subtask) > mock.position("2") {
+					t.Errorf("subtask %s should run after its parent and before the parent's dependents: %v", subtask, mock.finished)
+				}
+			}
+			if mock.position("5") < mock.position("3") || mock.position("5") < mock.position("4") {
+				t.Errorf("subtask 5 should run after the subtasks it depends on: %v", mock.finished)
+			}
+
+			if len(writer.inserted["plan.yaml#1"]) != 3 || !reflect.DeepEqual(writer.deps["plan.yaml#2"], []string{"3", "4", "5"}) {
+				t.Errorf("subtasks should be written back into the plan file: %+v %+v", writer.inserted, writer.deps)
+			}
+			if got := taskNumbers(plan.Tasks); !reflect.DeepEqual(got, []string{"1", "3", "4", "5", "2"}) {
+				t.Errorf("plan tasks = %v", got)
+			}
+			if mode == config.SchedulerModeWave {
+				var names []string
+				for _, wave := range plan.Waves {
+					names = append(names, wave.Name)
+				}
+				// Subtasks 3 and 4 share a file, so they get separate waves
+				if !reflect.DeepEqual(names, []string{"Wave 1", "Wave 1.1", "Wave 1.2", "Wave 1.3", "Wave 2"}) {
+					t.Errorf("waves = %v", names)
+				}
+			}
+		})
+	}
+}
+
+func taskNumbers(tasks []models.Task) []string {
+	numbers := make([]string, len(tasks))
+	for i, task := range tasks {
+		numbers[i] = task.Number
+	}
+	return numbers
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/harrison/conductor/internal/config"
 	"github.com/harrison/conductor/internal/learning"
 	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/prompts"
 	"github.com/harrison/conductor/internal/updater"
 )
 
@@ -24,6 +25,7 @@ const (
 	StatusInProgress = "in-progress"
 	StatusCompleted  = "completed"
 	StatusFailed     = "failed"
+	StatusSkipped    = "skipped"
 )
 
 // ErrQualityGateFailed indicates that a task failed quality control after exhausting retries.
@@ -166,28 +168,29 @@ type DefaultTaskExecutor struct {
 	clock                       func() time.Time
 	qcEnabled                   bool
 	retryLimit                  int
-	SourceFile                  string                   // Track which file this task comes from
-	FileLockManager             FileLockManager          // Per-file locking strategy
-	LearningStore               LearningStore            // Adaptive learning store (optional)
-	PlanFile                    string                   // Plan file path for learning queries
-	SessionID                   string                   // Session ID for learning tracking
-	RunNumber                   int                      // Run number for learning tracking
-	metrics                     *learning.PatternMetrics // Pattern detection metrics (optional)
-	SwapDuringRetries           bool                     // Enable inter-retry agent swapping (uses IntelligentAgentSwapper)
-	Plan                        *models.Plan             // Plan reference for integration prompt builder
-	EnforceDependencyChecks     bool                     // Run dependency checks before task invocation
-	CommandRunner               CommandRunner            // Command runner for dependency checks (optional)
-	WorkDir                     string                   // Working directory for dependency check commands
-	EnforceTestCommands         bool                     // Run test commands after agent output (v2.9+)
-	VerifyCriteria              bool                     // Run optional per-criterion verifications (v2.9+)
-	EnforceDocTargets           bool                     // Run documentation target verification for doc tasks (v2.9+)
-	EnableErrorPatternDetection bool                     // Enable error pattern detection on test failures (v2.11+)
-	EnableClaudeClassification  bool                     // Enable Claude-based error classification (v2.11+)
-	Logger                      RuntimeEnforcementLogger // Logger for runtime enforcement output (optional)
-	EventLogger                 Logger                   // Logger for execution events (task agent invoke, etc.)
-	TaskAgentSelector           *TaskAgentSelector       // Intelligent agent selector for task execution (v2.15+)
-	IntelligentAgentSelection   bool                     // Enable intelligent agent selection when task.Agent is empty
-	BudgetConfig                *config.BudgetConfig     // Budget tracking configuration (v2.19+)
+	SourceFile                  string                      // Track which file this task comes from
+	FileLockManager             FileLockManager             // Per-file locking strategy
+	LearningStore               LearningStore               // Adaptive learning store (optional)
+	PlanFile                    string                      // Plan file path for learning queries
+	SessionID                   string                      // Session ID for learning tracking
+	RunNumber                   int                         // Run number for learning tracking
+	metrics                     *learning.PatternMetrics    // Pattern detection metrics (optional)
+	SwapDuringRetries           bool                        // Enable inter-retry agent swapping (uses IntelligentAgentSwapper)
+	Plan                        *models.Plan                // Plan reference for integration prompt builder
+	EnforceDependencyChecks     bool                        // Run dependency checks before task invocation
+	CommandRunner               CommandRunner               // Command runner for dependency checks (optional)
+	WorkDir                     string                      // Working directory for dependency check commands
+	EnforceTestCommands         bool                        // Run test commands after agent output (v2.9+)
+	VerifyCriteria              bool                        // Run optional per-criterion verifications (v2.9+)
+	EnforceDocTargets           bool                        // Run documentation target verification for doc tasks (v2.9+)
+	EnableErrorPatternDetection bool                        // Enable error pattern detection on test failures (v2.11+)
+	EnableClaudeClassification  bool                        // Enable Claude-based error classification (v2.11+)
+	Logger                      RuntimeEnforcementLogger    // Logger for runtime enforcement output (optional)
+	EventLogger                 Logger                      // Logger for execution events (task agent invoke, etc.)
+	TaskAgentSelector           *TaskAgentSelector          // Intelligent agent selector for task execution (v2.15+)
+	IntelligentAgentSelection   bool                        // Enable intelligent agent selection when task.Agent is empty
+	BudgetConfig                *config.BudgetConfig        // Budget tracking configuration (v2.19+)
+	ContextBudget               *config.ContextBudgetConfig // Prompt context budget (v3.6+, nil = unlimited)
 
 	// Intelligent rate limit handling (v2.20+)
 	Waiter       *budget.RateLimitWaiter // Smart wait with countdown
@@ -220,6 +223,34 @@ type DefaultTaskExecutor struct {
 	// Human Time Estimation integration (v3.5+)
 	EstimationHook *EstimationHook // Human time estimation hook (optional)
 
+	// Worktree isolation integration (v3.6+)
+	WorktreeHook *WorktreeHook // Per-task git worktree isolation hook (optional)
+
+	// QC result cache integration (v3.6+)
+	QCResultCache *QCResultCache // Reuses QC verdicts for unchanged tasks across runs (optional)
+
+	// Spend tracking integration (v3.6+)
+	Spend *SpendTracker // Run cost accounting and budget.max_run_usd/max_task_usd caps (optional)
+
+	// Model routing integration (v3.6+)
+	ModelRouter *ModelRouter // Picks the Claude model per task and escalates on RED (optional)
+
+	// Risk gate integration (v3.6+)
+	RiskGate *RiskGate // Predicts failure from session history and escalates high-risk tasks (optional)
+
+	// Approval gate integration (v3.6+)
+	ApprovalGate *ApprovalGate // Holds type: approval tasks until approved or rejected (required for plans with gates)
+
+	// Conditional execution (v3.6+)
+	Conditions *TaskConditions // Evaluates when: expressions at launch (required for plans that use when:)
+
+	// Stall watchdog for streaming agents (v3.6+)
+	StallTimeout time.Duration // Restart an agent that streamed no events for this long (0 = disabled)
+	StallRetries int           // Restarts of a stalled agent before the task fails
+
+	// Agent subtasks (v3.6+)
+	Subtasks bool // Explain shows the subtasks response field the backends offer (executor.max_generated_tasks > 0)
+
 	// MinFailuresBeforeAdapt is the threshold for failure analysis (v2.34+)
 	// Defaults to 1 if not set
 	MinFailuresBeforeAdapt int
@@ -231,6 +262,28 @@ type DefaultTaskExecutor struct {
 	lastPatternResult      *PreTaskCheckResult            // Populated after Pattern Intelligence check (v2.24+)
 	lastArchResult         *architecture.CheckpointResult // Populated after Architecture checkpoint (v2.27+)
 	lastCommitVerification *CommitVerification            // Populated after commit verification (v2.30+)
+
+	// Guards Plan while accepted subtasks are inserted into it (v3.6+)
+	planMu sync.RWMutex
+}
+
+// currentPlan returns a copy of Plan that stays consistent while accepted
+// subtasks are inserted into the running plan (v3.6+).
+func (te *DefaultTaskExecutor) currentPlan() *models.Plan {
+	te.planMu.RLock()
+	defer te.planMu.RUnlock()
+	if te.Plan == nil {
+		return nil
+	}
+	plan := *te.Plan
+	return &plan
+}
+
+// updatePlan applies change to the plan while running tasks may read it (v3.6+).
+func (te *DefaultTaskExecutor) updatePlan(change func()) {
+	te.planMu.Lock()
+	defer te.planMu.Unlock()
+	change()
 }
 
 // NewTaskExecutor constructs a TaskExecutor implementation.
@@ -287,7 +340,8 @@ func NewTaskExecutor(invoker InvokerInterface, reviewer Reviewer, planUpdater Pl
 
 // preTaskHook queries the learning database and adapts agent/prompt before execution.
 // This hook enables adaptive learning from past failures and warm-up context injection.
-func (te *DefaultTaskExecutor) preTaskHook(ctx context.Context, task *models.Task) error {
+// Context added to the prompt is recorded in promptCtx for the context budget (v3.6+).
+func (te *DefaultTaskExecutor) preTaskHook(ctx context.Context, task *models.Task, promptCtx *promptContext) error {
 	// Learning disabled - no-op
 	if te.LearningStore == nil {
 		return nil
@@ -312,7 +366,9 @@ func (te *DefaultTaskExecutor) preTaskHook(ctx context.Context, task *models.Tas
 
 	// Enhance prompt with learning context if there are past failures
 	if analysis.FailedAttempts > 0 {
+		before := task.Prompt
 		task.Prompt = enhancePromptWithLearning(task.Prompt, analysis)
+		promptCtx.record(config.ContextSectionLearning, "", before, task.Prompt)
 	}
 
 	// Warm-up context injection (v2.29+)
@@ -327,6 +383,7 @@ func (te *DefaultTaskExecutor) preTaskHook(ctx context.Context, task *models.Tas
 			}
 		} else {
 			// Apply the modified task (with injected prompt)
+			promptCtx.record(config.ContextSectionWarmUp, "", task.Prompt, modifiedTask.Prompt)
 			*task = modifiedTask
 		}
 	}
@@ -335,27 +392,19 @@ func (te *DefaultTaskExecutor) preTaskHook(ctx context.Context, task *models.Tas
 }
 
 // enhancePromptWithLearning adds learning context to the task prompt.
+// Rendered from the retry_learning prompt template (v3.6+).
 func enhancePromptWithLearning(originalPrompt string, analysis *learning.FailureAnalysis) string {
 	if analysis == nil || analysis.FailedAttempts == 0 {
 		return originalPrompt
 	}
 
-	// Build learning context
-	learningContext := fmt.Sprintf("\n\nNote: This task has %d past failures. ", analysis.FailedAttempts)
-
-	if len(analysis.TriedAgents) > 0 {
-		learningContext += fmt.Sprintf("Previously tried agents: %s. ", strings.Join(analysis.TriedAgents, ", "))
-	}
-
-	if len(analysis.CommonPatterns) > 0 {
-		learningContext += fmt.Sprintf("Common issues: %s. ", strings.Join(analysis.CommonPatterns, ", "))
-	}
-
-	if analysis.SuggestedApproach != "" {
-		learningContext += fmt.Sprintf("\n\nRecommended approach: %s", analysis.SuggestedApproach)
-	}
-
-	return originalPrompt + learningContext
+	return prompts.Render(prompts.RetryLearning, prompts.RetryLearningData{
+		Prompt:            originalPrompt,
+		FailedAttempts:    analysis.FailedAttempts,
+		TriedAgents:       analysis.TriedAgents,
+		CommonPatterns:    analysis.CommonPatterns,
+		SuggestedApproach: analysis.SuggestedApproach,
+	})
 }
 
 // extractFailurePatterns identifies common failure patterns from QC output using keyword matching.
@@ -533,6 +582,10 @@ func (te *DefaultTaskExecutor) postTaskHook(ctx context.Context, task *models.Ta
 		FailurePatterns: failurePatterns,
 		LinesAdded:      task.LinesAdded,
 		LinesDeleted:    task.LinesDeleted,
+		Model:           task.Model,
+	}
+	if result != nil {
+		exec.Usage = unrecordedUsage(result.Usage, history, te.RunNumber)
 	}
 
 	// Record execution (graceful degradation on error)
@@ -593,7 +646,7 @@ func (te *DefaultTaskExecutor) postVerifyCommitHook(ctx context.Context, task mo
 	}
 
 	// Perform verification
-	result, err := te.CommitVerifier.Verify(ctx, task.CommitSpec, te.WorkDir)
+	result, err := te.CommitVerifier.Verify(ctx, task.CommitSpec, te.workDirFor(task))
 	if err != nil {
 		// Graceful degradation: log error but don't fail task
 		if te.Logger != nil {
@@ -732,9 +785,9 @@ func (te *DefaultTaskExecutor) executeWithRateLimitRecovery(ctx context.Context,
 				if te.Logger != nil {
 					te.Logger.Infof("Resuming session %s after rate limit", lastSessionID)
 				}
-			} else if te.hasGitChanges() {
+			} else if te.hasGitChanges(te.workDirFor(task)) {
 				// Fallback: Inject git diff context if no session ID but partial work exists
-				diffContext := te.getGitDiffContext()
+				diffContext := te.getGitDiffContext(te.workDirFor(task))
 				if diffContext != "" {
 					taskToExecute.Prompt = task.Prompt + diffContext
 					if te.Logger != nil {
@@ -787,6 +840,7 @@ func (te *DefaultTaskExecutor) executeWithRateLimitRecovery(ctx context.Context,
 					PausedAt:      time.Now(),
 					ResumeAt:      info.ResetAt,
 					Status:        budget.StatusPaused,
+					Reason:        budget.PauseReasonRateLimit,
 				}
 				if saveErr := te.StateManager.Save(state); saveErr != nil {
 					// Log warning but still return the rate limit error
@@ -826,18 +880,27 @@ func (te *DefaultTaskExecutor) executeWithRateLimitRecovery(ctx context.Context,
 	}
 }
 
+// workDirFor returns the directory commands for a task run in.
+// Tasks isolated in a git worktree (v3.6+) use the worktree path instead of WorkDir.
+func (te *DefaultTaskExecutor) workDirFor(task models.Task) string {
+	if task.WorkDir != "" {
+		return task.WorkDir
+	}
+	return te.WorkDir
+}
+
 // hasGitChanges checks if there are uncommitted changes in the working directory
-func (te *DefaultTaskExecutor) hasGitChanges() bool {
+func (te *DefaultTaskExecutor) hasGitChanges(workDir string) bool {
 	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = te.WorkDir
+	cmd.Dir = workDir
 	output, err := cmd.Output()
 	return err == nil && len(output) > 0
 }
 
 // getGitDiffContext returns a formatted git diff summary for injection into retry prompts
-func (te *DefaultTaskExecutor) getGitDiffContext() string {
+func (te *DefaultTaskExecutor) getGitDiffContext(workDir string) string {
 	cmd := exec.Command("git", "diff", "--stat", "HEAD")
-	cmd.Dir = te.WorkDir
+	cmd.Dir = workDir
 	output, err := cmd.Output()
 	if err != nil || len(output) == 0 {
 		return ""
@@ -859,75 +922,212 @@ func (te *DefaultTaskExecutor) getGitDiffContext() string {
 </rate_limit_recovery>`, diffStr)
 }
 
+// captureDiffBase records HEAD before the agent runs so diff-based QC review
+// can diff against it. A rollback checkpoint already provides the base.
+func (te *DefaultTaskExecutor) captureDiffBase(ctx context.Context, task *models.Task) {
+	qc, ok := te.reviewer.(*QualityController)
+	if !te.qcEnabled || !ok || !qc.DiffReview.Enabled || diffBase(*task) != "" {
+		return
+	}
+	output, err := NewShellCommandRunner(te.workDirFor(*task)).Run(ctx, "git rev-parse HEAD")
+	if err != nil {
+		if te.Logger != nil {
+			te.Logger.Warnf("Diff review: failed to capture base commit for task %s: %v", task.Number, err)
+		}
+		return
+	}
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	task.Metadata[qcDiffBaseKey] = strings.TrimSpace(output)
+}
+
+// recordFixOutcomes scores the latest QC review of each task listed in
+// task.Fixes as a review of work that needed fixing.
+func (te *DefaultTaskExecutor) recordFixOutcomes(ctx context.Context, task models.Task) {
+	if len(task.Fixes) == 0 || te.LearningStore == nil {
+		return
+	}
+	store, ok := te.LearningStore.(*learning.Store)
+	if !ok {
+		return
+	}
+	planFile := task.SourceFile
+	if planFile == "" {
+		planFile = te.PlanFile
+	}
+	for _, fixed := range task.Fixes {
+		if _, err := store.SetQCReviewOutcome(ctx, planFile, fixed, learning.QCOutcomeBad, learning.QCOutcomeSourceFixTask); err != nil && te.Logger != nil {
+			te.Logger.Warnf("QC calibration: failed to record that task %s fixes task %s: %v", task.Number, fixed, err)
+		}
+	}
+}
+
+// collectTaskDiff returns the git diff QC reviews for task, or nil when diff
+// review is off or the diff cannot be taken (QC then reviews the output only).
+func (te *DefaultTaskExecutor) collectTaskDiff(ctx context.Context, task models.Task, qc *QualityController) *TaskDiff {
+	if !qc.DiffReview.Enabled {
+		return nil
+	}
+	diff, err := CollectTaskDiff(ctx, NewShellCommandRunner(te.workDirFor(task)), task, te.lastCommitVerification)
+	if err != nil {
+		if te.Logger != nil {
+			te.Logger.Warnf("Diff review: failed to diff task %s, reviewing agent output only: %v", task.Number, err)
+		}
+		return nil
+	}
+	if diff == nil && te.Logger != nil {
+		te.Logger.Warnf("Diff review: no commit or base to diff task %s against, reviewing agent output only", task.Number)
+	}
+	return diff
+}
+
 // Execute runs an individual task, handling agent invocation, quality control, and plan updates.
 func (te *DefaultTaskExecutor) Execute(ctx context.Context, task models.Task) (models.TaskResult, error) {
-	// If budget auto-resume is enabled, use intelligent recovery wrapper
-	if te.BudgetConfig != nil && te.BudgetConfig.Enabled && te.BudgetConfig.AutoResume {
-		return te.executeWithRateLimitRecovery(ctx, task)
+	// Remember the prompt as written in the plan for the QC result cache (v3.6+).
+	// Learning, retries and rate limit recovery enrich task.Prompt later on.
+	if te.QCResultCache != nil {
+		metadata := make(map[string]interface{}, len(task.Metadata)+1)
+		for k, v := range task.Metadata {
+			metadata[k] = v
+		}
+		metadata[qcCachePromptKey] = task.Prompt
+		task.Metadata = metadata
 	}
 
-	// Standard execution path
-	return te.executeTask(ctx, task)
-}
+	// A false when: expression skips the task (v3.6+)
+	if task.When != "" {
+		if result, skipped, err := te.evaluateWhen(ctx, task); skipped || err != nil {
+			return result, err
+		}
+	}
 
-// executeTask is the core task execution logic (refactored from original Execute).
-func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task) (models.TaskResult, error) {
-	result := models.TaskResult{Task: task}
+	workDir := te.workDirFor(task)
+	if te.ApprovalGate != nil {
+		te.ApprovalGate.recordStart(ctx, task, workDir)
+	}
+	if te.Conditions != nil {
+		te.Conditions.runs.start(ctx, task, workDir)
+	}
 
-	// Determine which file to lock and update - priority order:
-	// 1. task.SourceFile (set for multi-file plans)
-	// 2. te.SourceFile (set by legacy code or single-file plans)
-	// 3. te.cfg.PlanPath (fallback)
-	fileToLock := te.cfg.PlanPath
-	if task.SourceFile != "" {
-		fileToLock = task.SourceFile
-	} else if te.SourceFile != "" {
-		fileToLock = te.SourceFile
+	var result models.TaskResult
+	var err error
+	if task.IsApproval() {
+		// Approval gates wait for a human instead of running an agent (v3.6+)
+		result, err = te.executeApproval(ctx, task)
+	} else {
+		result, err = te.executeIsolated(ctx, task)
 	}
 
-	// Acquire per-file lock to ensure only one task updates this file at a time
-	unlock := te.FileLockManager.Lock(fileToLock)
-	defer unlock()
-	// Sync LearningStore with QualityController for historical context loading
-	if te.LearningStore != nil && te.reviewer != nil {
-		if qc, ok := te.reviewer.(*QualityController); ok {
-			if store, ok := te.LearningStore.(*learning.Store); ok {
-				qc.LearningStore = store
-			}
+	recorded := result
+	if recorded.Task.Number == "" {
+		recorded.Task = task
+	}
+	if te.ApprovalGate != nil {
+		te.ApprovalGate.recordResult(ctx, recorded, workDir)
+	}
+	if te.Conditions != nil {
+		te.Conditions.runs.finish(ctx, recorded, workDir)
+	}
+	return result, err
+}
+
+// executeIsolated runs the task in its git worktree when worktree isolation is on.
+func (te *DefaultTaskExecutor) executeIsolated(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	// Worktree isolation: run the task in its own git worktree (v3.6+)
+	if te.WorktreeHook != nil {
+		return te.executeInWorktree(ctx, task)
+	}
+
+	return te.executeWithRecovery(ctx, task)
+}
+
+// executeInWorktree runs a task inside its git worktree and merges the result back.
+// Failing to create the worktree or to merge it is reported as a task failure.
+func (te *DefaultTaskExecutor) executeInWorktree(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	if err := te.WorktreeHook.PreTask(ctx, &task); err != nil {
+		result := models.TaskResult{
+			Task:   task,
+			Status: models.StatusFailed,
+			Error:  fmt.Errorf("worktree isolation failed: %w", err),
 		}
+		_ = te.updatePlanStatus(task, StatusFailed, false)
+		return result, result.Error
 	}
 
+	result, err := te.executeWithRecovery(ctx, task)
+
+	if mergeErr := te.WorktreeHook.PostTask(ctx, &task, &result); mergeErr != nil {
+		result.Status = models.StatusFailed
+		result.Error = mergeErr
+		_ = te.updatePlanStatus(task, StatusFailed, false)
+		return result, mergeErr
+	}
+
+	return result, err
+}
+
+// executeWithRecovery dispatches to the rate limit recovery wrapper or the standard path.
+func (te *DefaultTaskExecutor) executeWithRecovery(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	// If budget auto-resume is enabled, use intelligent recovery wrapper
+	if te.BudgetConfig != nil && te.BudgetConfig.Enabled && te.BudgetConfig.AutoResume {
+		return te.executeWithRateLimitRecovery(ctx, task)
+	}
+
+	// Standard execution path
+	return te.executeTask(ctx, task)
+}
+
+// prepareTask runs the pre-task hooks that shape the task before its first
+// invocation and returns the prepared task. Context added to the prompt is
+// recorded in promptCtx. Returns an error when a hook blocks the task.
+//
+// With dry set (conductor explain), hooks with side effects (checkpoints, LOC
+// baseline, diff base, fix outcomes, estimation, dependency checks, risk gate
+// approval) are skipped, and outcomes that would stop the task are noted in
+// dry instead of returned (v3.6+).
+func (te *DefaultTaskExecutor) prepareTask(ctx context.Context, task models.Task, promptCtx *promptContext, dry *dryRun) (models.Task, error) {
 	// Pre-task hook: Query learning database and adapt agent/prompt
-	if err := te.preTaskHook(ctx, &task); err != nil {
+	if err := te.preTaskHook(ctx, &task, promptCtx); err != nil {
 		// Hook errors are non-fatal but should be logged
 		// For now, continue without learning adaptation
 	}
 
-	// Rollback pre-task hook: Create task checkpoint before agent invocation (v3.2+)
-	// PreTask initializes task.Metadata if nil (for checkpoint storage)
-	if te.RollbackHook != nil {
-		if err := te.RollbackHook.PreTask(ctx, &task); err != nil {
-			// Graceful degradation: log but don't block
-			if te.Logger != nil {
-				te.Logger.Warnf("Rollback checkpoint failed for task %s: %v", task.Number, err)
+	if dry != nil {
+		dry.note("rollback checkpoint, LOC baseline and human time estimation hooks are not run (they do not change the prompt)")
+	} else {
+		// Rollback pre-task hook: Create task checkpoint before agent invocation (v3.2+)
+		// PreTask initializes task.Metadata if nil (for checkpoint storage)
+		if te.RollbackHook != nil {
+			if err := te.RollbackHook.PreTask(ctx, &task); err != nil {
+				// Graceful degradation: log but don't block
+				if te.Logger != nil {
+					te.Logger.Warnf("Rollback checkpoint failed for task %s: %v", task.Number, err)
+				}
 			}
 		}
-	}
 
-	// LOC Tracker pre-task hook: Capture baseline commit (v3.4+)
-	if te.LOCTrackerHook != nil {
-		if err := te.LOCTrackerHook.PreTask(ctx, &task); err != nil {
-			if te.Logger != nil {
-				te.Logger.Warnf("LOC baseline capture failed for task %s: %v", task.Number, err)
+		// LOC Tracker pre-task hook: Capture baseline commit (v3.4+)
+		if te.LOCTrackerHook != nil {
+			if err := te.LOCTrackerHook.PreTask(ctx, &task); err != nil {
+				if te.Logger != nil {
+					te.Logger.Warnf("LOC baseline capture failed for task %s: %v", task.Number, err)
+				}
 			}
 		}
-	}
 
-	// Human Time Estimation pre-task hook: Get human time estimate (v3.5+)
-	if te.EstimationHook != nil {
-		if err := te.EstimationHook.PreTask(ctx, &task); err != nil {
-			if te.Logger != nil {
-				te.Logger.Warnf("Human time estimation failed for task %s: %v", task.Number, err)
+		// Diff-based QC review: remember where the task started (v3.6+)
+		te.captureDiffBase(ctx, &task)
+
+		// QC calibration: the reviews that passed the tasks this one fixes missed a problem (v3.6+)
+		te.recordFixOutcomes(ctx, task)
+
+		// Human Time Estimation pre-task hook: Get human time estimate (v3.5+)
+		if te.EstimationHook != nil {
+			if err := te.EstimationHook.PreTask(ctx, &task); err != nil {
+				if te.Logger != nil {
+					te.Logger.Warnf("Human time estimation failed for task %s: %v", task.Number, err)
+				}
 			}
 		}
 	}
@@ -941,21 +1141,24 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 			if te.Logger != nil {
 				te.Logger.Warnf("Pattern Intelligence check failed for task %s: %v", task.Number, patternErr)
 			}
+			dry.note("pattern intelligence failed: %v", patternErr)
 		} else if patternResult != nil {
 			// Store for QC integration (v2.24+)
 			te.lastPatternResult = patternResult
 
 			// Handle block mode - task should not proceed
 			if patternResult.ShouldBlock {
-				result.Status = models.StatusFailed
-				result.Error = fmt.Errorf("pattern intelligence blocked: %s", patternResult.BlockReason)
-				_ = te.updatePlanStatus(task, StatusFailed, false)
-				return result, result.Error
+				if dry == nil {
+					return task, fmt.Errorf("pattern intelligence blocked: %s", patternResult.BlockReason)
+				}
+				dry.note("pattern intelligence would block this task: %s", patternResult.BlockReason)
 			}
 
 			// Apply prompt injection (warn/suggest modes)
 			if patternResult.PromptInjection != "" {
+				before := task.Prompt
 				task = ApplyPromptInjection(task, patternResult)
+				promptCtx.record(config.ContextSectionPattern, "", before, task.Prompt)
 			}
 		}
 	}
@@ -969,16 +1172,17 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 			if te.Logger != nil {
 				te.Logger.Warnf("Architecture checkpoint failed for task %s: %v", task.Number, archErr)
 			}
+			dry.note("architecture checkpoint failed: %v", archErr)
 		} else if archResult != nil {
 			// Store for QC integration (v2.27+)
 			te.lastArchResult = archResult
 
 			// Handle block mode - task should not proceed
 			if archResult.ShouldBlock {
-				result.Status = models.StatusFailed
-				result.Error = fmt.Errorf("architecture checkpoint blocked: %s", archResult.BlockReason)
-				_ = te.updatePlanStatus(task, StatusFailed, false)
-				return result, result.Error
+				if dry == nil {
+					return task, fmt.Errorf("architecture checkpoint blocked: %s", archResult.BlockReason)
+				}
+				dry.note("architecture checkpoint would block this task: %s", archResult.BlockReason)
 			}
 
 			// Handle escalate mode - for now, log and continue (user prompt TBD)
@@ -990,30 +1194,35 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 
 			// Apply prompt injection (warn/escalate modes)
 			if archResult.PromptInjection != "" {
+				before := task.Prompt
 				task.Prompt = task.Prompt + archResult.PromptInjection
+				promptCtx.record(config.ContextSectionArchitecture, "", before, task.Prompt)
 			}
 		}
 	}
 
 	// Run dependency checks before agent invocation (v2.9+)
 	if te.EnforceDependencyChecks && task.RuntimeMetadata != nil && len(task.RuntimeMetadata.DependencyChecks) > 0 {
-		runner := te.CommandRunner
-		if runner == nil {
-			runner = NewShellCommandRunner(te.WorkDir)
-		}
-		if err := RunDependencyChecks(ctx, runner, task); err != nil {
-			result.Status = models.StatusFailed
-			result.Error = fmt.Errorf("preflight dependency check failed: %w", err)
-			_ = te.updatePlanStatus(task, StatusFailed, false)
-			return result, result.Error
+		if dry != nil {
+			dry.note("%d dependency check(s) are not run", len(task.RuntimeMetadata.DependencyChecks))
+		} else {
+			runner := te.CommandRunner
+			if runner == nil {
+				runner = NewShellCommandRunner(te.workDirFor(task))
+			}
+			if err := RunDependencyChecks(ctx, runner, task); err != nil {
+				return task, fmt.Errorf("preflight dependency check failed: %w", err)
+			}
 		}
 	}
 
 	// Apply integration context to integration tasks AND any task with dependencies
 	// This helps all dependent tasks understand their dependencies, not just explicit integration tasks
 	// Must be done BEFORE agent invocation to inject file context
-	if te.Plan != nil && (task.Type == "integration" || len(task.DependsOn) > 0) {
-		task.Prompt = buildIntegrationPrompt(task, te.Plan)
+	if plan := te.currentPlan(); plan != nil && (task.Type == "integration" || len(task.DependsOn) > 0) {
+		before := task.Prompt
+		task.Prompt = buildIntegrationPrompt(task, plan)
+		promptCtx.record(config.ContextSectionIntegration, "", before, task.Prompt)
 	}
 
 	// Apply intelligent agent selection if enabled and task has no agent
@@ -1021,7 +1230,6 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 		selResult, err := te.TaskAgentSelector.SelectAgent(ctx, task)
 		if err == nil && selResult.Agent != "" {
 			task.Agent = selResult.Agent
-			result.Task.Agent = selResult.Agent
 			// Log the selection rationale
 			if te.Logger != nil {
 				te.Logger.Infof("[Task] Intelligent agent selection: %s - %s", selResult.Agent, selResult.Rationale)
@@ -1033,7 +1241,77 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 	// Apply default agent if provided and task still has no agent.
 	if task.Agent == "" && te.cfg.DefaultAgent != "" {
 		task.Agent = te.cfg.DefaultAgent
-		result.Task.Agent = te.cfg.DefaultAgent
+	}
+
+	// Risk gate: predict failure from session history, escalate high-risk tasks (v3.6+)
+	if assessment := te.RiskGate.Assess(task, te.PlanFile); assessment != nil {
+		if dry != nil {
+			dry.note("risk gate: %s", assessment.Summary())
+			assessment.Escalate(&task)
+			if assessment.HighRisk && assessment.NeedsApproval {
+				dry.note("the risk gate would pause this task for approval")
+			}
+		} else {
+			if te.EventLogger != nil {
+				te.EventLogger.LogAnomaly(assessment.Anomaly(task.Number))
+			}
+			if err := te.applyRiskAssessment(ctx, &task, assessment); err != nil {
+				return task, err
+			}
+		}
+	}
+
+	// Route the task to a model from its signals (v3.6+)
+	if te.ModelRouter != nil {
+		decision := te.ModelRouter.Route(ctx, task, te.PlanFile)
+		task.Model = decision.Model
+		if te.Logger != nil {
+			te.Logger.Infof("[Task %s] Model routing: %s (%s)", task.Number, decision.Model, decision.Reason)
+		}
+	}
+
+	return task, nil
+}
+
+// executeTask is the core task execution logic (refactored from original Execute).
+func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	result := models.TaskResult{Task: task}
+
+	// Determine which file to lock and update - priority order:
+	// 1. task.SourceFile (set for multi-file plans)
+	// 2. te.SourceFile (set by legacy code or single-file plans)
+	// 3. te.cfg.PlanPath (fallback)
+	fileToLock := te.cfg.PlanPath
+	if task.SourceFile != "" {
+		fileToLock = task.SourceFile
+	} else if te.SourceFile != "" {
+		fileToLock = te.SourceFile
+	}
+
+	// Acquire per-file lock to ensure only one task updates this file at a time
+	unlock := te.FileLockManager.Lock(fileToLock)
+	defer unlock()
+	// Sync LearningStore with QualityController for historical context loading
+	if te.LearningStore != nil && te.reviewer != nil {
+		if qc, ok := te.reviewer.(*QualityController); ok {
+			if store, ok := te.LearningStore.(*learning.Store); ok {
+				qc.LearningStore = store
+			}
+		}
+	}
+
+	// Track the context hooks add to the prompt for the context budget (v3.6+)
+	promptCtx := newPromptContext(task.Prompt)
+
+	// Pre-task hooks: learning, checkpoints, pattern intelligence, architecture,
+	// dependency checks, integration context, agent selection, risk gate and model routing
+	task, err := te.prepareTask(ctx, task, promptCtx, nil)
+	if err != nil {
+		result.Task = task
+		result.Status = models.StatusFailed
+		result.Error = err
+		_ = te.updatePlanStatus(task, StatusFailed, false)
+		return result, err
 	}
 
 	// Update result task to reflect any changes from hook
@@ -1081,7 +1359,26 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 			return result, err
 		}
 
-		invocation, err := te.invoker.Invoke(ctx, task)
+		// Stop before spending more once a run or task spend cap is reached (v3.6+)
+		if capErr := te.checkSpendCaps(task, result.Usage); capErr != nil {
+			result.Error = capErr
+			if capErr.Scope == SpendScopeRun {
+				// Leave the plan status untouched so the task runs again on resume
+				result.Status = models.StatusFailed
+				return result, capErr
+			}
+			result.Status = models.StatusRed
+			result.RetryCount = attempt - 1
+			_ = te.updatePlanStatus(task, StatusFailed, false)
+			te.postTaskHook(ctx, &task, &result, models.StatusRed)
+			te.rollbackPostTask(ctx, &task, models.StatusRed, attempt-1, false)
+			return result, capErr
+		}
+
+		// Keep the prompt within the context budget (v3.6+)
+		truncations := te.fitPromptContext(&task, promptCtx)
+
+		invocation, err := te.invokeAgent(ctx, task)
 		if err != nil {
 			// Wrap invocation errors with TimeoutError if it's a timeout
 			if errors.Is(err, context.DeadlineExceeded) {
@@ -1099,6 +1396,7 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 		}
 
 		totalDuration += invocation.Duration
+		te.recordUsage(&result, invocation.Usage)
 
 		if invocation.Error != nil {
 			taskErr := NewTaskError(task.Number, "task invocation failed", invocation.Error)
@@ -1135,6 +1433,12 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 		result.Duration = totalDuration
 		result.SessionID = invocation.SessionID // Capture for rate limit recovery
 
+		// Keep only the subtasks proposed by the latest attempt (v3.6+)
+		result.ProposedSubtasks = nil
+		if invocation.AgentResponse != nil {
+			result.ProposedSubtasks = invocation.AgentResponse.Subtasks
+		}
+
 		// Track task execution ID for LIP event collection (v2.29+)
 		var currentTaskExecutionID int64
 
@@ -1165,6 +1469,7 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 					DurationSecs: int64(invocation.Duration.Seconds()),
 					QCVerdict:    "", // Not yet determined
 					QCFeedback:   "",
+					Model:        task.Model,
 				}
 
 				// Record to get task_execution_id
@@ -1178,10 +1483,13 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 
 		// Initialize execution attempt record
 		execAttempt := models.ExecutionAttempt{
-			Attempt:     attempt + 1, // 1-indexed
-			Agent:       task.Agent,
-			AgentOutput: output, // Store parsed agent content (not raw CLI wrapper)
-			Duration:    invocation.Duration,
+			Attempt:            attempt + 1, // 1-indexed
+			Agent:              task.Agent,
+			Model:              task.Model,
+			AgentOutput:        output, // Store parsed agent content (not raw CLI wrapper)
+			Duration:           invocation.Duration,
+			Usage:              invocation.Usage,
+			ContextTruncations: truncations,
 		}
 
 		// Clear test failure from previous attempt
@@ -1192,7 +1500,7 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 		if te.EnforceTestCommands && len(task.TestCommands) > 0 {
 			runner := te.CommandRunner
 			if runner == nil {
-				runner = NewShellCommandRunner(te.WorkDir)
+				runner = NewShellCommandRunner(te.workDirFor(task))
 			}
 			testResults, testErr := RunTestCommands(ctx, runner, task)
 			te.lastTestResults = testResults // Store for QC prompt injection
@@ -1217,7 +1525,7 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 		if te.VerifyCriteria && len(task.StructuredCriteria) > 0 {
 			runner := te.CommandRunner
 			if runner == nil {
-				runner = NewShellCommandRunner(te.WorkDir)
+				runner = NewShellCommandRunner(te.workDirFor(task))
 			}
 			criterionResults, verifyErr := RunCriterionVerifications(ctx, runner, task)
 			te.lastCriterionResults = criterionResults // Store for QC prompt injection
@@ -1367,8 +1675,10 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 					}
 				}
 
+				before := task.Prompt
 				task.Prompt = fmt.Sprintf("%s\n\n<previous_attempt_failed reason=\"test_commands\">\n<test_results>\n%s\n</test_results>\n%s\n<action_required>Fix ALL test failures listed above before completing the task.</action_required>\n</previous_attempt_failed>",
 					task.Prompt, testFeedback, classificationContext)
+				promptCtx.record(config.ContextSectionTestFeedback, fmt.Sprintf("attempt %d", attempt+1), before, task.Prompt)
 			}
 
 			// Continue to next retry iteration
@@ -1403,6 +1713,7 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 		}
 
 		// Pass test/verification results to QC before review (v2.9+)
+		reviewTask := task
 		if qc, ok := te.reviewer.(*QualityController); ok {
 			qc.TestCommandResults = te.lastTestResults
 			qc.CriterionVerifyResults = te.lastCriterionResults
@@ -1418,9 +1729,14 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 			// Wire commit verification result to QC (v2.30+)
 			// This enables QC to consider commit presence/absence in verdict
 			qc.CommitVerification = te.lastCommitVerification
+
+			// Wire the git diff of the task's changes to QC (v3.6+)
+			if diff := te.collectTaskDiff(ctx, task, qc); diff != nil {
+				reviewTask = withTaskDiff(task, diff)
+			}
 		}
 
-		review, reviewErr := te.reviewer.Review(ctx, task, output)
+		review, reviewErr := te.reviewWithCache(ctx, reviewTask, output, attempt)
 		if reviewErr != nil {
 			result.Status = models.StatusFailed
 			result.Error = reviewErr
@@ -1433,6 +1749,9 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 			// Store QC feedback in execution attempt
 			execAttempt.QCFeedback = review.Feedback
 			execAttempt.Verdict = review.Flag
+			execAttempt.QCIssues = review.Issues
+			execAttempt.QCUsage = review.Usage
+			te.recordUsage(&result, review.Usage)
 
 			// Store QC feedback to plan file for this attempt (after QC review completes)
 			// This is the ONLY call to updateFeedback - we skip the pre-QC call to avoid duplicates
@@ -1484,6 +1803,8 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 					QCVerdict:       review.Flag,
 					QCFeedback:      review.Feedback,
 					FailurePatterns: failurePatterns,
+					Usage:           execAttempt.TotalUsage(),
+					Model:           task.Model,
 				}
 
 				// Record to database (graceful degradation on error)
@@ -1578,8 +1899,22 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 		case review.Flag == models.StatusRed:
 			lastErr = ErrQualityGateFailed
 
+			// Escalate to a stronger model before swapping agents (v3.6+)
+			escalated := false
+			if te.ModelRouter != nil && te.ModelRouter.Config.EscalateOnRed && attempt < te.retryLimit {
+				if next, ok := te.ModelRouter.Escalate(task.Model); ok {
+					if te.Logger != nil {
+						te.Logger.Infof("[Task %s] Model escalation: %s → %s", task.Number, task.Model, next)
+					}
+					task.Model = next
+					result.Task.Model = next
+					escalated = true
+				}
+			}
+
 			// Agent swap during retries using IntelligentAgentSwapper
-			if te.SwapDuringRetries {
+			// Only once the model can't be escalated any further
+			if te.SwapDuringRetries && !escalated {
 				var newAgent string
 				var swapReason string
 				swapped := false
@@ -1649,8 +1984,10 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 			// Format failed criteria for explicit feedback (v2.16+)
 			failedCriteriaFeedback := formatFailedCriteria(review.CriteriaResults)
 
+			before := task.Prompt
 			task.Prompt = fmt.Sprintf("%s\n\n<previous_attempt_failed reason=\"qc_feedback\">\n<qc_feedback>\n%s\n</qc_feedback>\n%s%s\n<action_required>Fix ALL issues listed above before completing the task.</action_required>\n</previous_attempt_failed>",
 				task.Prompt, review.Feedback, failedCriteriaFeedback, classificationContext)
+			promptCtx.record(config.ContextSectionQCFeedback, fmt.Sprintf("attempt %d", attempt+1), before, task.Prompt)
 		}
 	}
 
@@ -1667,6 +2004,127 @@ func (te *DefaultTaskExecutor) executeTask(ctx context.Context, task models.Task
 	return result, lastErr
 }
 
+// recordUsage adds invocation usage to the task result and the run spend tracker (v3.6+).
+func (te *DefaultTaskExecutor) recordUsage(result *models.TaskResult, usage models.Usage) {
+	result.Usage.Add(usage)
+	if te.Spend != nil {
+		te.Spend.Record(usage)
+	}
+}
+
+// unrecordedUsage returns the part of a task's usage not already stored by the
+// per-attempt records of this run, so the final record doesn't count it twice (v3.6+).
+func unrecordedUsage(total models.Usage, history []*learning.TaskExecution, runNumber int) models.Usage {
+	for _, exec := range history {
+		if exec.RunNumber != runNumber {
+			continue
+		}
+		total.InputTokens -= exec.Usage.InputTokens
+		total.OutputTokens -= exec.Usage.OutputTokens
+		total.CacheCreationInputTokens -= exec.Usage.CacheCreationInputTokens
+		total.CacheReadInputTokens -= exec.Usage.CacheReadInputTokens
+		total.CostUSD -= exec.Usage.CostUSD
+	}
+	// Rate limit recovery restarts the task's tally while earlier records remain
+	if total.CostUSD < 0 || total.InputTokens < 0 || total.OutputTokens < 0 ||
+		total.CacheCreationInputTokens < 0 || total.CacheReadInputTokens < 0 {
+		return models.Usage{}
+	}
+	return total
+}
+
+// checkSpendCaps returns an error once the run or task spend cap is reached (v3.6+).
+// When the run cap is reached and a StateManager is configured, the execution
+// state is saved so the run can be resumed with a higher cap.
+func (te *DefaultTaskExecutor) checkSpendCaps(task models.Task, taskUsage models.Usage) *ErrSpendCapExceeded {
+	if te.Spend == nil {
+		return nil
+	}
+	err := te.Spend.Check(task.Number, taskUsage)
+	if err == nil {
+		return nil
+	}
+	capErr := err.(*ErrSpendCapExceeded)
+
+	if capErr.Scope == SpendScopeRun && te.StateManager != nil {
+		capErr.StateID = te.Spend.pause(func() string {
+			now := time.Now()
+			state := &budget.ExecutionState{
+				SessionID: budget.GenerateSessionID(),
+				PlanFile:  te.PlanFile,
+				PausedAt:  now,
+				ResumeAt:  now, // Resumable as soon as the cap is raised
+				Status:    budget.StatusPaused,
+				Reason:    budget.PauseReasonSpendCap,
+				SpentUSD:  capErr.SpentUSD,
+			}
+			if saveErr := te.StateManager.Save(state); saveErr != nil {
+				if te.Logger != nil {
+					te.Logger.Warnf("Failed to save execution state: %v", saveErr)
+				}
+				return ""
+			}
+			return state.SessionID
+		})
+	}
+
+	if te.Logger != nil {
+		te.Logger.Warnf("%v", capErr)
+	}
+	return capErr
+}
+
+// qcCachePromptKey is the task metadata key holding the plan-defined prompt.
+const qcCachePromptKey = "qc_cache_prompt"
+
+// reviewWithCache runs the QC review, reusing a cached verdict when the task,
+// its files, criteria and QC agents are unchanged since an earlier review (v3.6+).
+// Retries within a run always get a fresh review: the agent may have changed
+// files outside the task's declared files, which the cache key does not cover.
+func (te *DefaultTaskExecutor) reviewWithCache(ctx context.Context, task models.Task, output string, attempt int) (*ReviewResult, error) {
+	if te.QCResultCache == nil {
+		return te.reviewer.Review(ctx, task, output)
+	}
+
+	planTask := task
+	if prompt, ok := task.Metadata[qcCachePromptKey].(string); ok {
+		planTask.Prompt = prompt
+	}
+	var agents models.QCAgentConfig
+	var aggregation config.QCAggregationConfig
+	if qc, ok := te.reviewer.(*QualityController); ok {
+		agents = qc.AgentConfig
+		aggregation = qc.Aggregation
+	}
+
+	if extra := riskQCAgents(task); len(extra) > 0 {
+		agents.AdditionalAgents = appendUniqueAgents(append([]string(nil), agents.AdditionalAgents...), extra...)
+	}
+
+	key, ok := te.QCResultCache.KeyFor(planTask, te.workDirFor(task), agents)
+	if !ok {
+		return te.reviewer.Review(ctx, task, output)
+	}
+	key = key.WithAggregation(aggregation)
+
+	if attempt == 0 {
+		if cached, hit := te.QCResultCache.Lookup(ctx, key); hit {
+			if te.Logger != nil {
+				te.Logger.Infof("QC cache hit for task %s: reusing %s verdict, files unchanged since last review", task.Number, cached.Flag)
+			}
+			return cached, nil
+		}
+	}
+
+	review, err := te.reviewer.Review(ctx, task, output)
+	if err == nil {
+		if saveErr := te.QCResultCache.Save(ctx, key, task.Number, review); saveErr != nil && te.Logger != nil {
+			te.Logger.Warnf("QC cache: failed to store verdict for task %s: %v", task.Number, saveErr)
+		}
+	}
+	return review, err
+}
+
 // getDetectedErrorPatterns extracts ErrorPattern objects from task metadata.
 // Returns a slice of patterns that were detected and stored during test command execution.
 func getDetectedErrorPatterns(task *models.Task) []*ErrorPattern {
@@ -1850,31 +2308,25 @@ func (te *DefaultTaskExecutor) persistCommitVerification(ctx context.Context, ta
 }
 
 // formatClassificationForRetry formats classification suggestions for retry agent
+// Rendered from the retry_classification prompt template (v3.6+)
 func formatClassificationForRetry(errors []*DetectedError) string {
 	if len(errors) == 0 {
 		return ""
 	}
 
-	var sb strings.Builder
-	sb.WriteString("\n<error_classification>\n")
-
+	var data prompts.RetryClassificationData
 	for i, err := range errors {
 		if err == nil || err.Pattern == nil {
 			continue
 		}
-
-		category := err.Pattern.Category.String()
-		suggestion := err.Pattern.Suggestion
-
-		if err.Method == "claude" {
-			sb.WriteString(fmt.Sprintf("<error index=\"%d\" category=\"%s\" confidence=\"%.0f%%\">\n", i+1, category, err.Confidence*100))
-		} else {
-			sb.WriteString(fmt.Sprintf("<error index=\"%d\" category=\"%s\">\n", i+1, category))
-		}
-		sb.WriteString(fmt.Sprintf("<suggestion>%s</suggestion>\n", suggestion))
-		sb.WriteString("</error>\n")
+		data.Errors = append(data.Errors, prompts.ClassifiedError{
+			Index:      i + 1,
+			Category:   err.Pattern.Category.String(),
+			Suggestion: err.Pattern.Suggestion,
+			Method:     err.Method,
+			Confidence: err.Confidence,
+		})
 	}
 
-	sb.WriteString("</error_classification>")
-	return sb.String()
+	return "\n" + prompts.Render(prompts.RetryClassification, data)
 }
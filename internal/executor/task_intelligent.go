@@ -10,6 +10,7 @@ import (
 	"github.com/harrison/conductor/internal/agent"
 	"github.com/harrison/conductor/internal/budget"
 	"github.com/harrison/conductor/internal/claude"
+	"github.com/harrison/conductor/internal/learning"
 	"github.com/harrison/conductor/internal/models"
 )
 
@@ -18,6 +19,9 @@ import (
 // Embeds BaseSelector for shared registry access and Claude invocation.
 type TaskAgentSelector struct {
 	BaseSelector
+
+	// AgentRankings scores agents by their past sessions (optional, v3.6+)
+	AgentRankings []learning.AgentRanking
 }
 
 // NewTaskAgentSelector creates a new task agent selector with the specified timeout.
@@ -121,6 +125,12 @@ func (tas *TaskAgentSelector) buildSelectionPrompt(task models.Task, availableAg
 		integrationStr = "\n\nThis is an INTEGRATION task requiring cross-component implementation."
 	}
 
+	// Add performance rankings from past sessions (v3.6+)
+	performanceStr := ""
+	if rankings := learning.FormatAgentRankings(tas.AgentRankings, availableAgents); rankings != "" {
+		performanceStr = "\n\nAGENT PERFORMANCE (scored on success rate, cost, speed and error recovery in past sessions, higher is better):\n" + rankings
+	}
+
 	prompt := fmt.Sprintf(`You are selecting the best agent to EXECUTE a task (not review it).
 
 TASK CONTEXT:
@@ -130,7 +140,7 @@ TASK CONTEXT:
 - Task Description: %s%s%s
 
 AVAILABLE AGENTS:
-%s
+%s%s
 
 INSTRUCTIONS:
 Select the single best agent to execute this task. Consider:
@@ -139,6 +149,7 @@ Select the single best agent to execute this task. Consider:
 3. The task description and what skills are required
 4. The success criteria (what needs to be achieved)
 5. Integration tasks need fullstack or architect expertise
+6. Performance scores, when listed: between comparable specialists, prefer the higher-scored agent
 
 IMPORTANT:
 - Only select ONE agent from the AVAILABLE AGENTS list
@@ -146,7 +157,7 @@ IMPORTANT:
 - For integration tasks, prefer fullstack-developer or architect agents
 
 Return JSON with "agent" (single agent name) and "rationale" (brief explanation).`,
-		task.Number, task.Name, filesStr, task.Prompt, criteriaStr, integrationStr, agentsStr)
+		task.Number, task.Name, filesStr, task.Prompt, criteriaStr, integrationStr, agentsStr, performanceStr)
 
 	return prompt
 }
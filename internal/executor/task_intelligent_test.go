@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/learning"
 	"github.com/harrison/conductor/internal/models"
 )
 
@@ -210,6 +211,25 @@ func TestTaskAgentSelector_BuildSelectionPrompt(t *testing.T) {
 			t.Error("expected prompt to mention integration task")
 		}
 	})
+
+	t.Run("agent rankings", func(t *testing.T) {
+		task := models.Task{Number: "4", Name: "Add handler", Prompt: "Add the handler"}
+		if strings.Contains(selector.buildSelectionPrompt(task, []string{"golang-pro"}), "AGENT PERFORMANCE") {
+			t.Error("expected no performance section without rankings")
+		}
+
+		selector.AgentRankings = []learning.AgentRanking{
+			{Agent: "golang-pro", Score: 0.82, Rank: 1, Domain: "general", DomainRank: 1, Sessions: 12},
+			{Agent: "python-pro", Score: 0.40, Rank: 2, Domain: "general", DomainRank: 2, Sessions: 5},
+		}
+		prompt := selector.buildSelectionPrompt(task, []string{"golang-pro", "frontend-developer"})
+		if !strings.Contains(prompt, "AGENT PERFORMANCE") || !strings.Contains(prompt, "golang-pro: score 0.82") {
+			t.Errorf("expected rankings in prompt:\n%s", prompt)
+		}
+		if strings.Contains(prompt, "python-pro") {
+			t.Error("rankings of unavailable agents should be left out")
+		}
+	})
 }
 
 func TestTaskAgentSelectionSchema(t *testing.T) {
@@ -14,6 +14,7 @@ import (
 	"github.com/harrison/conductor/internal/config"
 	"github.com/harrison/conductor/internal/learning"
 	"github.com/harrison/conductor/internal/models"
+	"github.com/harrison/conductor/internal/replay"
 )
 
 type stubInvoker struct {
@@ -211,6 +212,50 @@ func TestTaskExecutor_RetriesOnRedFlag(t *testing.T) {
 	}
 }
 
+func TestTaskExecutor_RecordsQCIssuesPerAttempt(t *testing.T) {
+	invoker := newStubInvoker(
+		&agent.InvocationResult{Output: `{"content":"first"}`, ExitCode: 0},
+		&agent.InvocationResult{Output: `{"content":"second"}`, ExitCode: 0},
+	)
+
+	reviewer := &stubReviewer{
+		results: []*ReviewResult{
+			{Flag: models.StatusRed, Feedback: "Error dropped", Issues: []models.Issue{
+				{Severity: "critical", Description: "error ignored", Location: "main.go:12"},
+			}},
+			{Flag: models.StatusGreen, Feedback: "Fixed"},
+		},
+		retryDecisions: map[int]bool{0: true},
+	}
+
+	executor, err := NewTaskExecutor(invoker, reviewer, &recordingUpdater{}, TaskExecutorConfig{
+		PlanPath: "plan.md",
+		QualityControl: models.QualityControlConfig{
+			Enabled:    true,
+			RetryOnRed: 1,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+
+	result, err := executor.Execute(context.Background(), models.Task{Number: "1", Name: "Demo", Prompt: "Do the thing"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if len(result.ExecutionHistory) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(result.ExecutionHistory))
+	}
+	first := result.ExecutionHistory[0]
+	if len(first.QCIssues) != 1 || first.QCIssues[0].Location != "main.go:12" {
+		t.Errorf("first attempt issues = %+v, want the RED review's issue", first.QCIssues)
+	}
+	if len(result.ExecutionHistory[1].QCIssues) != 0 {
+		t.Errorf("second attempt should have no issues, got %+v", result.ExecutionHistory[1].QCIssues)
+	}
+}
+
 // Tests (continuing from line 190 of original)...
 func TestTaskExecutor_AttemptsToRetryWhenReviewerAllows(t *testing.T) {
 	invoker := newStubInvoker(
@@ -3572,3 +3617,99 @@ func TestPersistCommitVerification(t *testing.T) {
 		executor.persistCommitVerification(context.Background(), task, 1, commitResult)
 	})
 }
+
+func TestTaskExecutor_FakeBackendEndToEnd(t *testing.T) {
+	fake := agent.NewFakeBackend()
+	router := agent.NewBackendRouter("fake", fake)
+
+	qc := NewQualityController(router)
+	qc.AgentConfig = models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"quality-control"}}
+
+	executor, err := NewTaskExecutor(router, qc, &recordingUpdater{}, TaskExecutorConfig{
+		PlanPath:       "plan.md",
+		QualityControl: models.QualityControlConfig{Enabled: true, RetryOnRed: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+
+	result, err := executor.Execute(context.Background(), models.Task{Number: "1", Name: "Demo", Prompt: "Do the thing"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if result.Status != models.StatusGreen {
+		t.Errorf("expected GREEN from fake backend, got %s", result.Status)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected task and QC invocations, got %d", len(calls))
+	}
+	if !strings.HasPrefix(calls[1].Name, "QC Review:") {
+		t.Errorf("expected second invocation to be the QC review, got %q", calls[1].Name)
+	}
+}
+
+// verdictSequenceBackend returns RED for the first QC review and GREEN afterwards.
+type verdictSequenceBackend struct {
+	fake    *agent.FakeBackend
+	reviews int
+}
+
+func (b *verdictSequenceBackend) Invoke(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+	if strings.HasPrefix(task.Name, "QC Review:") {
+		b.reviews++
+		b.fake.Verdict = models.StatusGreen
+		if b.reviews == 1 {
+			b.fake.Verdict = models.StatusRed
+		}
+	}
+	return b.fake.Invoke(ctx, task)
+}
+
+func TestTaskExecutor_ReplayReproducesRetryDecisions(t *testing.T) {
+	dir := t.TempDir()
+
+	run := func(backend agent.Backend, mode replay.Mode) (models.TaskResult, int) {
+		t.Helper()
+		store, err := replay.NewStore(dir, mode)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		invoker := agent.NewReplayBackend(backend, store)
+
+		qc := NewQualityController(invoker)
+		qc.AgentConfig = models.QCAgentConfig{Mode: "explicit", ExplicitList: []string{"quality-control"}}
+		qc.MaxRetries = 2
+
+		executor, err := NewTaskExecutor(invoker, qc, &recordingUpdater{}, TaskExecutorConfig{
+			PlanPath:       "plan.md",
+			QualityControl: models.QualityControlConfig{Enabled: true, RetryOnRed: 2},
+		})
+		if err != nil {
+			t.Fatalf("NewTaskExecutor returned error: %v", err)
+		}
+
+		result, err := executor.Execute(context.Background(), models.Task{Number: "1", Name: "Demo", Prompt: "Do the thing"})
+		if err != nil {
+			t.Fatalf("Execute returned error: %v", err)
+		}
+		return result, result.RetryCount
+	}
+
+	recorded, recordedRetries := run(&verdictSequenceBackend{fake: agent.NewFakeBackend()}, replay.ModeRecord)
+	if recorded.Status != models.StatusGreen || recordedRetries != 1 {
+		t.Fatalf("recorded run: status %s after %d retries, want GREEN after 1", recorded.Status, recordedRetries)
+	}
+
+	// The live backend would approve immediately; replay must follow the recorded RED -> GREEN path.
+	live := agent.NewFakeBackend()
+	replayed, replayedRetries := run(live, replay.ModeReplay)
+	if replayed.Status != recorded.Status || replayedRetries != recordedRetries {
+		t.Errorf("replayed run: status %s after %d retries, want %s after %d",
+			replayed.Status, replayedRetries, recorded.Status, recordedRetries)
+	}
+	if calls := live.Calls(); len(calls) != 0 {
+		t.Errorf("replay should not invoke the backend, got %d calls", len(calls))
+	}
+}
@@ -33,6 +33,14 @@ type WaveExecutor struct {
 	// Budget tracking (v2.19+)
 	budgetTracker *budget.UsageTracker
 	budgetConfig  *config.BudgetConfig
+	// Scheduling strategy (v3.6+): wave barriers (default) or dependency-driven
+	schedulerMode config.SchedulerMode
+	// Keep-going policy (v3.6+): failures block only their downstream subgraph
+	keepGoing bool
+	// Run control (v3.6+): pause/resume, per-task cancel/skip, concurrency override
+	control *RunController
+	// Agent subtasks (v3.6+): accepts subtasks proposed by finished tasks
+	subtasks *SubtaskPlanner
 }
 
 // NewWaveExecutor constructs a WaveExecutor with the provided task executor implementation.
@@ -89,6 +97,30 @@ func (w *WaveExecutor) SetBudgetTracking(tracker *budget.UsageTracker, cfg *conf
 	w.budgetConfig = cfg
 }
 
+// SetSchedulerMode selects how tasks are scheduled.
+// SchedulerModeDependency launches each task as soon as its dependencies are GREEN/YELLOW
+// instead of waiting for the whole previous wave to finish.
+func (w *WaveExecutor) SetSchedulerMode(mode config.SchedulerMode) {
+	w.schedulerMode = mode
+}
+
+// SetKeepGoing enables the keep-going policy.
+// When enabled, a RED/FAILED task no longer stops execution: its transitive dependents
+// are reported as BLOCKED without running, and unrelated branches of the plan continue.
+// Task-level errors are then surfaced through results only; cancellation, rate-limit
+// exits and budget exhaustion still stop execution.
+func (w *WaveExecutor) SetKeepGoing(enabled bool) {
+	w.keepGoing = enabled
+}
+
+// SetRunController enables live run control.
+// The controller is consulted before every launch: paused runs launch nothing new,
+// skipped tasks are reported without running, a concurrency override replaces the
+// wave limits, and each running task gets a context the controller can cancel.
+func (w *WaveExecutor) SetRunController(controller *RunController) {
+	w.control = controller
+}
+
 // SetPatternHook configures Pattern Intelligence for the task executor.
 // When set, enables STOP protocol analysis and duplicate detection for tasks.
 // This method assumes the wave executor's taskExecutor is a *DefaultTaskExecutor.
@@ -121,24 +153,127 @@ func (w *WaveExecutor) ExecutePlan(ctx context.Context, plan *models.Plan) ([]mo
 		taskMap[task.Number] = task
 	}
 
+	if w.control != nil {
+		for _, wave := range plan.Waves {
+			w.control.Register(wave.TaskNumbers)
+		}
+	}
+
+	if w.schedulerMode == config.SchedulerModeDependency {
+		return w.executeDependencyDriven(ctx, plan, taskMap)
+	}
+
 	var allResults []models.TaskResult
 	var firstErr error
 
-	for _, wave := range plan.Waves {
+	// Keep-going bookkeeping: blocked task -> failed upstream task
+	var graph *DependencyGraph
+	blocked := make(map[string]string)
+	if w.keepGoing {
+		graph = BuildDependencyGraph(plan.Tasks)
+	}
+
+	// Index loop: accepted subtasks add waves while the plan runs (v3.6+)
+	for i := 0; i < len(plan.Waves); i++ {
+		wave := plan.Waves[i]
+		if w.keepGoing && len(blocked) > 0 {
+			var blockedResults []models.TaskResult
+			wave, blockedResults = w.blockWaveTasks(wave, taskMap, blocked)
+			allResults = append(allResults, blockedResults...)
+		}
+
 		waveResults, err := w.executeWave(ctx, wave, taskMap, plan.Tasks)
 		allResults = append(allResults, waveResults...)
-		if err != nil {
+
+		if w.keepGoing {
+			markBlockedDependents(graph, waveResults, blocked)
+			if err != nil && isFatalExecutionError(err) {
+				firstErr = err
+				break
+			}
+		} else if err != nil {
 			if firstErr == nil {
 				firstErr = err
 			}
 			// Stop executing subsequent waves once an error is encountered.
 			break
 		}
+
+		if w.insertSubtaskWaves(plan, i, taskMap, waveResults, allResults) && w.keepGoing {
+			graph = BuildDependencyGraph(plan.Tasks)
+		}
 	}
 
 	return allResults, firstErr
 }
 
+// blockWaveTasks removes blocked tasks from a wave and returns synthetic BLOCKED
+// results for them (keep-going mode).
+func (w *WaveExecutor) blockWaveTasks(wave models.Wave, taskMap map[string]models.Task, blocked map[string]string) (models.Wave, []models.TaskResult) {
+	var blockedNumbers []string
+	var results []models.TaskResult
+	for _, taskNumber := range wave.TaskNumbers {
+		failedDep, isBlocked := blocked[taskNumber]
+		if !isBlocked {
+			continue
+		}
+		task, ok := taskMap[taskNumber]
+		if !ok {
+			continue
+		}
+		result := newBlockedResult(task, failedDep)
+		results = append(results, result)
+		blockedNumbers = append(blockedNumbers, taskNumber)
+		if w.logger != nil {
+			if logErr := w.logger.LogTaskResult(result); logErr != nil {
+				// Log error but don't fail execution
+			}
+		}
+	}
+	wave.TaskNumbers = filterOutTasks(wave.TaskNumbers, blockedNumbers)
+	return wave, results
+}
+
+// newBlockedResult creates the synthetic result for a task skipped because of a failed dependency.
+func newBlockedResult(task models.Task, failedDep string) models.TaskResult {
+	return models.TaskResult{
+		Task:   task,
+		Status: models.StatusBlocked,
+		Output: fmt.Sprintf("Blocked: dependency task %s failed", failedDep),
+	}
+}
+
+// markBlockedDependents records the transitive dependents of every RED/FAILED result
+// as blocked, keeping the first failed task that blocked them.
+func markBlockedDependents(graph *DependencyGraph, results []models.TaskResult, blocked map[string]string) {
+	for _, result := range results {
+		if result.Status != models.StatusRed && result.Status != models.StatusFailed {
+			continue
+		}
+		for dependent := range graph.TransitiveDependents(result.Task.Number) {
+			if _, exists := blocked[dependent]; !exists {
+				blocked[dependent] = result.Task.Number
+			}
+		}
+	}
+}
+
+// isFatalExecutionError reports whether an error must stop the run even in keep-going
+// mode: cancellation/timeouts, rate-limit exits (state saved for resume), budget
+// exhaustion and the run spend cap.
+func isFatalExecutionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rateLimitExit *ErrRateLimitExit
+	var spendCap *ErrSpendCapExceeded
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrBudgetExceeded) ||
+		errors.As(err, &rateLimitExit) ||
+		(errors.As(err, &spendCap) && spendCap.Scope == SpendScopeRun)
+}
+
 type taskExecutionResult struct {
 	taskNumber string
 	result     models.TaskResult
@@ -210,11 +345,18 @@ func (w *WaveExecutor) executeWave(ctx context.Context, wave models.Wave, taskMa
 		maxConcurrency = 1
 	}
 
-	semaphore := make(chan struct{}, maxConcurrency)
+	// With run control, the controller enforces the (possibly overridden) limit
+	// and the semaphore only bounds the goroutines of this wave.
+	semaphoreSize := maxConcurrency
+	if w.control != nil {
+		semaphoreSize = execCount
+	}
+	semaphore := make(chan struct{}, semaphoreSize)
 	resultsCh := make(chan taskExecutionResult, execCount)
 
 	var wg sync.WaitGroup
 	var launchErr error
+	var activeTasks int32
 
 	for _, taskNumber := range tasksToExecute {
 		if err := ctx.Err(); err != nil {
@@ -224,6 +366,18 @@ func (w *WaveExecutor) executeWave(ctx context.Context, wave models.Wave, taskMa
 
 		task := taskMap[taskNumber]
 
+		if w.control != nil {
+			active := func() int { return int(atomic.LoadInt32(&activeTasks)) }
+			if err := w.control.awaitLaunch(ctx, active, maxConcurrency); err != nil {
+				launchErr = err
+				break
+			}
+			if actor, skipped := w.control.takeSkip(taskNumber); skipped {
+				resultsCh <- taskExecutionResult{taskNumber: taskNumber, result: newControlSkippedResult(task, actor)}
+				continue
+			}
+		}
+
 		// Check context again before acquiring semaphore to avoid blocking on a cancelled context
 		select {
 		case <-ctx.Done():
@@ -234,6 +388,7 @@ func (w *WaveExecutor) executeWave(ctx context.Context, wave models.Wave, taskMa
 		}
 
 		wg.Add(1)
+		atomic.AddInt32(&activeTasks, 1)
 
 		// Log wave start only once, when the first task successfully acquires a semaphore slot
 		if !waveLogged && w.logger != nil {
@@ -245,13 +400,12 @@ func (w *WaveExecutor) executeWave(ctx context.Context, wave models.Wave, taskMa
 			atomic.AddInt32(&tasksLaunched, 1)
 			defer wg.Done()
 			defer func() { <-semaphore }()
-
-			// Set SourceFile on executor before execution (for multi-file plans)
-			if taskExec, ok := w.taskExecutor.(*DefaultTaskExecutor); ok {
-				if task.SourceFile != "" {
-					taskExec.SourceFile = task.SourceFile
+			defer func() {
+				atomic.AddInt32(&activeTasks, -1)
+				if w.control != nil {
+					w.control.wake()
 				}
-			}
+			}()
 
 			// Acquire package locks if guard is enabled (v2.9+)
 			var releasePackages func()
@@ -281,16 +435,7 @@ func (w *WaveExecutor) executeWave(ctx context.Context, wave models.Wave, taskMa
 				defer releasePackages()
 			}
 
-			result, err := w.taskExecutor.Execute(ctx, task)
-			if result.Task.Number == "" {
-				result.Task = task
-			}
-			if err != nil && result.Error == nil {
-				result.Error = err
-			}
-			if result.Status == "" && err != nil {
-				result.Status = models.StatusFailed
-			}
+			result, err := w.runTask(ctx, task)
 
 			select {
 			case resultsCh <- taskExecutionResult{taskNumber: task.Number, result: result, err: err}:
@@ -381,6 +526,46 @@ launchComplete:
 	return waveResults, execErr
 }
 
+// runTask executes a single task and normalizes the result so it always
+// carries the task, an error and a status when execution failed.
+func (w *WaveExecutor) runTask(ctx context.Context, task models.Task) (models.TaskResult, error) {
+	// Set SourceFile on executor before execution (for multi-file plans)
+	if taskExec, ok := w.taskExecutor.(*DefaultTaskExecutor); ok {
+		if task.SourceFile != "" {
+			taskExec.SourceFile = task.SourceFile
+		}
+	}
+
+	var finish func()
+	if w.control != nil {
+		ctx, finish = w.control.startTask(ctx, task.Number)
+	}
+
+	result, err := w.taskExecutor.Execute(ctx, task)
+	if finish != nil {
+		finish()
+	}
+	if result.Task.Number == "" {
+		result.Task = task
+	}
+	if err != nil && w.control != nil {
+		// A task cancelled through run control fails on its own; it must not
+		// look like a run-wide cancellation to the schedulers.
+		if actor, cancelled := w.control.cancelledActor(task.Number); cancelled {
+			err = fmt.Errorf("%w by %s", ErrTaskCancelled, actor)
+			result.Error = err
+			result.Status = models.StatusFailed
+		}
+	}
+	if err != nil && result.Error == nil {
+		result.Error = err
+	}
+	if result.Status == "" && err != nil {
+		result.Status = models.StatusFailed
+	}
+	return result, err
+}
+
 // filterOutTasks removes excluded tasks from the task list.
 func filterOutTasks(tasks []string, exclude []string) []string {
 	excludeMap := make(map[string]bool)
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/harrison/conductor/internal/config"
 	"github.com/harrison/conductor/internal/models"
 )
 
@@ -763,3 +764,115 @@ func TestWaveExecutor_MixedSkippedAndExecuted(t *testing.T) {
 }
 
 func (m *mockLoggerWithTaskLogging) SetGuardVerbose(verbose bool) {}
+
+// keepGoingPlan has two independent branches: 1 -> 2 -> 3 and 4 -> 5.
+func keepGoingPlan() *models.Plan {
+	return &models.Plan{
+		Tasks: []models.Task{
+			{Number: "1", Name: "Flaky"},
+			{Number: "2", Name: "Child", DependsOn: []string{"1"}},
+			{Number: "3", Name: "Grandchild", DependsOn: []string{"2"}},
+			{Number: "4", Name: "Independent"},
+			{Number: "5", Name: "Independent child", DependsOn: []string{"4"}},
+		},
+		Waves: []models.Wave{
+			{Name: "Wave 1", TaskNumbers: []string{"1", "4"}, MaxConcurrency: 10},
+			{Name: "Wave 2", TaskNumbers: []string{"2", "5"}, MaxConcurrency: 10},
+			{Name: "Wave 3", TaskNumbers: []string{"3"}, MaxConcurrency: 10},
+		},
+	}
+}
+
+func TestWaveExecutor_KeepGoingBlocksOnlyDependents(t *testing.T) {
+	for _, mode := range []config.SchedulerMode{config.SchedulerModeWave, config.SchedulerModeDependency} {
+		t.Run(string(mode), func(t *testing.T) {
+			mock := newTimelineMockExecutor()
+			mock.statuses["1"] = models.StatusRed
+			mock.errs["1"] = ErrQualityGateFailed
+
+			logger := &mockLoggerWithTaskLogging{}
+			w := NewWaveExecutor(mock, logger)
+			w.SetSchedulerMode(mode)
+			w.SetKeepGoing(true)
+
+			results, err := w.ExecutePlan(context.Background(), keepGoingPlan())
+			if err != nil {
+				t.Fatalf("ExecutePlan returned error in keep-going mode: %v", err)
+			}
+
+			if !mock.executed("4") || !mock.executed("5") {
+				t.Errorf("independent branch should keep running after a failure")
+			}
+			if mock.executed("2") || mock.executed("3") {
+				t.Errorf("dependents of the failed task must not run")
+			}
+
+			statuses := make(map[string]string)
+			for _, result := range results {
+				statuses[result.Task.Number] = result.Status
+			}
+			expected := map[string]string{
+				"1": models.StatusRed,
+				"2": models.StatusBlocked,
+				"3": models.StatusBlocked,
+				"4": models.StatusGreen,
+				"5": models.StatusGreen,
+			}
+			for taskNum, want := range expected {
+				if statuses[taskNum] != want {
+					t.Errorf("task %s status = %q, want %q", taskNum, statuses[taskNum], want)
+				}
+			}
+
+			execResult := models.NewExecutionResult(results, false, time.Second)
+			if execResult.Completed != 2 || execResult.Failed != 1 || execResult.Blocked != 2 {
+				t.Errorf("completed/failed/blocked = %d/%d/%d, want 2/1/2",
+					execResult.Completed, execResult.Failed, execResult.Blocked)
+			}
+
+			// Blocked tasks are logged like any other task result
+			loggedBlocked := 0
+			for _, result := range logger.taskResultCalls {
+				if result.Status == models.StatusBlocked {
+					loggedBlocked++
+				}
+			}
+			if loggedBlocked != 2 {
+				t.Errorf("expected 2 blocked task results to be logged, got %d", loggedBlocked)
+			}
+		})
+	}
+}
+
+func TestWaveExecutor_WithoutKeepGoingStopsAfterFailure(t *testing.T) {
+	mock := newTimelineMockExecutor()
+	mock.statuses["1"] = models.StatusRed
+	mock.errs["1"] = ErrQualityGateFailed
+
+	w := NewWaveExecutor(mock, nil)
+	_, err := w.ExecutePlan(context.Background(), keepGoingPlan())
+	if !errors.Is(err, ErrQualityGateFailed) {
+		t.Fatalf("expected ErrQualityGateFailed, got %v", err)
+	}
+	if mock.executed("5") {
+		t.Errorf("later waves should not run without keep-going")
+	}
+}
+
+func TestWaveExecutor_KeepGoingStopsOnFatalError(t *testing.T) {
+	mock := newTimelineMockExecutor()
+	mock.statuses["1"] = models.StatusFailed
+	mock.errs["1"] = &ErrRateLimitExit{ResumeAt: time.Now().Add(time.Hour), StateID: "state-1"}
+
+	w := NewWaveExecutor(mock, nil)
+	w.SetKeepGoing(true)
+
+	_, err := w.ExecutePlan(context.Background(), keepGoingPlan())
+	var rateLimitExit *ErrRateLimitExit
+	if !errors.As(err, &rateLimitExit) {
+		t.Fatalf("expected rate limit exit error, got %v", err)
+	}
+	if mock.executed("5") {
+		t.Errorf("execution should stop after a rate limit exit even in keep-going mode")
+	}
+}
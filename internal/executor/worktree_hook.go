@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// WorktreeHook wraps WorktreeManager for task-level git worktree isolation (v3.6+).
+// This is a thin adapter layer that:
+// - Creates (or joins) the task's worktree and points the agent at it (PreTask)
+// - Merges the worktree back into the run branch when QC is GREEN/YELLOW (PostTask)
+// - Removes the worktree once no task is using it
+//
+// Unlike RollbackHook, failures are not degraded gracefully: a task that asked for
+// isolation must not silently run in the shared working tree, and a merge conflict
+// means the task's work did not land.
+type WorktreeHook struct {
+	Manager *WorktreeManager
+	Logger  RuntimeEnforcementLogger
+}
+
+// NewWorktreeHook creates a new WorktreeHook.
+// Returns nil if manager is nil (graceful disable pattern consistent with other hooks).
+func NewWorktreeHook(manager *WorktreeManager, logger RuntimeEnforcementLogger) *WorktreeHook {
+	if manager == nil {
+		return nil
+	}
+	return &WorktreeHook{
+		Manager: manager,
+		Logger:  logger,
+	}
+}
+
+// PreTask acquires the task's worktree and sets task.WorkDir so the agent,
+// test commands and verifications run inside it.
+// Stores WorktreeInfo in task.Metadata["worktree"] for PostTask retrieval.
+func (h *WorktreeHook) PreTask(ctx context.Context, task *models.Task) error {
+	if h == nil || h.Manager == nil || task == nil {
+		return nil
+	}
+
+	wt, err := h.Manager.Acquire(ctx, *task)
+	if err != nil {
+		return err
+	}
+
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	task.Metadata["worktree"] = wt
+	task.WorkDir = wt.Path
+
+	GracefulInfo(h.Logger, "Worktree: Task %s running in %s (branch %s)", task.Number, wt.Path, wt.BranchName)
+	return nil
+}
+
+// ownsWorktree reports whether task runs in a worktree no other task shares,
+// so every change in it is the task's own.
+func ownsWorktree(task models.Task) bool {
+	wt, ok := task.Metadata["worktree"].(*WorktreeInfo)
+	return ok && wt != nil && !strings.HasPrefix(wt.Name, "group-")
+}
+
+// PostTask merges the task's worktree into the run branch when the task finished
+// GREEN or YELLOW, then releases the worktree. A merge failure is returned so the
+// caller can report the task as failed.
+func (h *WorktreeHook) PostTask(ctx context.Context, task *models.Task, result *models.TaskResult) error {
+	if h == nil || h.Manager == nil || task == nil {
+		return nil
+	}
+
+	var wt *WorktreeInfo
+	if task.Metadata != nil {
+		wt, _ = task.Metadata["worktree"].(*WorktreeInfo)
+	}
+	if wt == nil {
+		return nil
+	}
+
+	var mergeErr error
+	if result != nil && result.Error == nil &&
+		(result.Status == models.StatusGreen || result.Status == models.StatusYellow) {
+		if err := h.Manager.Merge(ctx, wt, *task); err != nil {
+			mergeErr = fmt.Errorf("task %s: %w", task.Number, err)
+		} else {
+			GracefulInfo(h.Logger, "Worktree: Merged %s into %s", wt.BranchName, wt.BaseBranch)
+		}
+	}
+
+	if err := h.Manager.Release(ctx, wt); err != nil {
+		GracefulWarn(h.Logger, "Worktree: Failed to clean up %s: %v", wt.Path, err)
+	}
+
+	return mergeErr
+}
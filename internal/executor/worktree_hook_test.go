@@ -0,0 +1,125 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harrison/conductor/internal/agent"
+	"github.com/harrison/conductor/internal/models"
+)
+
+func TestNewWorktreeHook_NilManager(t *testing.T) {
+	if hook := NewWorktreeHook(nil, nil); hook != nil {
+		t.Error("expected nil hook when manager is nil")
+	}
+}
+
+func TestWorktreeHook_PreTaskSetsWorkDir(t *testing.T) {
+	dir := initWorktreeRepo(t)
+	hook := NewWorktreeHook(newTestWorktreeManager(t, dir), nil)
+	task := &models.Task{Number: "1", Name: "Task"}
+
+	if err := hook.PreTask(context.Background(), task); err != nil {
+		t.Fatalf("PreTask returned error: %v", err)
+	}
+	wt, ok := task.Metadata["worktree"].(*WorktreeInfo)
+	if !ok {
+		t.Fatal("expected WorktreeInfo in task metadata")
+	}
+	if task.WorkDir != wt.Path {
+		t.Errorf("task.WorkDir = %q, want %q", task.WorkDir, wt.Path)
+	}
+	if _, err := os.Stat(task.WorkDir); err != nil {
+		t.Errorf("expected worktree directory to exist: %v", err)
+	}
+
+	if err := hook.PostTask(context.Background(), task, &models.TaskResult{Status: models.StatusRed}); err != nil {
+		t.Fatalf("PostTask returned error: %v", err)
+	}
+}
+
+func TestWorktreeHook_PostTaskDiscardsRedTask(t *testing.T) {
+	dir := initWorktreeRepo(t)
+	hook := NewWorktreeHook(newTestWorktreeManager(t, dir), nil)
+	task := &models.Task{Number: "1", Name: "Task"}
+
+	if err := hook.PreTask(context.Background(), task); err != nil {
+		t.Fatalf("PreTask returned error: %v", err)
+	}
+	writeFile(t, filepath.Join(task.WorkDir, "rejected.txt"), "bad change\n")
+
+	if err := hook.PostTask(context.Background(), task, &models.TaskResult{Status: models.StatusRed}); err != nil {
+		t.Fatalf("PostTask returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "rejected.txt")); err == nil {
+		t.Error("changes from a RED task must not be merged")
+	}
+	if _, err := os.Stat(task.WorkDir); !os.IsNotExist(err) {
+		t.Error("worktree should be removed after a RED task")
+	}
+}
+
+func TestTaskExecutor_WorktreeIsolationMergesGreenTask(t *testing.T) {
+	dir := initWorktreeRepo(t)
+
+	invoker := newStubInvoker()
+	invoker.invokeFunc = func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+		if task.WorkDir == "" {
+			t.Error("expected agent to be invoked with a worktree WorkDir")
+		}
+		writeFile(t, filepath.Join(task.WorkDir, "feature.txt"), "feature\n")
+		return &agent.InvocationResult{Output: `{"content":"done"}`, ExitCode: 0}, nil
+	}
+
+	te, err := NewTaskExecutor(invoker, nil, &recordingUpdater{}, TaskExecutorConfig{PlanPath: "plan.md"})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	te.WorktreeHook = NewWorktreeHook(newTestWorktreeManager(t, dir), nil)
+
+	result, err := te.Execute(context.Background(), models.Task{Number: "1", Name: "Feature", Prompt: "Add feature"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if result.Status != models.StatusGreen {
+		t.Errorf("expected GREEN, got %s", result.Status)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "feature.txt")); err != nil {
+		t.Errorf("expected worktree changes merged into main tree: %v", err)
+	}
+}
+
+func TestTaskExecutor_WorktreeMergeConflictFailsTask(t *testing.T) {
+	dir := initWorktreeRepo(t)
+
+	invoker := newStubInvoker()
+	invoker.invokeFunc = func(ctx context.Context, task models.Task) (*agent.InvocationResult, error) {
+		writeFile(t, filepath.Join(task.WorkDir, "shared.txt"), "from task\n")
+		// Simulate a concurrent task landing a conflicting change first
+		writeFile(t, filepath.Join(dir, "shared.txt"), "from another task\n")
+		gitAdd(t, dir, "shared.txt")
+		gitCommit(t, dir, "Concurrent change")
+		return &agent.InvocationResult{Output: `{"content":"done"}`, ExitCode: 0}, nil
+	}
+
+	updater := &recordingUpdater{}
+	te, err := NewTaskExecutor(invoker, nil, updater, TaskExecutorConfig{PlanPath: "plan.md"})
+	if err != nil {
+		t.Fatalf("NewTaskExecutor returned error: %v", err)
+	}
+	te.WorktreeHook = NewWorktreeHook(newTestWorktreeManager(t, dir), nil)
+
+	result, err := te.Execute(context.Background(), models.Task{Number: "1", Name: "Conflicting", Prompt: "Edit shared"})
+	if !errors.Is(err, ErrWorktreeMergeConflict) {
+		t.Fatalf("expected ErrWorktreeMergeConflict, got %v", err)
+	}
+	if result.Status != models.StatusFailed {
+		t.Errorf("expected FAILED, got %s", result.Status)
+	}
+	if last := updater.calls[len(updater.calls)-1]; last.status != StatusFailed {
+		t.Errorf("expected plan status %s after conflict, got %s", StatusFailed, last.status)
+	}
+}
@@ -0,0 +1,337 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// DefaultWorktreeBranchPrefix is the branch prefix used for task worktree branches.
+const DefaultWorktreeBranchPrefix = "conductor-worktree-"
+
+// ErrWorktreeMergeConflict indicates a task worktree could not be merged back
+// into the run branch because of conflicting changes.
+var ErrWorktreeMergeConflict = errors.New("worktree merge conflict")
+
+// worktreeNameSanitizer replaces characters that are unsafe in paths and branch names.
+var worktreeNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// WorktreeInfo holds information about a task worktree.
+type WorktreeInfo struct {
+	// Name identifies the worktree (task-<number> or group-<group id>).
+	Name string
+
+	// Path is the filesystem path of the worktree.
+	Path string
+
+	// BranchName is the branch checked out in the worktree.
+	BranchName string
+
+	// BaseBranch is the run branch the worktree was created from and merges back into.
+	BaseBranch string
+
+	// refs counts the tasks currently using this worktree.
+	refs int
+}
+
+// WorktreeManager creates, merges and removes git worktrees used to isolate
+// parallel task execution (v3.6+).
+//
+// Each task gets its own worktree branched from the current run branch. Tasks that
+// share a WorktreeGroup share a single worktree and branch, so their changes are
+// merged back together. Merges are serialized so concurrent tasks never race on
+// the run branch.
+type WorktreeManager struct {
+	// CommandRunner for executing git commands (optional, uses exec.Command if nil)
+	CommandRunner CommandRunner
+
+	// Checkpointer resolves the run branch and deletes worktree branches
+	Checkpointer GitCheckpointer
+
+	// WorkDir is the repository working directory (empty = current dir)
+	WorkDir string
+
+	// BaseDir is the directory worktrees are created in; relative paths resolve against WorkDir
+	BaseDir string
+
+	// BranchPrefix is prepended to worktree branch names (default: conductor-worktree-)
+	BranchPrefix string
+
+	mu        sync.Mutex
+	worktrees map[string]*WorktreeInfo
+}
+
+// NewWorktreeManager creates a WorktreeManager that runs git in workDir and
+// places worktrees under baseDir.
+func NewWorktreeManager(checkpointer GitCheckpointer, workDir, baseDir string) *WorktreeManager {
+	return &WorktreeManager{
+		Checkpointer: checkpointer,
+		WorkDir:      workDir,
+		BaseDir:      baseDir,
+		BranchPrefix: DefaultWorktreeBranchPrefix,
+		worktrees:    make(map[string]*WorktreeInfo),
+	}
+}
+
+// NewWorktreeManagerWithRunner creates a WorktreeManager with a custom CommandRunner (for testing).
+func NewWorktreeManagerWithRunner(runner CommandRunner, checkpointer GitCheckpointer, baseDir string) *WorktreeManager {
+	m := NewWorktreeManager(checkpointer, "", baseDir)
+	m.CommandRunner = runner
+	return m
+}
+
+// WorktreeName returns the worktree name used for a task.
+// Tasks in a worktree group share the group's worktree.
+func WorktreeName(task models.Task) string {
+	if group := strings.TrimSpace(task.WorktreeGroup); group != "" {
+		return "group-" + worktreeNameSanitizer.ReplaceAllString(group, "-")
+	}
+	return "task-" + worktreeNameSanitizer.ReplaceAllString(task.Number, "-")
+}
+
+// Acquire returns the worktree for a task, creating it from the current run branch
+// if no task is using it yet. Every successful Acquire must be paired with Release.
+func (m *WorktreeManager) Acquire(ctx context.Context, task models.Task) (*WorktreeInfo, error) {
+	name := WorktreeName(task)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.worktrees == nil {
+		m.worktrees = make(map[string]*WorktreeInfo)
+	}
+	if wt, ok := m.worktrees[name]; ok {
+		wt.refs++
+		return wt, nil
+	}
+
+	if m.Checkpointer == nil {
+		return nil, fmt.Errorf("worktree manager requires a git checkpointer")
+	}
+	baseBranch, err := m.Checkpointer.GetCurrentBranch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine run branch: %w", err)
+	}
+	if baseBranch == "" {
+		return nil, fmt.Errorf("worktree isolation requires a checked-out branch (HEAD is detached)")
+	}
+
+	wt := &WorktreeInfo{
+		Name:       name,
+		Path:       m.worktreePath(name),
+		BranchName: m.branchPrefix() + name,
+		BaseBranch: baseBranch,
+		refs:       1,
+	}
+
+	// Drop stale registrations left behind by an interrupted run (best effort)
+	_, _ = m.runCommand(ctx, "git", "worktree", "prune")
+
+	// -B resets a leftover branch from an interrupted run to the run branch
+	if _, err := m.runCommand(ctx, "git", "worktree", "add", "-B", wt.BranchName, wt.Path, baseBranch); err != nil {
+		return nil, fmt.Errorf("failed to create worktree %s: %w", wt.Path, err)
+	}
+
+	m.worktrees[name] = wt
+	return wt, nil
+}
+
+// Merge commits any pending changes in the worktree and merges its branch into
+// the run branch. On conflict the merge is aborted and an error wrapping
+// ErrWorktreeMergeConflict is returned.
+//
+// While other tasks of a group still use the worktree, only the changes to the
+// task's declared files are committed; everything else is left for the group's
+// last task to commit, so a task never commits another task's work in progress.
+func (m *WorktreeManager) Merge(ctx context.Context, wt *WorktreeInfo, task models.Task) error {
+	if wt == nil {
+		return fmt.Errorf("worktree cannot be nil")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if wt.refs > 1 {
+		if err := m.commitTaskFiles(ctx, wt, task); err != nil {
+			return err
+		}
+	} else {
+		status, err := m.runCommand(ctx, "git", "-C", wt.Path, "status", "--porcelain")
+		if err != nil {
+			return fmt.Errorf("failed to read worktree status for %s: %w", wt.Path, err)
+		}
+		if strings.TrimSpace(status) != "" {
+			if _, err := m.runCommand(ctx, "git", "-C", wt.Path, "add", "-A"); err != nil {
+				return fmt.Errorf("failed to stage worktree changes for task %s: %w", task.Number, err)
+			}
+			message := fmt.Sprintf("conductor: task %s - %s", task.Number, task.Name)
+			if _, err := m.runCommand(ctx, "git", "-C", wt.Path, "commit", "--no-verify", "-m", message); err != nil {
+				return fmt.Errorf("failed to commit worktree changes for task %s: %w", task.Number, err)
+			}
+		}
+	}
+
+	message := fmt.Sprintf("conductor: merge task %s (%s)", task.Number, wt.BranchName)
+	output, err := m.runCommand(ctx, "git", "merge", "--no-ff", "-m", message, wt.BranchName)
+	if err != nil {
+		// Leave the run branch exactly as it was before the merge attempt
+		_, _ = m.runCommand(ctx, "git", "merge", "--abort")
+		if strings.Contains(output, "CONFLICT") || strings.Contains(err.Error(), "CONFLICT") {
+			return fmt.Errorf("%w: task %s branch %s into %s: %s",
+				ErrWorktreeMergeConflict, task.Number, wt.BranchName, wt.BaseBranch, conflictedFiles(output+err.Error()))
+		}
+		return fmt.Errorf("failed to merge branch %s into %s: %w", wt.BranchName, wt.BaseBranch, err)
+	}
+
+	return nil
+}
+
+// commitTaskFiles commits the changes to a task's declared files in a shared
+// group worktree. Paths are passed to git commit so changes other tasks staged
+// in the same index are not included.
+func (m *WorktreeManager) commitTaskFiles(ctx context.Context, wt *WorktreeInfo, task models.Task) error {
+	files := normalizeTaskFiles(task)
+	if len(files) == 0 {
+		return nil
+	}
+
+	args := append([]string{"-C", wt.Path, "status", "--porcelain", "--untracked-files=all", "--"}, files...)
+	status, err := m.runCommand(ctx, "git", args...)
+	if err != nil {
+		return fmt.Errorf("failed to read worktree status for %s: %w", wt.Path, err)
+	}
+	var changed []string
+	for _, line := range strings.Split(status, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		path := line[3:]
+		if idx := strings.Index(path, " -> "); idx >= 0 {
+			path = path[idx+len(" -> "):]
+		}
+		changed = append(changed, strings.Trim(path, `"`))
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if _, err := m.runCommand(ctx, "git", append([]string{"-C", wt.Path, "add", "-A", "--"}, changed...)...); err != nil {
+		return fmt.Errorf("failed to stage worktree changes for task %s: %w", task.Number, err)
+	}
+	message := fmt.Sprintf("conductor: task %s - %s", task.Number, task.Name)
+	commitArgs := append([]string{"-C", wt.Path, "commit", "--no-verify", "-m", message, "--"}, changed...)
+	if _, err := m.runCommand(ctx, "git", commitArgs...); err != nil {
+		return fmt.Errorf("failed to commit worktree changes for task %s: %w", task.Number, err)
+	}
+	return nil
+}
+
+// Release drops a task's reference to its worktree. When no task uses the
+// worktree anymore it is removed along with its branch; unmerged changes are discarded.
+func (m *WorktreeManager) Release(ctx context.Context, wt *WorktreeInfo) error {
+	if wt == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wt.refs--
+	if wt.refs > 0 {
+		return nil
+	}
+	delete(m.worktrees, wt.Name)
+
+	if _, err := m.runCommand(ctx, "git", "worktree", "remove", "--force", wt.Path); err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", wt.Path, err)
+	}
+	if m.Checkpointer != nil {
+		if err := m.Checkpointer.DeleteCheckpoint(ctx, wt.BranchName); err != nil {
+			return fmt.Errorf("failed to delete worktree branch: %w", err)
+		}
+	}
+	return nil
+}
+
+// worktreePath returns the filesystem path for a named worktree.
+func (m *WorktreeManager) worktreePath(name string) string {
+	baseDir := m.BaseDir
+	if baseDir == "" {
+		baseDir = filepath.Join(".conductor", "worktrees")
+	}
+	if !filepath.IsAbs(baseDir) && m.WorkDir != "" {
+		baseDir = filepath.Join(m.WorkDir, baseDir)
+	}
+	return filepath.Join(baseDir, name)
+}
+
+// branchPrefix returns the configured branch prefix or the default.
+func (m *WorktreeManager) branchPrefix() string {
+	if m.BranchPrefix != "" {
+		return m.BranchPrefix
+	}
+	return DefaultWorktreeBranchPrefix
+}
+
+// runCommand executes a git command using CommandRunner or exec.Command.
+func (m *WorktreeManager) runCommand(ctx context.Context, name string, args ...string) (string, error) {
+	if m.CommandRunner != nil {
+		// Build full command string for the runner, quoting arguments with spaces
+		cmd := name
+		for _, arg := range args {
+			cmd += " " + quoteCommandArg(arg)
+		}
+		return m.CommandRunner.Run(ctx, cmd)
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	if m.WorkDir != "" {
+		cmd.Dir = m.WorkDir
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("%w: %s", err, string(output))
+	}
+	return string(output), nil
+}
+
+// quoteCommandArg single-quotes an argument for sh -c when it contains
+// characters the shell would otherwise interpret.
+func quoteCommandArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"$`\\;&|()<>*?#~") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// conflictedFiles extracts the conflicting paths from git merge output.
+// Falls back to the trimmed output when no CONFLICT lines are found.
+func conflictedFiles(output string) string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, "CONFLICT") {
+			continue
+		}
+		idx := strings.LastIndex(line, " in ")
+		if idx < 0 {
+			continue
+		}
+		file := strings.TrimSpace(line[idx+len(" in "):])
+		if file != "" && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 {
+		return strings.TrimSpace(output)
+	}
+	return "conflicting files: " + strings.Join(files, ", ")
+}
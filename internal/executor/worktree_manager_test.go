@@ -0,0 +1,324 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// recordingWorktreeRunner records every command and returns canned outputs.
+type recordingWorktreeRunner struct {
+	mu       sync.Mutex
+	commands []string
+	outputs  map[string]string
+	errors   map[string]error
+}
+
+func newRecordingWorktreeRunner() *recordingWorktreeRunner {
+	return &recordingWorktreeRunner{
+		outputs: make(map[string]string),
+		errors:  make(map[string]error),
+	}
+}
+
+func (r *recordingWorktreeRunner) Run(ctx context.Context, command string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commands = append(r.commands, command)
+	return r.outputs[command], r.errors[command]
+}
+
+func (r *recordingWorktreeRunner) ran(command string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.commands {
+		if c == command {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestWorktreeManager(t *testing.T, dir string) *WorktreeManager {
+	t.Helper()
+	cfg := config.DefaultRollbackConfig()
+	return NewWorktreeManager(NewGitCheckpointerWithWorkDir(&cfg, dir), dir, ".conductor/worktrees")
+}
+
+func initWorktreeRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	initGitRepo(t, dir)
+	writeFile(t, filepath.Join(dir, "shared.txt"), "original\n")
+	writeFile(t, filepath.Join(dir, ".gitignore"), ".conductor/\n")
+	gitAdd(t, dir, ".")
+	gitCommit(t, dir, "Initial commit")
+	return dir
+}
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\nOutput: %s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestWorktreeName(t *testing.T) {
+	tests := []struct {
+		name string
+		task models.Task
+		want string
+	}{
+		{"numeric task", models.Task{Number: "3"}, "task-3"},
+		{"dotted task", models.Task{Number: "1.2"}, "task-1.2"},
+		{"unsafe characters", models.Task{Number: "a/b c"}, "task-a-b-c"},
+		{"group wins over number", models.Task{Number: "4", WorktreeGroup: "backend api"}, "group-backend-api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WorktreeName(tt.task); got != tt.want {
+				t.Errorf("WorktreeName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorktreeManager_AcquireUsesRunnerAndCurrentBranch(t *testing.T) {
+	runner := newRecordingWorktreeRunner()
+	runner.outputs["git branch --show-current"] = "feature/run\n"
+	cfg := config.DefaultRollbackConfig()
+	m := NewWorktreeManagerWithRunner(runner, NewGitCheckpointerWithRunner(runner, &cfg), "/tmp/wt")
+
+	wt, err := m.Acquire(context.Background(), models.Task{Number: "7"})
+	if err != nil {
+		t.Fatalf("Acquire returned error: %v", err)
+	}
+
+	if wt.BaseBranch != "feature/run" {
+		t.Errorf("BaseBranch = %q, want %q", wt.BaseBranch, "feature/run")
+	}
+	if wt.Path != "/tmp/wt/task-7" {
+		t.Errorf("Path = %q, want %q", wt.Path, "/tmp/wt/task-7")
+	}
+	if wt.BranchName != "conductor-worktree-task-7" {
+		t.Errorf("BranchName = %q, want %q", wt.BranchName, "conductor-worktree-task-7")
+	}
+	if !runner.ran("git worktree add -B conductor-worktree-task-7 /tmp/wt/task-7 feature/run") {
+		t.Errorf("expected worktree add command, got %v", runner.commands)
+	}
+}
+
+func TestWorktreeManager_AcquireFailsOnDetachedHead(t *testing.T) {
+	runner := newRecordingWorktreeRunner()
+	cfg := config.DefaultRollbackConfig()
+	m := NewWorktreeManagerWithRunner(runner, NewGitCheckpointerWithRunner(runner, &cfg), "/tmp/wt")
+
+	if _, err := m.Acquire(context.Background(), models.Task{Number: "1"}); err == nil {
+		t.Fatal("expected error when HEAD is detached")
+	}
+	for _, c := range runner.commands {
+		if strings.HasPrefix(c, "git worktree add") {
+			t.Errorf("worktree should not be created on detached HEAD")
+		}
+	}
+}
+
+func TestWorktreeManager_MergeCommitsPendingChanges(t *testing.T) {
+	runner := newRecordingWorktreeRunner()
+	runner.outputs["git -C /tmp/wt/task-2 status --porcelain"] = " M main.go\n"
+	m := NewWorktreeManagerWithRunner(runner, nil, "/tmp/wt")
+
+	wt := &WorktreeInfo{Name: "task-2", Path: "/tmp/wt/task-2", BranchName: "conductor-worktree-task-2", BaseBranch: "main", refs: 1}
+	if err := m.Merge(context.Background(), wt, models.Task{Number: "2", Name: "Add feature"}); err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+
+	expected := []string{
+		"git -C /tmp/wt/task-2 add -A",
+		"git -C /tmp/wt/task-2 commit --no-verify -m 'conductor: task 2 - Add feature'",
+		"git merge --no-ff -m 'conductor: merge task 2 (conductor-worktree-task-2)' conductor-worktree-task-2",
+	}
+	for _, cmd := range expected {
+		if !runner.ran(cmd) {
+			t.Errorf("expected command %q, got %v", cmd, runner.commands)
+		}
+	}
+}
+
+func TestWorktreeManager_MergeConflictAbortsMerge(t *testing.T) {
+	runner := newRecordingWorktreeRunner()
+	mergeCmd := "git merge --no-ff -m 'conductor: merge task 2 (conductor-worktree-task-2)' conductor-worktree-task-2"
+	runner.outputs[mergeCmd] = "Auto-merging main.go\nCONFLICT (content): Merge conflict in main.go\nAutomatic merge failed\n"
+	runner.errors[mergeCmd] = errors.New("exit status 1")
+	m := NewWorktreeManagerWithRunner(runner, nil, "/tmp/wt")
+
+	wt := &WorktreeInfo{Name: "task-2", Path: "/tmp/wt/task-2", BranchName: "conductor-worktree-task-2", BaseBranch: "main", refs: 1}
+	err := m.Merge(context.Background(), wt, models.Task{Number: "2", Name: "Add feature"})
+	if !errors.Is(err, ErrWorktreeMergeConflict) {
+		t.Fatalf("expected ErrWorktreeMergeConflict, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "main.go") {
+		t.Errorf("expected conflicting file in error, got %q", err.Error())
+	}
+	if !runner.ran("git merge --abort") {
+		t.Errorf("expected merge to be aborted, got %v", runner.commands)
+	}
+}
+
+func TestWorktreeManager_MergesIntoRunBranch(t *testing.T) {
+	dir := initWorktreeRepo(t)
+	m := newTestWorktreeManager(t, dir)
+	ctx := context.Background()
+	task := models.Task{Number: "1", Name: "Add file"}
+
+	wt, err := m.Acquire(ctx, task)
+	if err != nil {
+		t.Fatalf("Acquire returned error: %v", err)
+	}
+	writeFile(t, filepath.Join(wt.Path, "new.txt"), "from worktree\n")
+
+	if _, err := os.Stat(filepath.Join(dir, "new.txt")); err == nil {
+		t.Fatal("worktree changes should not appear in the main tree before merge")
+	}
+
+	if err := m.Merge(ctx, wt, task); err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	if err := m.Release(ctx, wt); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "new.txt"))
+	if err != nil || string(content) != "from worktree\n" {
+		t.Errorf("expected merged file in main tree, got %q (err=%v)", content, err)
+	}
+	if _, err := os.Stat(wt.Path); !os.IsNotExist(err) {
+		t.Errorf("expected worktree directory to be removed")
+	}
+	if branches := gitOutput(t, dir, "branch", "--list", wt.BranchName); branches != "" {
+		t.Errorf("expected worktree branch to be deleted, got %q", branches)
+	}
+}
+
+func TestWorktreeManager_ConflictingTasksFailSecondMerge(t *testing.T) {
+	dir := initWorktreeRepo(t)
+	m := newTestWorktreeManager(t, dir)
+	ctx := context.Background()
+	first := models.Task{Number: "1", Name: "First"}
+	second := models.Task{Number: "2", Name: "Second"}
+
+	wt1, err := m.Acquire(ctx, first)
+	if err != nil {
+		t.Fatalf("Acquire(first) returned error: %v", err)
+	}
+	wt2, err := m.Acquire(ctx, second)
+	if err != nil {
+		t.Fatalf("Acquire(second) returned error: %v", err)
+	}
+	writeFile(t, filepath.Join(wt1.Path, "shared.txt"), "first\n")
+	writeFile(t, filepath.Join(wt2.Path, "shared.txt"), "second\n")
+
+	if err := m.Merge(ctx, wt1, first); err != nil {
+		t.Fatalf("Merge(first) returned error: %v", err)
+	}
+	err = m.Merge(ctx, wt2, second)
+	if !errors.Is(err, ErrWorktreeMergeConflict) {
+		t.Fatalf("expected ErrWorktreeMergeConflict, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "shared.txt") {
+		t.Errorf("expected conflicting file in error, got %q", err.Error())
+	}
+
+	_ = m.Release(ctx, wt1)
+	_ = m.Release(ctx, wt2)
+
+	content, _ := os.ReadFile(filepath.Join(dir, "shared.txt"))
+	if string(content) != "first\n" {
+		t.Errorf("main tree should keep the first task's change, got %q", content)
+	}
+	if status := gitOutput(t, dir, "status", "--porcelain"); status != "" {
+		t.Errorf("expected clean main tree after aborted merge, got %q", status)
+	}
+}
+
+func TestWorktreeManager_GroupSharesWorktree(t *testing.T) {
+	dir := initWorktreeRepo(t)
+	m := newTestWorktreeManager(t, dir)
+	ctx := context.Background()
+
+	wtA, err := m.Acquire(ctx, models.Task{Number: "1", WorktreeGroup: "backend"})
+	if err != nil {
+		t.Fatalf("Acquire returned error: %v", err)
+	}
+	wtB, err := m.Acquire(ctx, models.Task{Number: "2", WorktreeGroup: "backend"})
+	if err != nil {
+		t.Fatalf("Acquire returned error: %v", err)
+	}
+	if wtA != wtB {
+		t.Fatalf("tasks in the same group should share a worktree")
+	}
+
+	if err := m.Release(ctx, wtA); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+	if _, err := os.Stat(wtB.Path); err != nil {
+		t.Errorf("worktree should remain while another group task uses it: %v", err)
+	}
+	if err := m.Release(ctx, wtB); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+	if _, err := os.Stat(wtB.Path); !os.IsNotExist(err) {
+		t.Errorf("worktree should be removed after the last group task releases it")
+	}
+}
+
+func TestWorktreeManager_GroupMergeCommitsOnlyTaskFiles(t *testing.T) {
+	dir := initWorktreeRepo(t)
+	m := newTestWorktreeManager(t, dir)
+	ctx := context.Background()
+	first := models.Task{Number: "1", Name: "First", WorktreeGroup: "backend", Files: []string{"a.txt", "missing.txt"}}
+	second := models.Task{Number: "2", Name: "Second", WorktreeGroup: "backend", Files: []string{"b.txt"}}
+
+	wt, err := m.Acquire(ctx, first)
+	if err != nil {
+		t.Fatalf("Acquire returned error: %v", err)
+	}
+	if _, err := m.Acquire(ctx, second); err != nil {
+		t.Fatalf("Acquire returned error: %v", err)
+	}
+	writeFile(t, filepath.Join(wt.Path, "a.txt"), "first\n")
+	// The second task is still running and has already staged its work
+	writeFile(t, filepath.Join(wt.Path, "b.txt"), "in progress\n")
+	gitAdd(t, wt.Path, "b.txt")
+
+	if err := m.Merge(ctx, wt, first); err != nil {
+		t.Fatalf("Merge(first) returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.txt")); err != nil {
+		t.Errorf("first task's file should be merged: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b.txt")); err == nil {
+		t.Error("a running group task's staged changes must not be committed by another task")
+	}
+	_ = m.Release(ctx, wt)
+
+	if err := m.Merge(ctx, wt, second); err != nil {
+		t.Fatalf("Merge(second) returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b.txt")); err != nil {
+		t.Errorf("the group's last task should commit the remaining changes: %v", err)
+	}
+	_ = m.Release(ctx, wt)
+}
@@ -68,6 +68,48 @@ type IntelligentAgentSwapper struct {
 
 	// Logger is for TTS + visual during rate limit wait (passed to Invoker)
 	Logger budget.WaiterLogger
+
+	// AgentRankings scores agents by their past sessions (optional, v3.6+)
+	AgentRankings []AgentRanking
+}
+
+// AgentRanking is an agent's performance score from past sessions, computed by
+// the behavioral PerformanceScorer (v3.6+).
+type AgentRanking struct {
+	Agent      string
+	Score      float64 // Composite score (0.0-1.0)
+	Rank       int     // Rank among all scored agents, 1 = best
+	Domain     string  // Inferred domain, e.g. "backend"
+	DomainRank int     // Rank within Domain
+	Sessions   int     // Sessions the score is based on
+}
+
+// maxRankingLines caps the agent rankings listed in a selection prompt.
+const maxRankingLines = 10
+
+// FormatAgentRankings lists the rankings of agents for a selection prompt, best
+// first. When agents is non-empty only those agents are listed.
+// Returns an empty string when no listed agent has a ranking.
+func FormatAgentRankings(rankings []AgentRanking, agents []string) string {
+	allowed := make(map[string]bool, len(agents))
+	for _, name := range agents {
+		allowed[name] = true
+	}
+
+	var sb strings.Builder
+	lines := 0
+	for _, r := range rankings {
+		if len(agents) > 0 && !allowed[r.Agent] {
+			continue
+		}
+		if lines == maxRankingLines {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("- %s: score %.2f, rank %d of %d (#%d in %s) over %d sessions\n",
+			r.Agent, r.Score, r.Rank, len(rankings), r.DomainRank, r.Domain, r.Sessions))
+		lines++
+	}
+	return sb.String()
 }
 
 // NewIntelligentAgentSwapper creates a new IntelligentAgentSwapper with the specified timeout.
@@ -208,9 +250,18 @@ func (ias *IntelligentAgentSwapper) buildSwapPrompt(ctx context.Context, swapCtx
 		}
 	}
 
+	availableAgents := ias.getAvailableAgents()
+
+	// Performance rankings from past sessions (v3.6+)
+	if rankings := FormatAgentRankings(ias.AgentRankings, availableAgents); rankings != "" {
+		sb.WriteString("\n<performance_context source=\"behavioral_scoring\">\n")
+		sb.WriteString("Agents scored on success rate, cost, speed and error recovery in past sessions (higher is better):\n")
+		sb.WriteString(rankings)
+		sb.WriteString("</performance_context>\n")
+	}
+
 	// Available agents with name attributes
 	sb.WriteString("\n<available_agents>\n")
-	availableAgents := ias.getAvailableAgents()
 	if len(availableAgents) > 0 {
 		for _, agentName := range availableAgents {
 			if a, exists := ias.Registry.Get(agentName); exists && a.Description != "" {
@@ -235,6 +286,7 @@ func (ias *IntelligentAgentSwapper) buildSwapPrompt(ctx context.Context, swapCtx
 <item priority="3">Historical success - agents that succeeded with similar files</item>
 <item priority="4">Current agent weaknesses - don't recommend the same agent unless no alternative</item>
 <item priority="5">Progress made - if significant progress, maybe same approach with different agent</item>
+<item priority="6">Performance scores - between comparable specialists, prefer the higher-scored agent</item>
 </considerations>
 
 <constraints>
@@ -490,6 +490,45 @@ func TestBuildSwapPrompt_FullContext(t *testing.T) {
 	}
 }
 
+func TestBuildSwapPrompt_AgentRankings(t *testing.T) {
+	swapper := NewIntelligentAgentSwapper(agent.NewRegistry(""), nil, nil, 90*time.Second, &MockWaiterLogger{})
+	swapCtx := &SwapContext{TaskNumber: "2", TaskName: "API", CurrentAgent: "backend-developer"}
+
+	prompt, err := swapper.buildSwapPrompt(context.Background(), swapCtx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contains(prompt, "<performance_context") {
+		t.Error("prompt should have no performance context without rankings")
+	}
+
+	swapper.AgentRankings = []AgentRanking{
+		{Agent: "golang-pro", Score: 0.82, Rank: 1, Domain: "general", DomainRank: 1, Sessions: 14},
+		{Agent: "backend-developer", Score: 0.41, Rank: 2, Domain: "backend", DomainRank: 1, Sessions: 9},
+	}
+	prompt, err = swapper.buildSwapPrompt(context.Background(), swapCtx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !contains(prompt, "<performance_context source=\"behavioral_scoring\">") ||
+		!contains(prompt, "- golang-pro: score 0.82, rank 1 of 2 (#1 in general) over 14 sessions") {
+		t.Errorf("prompt should list agent rankings:\n%s", prompt)
+	}
+}
+
+func TestFormatAgentRankings(t *testing.T) {
+	rankings := []AgentRanking{
+		{Agent: "golang-pro", Score: 0.9, Rank: 1},
+		{Agent: "python-pro", Score: 0.7, Rank: 2},
+	}
+	if got := FormatAgentRankings(rankings, []string{"python-pro", "code-reviewer"}); !contains(got, "python-pro") || contains(got, "golang-pro") {
+		t.Errorf("only available agents should be listed, got %q", got)
+	}
+	if got := FormatAgentRankings(rankings, []string{"code-reviewer"}); got != "" {
+		t.Errorf("expected no rankings, got %q", got)
+	}
+}
+
 func TestBuildSwapPrompt_TruncatesLongContent(t *testing.T) {
 	registry := agent.NewRegistry("")
 	kg := NewMockKnowledgeGraph()
@@ -413,6 +413,97 @@ CREATE INDEX IF NOT EXISTS idx_kg_edges_weight ON kg_edges(weight DESC);
 		// human_estimate_source: where the estimate came from (e.g., "claude-haiku")
 		SQL: ``,
 	},
+	{
+		Version:     14,
+		Description: "Add QC result cache table for reusing verdicts across runs",
+		SQL: `
+-- QC result cache table (v3.6+)
+-- Content-addressed QC verdicts keyed by task, file contents, criteria and QC agent set
+CREATE TABLE IF NOT EXISTS qc_result_cache (
+    cache_key TEXT PRIMARY KEY,
+    task_number TEXT NOT NULL,
+    task_hash TEXT NOT NULL,
+    files_hash TEXT NOT NULL,
+    criteria_hash TEXT NOT NULL,
+    agent_set TEXT NOT NULL,
+    verdict TEXT NOT NULL,
+    result TEXT NOT NULL, -- JSON-encoded review result
+    hit_count INTEGER DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_hit_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_qc_result_cache_task_hash ON qc_result_cache(task_hash);
+CREATE INDEX IF NOT EXISTS idx_qc_result_cache_created ON qc_result_cache(created_at DESC);
+`,
+	},
+	{
+		Version:     15,
+		Description: "Add token usage and cost columns to task_executions",
+		// This migration adds columns for per-attempt cost accounting.
+		// input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens: tokens reported by the CLI
+		// cost_usd: cost of the agent invocation and its QC review
+		SQL: ``,
+	},
+	{
+		Version:     16,
+		Description: "Add model column to task_executions for model routing",
+		// This migration records the Claude model chosen by model routing for each attempt,
+		// so routing and escalation decisions can be compared by outcome and cost.
+		SQL: ``,
+	},
+	{
+		Version:     17,
+		Description: "Add QC agent verdicts table for weighted verdict aggregation",
+		SQL: `
+-- QC agent verdicts table (v3.6+)
+-- Each QC agent's verdict in a multi-agent review, with the verdict the review settled on
+CREATE TABLE IF NOT EXISTS qc_agent_verdicts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    plan_file TEXT,
+    task_number TEXT NOT NULL,
+    agent TEXT NOT NULL,
+    verdict TEXT NOT NULL,
+    final_verdict TEXT NOT NULL,
+    strategy TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_qc_agent_verdicts_agent ON qc_agent_verdicts(agent);
+CREATE INDEX IF NOT EXISTS idx_qc_agent_verdicts_task ON qc_agent_verdicts(task_number);
+`,
+	},
+	{
+		Version:     18,
+		Description: "Add review outcome columns to qc_agent_verdicts for QC calibration",
+		// This migration groups the verdicts of one review and records what the task's
+		// outcome later showed about them, so each agent can be scored for calibration.
+		// review_id: shared by the verdicts of one review
+		// file_types: comma-separated extensions of the reviewed task's files
+		// outcome: good or bad, empty until known
+		// outcome_source: tests, fix_task or override
+		SQL: `
+CREATE INDEX IF NOT EXISTS idx_qc_agent_verdicts_review ON qc_agent_verdicts(review_id);
+`,
+	},
+	{
+		Version:     19,
+		Description: "Add QC reviews table to allocate review IDs",
+		// Review IDs come from this table's autoincrement key, so parallel tasks
+		// recording reviews at the same time never share an ID. It is seeded with
+		// the highest review_id already recorded.
+		SQL: `
+CREATE TABLE IF NOT EXISTS qc_reviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    plan_file TEXT,
+    task_number TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT OR IGNORE INTO qc_reviews (id)
+    SELECT review_id FROM qc_agent_verdicts WHERE review_id IS NOT NULL ORDER BY review_id DESC LIMIT 1;
+`,
+	},
 }
 
 // MigrationVersion represents a record of an applied migration
@@ -490,6 +581,27 @@ func (s *Store) ApplyMigrations(ctx context.Context) error {
 			}
 		}
 
+		// Handle migration 15 special case: add usage columns idempotently
+		if migration.Version == 15 {
+			if err := s.applyMigration15Tx(ctx, tx); err != nil {
+				return fmt.Errorf("apply migration %d (%s): %w", migration.Version, migration.Description, err)
+			}
+		}
+
+		// Handle migration 16 special case: add model column idempotently
+		if migration.Version == 16 {
+			if err := s.applyMigration16Tx(ctx, tx); err != nil {
+				return fmt.Errorf("apply migration %d (%s): %w", migration.Version, migration.Description, err)
+			}
+		}
+
+		// Handle migration 18 special case: add outcome columns idempotently
+		if migration.Version == 18 {
+			if err := s.applyMigration18Tx(ctx, tx); err != nil {
+				return fmt.Errorf("apply migration %d (%s): %w", migration.Version, migration.Description, err)
+			}
+		}
+
 		// Execute migration SQL (indexes are IF NOT EXISTS, safe to re-run)
 		if migration.SQL != "" {
 			if _, err := tx.ExecContext(ctx, migration.SQL); err != nil {
@@ -823,3 +935,52 @@ func (s *Store) applyMigration13Tx(ctx context.Context, tx *sql.Tx) error {
 
 	return nil
 }
+
+// applyMigration15Tx adds token usage and cost columns to task_executions (within transaction).
+func (s *Store) applyMigration15Tx(ctx context.Context, tx *sql.Tx) error {
+	columns := []struct {
+		name string
+		def  string
+	}{
+		{"input_tokens", "INTEGER DEFAULT 0"},
+		{"output_tokens", "INTEGER DEFAULT 0"},
+		{"cache_creation_tokens", "INTEGER DEFAULT 0"},
+		{"cache_read_tokens", "INTEGER DEFAULT 0"},
+		{"cost_usd", "REAL DEFAULT 0"},
+	}
+
+	for _, col := range columns {
+		if err := s.addColumnIfNotExistsTx(ctx, tx, "task_executions", col.name, col.def); err != nil {
+			return fmt.Errorf("add column %s: %w", col.name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration16Tx adds the routed model column to task_executions (within transaction).
+func (s *Store) applyMigration16Tx(ctx context.Context, tx *sql.Tx) error {
+	if err := s.addColumnIfNotExistsTx(ctx, tx, "task_executions", "model", "TEXT"); err != nil {
+		return fmt.Errorf("add column model: %w", err)
+	}
+	return nil
+}
+
+// applyMigration18Tx adds the review outcome columns to qc_agent_verdicts (within transaction).
+func (s *Store) applyMigration18Tx(ctx context.Context, tx *sql.Tx) error {
+	columns := []struct {
+		name string
+		def  string
+	}{
+		{"review_id", "INTEGER"},
+		{"file_types", "TEXT"},
+		{"outcome", "TEXT"},
+		{"outcome_source", "TEXT"},
+	}
+	for _, col := range columns {
+		if err := s.addColumnIfNotExistsTx(ctx, tx, "qc_agent_verdicts", col.name, col.def); err != nil {
+			return fmt.Errorf("add column %s: %w", col.name, err)
+		}
+	}
+	return nil
+}
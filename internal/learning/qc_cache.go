@@ -0,0 +1,112 @@
+package learning
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// QCCacheEntry is a cached QC review result (v3.6+).
+// The cache key is derived from the task definition, the contents of the task's
+// files, its criteria and the QC agent set, so an entry is only reused when
+// none of those changed.
+type QCCacheEntry struct {
+	CacheKey     string
+	TaskNumber   string
+	TaskHash     string // pattern.TaskHasher full hash of the task definition
+	FilesHash    string // Hash of the task files' git blob hashes
+	CriteriaHash string // Hash of success/integration criteria and test commands
+	AgentSet     string // Canonical QC agent configuration
+	Verdict      string // GREEN, YELLOW or RED
+	Result       string // JSON-encoded review result
+	HitCount     int
+	CreatedAt    time.Time
+}
+
+// StoreQCResult inserts or replaces a cached QC result.
+func (s *Store) StoreQCResult(ctx context.Context, entry *QCCacheEntry) error {
+	if entry == nil {
+		return fmt.Errorf("entry cannot be nil")
+	}
+	if entry.CacheKey == "" {
+		return fmt.Errorf("cache key cannot be empty")
+	}
+
+	query := `INSERT INTO qc_result_cache
+		(cache_key, task_number, task_hash, files_hash, criteria_hash, agent_set, verdict, result, hit_count, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0, CURRENT_TIMESTAMP)
+		ON CONFLICT(cache_key) DO UPDATE SET
+			task_number = excluded.task_number,
+			verdict = excluded.verdict,
+			result = excluded.result,
+			hit_count = 0,
+			created_at = CURRENT_TIMESTAMP,
+			last_hit_at = NULL`
+
+	_, err := s.db.ExecContext(ctx, query,
+		entry.CacheKey,
+		entry.TaskNumber,
+		entry.TaskHash,
+		entry.FilesHash,
+		entry.CriteriaHash,
+		entry.AgentSet,
+		entry.Verdict,
+		entry.Result,
+	)
+	if err != nil {
+		return fmt.Errorf("store qc result: %w", err)
+	}
+
+	return nil
+}
+
+// GetQCResult retrieves a cached QC result by key and records the hit.
+// Returns nil, nil if no entry exists.
+func (s *Store) GetQCResult(ctx context.Context, cacheKey string) (*QCCacheEntry, error) {
+	query := `SELECT cache_key, task_number, task_hash, files_hash, criteria_hash, agent_set, verdict, result, hit_count, created_at
+		FROM qc_result_cache WHERE cache_key = ?`
+
+	entry := &QCCacheEntry{}
+	err := s.db.QueryRowContext(ctx, query, cacheKey).Scan(
+		&entry.CacheKey,
+		&entry.TaskNumber,
+		&entry.TaskHash,
+		&entry.FilesHash,
+		&entry.CriteriaHash,
+		&entry.AgentSet,
+		&entry.Verdict,
+		&entry.Result,
+		&entry.HitCount,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get qc result: %w", err)
+	}
+
+	// Hit tracking is best-effort; a failed update doesn't invalidate the entry
+	_, _ = s.db.ExecContext(ctx,
+		`UPDATE qc_result_cache SET hit_count = hit_count + 1, last_hit_at = CURRENT_TIMESTAMP WHERE cache_key = ?`,
+		cacheKey)
+
+	return entry, nil
+}
+
+// DeleteQCResults removes cached QC results created before the cutoff.
+// A zero cutoff removes every entry. Returns the number of entries removed.
+func (s *Store) DeleteQCResults(ctx context.Context, before time.Time) (int64, error) {
+	var res sql.Result
+	var err error
+	if before.IsZero() {
+		res, err = s.db.ExecContext(ctx, `DELETE FROM qc_result_cache`)
+	} else {
+		res, err = s.db.ExecContext(ctx, `DELETE FROM qc_result_cache WHERE created_at < ?`, before.UTC().Format("2006-01-02 15:04:05"))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("delete qc results: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,95 @@
+package learning
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestQCResultCacheStoreMethods(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	entry := &QCCacheEntry{
+		CacheKey:     "key-1",
+		TaskNumber:   "3",
+		TaskHash:     "taskhash",
+		FilesHash:    "fileshash",
+		CriteriaHash: "criteriahash",
+		AgentSet:     "mode=auto",
+		Verdict:      "GREEN",
+		Result:       `{"Flag":"GREEN"}`,
+	}
+
+	t.Run("missing key returns nil", func(t *testing.T) {
+		got, err := store.GetQCResult(ctx, "missing")
+		if err != nil || got != nil {
+			t.Errorf("GetQCResult(missing) = %v, %v; want nil, nil", got, err)
+		}
+	})
+
+	t.Run("store and get", func(t *testing.T) {
+		if err := store.StoreQCResult(ctx, entry); err != nil {
+			t.Fatalf("StoreQCResult() error = %v", err)
+		}
+
+		got, err := store.GetQCResult(ctx, "key-1")
+		if err != nil || got == nil {
+			t.Fatalf("GetQCResult() = %v, %v", got, err)
+		}
+		if got.Verdict != "GREEN" || got.Result != `{"Flag":"GREEN"}` || got.TaskNumber != "3" || got.FilesHash != "fileshash" {
+			t.Errorf("unexpected entry: %+v", got)
+		}
+		if got.CreatedAt.IsZero() {
+			t.Error("expected created_at to be set")
+		}
+
+		// The first lookup counts as a hit for the next one
+		got, _ = store.GetQCResult(ctx, "key-1")
+		if got.HitCount != 1 {
+			t.Errorf("HitCount = %d, want 1", got.HitCount)
+		}
+	})
+
+	t.Run("store replaces existing entry", func(t *testing.T) {
+		replaced := *entry
+		replaced.Verdict = "RED"
+		replaced.Result = `{"Flag":"RED"}`
+		if err := store.StoreQCResult(ctx, &replaced); err != nil {
+			t.Fatalf("StoreQCResult() error = %v", err)
+		}
+
+		got, _ := store.GetQCResult(ctx, "key-1")
+		if got.Verdict != "RED" || got.HitCount != 0 {
+			t.Errorf("expected replaced entry with reset hit count, got %+v", got)
+		}
+	})
+
+	t.Run("rejects invalid entries", func(t *testing.T) {
+		if err := store.StoreQCResult(ctx, nil); err == nil {
+			t.Error("expected error for nil entry")
+		}
+		if err := store.StoreQCResult(ctx, &QCCacheEntry{}); err == nil {
+			t.Error("expected error for empty cache key")
+		}
+	})
+
+	t.Run("delete by age", func(t *testing.T) {
+		removed, err := store.DeleteQCResults(ctx, time.Now().Add(-time.Hour))
+		if err != nil || removed != 0 {
+			t.Errorf("DeleteQCResults(1h ago) = %d, %v; want 0 (entry is newer)", removed, err)
+		}
+
+		removed, err = store.DeleteQCResults(ctx, time.Now().Add(time.Hour))
+		if err != nil || removed != 1 {
+			t.Errorf("DeleteQCResults(in 1h) = %d, %v; want 1", removed, err)
+		}
+		if got, _ := store.GetQCResult(ctx, "key-1"); got != nil {
+			t.Errorf("entry should be deleted, got %+v", got)
+		}
+	})
+}
@@ -0,0 +1,303 @@
+package learning
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// QC review outcomes (v3.6+). A review's outcome is what later evidence showed
+// about the reviewed work, against which each agent's verdict is scored.
+const (
+	QCOutcomeGood = "good" // The work was sound; RED verdicts were false alarms
+	QCOutcomeBad  = "bad"  // The work needed fixing; passing verdicts missed it
+)
+
+// Sources of a QC review outcome, weakest first. A stronger source replaces
+// the outcome recorded by a weaker one, never the reverse.
+const (
+	QCOutcomeSourceTests    = "tests"    // Test commands passed and another reviewer contradicted a RED
+	QCOutcomeSourceFixTask  = "fix_task" // A later task was declared to fix this one
+	QCOutcomeSourceOverride = "override" // A human set the outcome
+)
+
+// qcOutcomeSourceRank orders outcome sources by how much they are trusted.
+var qcOutcomeSourceRank = map[string]int{
+	QCOutcomeSourceTests:    1,
+	QCOutcomeSourceFixTask:  2,
+	QCOutcomeSourceOverride: 3,
+}
+
+// QCAgentVerdict is one QC agent's verdict in a multi-agent review (v3.6+).
+type QCAgentVerdict struct {
+	PlanFile      string
+	TaskNumber    string
+	Agent         string
+	Verdict       string   // GREEN, YELLOW or RED
+	FinalVerdict  string   // Verdict the review settled on
+	Strategy      string   // Aggregation strategy that produced FinalVerdict
+	FileTypes     []string // Extensions of the reviewed task's files, e.g. ".go"
+	Outcome       string   // QCOutcomeGood or QCOutcomeBad when known at review time
+	OutcomeSource string   // Where Outcome came from
+}
+
+// QCAgentAgreement summarizes how often an agent's verdicts agreed with the
+// outcome of the reviewed work (v3.6+). Only reviews with a known outcome count.
+// GREEN and YELLOW both count as passing, so an agent agrees when it passed
+// sound work or failed work that needed fixing. The review's own final verdict
+// is not used: it is produced by the agents being scored.
+type QCAgentAgreement struct {
+	Agent   string
+	Reviews int
+	Agreed  int
+}
+
+// Rate returns the agreement rate, or 0 with no recorded reviews.
+func (a QCAgentAgreement) Rate() float64 {
+	if a.Reviews == 0 {
+		return 0
+	}
+	return float64(a.Agreed) / float64(a.Reviews)
+}
+
+// QCCalibration scores a QC agent's verdicts, or all verdicts on one file
+// type, against review outcomes (v3.6+). RED is the positive class: a true
+// positive is a RED on work that needed fixing, a false negative a passing
+// verdict on it. GREEN and YELLOW both count as passing.
+type QCCalibration struct {
+	Name           string // Agent or file type
+	Reviews        int    // All recorded verdicts
+	Labeled        int    // Verdicts whose review has a known outcome
+	TruePositives  int
+	FalsePositives int
+	FalseNegatives int
+	TrueNegatives  int
+}
+
+// Precision returns the share of RED verdicts that were right, or 0 with no REDs.
+func (c QCCalibration) Precision() float64 {
+	if c.TruePositives+c.FalsePositives == 0 {
+		return 0
+	}
+	return float64(c.TruePositives) / float64(c.TruePositives+c.FalsePositives)
+}
+
+// Recall returns the share of bad outcomes the verdicts caught, or 0 with none.
+func (c QCCalibration) Recall() float64 {
+	if c.TruePositives+c.FalseNegatives == 0 {
+		return 0
+	}
+	return float64(c.TruePositives) / float64(c.TruePositives+c.FalseNegatives)
+}
+
+// Accuracy returns the share of labeled verdicts that matched the outcome,
+// or 0 with none labeled.
+func (c QCCalibration) Accuracy() float64 {
+	if c.Labeled == 0 {
+		return 0
+	}
+	return float64(c.TruePositives+c.TrueNegatives) / float64(c.Labeled)
+}
+
+// add scores one verdict against its review's outcome.
+func (c *QCCalibration) add(verdict, outcome string) {
+	c.Reviews++
+	red := verdict == "RED"
+	switch outcome {
+	case QCOutcomeBad:
+		c.Labeled++
+		if red {
+			c.TruePositives++
+		} else {
+			c.FalseNegatives++
+		}
+	case QCOutcomeGood:
+		c.Labeled++
+		if red {
+			c.FalsePositives++
+		} else {
+			c.TrueNegatives++
+		}
+	}
+}
+
+// RecordQCVerdicts stores the agent verdicts of one review under a new review ID.
+func (s *Store) RecordQCVerdicts(ctx context.Context, verdicts []QCAgentVerdict) error {
+	if len(verdicts) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The autoincrement key keeps IDs unique across concurrent reviews
+	first := verdicts[0]
+	res, err := tx.ExecContext(ctx, `INSERT INTO qc_reviews (plan_file, task_number) VALUES (?, ?)`, first.PlanFile, first.TaskNumber)
+	if err != nil {
+		return fmt.Errorf("allocate qc review id: %w", err)
+	}
+	reviewID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("allocate qc review id: %w", err)
+	}
+
+	query := `INSERT INTO qc_agent_verdicts (plan_file, task_number, agent, verdict, final_verdict, strategy,
+		review_id, file_types, outcome, outcome_source)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, v := range verdicts {
+		if v.Agent == "" || v.Verdict == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, query, v.PlanFile, v.TaskNumber, v.Agent, v.Verdict, v.FinalVerdict, v.Strategy,
+			reviewID, strings.Join(v.FileTypes, ","), v.Outcome, v.OutcomeSource); err != nil {
+			return fmt.Errorf("record qc verdict: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit qc verdicts: %w", err)
+	}
+	return nil
+}
+
+// GetQCAgentAgreement returns the agreement of each of agents with recorded
+// review outcomes. Agents without a review with a known outcome are omitted.
+func (s *Store) GetQCAgentAgreement(ctx context.Context, agents []string) (map[string]QCAgentAgreement, error) {
+	result := make(map[string]QCAgentAgreement)
+	if len(agents) == 0 {
+		return result, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(agents)), ",")
+	query := fmt.Sprintf(`SELECT agent, COUNT(*),
+		SUM(CASE WHEN (verdict = 'RED') = (outcome = ?) THEN 1 ELSE 0 END)
+		FROM qc_agent_verdicts WHERE agent IN (%s) AND outcome IN (?, ?) GROUP BY agent`, placeholders)
+	args := []interface{}{QCOutcomeBad}
+	for _, agent := range agents {
+		args = append(args, agent)
+	}
+	args = append(args, QCOutcomeGood, QCOutcomeBad)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query qc agent agreement: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a QCAgentAgreement
+		if err := rows.Scan(&a.Agent, &a.Reviews, &a.Agreed); err != nil {
+			return nil, fmt.Errorf("scan qc agent agreement: %w", err)
+		}
+		result[a.Agent] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate qc agent agreement: %w", err)
+	}
+	return result, nil
+}
+
+// SetQCReviewOutcome records the outcome of the latest recorded review of a
+// task. An outcome from a weaker source than the one already recorded is
+// ignored. planFile matches by path or base name. Returns the number of agent
+// verdicts updated, 0 when the task has no recorded review.
+func (s *Store) SetQCReviewOutcome(ctx context.Context, planFile, taskNumber, outcome, source string) (int64, error) {
+	if outcome != QCOutcomeGood && outcome != QCOutcomeBad {
+		return 0, fmt.Errorf("invalid qc outcome %q: must be %s or %s", outcome, QCOutcomeGood, QCOutcomeBad)
+	}
+	rank, ok := qcOutcomeSourceRank[source]
+	if !ok {
+		return 0, fmt.Errorf("invalid qc outcome source %q", source)
+	}
+
+	planClause, planArgs := planFileFilter(planFile)
+	var reviewID sql.NullInt64
+	var existing string
+	query := `SELECT review_id, COALESCE(outcome_source, '') FROM qc_agent_verdicts
+		WHERE task_number = ? AND review_id IS NOT NULL AND ` + planClause + `
+		ORDER BY review_id DESC LIMIT 1`
+	err := s.db.QueryRowContext(ctx, query, append([]interface{}{taskNumber}, planArgs...)...).Scan(&reviewID, &existing)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("find qc review: %w", err)
+	}
+	if qcOutcomeSourceRank[existing] > rank {
+		return 0, nil
+	}
+
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE qc_agent_verdicts SET outcome = ?, outcome_source = ? WHERE review_id = ?`,
+		outcome, source, reviewID.Int64)
+	if err != nil {
+		return 0, fmt.Errorf("set qc review outcome: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetQCCalibration scores recorded agent verdicts against review outcomes,
+// by agent and by file type. An empty planFile covers every plan.
+func (s *Store) GetQCCalibration(ctx context.Context, planFile string) (byAgent, byFileType map[string]QCCalibration, err error) {
+	query := `SELECT agent, verdict, COALESCE(file_types, ''), COALESCE(outcome, '') FROM qc_agent_verdicts`
+	var args []interface{}
+	if planFile != "" {
+		var clause string
+		clause, args = planFileFilter(planFile)
+		query += " WHERE " + clause
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query qc calibration: %w", err)
+	}
+	defer rows.Close()
+
+	agents := make(map[string]*QCCalibration)
+	fileTypes := make(map[string]*QCCalibration)
+	score := func(m map[string]*QCCalibration, name, verdict, outcome string) {
+		c, ok := m[name]
+		if !ok {
+			c = &QCCalibration{Name: name}
+			m[name] = c
+		}
+		c.add(verdict, outcome)
+	}
+	for rows.Next() {
+		var agent, verdict, types, outcome string
+		if err := rows.Scan(&agent, &verdict, &types, &outcome); err != nil {
+			return nil, nil, fmt.Errorf("scan qc calibration: %w", err)
+		}
+		score(agents, agent, verdict, outcome)
+		for _, ext := range strings.Split(types, ",") {
+			if ext != "" {
+				score(fileTypes, ext, verdict, outcome)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("iterate qc calibration: %w", err)
+	}
+
+	byAgent = make(map[string]QCCalibration, len(agents))
+	for name, c := range agents {
+		byAgent[name] = *c
+	}
+	byFileType = make(map[string]QCCalibration, len(fileTypes))
+	for name, c := range fileTypes {
+		byFileType[name] = *c
+	}
+	return byAgent, byFileType, nil
+}
+
+// planFileFilter matches qc_agent_verdicts rows recorded for planFile, whether
+// they store it as given, by base name or under another directory.
+func planFileFilter(planFile string) (string, []interface{}) {
+	base := filepath.Base(planFile)
+	return "(plan_file = ? OR plan_file = ? OR plan_file LIKE ?)", []interface{}{planFile, base, "%/" + base}
+}
@@ -0,0 +1,171 @@
+package learning
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestQCAgentVerdicts(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	reviews := [][]QCAgentVerdict{
+		{
+			{TaskNumber: "1", Agent: "code-reviewer", Verdict: "GREEN", FinalVerdict: "RED", Outcome: QCOutcomeGood},
+			{TaskNumber: "1", Agent: "strict-reviewer", Verdict: "RED", FinalVerdict: "RED", Outcome: QCOutcomeGood},
+		},
+		{
+			{TaskNumber: "2", Agent: "code-reviewer", Verdict: "YELLOW", FinalVerdict: "RED", Outcome: QCOutcomeGood},
+			{TaskNumber: "2", Agent: "strict-reviewer", Verdict: "RED", FinalVerdict: "RED", Outcome: QCOutcomeGood},
+			{TaskNumber: "2", Agent: "broken-reviewer", Verdict: "", FinalVerdict: "RED", Outcome: QCOutcomeGood},
+		},
+		{
+			{TaskNumber: "3", Agent: "code-reviewer", Verdict: "RED", FinalVerdict: "RED", Outcome: QCOutcomeBad},
+			{TaskNumber: "3", Agent: "strict-reviewer", Verdict: "RED", FinalVerdict: "RED", Outcome: QCOutcomeBad},
+		},
+		{
+			// No outcome yet: not scored
+			{TaskNumber: "4", Agent: "code-reviewer", Verdict: "GREEN", FinalVerdict: "RED"},
+			{TaskNumber: "4", Agent: "strict-reviewer", Verdict: "RED", FinalVerdict: "RED"},
+		},
+	}
+	for _, verdicts := range reviews {
+		if err := store.RecordQCVerdicts(ctx, verdicts); err != nil {
+			t.Fatalf("RecordQCVerdicts() error = %v", err)
+		}
+	}
+
+	// strict-reviewer always matched the strictest final verdict, but only one outcome
+	agreement, err := store.GetQCAgentAgreement(ctx, []string{"code-reviewer", "strict-reviewer", "broken-reviewer", "unknown"})
+	if err != nil {
+		t.Fatalf("GetQCAgentAgreement() error = %v", err)
+	}
+	if got := agreement["code-reviewer"]; got.Reviews != 3 || got.Agreed != 3 || got.Rate() != 1 {
+		t.Errorf("code-reviewer agreement = %+v, want 3/3", got)
+	}
+	if got := agreement["strict-reviewer"]; got.Reviews != 3 || got.Agreed != 1 {
+		t.Errorf("strict-reviewer agreement = %+v, want 1/3", got)
+	}
+	if _, ok := agreement["broken-reviewer"]; ok {
+		t.Error("agents without a verdict should not be recorded")
+	}
+	if _, ok := agreement["unknown"]; ok {
+		t.Error("agents without reviews should be omitted")
+	}
+}
+
+func TestQCCalibration(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	plan := "/work/plans/api.yaml"
+	review := func(task string, outcome, source string, verdicts map[string]string) {
+		t.Helper()
+		var vs []QCAgentVerdict
+		for agent, verdict := range verdicts {
+			vs = append(vs, QCAgentVerdict{PlanFile: plan, TaskNumber: task, Agent: agent, Verdict: verdict,
+				FinalVerdict: "GREEN", FileTypes: []string{".go"}, Outcome: outcome, OutcomeSource: source})
+		}
+		if err := store.RecordQCVerdicts(ctx, vs); err != nil {
+			t.Fatalf("RecordQCVerdicts() error = %v", err)
+		}
+	}
+
+	// Task 1: strict-reviewer's RED contradicted by tests
+	review("1", QCOutcomeGood, QCOutcomeSourceTests, map[string]string{"code-reviewer": "GREEN", "strict-reviewer": "RED"})
+	// Task 2: reviewed twice; only the latest review is labeled by the fix task
+	review("2", "", "", map[string]string{"code-reviewer": "RED", "strict-reviewer": "RED"})
+	review("2", "", "", map[string]string{"code-reviewer": "GREEN", "strict-reviewer": "RED"})
+	n, err := store.SetQCReviewOutcome(ctx, "api.yaml", "2", QCOutcomeBad, QCOutcomeSourceFixTask)
+	if err != nil || n != 2 {
+		t.Fatalf("SetQCReviewOutcome() = %d, %v; want 2 verdicts updated", n, err)
+	}
+
+	// Test evidence cannot replace a human override
+	if _, err := store.SetQCReviewOutcome(ctx, plan, "1", QCOutcomeBad, QCOutcomeSourceOverride); err != nil {
+		t.Fatalf("SetQCReviewOutcome() error = %v", err)
+	}
+	if n, _ := store.SetQCReviewOutcome(ctx, plan, "1", QCOutcomeGood, QCOutcomeSourceTests); n != 0 {
+		t.Error("a weaker source should not replace an override")
+	}
+	if n, _ := store.SetQCReviewOutcome(ctx, plan, "9", QCOutcomeBad, QCOutcomeSourceOverride); n != 0 {
+		t.Error("a task without reviews should update nothing")
+	}
+	if _, err := store.SetQCReviewOutcome(ctx, plan, "1", "maybe", QCOutcomeSourceOverride); err == nil {
+		t.Error("expected an invalid outcome to be rejected")
+	}
+
+	byAgent, byFileType, err := store.GetQCCalibration(ctx, "")
+	if err != nil {
+		t.Fatalf("GetQCCalibration() error = %v", err)
+	}
+	// code-reviewer: task 1 GREEN on bad work (override), task 2 GREEN on bad work
+	if got := byAgent["code-reviewer"]; got.Reviews != 3 || got.Labeled != 2 || got.FalseNegatives != 2 || got.Recall() != 0 {
+		t.Errorf("code-reviewer calibration = %+v", got)
+	}
+	// strict-reviewer: both labeled REDs were right
+	if got := byAgent["strict-reviewer"]; got.TruePositives != 2 || got.Precision() != 1 || got.Recall() != 1 || got.Accuracy() != 1 {
+		t.Errorf("strict-reviewer calibration = %+v", got)
+	}
+	if got := byFileType[".go"]; got.Reviews != 6 || got.Labeled != 4 || got.Accuracy() != 0.5 {
+		t.Errorf(".go calibration = %+v", got)
+	}
+
+	byAgent, _, err = store.GetQCCalibration(ctx, "other.yaml")
+	if err != nil || len(byAgent) != 0 {
+		t.Errorf("another plan should have no calibration, got %v, %v", byAgent, err)
+	}
+}
+
+func TestRecordQCVerdicts_ConcurrentReviewsGetDistinctIDs(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "learning.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	const reviews = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, reviews)
+	for i := 0; i < reviews; i++ {
+		wg.Add(1)
+		go func(task string) {
+			defer wg.Done()
+			errs <- store.RecordQCVerdicts(ctx, []QCAgentVerdict{
+				{TaskNumber: task, Agent: "code-reviewer", Verdict: "GREEN", FinalVerdict: "GREEN"},
+				{TaskNumber: task, Agent: "strict-reviewer", Verdict: "RED", FinalVerdict: "GREEN"},
+			})
+		}(fmt.Sprint(i + 1))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("RecordQCVerdicts() error = %v", err)
+		}
+	}
+
+	var ids, mixed int
+	if err := store.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT review_id) FROM qc_agent_verdicts`).Scan(&ids); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (SELECT review_id FROM qc_agent_verdicts
+		GROUP BY review_id HAVING COUNT(DISTINCT task_number) > 1)`).Scan(&mixed); err != nil {
+		t.Fatal(err)
+	}
+	if ids != reviews || mixed != 0 {
+		t.Errorf("got %d review IDs (%d shared between tasks), want %d distinct", ids, mixed, reviews)
+	}
+}
@@ -0,0 +1,116 @@
+package learning
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SessionToolUsage is how often a session used one tool.
+type SessionToolUsage struct {
+	Name   string
+	Count  int
+	Errors int
+}
+
+// SessionHistory is one recorded or ingested agent session with its outcome and
+// tool usage, the input for behavioral failure prediction and agent scoring (v3.6+).
+// Sessions not linked to a task execution count as successful, as in observe.
+type SessionHistory struct {
+	ID           int64
+	Project      string
+	PlanFile     string // Empty for sessions not linked to a task execution
+	TaskNumber   string
+	Agent        string
+	Model        string
+	Start        time.Time
+	DurationSecs int64
+	Success      bool
+	ErrorCount   int // Failed tool executions
+	InputTokens  int64
+	OutputTokens int64
+	Tools        []SessionToolUsage
+}
+
+// GetSessionHistory returns the most recent sessions with their tool usage,
+// newest first. A limit <= 0 returns every session.
+func (s *Store) GetSessionHistory(ctx context.Context, limit int) ([]SessionHistory, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT
+			bs.id,
+			COALESCE(bs.project_path, ''),
+			COALESCE(te.plan_file, ''),
+			COALESCE(te.task_number, ''),
+			COALESCE(te.agent, bs.agent_type, ''),
+			COALESCE(bs.model_name, ''),
+			bs.session_start,
+			COALESCE(bs.total_duration_seconds, 0),
+			COALESCE(te.success, 1),
+			COALESCE((SELECT SUM(input_tokens) FROM token_usage tu WHERE tu.session_id = bs.id), 0),
+			COALESCE((SELECT SUM(output_tokens) FROM token_usage tu WHERE tu.session_id = bs.id), 0)
+		FROM behavioral_sessions bs
+		LEFT JOIN task_executions te ON bs.task_execution_id = te.id AND bs.task_execution_id > 0
+		ORDER BY bs.session_start DESC, bs.id DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query session history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []SessionHistory
+	index := make(map[int64]int)
+	for rows.Next() {
+		var h SessionHistory
+		var start sql.NullTime
+		if err := rows.Scan(&h.ID, &h.Project, &h.PlanFile, &h.TaskNumber, &h.Agent, &h.Model,
+			&start, &h.DurationSecs, &h.Success, &h.InputTokens, &h.OutputTokens); err != nil {
+			return nil, fmt.Errorf("scan session history row: %w", err)
+		}
+		if start.Valid {
+			h.Start = start.Time
+		}
+		index[h.ID] = len(history)
+		history = append(history, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate session history: %w", err)
+	}
+	if len(history) == 0 {
+		return history, nil
+	}
+
+	toolRows, err := s.db.QueryContext(ctx, `
+		SELECT session_id, tool_name, COUNT(*), SUM(CASE WHEN success THEN 0 ELSE 1 END)
+		FROM tool_executions
+		WHERE session_id IN (
+			SELECT id FROM behavioral_sessions ORDER BY session_start DESC, id DESC LIMIT ?
+		)
+		GROUP BY session_id, tool_name
+		ORDER BY session_id, tool_name`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query session tools: %w", err)
+	}
+	defer toolRows.Close()
+
+	for toolRows.Next() {
+		var sessionID int64
+		var tool SessionToolUsage
+		if err := toolRows.Scan(&sessionID, &tool.Name, &tool.Count, &tool.Errors); err != nil {
+			return nil, fmt.Errorf("scan session tool row: %w", err)
+		}
+		if i, ok := index[sessionID]; ok {
+			history[i].Tools = append(history[i].Tools, tool)
+			history[i].ErrorCount += tool.Errors
+		}
+	}
+	if err := toolRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate session tools: %w", err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,62 @@
+package learning
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetSessionHistory(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	exec := &TaskExecution{PlanFile: "plan.md", TaskNumber: "3", TaskName: "Parser", Agent: "golang-pro", Prompt: "p", Success: false}
+	if err := store.RecordExecution(ctx, exec); err != nil {
+		t.Fatalf("RecordExecution: %v", err)
+	}
+	start := time.Now().Add(-time.Hour)
+	if _, err := store.RecordSessionMetrics(ctx, &BehavioralSessionData{TaskExecutionID: exec.ID, SessionStart: start, TotalDurationSecs: 120},
+		[]ToolExecutionData{
+			{ToolName: "Bash", Success: true},
+			{ToolName: "Bash", Success: false},
+			{ToolName: "Edit", Success: true},
+		}, nil, nil,
+		[]TokenUsageData{{InputTokens: 1000, OutputTokens: 200}}); err != nil {
+		t.Fatalf("RecordSessionMetrics: %v", err)
+	}
+
+	// An ingested session not linked to a task execution
+	if _, _, err := store.UpsertClaudeSession(ctx, "ext-1", "/work/app", "frontend-developer"); err != nil {
+		t.Fatalf("UpsertClaudeSession: %v", err)
+	}
+
+	history, err := store.GetSessionHistory(ctx, 0)
+	if err != nil {
+		t.Fatalf("GetSessionHistory: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(history))
+	}
+
+	ingested, recorded := history[0], history[1]
+	if ingested.Agent != "frontend-developer" || ingested.Project != "/work/app" || !ingested.Success || len(ingested.Tools) != 0 {
+		t.Errorf("unexpected ingested session: %+v", ingested)
+	}
+	if recorded.Agent != "golang-pro" || recorded.PlanFile != "plan.md" || recorded.TaskNumber != "3" || recorded.Success {
+		t.Errorf("unexpected recorded session: %+v", recorded)
+	}
+	if recorded.DurationSecs != 120 || recorded.InputTokens != 1000 || recorded.OutputTokens != 200 {
+		t.Errorf("unexpected duration/tokens: %+v", recorded)
+	}
+	if len(recorded.Tools) != 2 || recorded.Tools[0] != (SessionToolUsage{Name: "Bash", Count: 2, Errors: 1}) || recorded.ErrorCount != 1 {
+		t.Errorf("unexpected tool usage: %+v (errors %d)", recorded.Tools, recorded.ErrorCount)
+	}
+
+	if limited, err := store.GetSessionHistory(ctx, 1); err != nil || len(limited) != 1 || limited[0].ID != ingested.ID {
+		t.Errorf("limit should keep the newest session, got %+v, %v", limited, err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/harrison/conductor/internal/models"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -43,6 +44,12 @@ type TaskExecution struct {
 	// Human time estimation (v3.5+)
 	HumanEstimateSecs   int64  `json:"human_estimate_secs"`
 	HumanEstimateSource string `json:"human_estimate_source"`
+
+	// Token usage and cost of the attempt, including its QC review (v3.6+)
+	Usage models.Usage `json:"usage"`
+
+	// Claude model chosen by model routing, empty when routing is off (v3.6+)
+	Model string `json:"model,omitempty"`
 }
 
 // ApproachHistory tracks different approaches tried for recurring task patterns
@@ -205,8 +212,9 @@ func (s *Store) RecordExecution(ctx context.Context, exec *TaskExecution) error
 	}
 
 	query := `INSERT INTO task_executions
-		(plan_file, run_number, task_number, task_name, agent, prompt, success, output, error_message, duration_seconds, qc_verdict, qc_feedback, failure_patterns, context, lines_added, lines_deleted, human_estimate_secs, human_estimate_source)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		(plan_file, run_number, task_number, task_name, agent, prompt, success, output, error_message, duration_seconds, qc_verdict, qc_feedback, failure_patterns, context, lines_added, lines_deleted, human_estimate_secs, human_estimate_source,
+		 input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cost_usd, model)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	result, err := s.db.ExecContext(ctx, query,
 		exec.PlanFile,
@@ -227,6 +235,12 @@ func (s *Store) RecordExecution(ctx context.Context, exec *TaskExecution) error
 		exec.LinesDeleted,
 		exec.HumanEstimateSecs,
 		exec.HumanEstimateSource,
+		exec.Usage.InputTokens,
+		exec.Usage.OutputTokens,
+		exec.Usage.CacheCreationInputTokens,
+		exec.Usage.CacheReadInputTokens,
+		exec.Usage.CostUSD,
+		exec.Model,
 	)
 	if err != nil {
 		return fmt.Errorf("insert task execution: %w", err)
@@ -325,7 +339,9 @@ func (s *Store) GetExecutions(planFile string) ([]*TaskExecution, error) {
 
 // GetExecutionHistory retrieves all executions for a specific task, ordered by most recent first
 func (s *Store) GetExecutionHistory(ctx context.Context, planFile, taskNumber string) ([]*TaskExecution, error) {
-	query := `SELECT id, plan_file, run_number, task_number, task_name, agent, prompt, success, output, error_message, duration_seconds, qc_verdict, qc_feedback, failure_patterns, timestamp, context
+	query := `SELECT id, plan_file, run_number, task_number, task_name, agent, prompt, success, output, error_message, duration_seconds, qc_verdict, qc_feedback, failure_patterns, timestamp, context,
+		COALESCE(input_tokens, 0), COALESCE(output_tokens, 0), COALESCE(cache_creation_tokens, 0), COALESCE(cache_read_tokens, 0), COALESCE(cost_usd, 0),
+		COALESCE(model, '')
 		FROM task_executions
 		WHERE plan_file = ? AND task_number = ?
 		ORDER BY id DESC`
@@ -358,6 +374,12 @@ func (s *Store) GetExecutionHistory(ctx context.Context, planFile, taskNumber st
 			&failurePatterns,
 			&exec.Timestamp,
 			&contextVal,
+			&exec.Usage.InputTokens,
+			&exec.Usage.OutputTokens,
+			&exec.Usage.CacheCreationInputTokens,
+			&exec.Usage.CacheReadInputTokens,
+			&exec.Usage.CostUSD,
+			&exec.Model,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan execution row: %w", err)
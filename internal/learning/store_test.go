@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/harrison/conductor/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -1934,3 +1935,66 @@ func TestRecordExecution_WithLOC(t *testing.T) {
 		assert.Equal(t, 200, linesDeleted)
 	})
 }
+
+func TestRecordExecution_WithUsage(t *testing.T) {
+	ctx := context.Background()
+	store := setupTestStore(t)
+	defer store.Close()
+
+	usage := models.Usage{
+		InputTokens:              1200,
+		OutputTokens:             340,
+		CacheCreationInputTokens: 50,
+		CacheReadInputTokens:     9000,
+		CostUSD:                  0.0425,
+	}
+	exec := &TaskExecution{
+		PlanFile:   "test-plan.yaml",
+		RunNumber:  1,
+		TaskNumber: "1",
+		TaskName:   "Add new feature",
+		Prompt:     "Implement feature X",
+		Success:    true,
+		QCVerdict:  "GREEN",
+		Usage:      usage,
+	}
+	require.NoError(t, store.RecordExecution(ctx, exec))
+
+	history, err := store.GetExecutionHistory(ctx, "test-plan.yaml", "1")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, usage, history[0].Usage)
+
+	// Records without usage read back as zero
+	require.NoError(t, store.RecordExecution(ctx, &TaskExecution{PlanFile: "test-plan.yaml", TaskNumber: "2", TaskName: "Docs", Prompt: "Update README"}))
+	history, err = store.GetExecutionHistory(ctx, "test-plan.yaml", "2")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.True(t, history[0].Usage.IsZero())
+}
+
+func TestRecordExecution_WithModel(t *testing.T) {
+	ctx := context.Background()
+	store := setupTestStore(t)
+	defer store.Close()
+
+	require.NoError(t, store.RecordExecution(ctx, &TaskExecution{
+		PlanFile:   "test-plan.yaml",
+		TaskNumber: "1",
+		TaskName:   "Add new feature",
+		Prompt:     "Implement feature X",
+		Model:      "opus",
+	}))
+	require.NoError(t, store.RecordExecution(ctx, &TaskExecution{
+		PlanFile:   "test-plan.yaml",
+		TaskNumber: "1",
+		TaskName:   "Add new feature",
+		Prompt:     "Implement feature X",
+	}))
+
+	history, err := store.GetExecutionHistory(ctx, "test-plan.yaml", "1")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "", history[0].Model)
+	assert.Equal(t, "opus", history[1].Model)
+}
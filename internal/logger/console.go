@@ -468,8 +468,10 @@ func (cl *ConsoleLogger) logTaskResultDefault(result models.TaskResult) error {
 			statusColor = color.New(color.FgGreen)
 		case models.StatusRed, models.StatusFailed:
 			statusColor = color.New(color.FgRed)
-		case models.StatusYellow:
+		case models.StatusYellow, models.StatusBlocked:
 			statusColor = color.New(color.FgYellow)
+		case models.StatusSkipped:
+			statusColor = color.New(color.FgCyan)
 		default:
 			statusColor = color.New(color.FgWhite)
 		}
@@ -611,6 +613,10 @@ func getStatusIcon(status string) string {
 		return "✗"
 	case models.StatusYellow:
 		return "⚠"
+	case models.StatusBlocked:
+		return "⊘"
+	case models.StatusSkipped:
+		return "↷"
 	default:
 		return "•"
 	}
@@ -716,6 +722,18 @@ func (cl *ConsoleLogger) LogSummary(result models.ExecutionResult) {
 			output += fmt.Sprintf("[%s] Failed: %d\n", ts, result.Failed)
 		}
 
+		// Yellow for tasks blocked by failed dependencies (keep-going mode)
+		if result.Blocked > 0 {
+			blockedText := color.New(color.FgYellow).Sprintf("Blocked: %d", result.Blocked)
+			output += fmt.Sprintf("[%s] %s\n", ts, blockedText)
+		}
+
+		// Cyan for tasks skipped by their when: expression (v3.6+)
+		if result.Skipped > 0 {
+			skippedText := color.New(color.FgCyan).Sprintf("Skipped: %d", result.Skipped)
+			output += fmt.Sprintf("[%s] %s\n", ts, skippedText)
+		}
+
 		output += fmt.Sprintf("[%s] Duration: %s\n", ts, durationStr)
 
 		// Status Breakdown section
@@ -773,6 +791,12 @@ func (cl *ConsoleLogger) LogSummary(result models.ExecutionResult) {
 			output += fmt.Sprintf("[%s]   Net: %+d\n", ts, net)
 		}
 
+		// Cost section (v3.6+)
+		if !result.Usage.IsZero() {
+			costColored := color.New(color.FgCyan).Sprintf("$%.4f", result.Usage.CostUSD)
+			output += fmt.Sprintf("[%s] Cost: %s (%d tokens)\n", ts, costColored, result.Usage.TotalTokens())
+		}
+
 		// Average Duration section
 		if result.AvgTaskDuration > 0 {
 			avgStr := formatDurationWithDecimal(result.AvgTaskDuration)
@@ -794,12 +818,40 @@ func (cl *ConsoleLogger) LogSummary(result models.ExecutionResult) {
 				}
 			}
 		}
+
+		// Blocked tasks section (keep-going mode)
+		if result.Blocked > 0 && len(result.BlockedTasks) > 0 {
+			blockedHeader := color.New(color.FgYellow).Sprint("Blocked tasks:")
+			output += fmt.Sprintf("[%s] %s\n", ts, blockedHeader)
+			for _, blockedTask := range result.BlockedTasks {
+				taskHeader := fmt.Sprintf("Task %s (%s)", blockedTask.Task.Number, blockedTask.Task.Name)
+				taskColored := color.New(color.FgYellow).Sprint(taskHeader)
+				output += fmt.Sprintf("[%s]   - %s: %s\n", ts, taskColored, blockedTask.Output)
+			}
+		}
+
+		// Skipped tasks section, with the expression that was false
+		if result.Skipped > 0 && len(result.SkippedTasks) > 0 {
+			skippedHeader := color.New(color.FgCyan).Sprint("Skipped tasks:")
+			output += fmt.Sprintf("[%s] %s\n", ts, skippedHeader)
+			for _, skippedTask := range result.SkippedTasks {
+				taskHeader := fmt.Sprintf("Task %s (%s)", skippedTask.Task.Number, skippedTask.Task.Name)
+				taskColored := color.New(color.FgCyan).Sprint(taskHeader)
+				output += fmt.Sprintf("[%s]   - %s: %s\n", ts, taskColored, skippedTask.Output)
+			}
+		}
 	} else {
 		// Plain text summary
 		output = fmt.Sprintf("[%s] === Execution Summary ===\n", ts)
 		output += fmt.Sprintf("[%s] Total tasks: %d\n", ts, result.TotalTasks)
 		output += fmt.Sprintf("[%s] Completed: %d\n", ts, result.Completed)
 		output += fmt.Sprintf("[%s] Failed: %d\n", ts, result.Failed)
+		if result.Blocked > 0 {
+			output += fmt.Sprintf("[%s] Blocked: %d\n", ts, result.Blocked)
+		}
+		if result.Skipped > 0 {
+			output += fmt.Sprintf("[%s] Skipped: %d\n", ts, result.Skipped)
+		}
 		output += fmt.Sprintf("[%s] Duration: %s\n", ts, durationStr)
 
 		// Status Breakdown section
@@ -835,6 +887,11 @@ func (cl *ConsoleLogger) LogSummary(result models.ExecutionResult) {
 			output += fmt.Sprintf("[%s]   Net: %+d\n", ts, result.TotalLinesAdded-result.TotalLinesDeleted)
 		}
 
+		// Cost section (v3.6+)
+		if !result.Usage.IsZero() {
+			output += fmt.Sprintf("[%s] Cost: $%.4f (%d tokens)\n", ts, result.Usage.CostUSD, result.Usage.TotalTokens())
+		}
+
 		// Average Duration section
 		if result.AvgTaskDuration > 0 {
 			avgStr := formatDurationWithDecimal(result.AvgTaskDuration)
@@ -853,6 +910,22 @@ func (cl *ConsoleLogger) LogSummary(result models.ExecutionResult) {
 				}
 			}
 		}
+
+		// Blocked tasks section (keep-going mode)
+		if result.Blocked > 0 && len(result.BlockedTasks) > 0 {
+			output += fmt.Sprintf("[%s] Blocked tasks:\n", ts)
+			for _, blockedTask := range result.BlockedTasks {
+				output += fmt.Sprintf("[%s]   - Task %s (%s): %s\n", ts, blockedTask.Task.Number, blockedTask.Task.Name, blockedTask.Output)
+			}
+		}
+
+		// Skipped tasks section, with the expression that was false
+		if result.Skipped > 0 && len(result.SkippedTasks) > 0 {
+			output += fmt.Sprintf("[%s] Skipped tasks:\n", ts)
+			for _, skippedTask := range result.SkippedTasks {
+				output += fmt.Sprintf("[%s]   - Task %s (%s): %s\n", ts, skippedTask.Task.Number, skippedTask.Task.Name, skippedTask.Output)
+			}
+		}
 	}
 
 	cl.writer.Write([]byte(output))
@@ -1384,6 +1457,52 @@ func (cl *ConsoleLogger) LogRateLimitCountdown(remaining, total time.Duration) {
 	cl.writer.Write([]byte(message))
 }
 
+// LogControlEvent logs a run control change and who made it (v3.6+).
+// Format: "[HH:MM:SS] [CONTROL] actor paused new task launches"
+func (cl *ConsoleLogger) LogControlEvent(event models.ControlEvent) {
+	if cl.writer == nil {
+		return
+	}
+	if !cl.shouldLog("info") {
+		return
+	}
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+
+	prefix := "[CONTROL]"
+	if cl.colorOutput {
+		prefix = color.New(color.FgCyan, color.Bold).Sprint(prefix)
+	}
+	message := fmt.Sprintf("[%s] %s %s %s\n", timestamp(), prefix, event.Actor, event.Description())
+	cl.writer.Write([]byte(message))
+}
+
+// LogTaskActivity logs a live tool call or text event from a streaming agent (v3.6+).
+// Format: "[HH:MM:SS] [Task N] ▸ Read internal/foo.go" or "[HH:MM:SS] [Task N] 💬 text"
+func (cl *ConsoleLogger) LogTaskActivity(activity models.AgentActivity) {
+	if cl.writer == nil {
+		return
+	}
+	if !cl.shouldLog("info") {
+		return
+	}
+	cl.mutex.Lock()
+	defer cl.mutex.Unlock()
+
+	marker := "💬"
+	if activity.Kind == models.ActivityToolUse {
+		marker = "▸"
+	}
+	prefix := fmt.Sprintf("[Task %s]", activity.TaskNumber)
+	detail := activity.Description(100)
+	if cl.colorOutput {
+		prefix = color.New(color.FgCyan).Sprint(prefix)
+		detail = color.New(color.Faint).Sprint(detail)
+	}
+	message := fmt.Sprintf("[%s] %s %s %s\n", timestamp(), prefix, marker, detail)
+	cl.writer.Write([]byte(message))
+}
+
 // LogRateLimitAnnounce is a no-op for console (TTS only)
 func (cl *ConsoleLogger) LogRateLimitAnnounce(remaining, total time.Duration) {
 	// No-op: TTS announcements are handled by TTS logger
@@ -2312,3 +2431,9 @@ func (n *NoOpLogger) LogRateLimitPause(delay time.Duration)                {}
 func (n *NoOpLogger) LogRateLimitResume()                                  {}
 func (n *NoOpLogger) LogRateLimitCountdown(remaining, total time.Duration) {}
 func (n *NoOpLogger) LogRateLimitAnnounce(remaining, total time.Duration)  {}
+
+// LogControlEvent is a no-op implementation.
+func (n *NoOpLogger) LogControlEvent(event models.ControlEvent) {}
+
+// LogTaskActivity is a no-op implementation.
+func (n *NoOpLogger) LogTaskActivity(activity models.AgentActivity) {}
@@ -3587,3 +3587,56 @@ func TestLogSummary_LOCMetrics_AfterFilesModified(t *testing.T) {
 		t.Error("expected 'Lines of Code:' to appear BEFORE 'Average Duration:'")
 	}
 }
+
+// TestLogSummary_BlockedTasks verifies blocked tasks are reported separately from failures
+func TestLogSummary_BlockedTasks(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewConsoleLogger(buf, "info")
+
+	result := models.ExecutionResult{
+		TotalTasks: 3,
+		Completed:  1,
+		Failed:     1,
+		Blocked:    1,
+		Duration:   time.Minute,
+		FailedTasks: []models.TaskResult{
+			{Task: models.Task{Number: "1", Name: "Flaky Task"}, Status: models.StatusRed},
+		},
+		BlockedTasks: []models.TaskResult{
+			{
+				Task:   models.Task{Number: "2", Name: "Downstream Task"},
+				Status: models.StatusBlocked,
+				Output: "Blocked: dependency task 1 failed",
+			},
+		},
+	}
+
+	logger.LogSummary(result)
+	output := buf.String()
+
+	if !strings.Contains(output, "Blocked: 1") {
+		t.Errorf("expected 'Blocked: 1' in output, got: %s", output)
+	}
+	if !strings.Contains(output, "Blocked tasks") {
+		t.Error("expected 'Blocked tasks' section in output")
+	}
+	if !strings.Contains(output, "Downstream Task") || !strings.Contains(output, "dependency task 1 failed") {
+		t.Error("expected blocked task details in output")
+	}
+}
+
+func TestLogControlEvent(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewConsoleLogger(buf, "info")
+
+	logger.LogControlEvent(models.ControlEvent{Action: models.ControlActionCancel, TaskNumber: "4", Actor: "alice@host"})
+	logger.LogControlEvent(models.ControlEvent{Action: models.ControlActionConcurrency, Value: 2, Actor: "bob"})
+
+	output := buf.String()
+	if !strings.Contains(output, "[CONTROL] alice@host cancelled task 4") {
+		t.Errorf("expected cancel event with actor, got: %s", output)
+	}
+	if !strings.Contains(output, "[CONTROL] bob set max concurrency to 2") {
+		t.Errorf("expected concurrency event with actor, got: %s", output)
+	}
+}
@@ -0,0 +1,468 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// EventSchemaVersion is the version of the JSONL event schema (v3.6+).
+// It is bumped only for breaking changes; new event types and new optional
+// fields are added without a bump.
+const EventSchemaVersion = 1
+
+// EventsFileName is the name of the event stream inside a run directory.
+const EventsFileName = "events.jsonl"
+
+// Event types written to events.jsonl (v3.6+).
+const (
+	EventRunStart               = "run.start"
+	EventRunSummary             = "run.summary"
+	EventWaveStart              = "wave.start"
+	EventWaveComplete           = "wave.complete"
+	EventTaskInvoke             = "task.invoke"
+	EventTaskResult             = "task.result"
+	EventQCSelection            = "qc.selection"
+	EventQCIntelligentSelection = "qc.intelligent_selection"
+	EventQCVerdicts             = "qc.verdicts"
+	EventQCAggregated           = "qc.aggregated"
+	EventQCCriteria             = "qc.criteria"
+	EventAnomaly                = "anomaly"
+	EventBudgetStatus           = "budget.status"
+	EventBudgetWarning          = "budget.warning"
+	EventRateLimitPause         = "rate_limit.pause"
+	EventRateLimitResume        = "rate_limit.resume"
+	EventControl                = "control"
+	EventTaskActivity           = "task.activity"
+)
+
+// Event is one line of the JSONL event stream.
+// Data holds the type-specific payload (one of the *Data structs below).
+type Event struct {
+	SchemaVersion int         `json:"schema_version"`
+	Seq           int64       `json:"seq"`
+	Time          time.Time   `json:"time"`
+	RunID         string      `json:"run_id"`
+	Type          string      `json:"type"`
+	Data          interface{} `json:"data,omitempty"`
+}
+
+// RunStartData is the payload of run.start events.
+type RunStartData struct {
+	Version string `json:"conductor_version,omitempty"`
+}
+
+// WaveData is the payload of wave.start and wave.complete events.
+type WaveData struct {
+	Wave           string   `json:"wave"`
+	Tasks          []string `json:"tasks"`
+	MaxConcurrency int      `json:"max_concurrency"`
+	DurationMs     int64    `json:"duration_ms,omitempty"`
+	Statuses       []string `json:"statuses,omitempty"` // Per-task statuses, in Tasks order (wave.complete only)
+}
+
+// TaskData is the payload of task.invoke and task.result events.
+type TaskData struct {
+	Task       string `json:"task"`
+	Name       string `json:"name"`
+	Agent      string `json:"agent,omitempty"`
+	Status     string `json:"status,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	RetryCount int    `json:"retry_count,omitempty"`
+	Attempts   int    `json:"attempts,omitempty"`
+	Error      string `json:"error,omitempty"`
+	SessionID  string `json:"session_id,omitempty"`
+
+	// Token usage and cost across attempts and QC reviews (v3.6+)
+	CostUSD     float64 `json:"cost_usd,omitempty"`
+	TotalTokens int64   `json:"total_tokens,omitempty"`
+}
+
+// QCSelectionData is the payload of qc.selection events.
+type QCSelectionData struct {
+	Agents []string `json:"agents"`
+	Mode   string   `json:"mode"`
+}
+
+// QCIntelligentSelectionData is the payload of qc.intelligent_selection events.
+type QCIntelligentSelectionData struct {
+	Rationale      string `json:"rationale,omitempty"`
+	Fallback       bool   `json:"fallback"`
+	FallbackReason string `json:"fallback_reason,omitempty"`
+}
+
+// QCVerdictsData is the payload of qc.verdicts events (agent name -> verdict).
+type QCVerdictsData struct {
+	Verdicts map[string]string `json:"verdicts"`
+}
+
+// QCAggregatedData is the payload of qc.aggregated events.
+type QCAggregatedData struct {
+	Verdict  string `json:"verdict"`
+	Strategy string `json:"strategy"`
+}
+
+// QCCriteriaData is the payload of qc.criteria events.
+type QCCriteriaData struct {
+	Agent    string                   `json:"agent"`
+	Criteria []models.CriterionResult `json:"criteria"`
+}
+
+// AnomalyData is the payload of anomaly events.
+type AnomalyData struct {
+	AnomalyType string `json:"anomaly_type"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Task        string `json:"task,omitempty"`
+	Wave        string `json:"wave,omitempty"`
+}
+
+// BudgetStatusData is the payload of budget.status events.
+// Status is the budget tracker's status encoded as JSON.
+type BudgetStatusData struct {
+	Status json.RawMessage `json:"status,omitempty"`
+}
+
+// BudgetWarningData is the payload of budget.warning events.
+type BudgetWarningData struct {
+	PercentUsed float64 `json:"percent_used"`
+}
+
+// RateLimitData is the payload of rate_limit.pause events.
+type RateLimitData struct {
+	DelayMs int64 `json:"delay_ms"`
+}
+
+// SummaryData is the payload of run.summary events.
+type SummaryData struct {
+	TotalTasks        int            `json:"total_tasks"`
+	Completed         int            `json:"completed"`
+	Failed            int            `json:"failed"`
+	Blocked           int            `json:"blocked"`
+	Skipped           int            `json:"skipped,omitempty"` // Tasks skipped by their when: expression (v3.6+)
+	DurationMs        int64          `json:"duration_ms"`
+	FailedTasks       []string       `json:"failed_tasks,omitempty"`
+	BlockedTasks      []string       `json:"blocked_tasks,omitempty"`
+	SkippedTasks      []string       `json:"skipped_tasks,omitempty"`
+	StatusBreakdown   map[string]int `json:"status_breakdown,omitempty"`
+	AgentUsage        map[string]int `json:"agent_usage,omitempty"`
+	TotalLinesAdded   int            `json:"total_lines_added,omitempty"`
+	TotalLinesDeleted int            `json:"total_lines_deleted,omitempty"`
+	CostUSD           float64        `json:"cost_usd,omitempty"`     // Run cost (v3.6+)
+	TotalTokens       int64          `json:"total_tokens,omitempty"` // Run tokens (v3.6+)
+}
+
+// EventLogger writes one JSON object per orchestrator event to
+// .conductor/logs/<run>/events.jsonl (v3.6+). Unlike FileLogger and
+// ConsoleLogger it does no formatting and no level filtering: every event
+// is recorded so dashboards and CI can consume the stream directly.
+// It is thread-safe and implements the executor.Logger interface.
+type EventLogger struct {
+	runID string
+	path  string
+	file  *os.File
+	seq   int64
+	mu    sync.Mutex
+	now   func() time.Time
+}
+
+// NewEventLogger creates an EventLogger writing to logDir/<runID>/events.jsonl.
+// An empty runID uses a timestamped "run-YYYYMMDD-HHMMSS" ID.
+func NewEventLogger(logDir string, runID string) (*EventLogger, error) {
+	if runID == "" {
+		runID = fmt.Sprintf("run-%s", time.Now().Format("20060102-150405"))
+	}
+
+	runDir := filepath.Join(logDir, runID)
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create run log directory: %w", err)
+	}
+
+	path := filepath.Join(runDir, EventsFileName)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event log file: %w", err)
+	}
+
+	return &EventLogger{
+		runID: runID,
+		path:  path,
+		file:  file,
+		now:   time.Now,
+	}, nil
+}
+
+// Path returns the path of the events.jsonl file.
+func (el *EventLogger) Path() string {
+	return el.path
+}
+
+// RunID returns the run ID recorded on every event.
+func (el *EventLogger) RunID() string {
+	return el.runID
+}
+
+// LogRunStart records the start of a run with the conductor version.
+func (el *EventLogger) LogRunStart(version string) {
+	el.emit(EventRunStart, RunStartData{Version: version})
+}
+
+// LogWaveStart records the start of a wave.
+func (el *EventLogger) LogWaveStart(wave models.Wave) {
+	el.emit(EventWaveStart, WaveData{
+		Wave:           wave.Name,
+		Tasks:          nonNilStrings(wave.TaskNumbers),
+		MaxConcurrency: wave.MaxConcurrency,
+	})
+}
+
+// LogWaveComplete records the completion of a wave with per-task statuses.
+func (el *EventLogger) LogWaveComplete(wave models.Wave, duration time.Duration, results []models.TaskResult) {
+	statusByTask := make(map[string]string, len(results))
+	for _, result := range results {
+		statusByTask[result.Task.Number] = result.Status
+	}
+	statuses := make([]string, 0, len(wave.TaskNumbers))
+	for _, taskNum := range wave.TaskNumbers {
+		statuses = append(statuses, statusByTask[taskNum])
+	}
+
+	el.emit(EventWaveComplete, WaveData{
+		Wave:           wave.Name,
+		Tasks:          nonNilStrings(wave.TaskNumbers),
+		MaxConcurrency: wave.MaxConcurrency,
+		DurationMs:     duration.Milliseconds(),
+		Statuses:       statuses,
+	})
+}
+
+// LogTaskResult records the final result of a task.
+func (el *EventLogger) LogTaskResult(result models.TaskResult) error {
+	data := TaskData{
+		Task:        result.Task.Number,
+		Name:        result.Task.Name,
+		Agent:       result.Task.Agent,
+		Status:      result.Status,
+		DurationMs:  result.Duration.Milliseconds(),
+		RetryCount:  result.RetryCount,
+		Attempts:    len(result.ExecutionHistory),
+		SessionID:   result.SessionID,
+		CostUSD:     result.Usage.CostUSD,
+		TotalTokens: result.Usage.TotalTokens(),
+	}
+	if result.Error != nil {
+		data.Error = result.Error.Error()
+	}
+	return el.write(EventTaskResult, data)
+}
+
+// LogProgress is a no-op: progress is derivable from task.result events.
+func (el *EventLogger) LogProgress(results []models.TaskResult) {
+	// No-op: progress bars are console-only
+}
+
+// LogSummary records the execution summary.
+func (el *EventLogger) LogSummary(result models.ExecutionResult) {
+	data := SummaryData{
+		TotalTasks:        result.TotalTasks,
+		Completed:         result.Completed,
+		Failed:            result.Failed,
+		Blocked:           result.Blocked,
+		Skipped:           result.Skipped,
+		DurationMs:        result.Duration.Milliseconds(),
+		StatusBreakdown:   result.StatusBreakdown,
+		AgentUsage:        result.AgentUsage,
+		TotalLinesAdded:   result.TotalLinesAdded,
+		TotalLinesDeleted: result.TotalLinesDeleted,
+		CostUSD:           result.Usage.CostUSD,
+		TotalTokens:       result.Usage.TotalTokens(),
+	}
+	for _, failed := range result.FailedTasks {
+		data.FailedTasks = append(data.FailedTasks, failed.Task.Number)
+	}
+	for _, blocked := range result.BlockedTasks {
+		data.BlockedTasks = append(data.BlockedTasks, blocked.Task.Number)
+	}
+	for _, skipped := range result.SkippedTasks {
+		data.SkippedTasks = append(data.SkippedTasks, skipped.Task.Number)
+	}
+	el.emit(EventRunSummary, data)
+}
+
+// LogTaskAgentInvoke records that a task agent is about to be invoked.
+func (el *EventLogger) LogTaskAgentInvoke(task models.Task) {
+	el.emit(EventTaskInvoke, TaskData{
+		Task:  task.Number,
+		Name:  task.Name,
+		Agent: task.Agent,
+	})
+}
+
+// LogQCAgentSelection records which QC agents were selected.
+func (el *EventLogger) LogQCAgentSelection(agents []string, mode string) {
+	el.emit(EventQCSelection, QCSelectionData{Agents: nonNilStrings(agents), Mode: mode})
+}
+
+// LogQCIndividualVerdicts records each QC agent's verdict.
+func (el *EventLogger) LogQCIndividualVerdicts(verdicts map[string]string) {
+	if verdicts == nil {
+		verdicts = map[string]string{}
+	}
+	el.emit(EventQCVerdicts, QCVerdictsData{Verdicts: verdicts})
+}
+
+// LogQCAggregatedResult records the final QC verdict and the aggregation strategy.
+func (el *EventLogger) LogQCAggregatedResult(verdict string, strategy string) {
+	el.emit(EventQCAggregated, QCAggregatedData{Verdict: verdict, Strategy: strategy})
+}
+
+// LogQCCriteriaResults records per-criterion results from one QC agent.
+func (el *EventLogger) LogQCCriteriaResults(agentName string, results []models.CriterionResult) {
+	if results == nil {
+		results = []models.CriterionResult{}
+	}
+	el.emit(EventQCCriteria, QCCriteriaData{Agent: agentName, Criteria: results})
+}
+
+// LogQCIntelligentSelectionMetadata records the rationale of intelligent QC selection.
+func (el *EventLogger) LogQCIntelligentSelectionMetadata(rationale string, fallback bool, fallbackReason string) {
+	el.emit(EventQCIntelligentSelection, QCIntelligentSelectionData{
+		Rationale:      rationale,
+		Fallback:       fallback,
+		FallbackReason: fallbackReason,
+	})
+}
+
+// LogAnomaly records a wave anomaly. Values without the anomaly getters are ignored.
+func (el *EventLogger) LogAnomaly(anomaly interface{}) {
+	type anomalyDisplay interface {
+		GetType() string
+		GetDescription() string
+		GetSeverity() string
+		GetTaskNumber() string
+		GetWaveName() string
+	}
+
+	a, ok := anomaly.(anomalyDisplay)
+	if !ok {
+		return
+	}
+
+	el.emit(EventAnomaly, AnomalyData{
+		AnomalyType: a.GetType(),
+		Description: a.GetDescription(),
+		Severity:    a.GetSeverity(),
+		Task:        a.GetTaskNumber(),
+		Wave:        a.GetWaveName(),
+	})
+}
+
+// LogBudgetStatus records the budget tracker's status.
+func (el *EventLogger) LogBudgetStatus(status interface{}) {
+	data := BudgetStatusData{}
+	if status != nil {
+		if raw, err := json.Marshal(status); err == nil {
+			data.Status = raw
+		}
+	}
+	el.emit(EventBudgetStatus, data)
+}
+
+// LogBudgetWarning records a warning when approaching the budget limit.
+func (el *EventLogger) LogBudgetWarning(percentUsed float64) {
+	el.emit(EventBudgetWarning, BudgetWarningData{PercentUsed: percentUsed})
+}
+
+// LogRateLimitPause records a pause due to a rate limit.
+func (el *EventLogger) LogRateLimitPause(delay time.Duration) {
+	el.emit(EventRateLimitPause, RateLimitData{DelayMs: delay.Milliseconds()})
+}
+
+// LogRateLimitResume records the end of a rate limit pause.
+func (el *EventLogger) LogRateLimitResume() {
+	el.emit(EventRateLimitResume, nil)
+}
+
+// LogRateLimitCountdown is a no-op: countdown ticks are console only.
+func (el *EventLogger) LogRateLimitCountdown(remaining, total time.Duration) {
+	// No-op: the pause event already carries the full delay
+}
+
+// LogRateLimitAnnounce is a no-op: announcements are TTS only.
+func (el *EventLogger) LogRateLimitAnnounce(remaining, total time.Duration) {
+	// No-op: TTS announcements are not recorded
+}
+
+// LogControlEvent records a run control change.
+func (el *EventLogger) LogControlEvent(event models.ControlEvent) {
+	el.emit(EventControl, event)
+}
+
+// LogTaskActivity records a live tool call or text event from a streaming agent.
+func (el *EventLogger) LogTaskActivity(activity models.AgentActivity) {
+	el.emit(EventTaskActivity, activity)
+}
+
+// Close flushes and closes the event log file.
+func (el *EventLogger) Close() error {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if el.file != nil {
+		if err := el.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync event log: %w", err)
+		}
+		if err := el.file.Close(); err != nil {
+			return fmt.Errorf("failed to close event log: %w", err)
+		}
+		el.file = nil
+	}
+
+	return nil
+}
+
+// emit writes an event, ignoring write errors (logging must never fail a run).
+func (el *EventLogger) emit(eventType string, data interface{}) {
+	_ = el.write(eventType, data)
+}
+
+// write appends one event line to the stream.
+func (el *EventLogger) write(eventType string, data interface{}) error {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if el.file == nil {
+		return nil
+	}
+
+	el.seq++
+	line, err := json.Marshal(Event{
+		SchemaVersion: EventSchemaVersion,
+		Seq:           el.seq,
+		Time:          el.now().UTC(),
+		RunID:         el.runID,
+		Type:          eventType,
+		Data:          data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	if _, err := el.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write event log: %w", err)
+	}
+	return nil
+}
+
+// nonNilStrings returns an empty slice for nil so lists encode as [] rather than null.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,247 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// testAnomaly satisfies the anomaly getter interface used by LogAnomaly
+type testAnomaly struct{}
+
+func (testAnomaly) GetType() string        { return "consecutive_failures" }
+func (testAnomaly) GetDescription() string { return "3 failures in a row" }
+func (testAnomaly) GetSeverity() string    { return "high" }
+func (testAnomaly) GetTaskNumber() string  { return "4" }
+func (testAnomaly) GetWaveName() string    { return "Wave 2" }
+
+// readEvents decodes every line of an events.jsonl file
+func readEvents(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open event log: %v", err)
+	}
+	defer file.Close()
+
+	var events []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// TestEventLoggerWritesRunDirectory verifies the stream lives at <logDir>/<run>/events.jsonl
+func TestEventLoggerWritesRunDirectory(t *testing.T) {
+	logDir := t.TempDir()
+
+	el, err := NewEventLogger(logDir, "run-20260101-120000")
+	if err != nil {
+		t.Fatalf("NewEventLogger() error = %v", err)
+	}
+	defer el.Close()
+
+	want := filepath.Join(logDir, "run-20260101-120000", "events.jsonl")
+	if el.Path() != want {
+		t.Errorf("Path() = %q, want %q", el.Path(), want)
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Errorf("expected events file to exist: %v", err)
+	}
+}
+
+// TestEventLoggerSharesFileLoggerRunID verifies text log and event stream use one run ID
+func TestEventLoggerSharesFileLoggerRunID(t *testing.T) {
+	logDir := t.TempDir()
+
+	fl, err := NewFileLoggerWithDir(logDir)
+	if err != nil {
+		t.Fatalf("NewFileLoggerWithDir() error = %v", err)
+	}
+	defer fl.Close()
+
+	el, err := NewEventLogger(fl.LogDir(), fl.RunID())
+	if err != nil {
+		t.Fatalf("NewEventLogger() error = %v", err)
+	}
+	defer el.Close()
+
+	if _, err := os.Stat(filepath.Join(logDir, el.RunID()+".log")); err != nil {
+		t.Errorf("run ID %q does not match the run log file: %v", el.RunID(), err)
+	}
+}
+
+// TestEventLoggerEventSchema verifies every event carries the envelope and its payload
+func TestEventLoggerEventSchema(t *testing.T) {
+	el, err := NewEventLogger(t.TempDir(), "run-test")
+	if err != nil {
+		t.Fatalf("NewEventLogger() error = %v", err)
+	}
+	fixed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	el.now = func() time.Time { return fixed }
+
+	wave := models.Wave{Name: "Wave 1", TaskNumbers: []string{"1", "2"}, MaxConcurrency: 2}
+	el.LogRunStart("3.6.0")
+	el.LogWaveStart(wave)
+	el.LogTaskAgentInvoke(models.Task{Number: "1", Name: "Setup", Agent: "golang-pro"})
+	el.LogQCAgentSelection([]string{"code-reviewer"}, "auto")
+	el.LogQCIndividualVerdicts(map[string]string{"code-reviewer": "GREEN"})
+	el.LogQCAggregatedResult("GREEN", "unanimous")
+	el.LogQCCriteriaResults("code-reviewer", []models.CriterionResult{{Index: 0, Criterion: "builds", Passed: true}})
+	el.LogQCIntelligentSelectionMetadata("go code", true, "timeout")
+	if err := el.LogTaskResult(models.TaskResult{
+		Task:     models.Task{Number: "2", Name: "Build"},
+		Status:   models.StatusFailed,
+		Duration: 1500 * time.Millisecond,
+		Error:    errors.New("boom"),
+	}); err != nil {
+		t.Fatalf("LogTaskResult() error = %v", err)
+	}
+	el.LogWaveComplete(wave, 2*time.Second, []models.TaskResult{
+		{Task: models.Task{Number: "2"}, Status: models.StatusFailed},
+		{Task: models.Task{Number: "1"}, Status: models.StatusGreen},
+	})
+	el.LogAnomaly(testAnomaly{})
+	el.LogAnomaly("not an anomaly")
+	el.LogBudgetStatus(map[string]int{"tokens": 10})
+	el.LogBudgetWarning(85.5)
+	el.LogRateLimitPause(90 * time.Second)
+	el.LogRateLimitCountdown(time.Second, 2*time.Second)
+	el.LogRateLimitAnnounce(time.Second, 2*time.Second)
+	el.LogRateLimitResume()
+	el.LogControlEvent(models.ControlEvent{Action: models.ControlActionSkip, TaskNumber: "2", Actor: "bob"})
+	el.LogProgress(nil)
+	el.LogSummary(models.ExecutionResult{
+		TotalTasks:  2,
+		Completed:   1,
+		Failed:      1,
+		Duration:    3 * time.Second,
+		FailedTasks: []models.TaskResult{{Task: models.Task{Number: "2"}}},
+	})
+	if err := el.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	events := readEvents(t, el.Path())
+	wantTypes := []string{
+		EventRunStart, EventWaveStart, EventTaskInvoke, EventQCSelection, EventQCVerdicts,
+		EventQCAggregated, EventQCCriteria, EventQCIntelligentSelection, EventTaskResult,
+		EventWaveComplete, EventAnomaly, EventBudgetStatus, EventBudgetWarning,
+		EventRateLimitPause, EventRateLimitResume, EventControl, EventRunSummary,
+	}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d: %v", len(events), len(wantTypes), events)
+	}
+
+	for i, event := range events {
+		if event["type"] != wantTypes[i] {
+			t.Errorf("event %d type = %v, want %s", i, event["type"], wantTypes[i])
+		}
+		if event["schema_version"] != float64(EventSchemaVersion) {
+			t.Errorf("event %d schema_version = %v", i, event["schema_version"])
+		}
+		if event["seq"] != float64(i+1) {
+			t.Errorf("event %d seq = %v, want %d", i, event["seq"], i+1)
+		}
+		if event["run_id"] != "run-test" {
+			t.Errorf("event %d run_id = %v", i, event["run_id"])
+		}
+		if event["time"] != "2026-01-02T03:04:05Z" {
+			t.Errorf("event %d time = %v", i, event["time"])
+		}
+	}
+
+	result := events[8]["data"].(map[string]interface{})
+	if result["status"] != models.StatusFailed || result["error"] != "boom" || result["duration_ms"] != float64(1500) {
+		t.Errorf("unexpected task.result payload: %v", result)
+	}
+
+	complete := events[9]["data"].(map[string]interface{})
+	statuses := complete["statuses"].([]interface{})
+	if len(statuses) != 2 || statuses[0] != models.StatusGreen || statuses[1] != models.StatusFailed {
+		t.Errorf("wave.complete statuses should follow wave task order, got %v", statuses)
+	}
+
+	anomaly := events[10]["data"].(map[string]interface{})
+	if anomaly["anomaly_type"] != "consecutive_failures" || anomaly["task"] != "4" {
+		t.Errorf("unexpected anomaly payload: %v", anomaly)
+	}
+
+	budget := events[11]["data"].(map[string]interface{})
+	if status := budget["status"].(map[string]interface{}); status["tokens"] != float64(10) {
+		t.Errorf("unexpected budget.status payload: %v", budget)
+	}
+
+	control := events[15]["data"].(map[string]interface{})
+	if control["action"] != models.ControlActionSkip || control["actor"] != "bob" {
+		t.Errorf("unexpected control payload: %v", control)
+	}
+
+	summary := events[16]["data"].(map[string]interface{})
+	if failed := summary["failed_tasks"].([]interface{}); len(failed) != 1 || failed[0] != "2" {
+		t.Errorf("unexpected run.summary payload: %v", summary)
+	}
+}
+
+// TestEventLoggerConcurrentWrites verifies lines never interleave under concurrent use
+func TestEventLoggerConcurrentWrites(t *testing.T) {
+	el, err := NewEventLogger(t.TempDir(), "run-concurrent")
+	if err != nil {
+		t.Fatalf("NewEventLogger() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				el.LogTaskAgentInvoke(models.Task{Number: "1", Name: "Concurrent"})
+			}
+		}()
+	}
+	wg.Wait()
+	el.Close()
+
+	events := readEvents(t, el.Path())
+	if len(events) != 200 {
+		t.Fatalf("got %d events, want 200", len(events))
+	}
+	seen := make(map[float64]bool)
+	for _, event := range events {
+		seen[event["seq"].(float64)] = true
+	}
+	if len(seen) != 200 {
+		t.Errorf("expected 200 unique sequence numbers, got %d", len(seen))
+	}
+}
+
+// TestEventLoggerAfterClose verifies logging after Close is a no-op
+func TestEventLoggerAfterClose(t *testing.T) {
+	el, err := NewEventLogger(t.TempDir(), "run-closed")
+	if err != nil {
+		t.Fatalf("NewEventLogger() error = %v", err)
+	}
+	el.Close()
+
+	el.LogRateLimitResume()
+	if err := el.LogTaskResult(models.TaskResult{}); err != nil {
+		t.Errorf("LogTaskResult after Close() error = %v", err)
+	}
+	if events := readEvents(t, el.Path()); len(events) != 0 {
+		t.Errorf("expected no events after Close, got %d", len(events))
+	}
+}
@@ -102,6 +102,17 @@ func NewFileLoggerWithDirAndLevel(logDir string, logLevel string) (*FileLogger,
 	return logger, nil
 }
 
+// RunID returns the identifier of this run (run-YYYYMMDD-HHMMSS), taken from the run log name.
+// EventLogger uses it so the text log and the event stream of a run share one ID.
+func (fl *FileLogger) RunID() string {
+	return strings.TrimSuffix(filepath.Base(fl.runFile), filepath.Ext(fl.runFile))
+}
+
+// LogDir returns the directory the run log is written to.
+func (fl *FileLogger) LogDir() string {
+	return fl.logDir
+}
+
 // shouldLog checks if a message at the given level should be logged.
 // Returns true if messageLevel >= configured logLevel.
 func (fl *FileLogger) shouldLog(messageLevel string) bool {
@@ -217,6 +228,8 @@ func (fl *FileLogger) LogSummary(result models.ExecutionResult) {
 			"[%s] Total tasks:  %d\n"+
 			"[%s] Completed:    %d\n"+
 			"[%s] Failed:       %d\n"+
+			"[%s] Blocked:      %d\n"+
+			"[%s] Skipped:      %d\n"+
 			"[%s] Total time:   %.1fs\n"+
 			"[%s] Status:       %s (%d/%d tasks passed)\n"+
 			"[%s] Completed at: %s\n",
@@ -228,6 +241,10 @@ func (fl *FileLogger) LogSummary(result models.ExecutionResult) {
 		timestamp,
 		result.Failed,
 		timestamp,
+		result.Blocked,
+		timestamp,
+		result.Skipped,
+		timestamp,
 		result.Duration.Seconds(),
 		timestamp,
 		status,
@@ -237,6 +254,11 @@ func (fl *FileLogger) LogSummary(result models.ExecutionResult) {
 		time.Now().Format(time.RFC3339),
 	)
 
+	// Cost line (v3.6+)
+	if !result.Usage.IsZero() {
+		message += fmt.Sprintf("[%s] Total cost:   $%.4f (%d tokens)\n", timestamp, result.Usage.CostUSD, result.Usage.TotalTokens())
+	}
+
 	fl.writeRunLog(message)
 }
 
@@ -260,6 +282,9 @@ func (fl *FileLogger) LogTaskResult(result models.TaskResult) error {
 	content += fmt.Sprintf("Status: %s\n", result.Status)
 	content += fmt.Sprintf("Duration: %.1fs\n", result.Duration.Seconds())
 	content += fmt.Sprintf("Retry Count: %d\n", result.RetryCount)
+	if !result.Usage.IsZero() {
+		content += fmt.Sprintf("Cost: $%.4f (%d tokens)\n", result.Usage.CostUSD, result.Usage.TotalTokens())
+	}
 	content += "\n"
 
 	if result.Task.Prompt != "" {
@@ -271,7 +296,18 @@ func (fl *FileLogger) LogTaskResult(result models.TaskResult) error {
 		content += "=== Execution History ===\n\n"
 		for _, attempt := range result.ExecutionHistory {
 			content += fmt.Sprintf("#### Attempt %d (Agent: %s) - %s\n", attempt.Attempt, attempt.Agent, attempt.Verdict)
-			content += fmt.Sprintf("Duration: %.1fs\n\n", attempt.Duration.Seconds())
+			content += fmt.Sprintf("Duration: %.1fs\n", attempt.Duration.Seconds())
+			if usage := attempt.TotalUsage(); !usage.IsZero() {
+				content += fmt.Sprintf("Cost: $%.4f (agent $%.4f, QC $%.4f)\n", usage.CostUSD, attempt.Usage.CostUSD, attempt.QCUsage.CostUSD)
+			}
+			for _, t := range attempt.ContextTruncations {
+				if t.Action == models.ContextSummarized {
+					content += fmt.Sprintf("Context budget: summarized %s (~%d -> ~%d tokens)\n", t.Section, t.Tokens, t.KeptTokens)
+				} else {
+					content += fmt.Sprintf("Context budget: dropped %s (~%d tokens)\n", t.Section, t.Tokens)
+				}
+			}
+			content += "\n"
 
 			if attempt.AgentOutput != "" {
 				content += fmt.Sprintf("Agent Output (JSON):\n%s\n\n", attempt.AgentOutput)
@@ -562,6 +598,36 @@ func (fl *FileLogger) LogRateLimitResume() {
 	fl.writeRunLog(message)
 }
 
+// LogControlEvent logs a run control change and who made it (v3.6+).
+// Format: "[HH:MM:SS] [CONTROL] actor cancelled task N"
+func (fl *FileLogger) LogControlEvent(event models.ControlEvent) {
+	if !fl.shouldLog("info") {
+		return
+	}
+
+	ts := event.Time.Format("15:04:05")
+	if event.Time.IsZero() {
+		ts = time.Now().Format("15:04:05")
+	}
+	message := fmt.Sprintf("[%s] [CONTROL] %s %s\n", ts, event.Actor, event.Description())
+	fl.writeRunLog(message)
+}
+
+// LogTaskActivity logs a live tool call or text event from a streaming agent (v3.6+).
+// Format: "[HH:MM:SS] [ACTIVITY] Task N: Read internal/foo.go"
+func (fl *FileLogger) LogTaskActivity(activity models.AgentActivity) {
+	if !fl.shouldLog("info") {
+		return
+	}
+
+	ts := activity.Time.Format("15:04:05")
+	if activity.Time.IsZero() {
+		ts = time.Now().Format("15:04:05")
+	}
+	message := fmt.Sprintf("[%s] [ACTIVITY] Task %s: %s\n", ts, activity.TaskNumber, activity.Description(200))
+	fl.writeRunLog(message)
+}
+
 // LogRateLimitCountdown logs countdown progress during rate limit wait.
 // Format: "[HH:MM:SS] [RATE LIMIT] Countdown: Xs remaining (Y% complete)"
 func (fl *FileLogger) LogRateLimitCountdown(remaining, total time.Duration) {
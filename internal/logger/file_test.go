@@ -544,6 +544,31 @@ func TestCloseTwice(t *testing.T) {
 	}
 }
 
+// TestFileLogControlEvent verifies run control changes are logged with their actor
+func TestFileLogControlEvent(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldWd)
+
+	logger, err := NewFileLogger()
+	if err != nil {
+		t.Fatalf("NewFileLogger() error = %v", err)
+	}
+	defer logger.Close()
+
+	logger.LogControlEvent(models.ControlEvent{Action: models.ControlActionPause, Actor: "alice@host", Time: time.Now()})
+	logger.LogControlEvent(models.ControlEvent{Action: models.ControlActionSkip, TaskNumber: "7", Actor: "bob"})
+
+	content := readRunLog(t, tmpDir)
+	if !strings.Contains(content, "[CONTROL] alice@host paused new task launches") {
+		t.Errorf("expected pause event in run log, got: %s", content)
+	}
+	if !strings.Contains(content, "[CONTROL] bob skipped task 7") {
+		t.Errorf("expected skip event in run log, got: %s", content)
+	}
+}
+
 // Helper function to read the current run log file
 func readRunLog(t *testing.T, tmpDir string) string {
 	t.Helper()
@@ -658,3 +683,42 @@ func TestLogTaskResult_WithExecutionHistory(t *testing.T) {
 		t.Errorf("Missing attempt 3 QC feedback")
 	}
 }
+
+// TestLogTaskResult_ContextTruncations verifies context budget truncations are logged per attempt
+func TestLogTaskResult_ContextTruncations(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger, err := NewFileLoggerWithDir(filepath.Join(tmpDir, "logs"))
+	if err != nil {
+		t.Fatalf("NewFileLogger() error = %v", err)
+	}
+	defer logger.Close()
+
+	result := models.TaskResult{
+		Task:   models.Task{Number: "6", Name: "Budgeted"},
+		Status: models.StatusGreen,
+		ExecutionHistory: []models.ExecutionAttempt{{
+			Attempt: 2,
+			Verdict: models.StatusGreen,
+			ContextTruncations: []models.ContextTruncation{
+				{Section: "warm_up", Action: models.ContextDropped, Tokens: 420},
+				{Section: "learning", Action: models.ContextSummarized, Tokens: 900, KeptTokens: 310},
+			},
+		}},
+	}
+	if err := logger.LogTaskResult(result); err != nil {
+		t.Fatalf("LogTaskResult() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "logs", "tasks", "task-6.log"))
+	if err != nil {
+		t.Fatalf("Failed to read task log: %v", err)
+	}
+	for _, want := range []string{
+		"Context budget: dropped warm_up (~420 tokens)",
+		"Context budget: summarized learning (~900 -> ~310 tokens)",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("task log missing %q", want)
+		}
+	}
+}
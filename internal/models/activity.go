@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Agent activity kinds reported by streaming invocations (v3.6+)
+const (
+	ActivityStart      = "start"       // Agent process launched
+	ActivityStatus     = "status"      // Any other stream event (init, tool results, ...)
+	ActivityText       = "text"        // Assistant text
+	ActivityToolUse    = "tool_use"    // Tool call
+	ActivityToolResult = "tool_result" // Tool call finished
+	ActivityResult     = "result"      // Final result envelope
+)
+
+// AgentActivity is one event from a streaming agent invocation (v3.6+).
+type AgentActivity struct {
+	Kind       string    `json:"kind"`
+	TaskNumber string    `json:"task,omitempty"`
+	Tool       string    `json:"tool,omitempty"`   // Tool name for ActivityToolUse
+	Detail     string    `json:"detail,omitempty"` // Assistant text or a summary of the tool input
+	Time       time.Time `json:"time"`
+}
+
+// IsVisible reports whether the activity is worth showing as a live progress
+// line. Other kinds only serve as heartbeats.
+func (a AgentActivity) IsVisible() bool {
+	return a.Kind == ActivityText || a.Kind == ActivityToolUse
+}
+
+// Description returns a single-line summary of the activity, at most maxLen
+// characters long (values of 3 or less mean no limit).
+func (a AgentActivity) Description(maxLen int) string {
+	var desc string
+	switch a.Kind {
+	case ActivityToolUse:
+		desc = a.Tool
+		if a.Detail != "" {
+			desc += " " + a.Detail
+		}
+	default:
+		desc = a.Detail
+	}
+
+	desc = strings.Join(strings.Fields(desc), " ")
+	if maxLen > 3 && len([]rune(desc)) > maxLen {
+		desc = string([]rune(desc)[:maxLen-3]) + "..."
+	}
+	return desc
+}
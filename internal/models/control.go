@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Run control actions (v3.6+)
+const (
+	ControlActionPause       = "pause"       // Stop launching new tasks
+	ControlActionResume      = "resume"      // Resume launching tasks
+	ControlActionCancel      = "cancel"      // Cancel one in-flight task
+	ControlActionSkip        = "skip"        // Skip one pending task
+	ControlActionConcurrency = "concurrency" // Change max_concurrency
+)
+
+// ControlEvent records a change applied to a running plan through run control (v3.6+).
+type ControlEvent struct {
+	Action     string    `json:"action"`
+	TaskNumber string    `json:"task,omitempty"`
+	Value      int       `json:"value,omitempty"` // New max concurrency for ControlActionConcurrency
+	Actor      string    `json:"actor"`
+	Time       time.Time `json:"time"`
+}
+
+// Description returns a human-readable summary of the change.
+func (e ControlEvent) Description() string {
+	switch e.Action {
+	case ControlActionPause:
+		return "paused new task launches"
+	case ControlActionResume:
+		return "resumed task launches"
+	case ControlActionCancel:
+		return fmt.Sprintf("cancelled task %s", e.TaskNumber)
+	case ControlActionSkip:
+		return fmt.Sprintf("skipped task %s", e.TaskNumber)
+	case ControlActionConcurrency:
+		if e.Value == 0 {
+			return "set max concurrency to unlimited"
+		}
+		return fmt.Sprintf("set max concurrency to %d", e.Value)
+	default:
+		return e.Action
+	}
+}
+
+// ControlStatus is a snapshot of run control state (v3.6+).
+type ControlStatus struct {
+	Paused bool `json:"paused"`
+	// MaxConcurrency is the limit set through run control (0 = unlimited).
+	// Only meaningful when ConcurrencyOverride is true; otherwise plan limits apply.
+	MaxConcurrency      int      `json:"max_concurrency"`
+	ConcurrencyOverride bool     `json:"concurrency_override"`
+	Pending             []string `json:"pending"`
+	Running             []string `json:"running"`
+	Done                []string `json:"done"`
+	Skipped             []string `json:"skipped,omitempty"`
+	Cancelled           []string `json:"cancelled,omitempty"`
+}
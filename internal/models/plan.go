@@ -84,9 +84,11 @@ type QualityControlConfig struct {
 	RetryOnRed int           // Number of retries on RED status
 }
 
-// WorktreeGroup represents a group of related tasks for organization.
-// NOTE: Groups are organizational metadata only - NOT used for execution control.
-// Conductor uses Task.DependsOn to determine execution order via dependency graph.
+// WorktreeGroup represents a group of related tasks.
+// Groups do not affect execution order - Conductor uses Task.DependsOn to determine
+// execution order via dependency graph. With executor.isolation set to "worktree"
+// (v3.6+), tasks in the same group share one git worktree and branch, so their
+// changes are merged back into the run branch together.
 type WorktreeGroup struct {
 	GroupID        string // Unique identifier for the group
 	Description    string // Human-readable description of the group
@@ -11,6 +11,18 @@ type AgentResponse struct {
 	Files     []string               `json:"files_modified"`       // Modified file paths
 	Metadata  map[string]interface{} `json:"metadata"`             // Additional data
 	SessionID string                 `json:"session_id,omitempty"` // Claude CLI session ID
+
+	// Subtasks proposed by an agent that found its task too big (v3.6+)
+	Subtasks []ProposedSubtask `json:"subtasks,omitempty"`
+}
+
+// ProposedSubtask is a task an agent asks to add to the plan after its own (v3.6+).
+// DependsOn lists plan task numbers or the names of earlier subtasks in the same response.
+type ProposedSubtask struct {
+	Name      string   `json:"name"`
+	Files     []string `json:"files,omitempty"`
+	Prompt    string   `json:"prompt"`
+	DependsOn []string `json:"depends_on,omitempty"`
 }
 
 // Validate checks if required fields are present
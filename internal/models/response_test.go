@@ -96,6 +96,19 @@ func TestAgentResponse_Unmarshal(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "proposed subtasks",
+			input: `{"status":"success","summary":"Partial","output":"result","subtasks":[{"name":"Users handler","files":["api/users.go"],"prompt":"Add the users handler","depends_on":["3"]}]}`,
+			want: AgentResponse{
+				Status:  "success",
+				Summary: "Partial",
+				Output:  "result",
+				Subtasks: []ProposedSubtask{
+					{Name: "Users handler", Files: []string{"api/users.go"}, Prompt: "Add the users handler", DependsOn: []string{"3"}},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "invalid JSON",
 			input:   `{invalid}`,
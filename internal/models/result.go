@@ -8,16 +8,51 @@ const (
 	StatusYellow = "YELLOW" // Task completed with warnings
 	StatusRed    = "RED"    // Task failed quality control
 	StatusFailed = "FAILED" // Task failed to execute
+
+	// StatusBlocked marks a task that was never executed because one of its
+	// transitive dependencies ended RED/FAILED (keep-going mode, v3.6+)
+	StatusBlocked = "BLOCKED"
+
+	// StatusSkipped marks a task that was not executed because its `when:`
+	// expression was false at launch (v3.6+). Its dependents still run.
+	StatusSkipped = "SKIPPED"
 )
 
 // ExecutionAttempt represents a single execution attempt (for retry tracking)
 type ExecutionAttempt struct {
-	Attempt     int    // Attempt number (1-indexed)
-	Agent       string // Agent used for this attempt
-	AgentOutput string // Raw JSON output from agent
-	QCFeedback  string // Raw JSON output from QC review
-	Verdict     string // QC verdict: "GREEN", "RED", "YELLOW"
-	Duration    time.Duration
+	Attempt            int    // Attempt number (1-indexed)
+	Agent              string // Agent used for this attempt
+	Model              string // Claude model chosen by model routing (v3.6+, empty when routing is off)
+	AgentOutput        string // Raw JSON output from agent
+	QCFeedback         string // Raw JSON output from QC review
+	Verdict            string // QC verdict: "GREEN", "RED", "YELLOW"
+	Duration           time.Duration
+	QCIssues           []Issue             // Issues reported by QC for this attempt (v3.6+)
+	Usage              Usage               // Tokens and cost of the agent invocation (v3.6+)
+	QCUsage            Usage               // Tokens and cost of the QC review (v3.6+)
+	ContextTruncations []ContextTruncation // Prompt context cut to fit the context budget (v3.6+)
+}
+
+// ContextTruncation records a prompt context section that was summarized or
+// dropped to keep a task prompt within executor.context_budget (v3.6+).
+type ContextTruncation struct {
+	Section    string // Context section, e.g. "warm_up" or "qc_feedback (attempt 1)"
+	Action     string // ContextSummarized or ContextDropped
+	Tokens     int    // Approximate tokens before truncation
+	KeptTokens int    // Approximate tokens left in the prompt (0 when dropped)
+}
+
+// Context truncation actions.
+const (
+	ContextSummarized = "summarized"
+	ContextDropped    = "dropped"
+)
+
+// TotalUsage returns the combined agent and QC usage of the attempt.
+func (a ExecutionAttempt) TotalUsage() Usage {
+	total := a.Usage
+	total.Add(a.QCUsage)
+	return total
 }
 
 // TaskResult represents the result of executing a single task
@@ -31,6 +66,8 @@ type TaskResult struct {
 	ReviewFeedback   string             // Feedback from QC review
 	ExecutionHistory []ExecutionAttempt // Detailed history of all attempts
 	SessionID        string             // Claude CLI session ID (for rate limit recovery)
+	Usage            Usage              // Tokens and cost across all attempts and QC reviews (v3.6+)
+	ProposedSubtasks []ProposedSubtask  // Subtasks the agent proposed in its final attempt (v3.6+)
 }
 
 // ExecutionResult represents the aggregate result of executing a plan
@@ -40,6 +77,10 @@ type ExecutionResult struct {
 	Failed          int            `json:"failed" yaml:"failed"`                       // Number of failed tasks
 	Duration        time.Duration  `json:"duration" yaml:"duration"`                   // Total execution time
 	FailedTasks     []TaskResult   `json:"failed_tasks" yaml:"failed_tasks"`           // Details of failed tasks
+	Blocked         int            `json:"blocked" yaml:"blocked"`                     // Number of tasks blocked by failed dependencies
+	BlockedTasks    []TaskResult   `json:"blocked_tasks" yaml:"blocked_tasks"`         // Details of blocked tasks
+	Skipped         int            `json:"skipped" yaml:"skipped"`                     // Number of tasks skipped by their when: expression (v3.6+)
+	SkippedTasks    []TaskResult   `json:"skipped_tasks" yaml:"skipped_tasks"`         // Details of skipped tasks, with the reason in Output
 	StatusBreakdown map[string]int `json:"status_breakdown" yaml:"status_breakdown"`   // Count by QC status (GREEN/YELLOW/RED)
 	AgentUsage      map[string]int `json:"agent_usage" yaml:"agent_usage"`             // Count by agent name
 	TotalFiles      int            `json:"total_files" yaml:"total_files"`             // Count of unique files modified
@@ -48,6 +89,13 @@ type ExecutionResult struct {
 	// LOC tracking aggregates (v3.4+)
 	TotalLinesAdded   int `json:"total_lines_added" yaml:"total_lines_added"`
 	TotalLinesDeleted int `json:"total_lines_deleted" yaml:"total_lines_deleted"`
+
+	// Usage is the token and cost total across all tasks (v3.6+)
+	Usage Usage `json:"usage" yaml:"usage"`
+
+	// TaskResults holds every task result the metrics were calculated from (v3.6+).
+	// Used to build per-task reports; not serialized.
+	TaskResults []TaskResult `json:"-" yaml:"-"`
 }
 
 // calculateMetricsFromResults calculates all metrics from a slice of TaskResults.
@@ -59,11 +107,16 @@ func (er *ExecutionResult) calculateMetricsFromResults(results []TaskResult) {
 	er.StatusBreakdown[StatusYellow] = 0
 	er.StatusBreakdown[StatusRed] = 0
 
+	er.TaskResults = results
+
 	// Reset counters
 	er.Completed = 0
 	er.Failed = 0
+	er.Blocked = 0
+	er.Skipped = 0
 	er.TotalLinesAdded = 0
 	er.TotalLinesDeleted = 0
+	er.Usage = Usage{}
 
 	// Track unique files using a map (set)
 	uniqueFiles := make(map[string]bool)
@@ -91,14 +144,25 @@ func (er *ExecutionResult) calculateMetricsFromResults(results []TaskResult) {
 		// Aggregate LOC metrics
 		er.TotalLinesAdded += result.Task.LinesAdded
 		er.TotalLinesDeleted += result.Task.LinesDeleted
+		er.Usage.Add(result.Usage)
 
-		// Track completed/failed
+		// Track completed/failed/blocked
 		if result.Status == StatusRed || result.Status == StatusFailed {
 			er.Failed++
 			// Only append to FailedTasks if it's initialized (not nil)
 			if er.FailedTasks != nil {
 				er.FailedTasks = append(er.FailedTasks, result)
 			}
+		} else if result.Status == StatusBlocked {
+			er.Blocked++
+			if er.BlockedTasks != nil {
+				er.BlockedTasks = append(er.BlockedTasks, result)
+			}
+		} else if result.Status == StatusSkipped {
+			er.Skipped++
+			if er.SkippedTasks != nil {
+				er.SkippedTasks = append(er.SkippedTasks, result)
+			}
 		} else {
 			er.Completed++
 		}
@@ -128,6 +192,8 @@ func NewExecutionResult(results []TaskResult, success bool, totalDuration time.D
 		TotalTasks:      len(results),
 		Duration:        totalDuration,
 		FailedTasks:     []TaskResult{},
+		BlockedTasks:    []TaskResult{},
+		SkippedTasks:    []TaskResult{},
 		StatusBreakdown: make(map[string]int),
 		AgentUsage:      make(map[string]int),
 	}
@@ -835,3 +835,98 @@ func TestExecutionResult_HelperFunction(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutionResult_BlockedTasks(t *testing.T) {
+	results := []TaskResult{
+		{Status: StatusGreen, Task: Task{Number: "1", Name: "Task 1"}},
+		{Status: StatusRed, Task: Task{Number: "2", Name: "Task 2"}},
+		{Status: StatusBlocked, Task: Task{Number: "3", Name: "Task 3"}, Output: "Blocked: dependency task 2 failed"},
+		{Status: StatusBlocked, Task: Task{Number: "4", Name: "Task 4"}, Output: "Blocked: dependency task 2 failed"},
+	}
+
+	er := NewExecutionResult(results, false, time.Minute)
+
+	if er.Completed != 1 {
+		t.Errorf("Completed = %d, want 1", er.Completed)
+	}
+	if er.Failed != 1 {
+		t.Errorf("Failed = %d, want 1", er.Failed)
+	}
+	if er.Blocked != 2 {
+		t.Errorf("Blocked = %d, want 2", er.Blocked)
+	}
+	if len(er.BlockedTasks) != 2 {
+		t.Fatalf("len(BlockedTasks) = %d, want 2", len(er.BlockedTasks))
+	}
+	if er.BlockedTasks[0].Task.Number != "3" {
+		t.Errorf("BlockedTasks[0] = task %s, want 3", er.BlockedTasks[0].Task.Number)
+	}
+	if er.StatusBreakdown[StatusBlocked] != 2 {
+		t.Errorf("StatusBreakdown[BLOCKED] = %d, want 2", er.StatusBreakdown[StatusBlocked])
+	}
+}
+
+func TestExecutionResult_SkippedTasks(t *testing.T) {
+	results := []TaskResult{
+		{Status: StatusGreen, Task: Task{Number: "1", Name: "Task 1"}},
+		{Status: StatusSkipped, Task: Task{Number: "2", Name: "Task 2"}, Output: `Skipped: when changed("db/**") is false`},
+		{Status: StatusGreen, Task: Task{Number: "3", Name: "Task 3"}},
+	}
+
+	er := NewExecutionResult(results, true, time.Minute)
+
+	if er.Completed != 2 || er.Failed != 0 || er.Skipped != 1 {
+		t.Errorf("Completed/Failed/Skipped = %d/%d/%d, want 2/0/1", er.Completed, er.Failed, er.Skipped)
+	}
+	if len(er.SkippedTasks) != 1 || er.SkippedTasks[0].Task.Number != "2" {
+		t.Fatalf("SkippedTasks = %+v, want task 2", er.SkippedTasks)
+	}
+	if er.StatusBreakdown[StatusSkipped] != 1 {
+		t.Errorf("StatusBreakdown[SKIPPED] = %d, want 1", er.StatusBreakdown[StatusSkipped])
+	}
+}
+
+func TestExecutionResult_UsageRollup(t *testing.T) {
+	results := []TaskResult{
+		{
+			Status: StatusGreen,
+			Task:   Task{Number: "1", Name: "T1"},
+			Usage:  Usage{InputTokens: 100, OutputTokens: 20, CostUSD: 0.25},
+		},
+		{
+			Status: StatusRed,
+			Task:   Task{Number: "2", Name: "T2"},
+			Usage:  Usage{InputTokens: 50, CacheReadInputTokens: 400, CostUSD: 0.5},
+		},
+		{Status: StatusGreen, Task: Task{Number: "3", Name: "T3"}},
+	}
+
+	result := NewExecutionResult(results, true, time.Minute)
+	want := Usage{InputTokens: 150, OutputTokens: 20, CacheReadInputTokens: 400, CostUSD: 0.75}
+	if result.Usage != want {
+		t.Errorf("Usage = %+v, want %+v", result.Usage, want)
+	}
+	if got := result.Usage.TotalTokens(); got != 570 {
+		t.Errorf("TotalTokens() = %d, want 570", got)
+	}
+
+	// Recalculating must not double count
+	result.CalculateMetrics(results)
+	if result.Usage != want {
+		t.Errorf("Usage after CalculateMetrics = %+v, want %+v", result.Usage, want)
+	}
+}
+
+func TestExecutionAttempt_TotalUsage(t *testing.T) {
+	attempt := ExecutionAttempt{
+		Usage:   Usage{InputTokens: 10, CostUSD: 0.1},
+		QCUsage: Usage{OutputTokens: 5, CostUSD: 0.05},
+	}
+	got := attempt.TotalUsage()
+	if got.InputTokens != 10 || got.OutputTokens != 5 || got.CostUSD < 0.1499 || got.CostUSD > 0.1501 {
+		t.Errorf("TotalUsage() = %+v", got)
+	}
+	if !(ExecutionAttempt{}).TotalUsage().IsZero() {
+		t.Error("expected zero usage for an attempt without invocations")
+	}
+}
@@ -9,7 +9,27 @@ import (
 // It requires 'status' and 'summary' fields, uses enum constraints for status,
 // and supports dynamic metadata through additionalProperties.
 func AgentResponseSchema() string {
-	return `{
+	return AgentResponseSchemaWithOptions(false)
+}
+
+// AgentResponseSchemaWithOptions returns the AgentResponse schema. When
+// allowSubtasks is true it includes the subtasks field agents use to propose
+// follow-up tasks (v3.6+); it is left out unless subtasks will be accepted.
+func AgentResponseSchemaWithOptions(allowSubtasks bool) string {
+	if !allowSubtasks {
+		return agentResponseSchema
+	}
+	var schema map[string]interface{}
+	var subtasks interface{}
+	_ = json.Unmarshal([]byte(agentResponseSchema), &schema)
+	_ = json.Unmarshal([]byte(agentSubtasksSchema), &subtasks)
+	schema["properties"].(map[string]interface{})["subtasks"] = subtasks
+	jsonBytes, _ := json.Marshal(schema)
+	return string(jsonBytes)
+}
+
+// agentResponseSchema is the AgentResponse schema without the subtasks field.
+const agentResponseSchema = `{
   "$schema": "http://json-schema.org/draft-07/schema#",
   "title": "Agent Response",
   "description": "Structured JSON output from an agent task execution",
@@ -55,7 +75,41 @@ func AgentResponseSchema() string {
   },
   "additionalProperties": false
 }`
-}
+
+// agentSubtasksSchema is the schema of the subtasks field.
+const agentSubtasksSchema = `{
+  "type": "array",
+  "description": "Only if the task is too big to finish: follow-up tasks for the remaining work, run after this task",
+  "items": {
+    "type": "object",
+    "required": ["name", "prompt"],
+    "properties": {
+      "name": {
+        "type": "string",
+        "description": "Short task title"
+      },
+      "files": {
+        "type": "array",
+        "items": {
+          "type": "string"
+        },
+        "description": "Files the subtask will create or modify"
+      },
+      "prompt": {
+        "type": "string",
+        "description": "Complete instructions for the agent that runs the subtask"
+      },
+      "depends_on": {
+        "type": "array",
+        "items": {
+          "type": "string"
+        },
+        "description": "Plan task numbers or names of earlier subtasks it must wait for"
+      }
+    },
+    "additionalProperties": false
+  }
+}`
 
 // QCResponseSchema returns a JSON Schema for the QCResponse struct.
 // This schema enforces the structure expected from Quality Control reviews.
@@ -73,6 +73,26 @@ func TestAgentResponseSchema(t *testing.T) {
 	}
 }
 
+func TestAgentResponseSchemaWithOptions(t *testing.T) {
+	for _, allowSubtasks := range []bool{false, true} {
+		var parsed struct {
+			Properties map[string]interface{} `json:"properties"`
+		}
+		if err := json.Unmarshal([]byte(AgentResponseSchemaWithOptions(allowSubtasks)), &parsed); err != nil {
+			t.Fatalf("AgentResponseSchemaWithOptions(%v) returned invalid JSON: %v", allowSubtasks, err)
+		}
+		if _, ok := parsed.Properties["subtasks"]; ok != allowSubtasks {
+			t.Errorf("AgentResponseSchemaWithOptions(%v): subtasks present = %v", allowSubtasks, ok)
+		}
+		if _, ok := parsed.Properties["summary"]; !ok {
+			t.Errorf("AgentResponseSchemaWithOptions(%v) lost the summary property", allowSubtasks)
+		}
+	}
+	if AgentResponseSchema() != AgentResponseSchemaWithOptions(false) {
+		t.Error("AgentResponseSchema should not offer subtasks")
+	}
+}
+
 func TestQCResponseSchemaWithoutCriteria(t *testing.T) {
 	schema := QCResponseSchema(false)
 
@@ -59,6 +59,21 @@ type Task struct {
 	// Rate limit recovery (v2.21+)
 	ResumeSessionID string `json:"-" yaml:"-"` // Session ID for --resume flag (runtime only, not persisted)
 
+	// Agent backend selection (v3.6+)
+	Backend string `yaml:"backend,omitempty" json:"backend,omitempty"` // Backend name overriding agent/default backend routing
+
+	// QC calibration (v3.6+)
+	Fixes []string `yaml:"fixes,omitempty" json:"fixes,omitempty"` // Task numbers whose work this task fixes; their last QC review missed a problem
+
+	// Conditional execution (v3.6+)
+	When string `yaml:"when,omitempty" json:"when,omitempty"` // Expression evaluated at launch; the task is SKIPPED when it is false
+
+	// Model routing (v3.6+)
+	Model string `json:"-" yaml:"-"` // Claude model for this invocation (runtime only, set by ModelRouter)
+
+	// Worktree isolation (v3.6+)
+	WorkDir string `json:"-" yaml:"-"` // Directory the agent runs in (runtime only, set by WorktreeHook)
+
 	// Commit specification (v2.30+)
 	CommitSpec *CommitSpec `yaml:"commit,omitempty" json:"commit,omitempty"` // Expected commit for verification
 
@@ -174,6 +189,11 @@ func (t *Task) IsIntegration() bool {
 	return t.Type == "integration"
 }
 
+// IsApproval returns true if the task is a human approval gate (v3.6+)
+func (t *Task) IsApproval() bool {
+	return strings.EqualFold(strings.TrimSpace(t.Type), "approval")
+}
+
 // UnmarshalYAML handles custom YAML unmarshaling for Task to support mixed dependency formats
 // Supports both:
 //   - Numeric-only: depends_on: [1, 2, 3]
@@ -0,0 +1,30 @@
+package models
+
+// Usage records the tokens and cost reported by the claude CLI for one or more
+// invocations (v3.6+). Zero values mean the backend did not report usage.
+type Usage struct {
+	InputTokens              int64   `json:"input_tokens,omitempty" yaml:"input_tokens,omitempty"`
+	OutputTokens             int64   `json:"output_tokens,omitempty" yaml:"output_tokens,omitempty"`
+	CacheCreationInputTokens int64   `json:"cache_creation_input_tokens,omitempty" yaml:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int64   `json:"cache_read_input_tokens,omitempty" yaml:"cache_read_input_tokens,omitempty"`
+	CostUSD                  float64 `json:"cost_usd,omitempty" yaml:"cost_usd,omitempty"`
+}
+
+// Add accumulates other into u.
+func (u *Usage) Add(other Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheCreationInputTokens += other.CacheCreationInputTokens
+	u.CacheReadInputTokens += other.CacheReadInputTokens
+	u.CostUSD += other.CostUSD
+}
+
+// TotalTokens returns all input, output and cache tokens.
+func (u Usage) TotalTokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+}
+
+// IsZero reports whether no usage was recorded.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
@@ -0,0 +1,79 @@
+// Package notify posts run events to HTTP webhooks (v3.6+).
+//
+// It mirrors the TTS announcer: the same execution events that are spoken
+// (wave start/complete, QC failures, anomalies, budget warnings, rate-limit
+// pauses, run completion) are delivered as JSON to configured webhooks, with
+// per-webhook event filters, retries, HMAC signing and templated payloads.
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Notification event types.
+const (
+	EventWaveStart       = "wave.start"
+	EventWaveComplete    = "wave.complete"
+	EventTaskFailed      = "task.failed" // Task ended RED (QC failure) or FAILED
+	EventAnomaly         = "anomaly"
+	EventBudgetWarning   = "budget.warning"
+	EventRateLimitPause  = "rate_limit.pause"
+	EventRateLimitResume = "rate_limit.resume"
+	EventRunComplete     = "run.complete"
+)
+
+// knownEventTypes lists every event type a webhook can subscribe to.
+var knownEventTypes = map[string]bool{
+	EventWaveStart:       true,
+	EventWaveComplete:    true,
+	EventTaskFailed:      true,
+	EventAnomaly:         true,
+	EventBudgetWarning:   true,
+	EventRateLimitPause:  true,
+	EventRateLimitResume: true,
+	EventRunComplete:     true,
+}
+
+// EventTypes returns every event type, sorted.
+func EventTypes() []string {
+	types := make([]string, 0, len(knownEventTypes))
+	for eventType := range knownEventTypes {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Event is the payload delivered to webhooks. It is sent as JSON unless the
+// webhook has a template, in which case the template renders it.
+type Event struct {
+	Type     string                 `json:"type"`
+	Time     time.Time              `json:"time"`
+	Message  string                 `json:"message"`        // Human-readable summary, suitable for chat
+	Severity string                 `json:"severity"`       // "info", "warning" or "error"
+	Plan     string                 `json:"plan,omitempty"` // Plan file being executed
+	Wave     string                 `json:"wave,omitempty"` // Wave name, for wave and anomaly events
+	Task     string                 `json:"task,omitempty"` // Task number, for task and anomaly events
+	TaskName string                 `json:"task_name,omitempty"`
+	Status   string                 `json:"status,omitempty"` // Task or run status
+	Data     map[string]interface{} `json:"data,omitempty"`   // Event-specific details
+}
+
+// Event severities.
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// validateEventTypes rejects unknown event types in a webhook filter.
+func validateEventTypes(types []string) error {
+	for _, eventType := range types {
+		if !knownEventTypes[eventType] {
+			return fmt.Errorf("unknown event type %q (valid: %v)", eventType, EventTypes())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/executor"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// Compile-time interface compliance check.
+var _ executor.Logger = (*Notifier)(nil)
+
+// Notifier implements executor.Logger and fans notification events out to webhooks.
+// It reacts to the same events the TTS announcer speaks; all other logger
+// methods are no-ops.
+type Notifier struct {
+	// Plan is the plan file recorded on every event (optional)
+	Plan string
+
+	webhooks []*Webhook
+	now      func() time.Time
+}
+
+// NewNotifier creates a Notifier with one Webhook per configuration.
+// httpTimeout bounds each request (typically timeouts.http).
+func NewNotifier(cfg config.NotificationsConfig, httpTimeout time.Duration) (*Notifier, error) {
+	n := &Notifier{now: time.Now}
+	for _, webhookCfg := range cfg.Webhooks {
+		webhook, err := NewWebhook(webhookCfg, httpTimeout)
+		if err != nil {
+			n.Close()
+			return nil, err
+		}
+		n.webhooks = append(n.webhooks, webhook)
+	}
+	return n, nil
+}
+
+// Webhooks returns the configured webhooks.
+func (n *Notifier) Webhooks() []*Webhook {
+	return n.webhooks
+}
+
+// Close flushes queued deliveries on every webhook.
+func (n *Notifier) Close() {
+	for _, webhook := range n.webhooks {
+		webhook.Close()
+	}
+}
+
+// publish stamps an event and queues it on every webhook.
+func (n *Notifier) publish(event Event) {
+	event.Time = n.now().UTC()
+	event.Plan = n.Plan
+	for _, webhook := range n.webhooks {
+		webhook.Send(event)
+	}
+}
+
+// LogWaveStart sends a wave.start event.
+func (n *Notifier) LogWaveStart(wave models.Wave) {
+	n.publish(Event{
+		Type:     EventWaveStart,
+		Message:  fmt.Sprintf("Starting %s with %d tasks", wave.Name, len(wave.TaskNumbers)),
+		Severity: SeverityInfo,
+		Wave:     wave.Name,
+		Data: map[string]interface{}{
+			"tasks":           wave.TaskNumbers,
+			"max_concurrency": wave.MaxConcurrency,
+		},
+	})
+}
+
+// LogWaveComplete sends a wave.complete event with failure counts.
+func (n *Notifier) LogWaveComplete(wave models.Wave, duration time.Duration, results []models.TaskResult) {
+	var failed []string
+	for _, result := range results {
+		if result.Status == models.StatusRed || result.Status == models.StatusFailed {
+			failed = append(failed, result.Task.Number)
+		}
+	}
+
+	event := Event{
+		Type:     EventWaveComplete,
+		Message:  fmt.Sprintf("%s completed, all tasks passed", wave.Name),
+		Severity: SeverityInfo,
+		Wave:     wave.Name,
+		Data: map[string]interface{}{
+			"duration_ms":  duration.Milliseconds(),
+			"tasks":        len(results),
+			"failed_tasks": failed,
+		},
+	}
+	if len(failed) > 0 {
+		event.Message = fmt.Sprintf("%s completed with %d failures", wave.Name, len(failed))
+		event.Severity = SeverityWarning
+	}
+	n.publish(event)
+}
+
+// LogTaskResult sends a task.failed event for RED and FAILED tasks.
+func (n *Notifier) LogTaskResult(result models.TaskResult) error {
+	if result.Status != models.StatusRed && result.Status != models.StatusFailed {
+		return nil
+	}
+
+	message := fmt.Sprintf("Task %s (%s) failed quality control", result.Task.Number, result.Task.Name)
+	data := map[string]interface{}{
+		"attempts":    len(result.ExecutionHistory),
+		"duration_ms": result.Duration.Milliseconds(),
+	}
+	if result.ReviewFeedback != "" {
+		data["feedback"] = result.ReviewFeedback
+	}
+	if result.Status == models.StatusFailed {
+		message = fmt.Sprintf("Task %s (%s) failed", result.Task.Number, result.Task.Name)
+		if result.Error != nil {
+			data["error"] = result.Error.Error()
+		}
+	}
+
+	n.publish(Event{
+		Type:     EventTaskFailed,
+		Message:  message,
+		Severity: SeverityError,
+		Task:     result.Task.Number,
+		TaskName: result.Task.Name,
+		Status:   result.Status,
+		Data:     data,
+	})
+	return nil
+}
+
+// LogProgress is a no-op implementation.
+func (n *Notifier) LogProgress(results []models.TaskResult) {
+}
+
+// LogSummary sends a run.complete event.
+func (n *Notifier) LogSummary(result models.ExecutionResult) {
+	event := Event{
+		Type:     EventRunComplete,
+		Message:  fmt.Sprintf("Run completed. All %d tasks passed", result.TotalTasks),
+		Severity: SeverityInfo,
+		Status:   "SUCCESS",
+		Data: map[string]interface{}{
+			"total_tasks": result.TotalTasks,
+			"completed":   result.Completed,
+			"failed":      result.Failed,
+			"blocked":     result.Blocked,
+			"skipped":     result.Skipped,
+			"duration_ms": result.Duration.Milliseconds(),
+		},
+	}
+	if !result.Usage.IsZero() {
+		event.Data["cost_usd"] = result.Usage.CostUSD
+		event.Data["total_tokens"] = result.Usage.TotalTokens()
+	}
+	if result.Failed > 0 {
+		event.Message = fmt.Sprintf("Run completed. %d of %d tasks passed, %d failed", result.Completed, result.TotalTasks, result.Failed)
+		event.Severity = SeverityError
+		event.Status = "FAILED"
+	}
+	n.publish(event)
+}
+
+// LogTaskAgentInvoke is a no-op implementation.
+func (n *Notifier) LogTaskAgentInvoke(task models.Task) {
+}
+
+// LogQCAgentSelection is a no-op implementation.
+func (n *Notifier) LogQCAgentSelection(agents []string, mode string) {
+}
+
+// LogQCIndividualVerdicts is a no-op implementation.
+func (n *Notifier) LogQCIndividualVerdicts(verdicts map[string]string) {
+}
+
+// LogQCAggregatedResult is a no-op implementation.
+// QC failures are reported once per task through LogTaskResult.
+func (n *Notifier) LogQCAggregatedResult(verdict string, strategy string) {
+}
+
+// LogQCCriteriaResults is a no-op implementation.
+func (n *Notifier) LogQCCriteriaResults(agentName string, results []models.CriterionResult) {
+}
+
+// LogQCIntelligentSelectionMetadata is a no-op implementation.
+func (n *Notifier) LogQCIntelligentSelectionMetadata(rationale string, fallback bool, fallbackReason string) {
+}
+
+// LogAnomaly sends an anomaly event.
+func (n *Notifier) LogAnomaly(anomaly interface{}) {
+	type anomalyDisplay interface {
+		GetType() string
+		GetDescription() string
+		GetSeverity() string
+		GetTaskNumber() string
+		GetWaveName() string
+	}
+
+	a, ok := anomaly.(anomalyDisplay)
+	if !ok {
+		return
+	}
+
+	severity := SeverityWarning
+	if a.GetSeverity() == "high" {
+		severity = SeverityError
+	}
+	n.publish(Event{
+		Type:     EventAnomaly,
+		Message:  fmt.Sprintf("Anomaly in %s: %s", a.GetWaveName(), a.GetDescription()),
+		Severity: severity,
+		Wave:     a.GetWaveName(),
+		Task:     a.GetTaskNumber(),
+		Data: map[string]interface{}{
+			"anomaly_type": a.GetType(),
+			"severity":     a.GetSeverity(),
+		},
+	})
+}
+
+// LogBudgetStatus is a no-op implementation.
+func (n *Notifier) LogBudgetStatus(status interface{}) {
+}
+
+// LogBudgetWarning sends a budget.warning event.
+func (n *Notifier) LogBudgetWarning(percentUsed float64) {
+	n.publish(Event{
+		Type:     EventBudgetWarning,
+		Message:  fmt.Sprintf("Budget at %.0f percent", percentUsed),
+		Severity: SeverityWarning,
+		Data:     map[string]interface{}{"percent_used": percentUsed},
+	})
+}
+
+// LogRateLimitPause sends a rate_limit.pause event.
+func (n *Notifier) LogRateLimitPause(delay time.Duration) {
+	n.publish(Event{
+		Type:     EventRateLimitPause,
+		Message:  fmt.Sprintf("Rate limited, pausing for %s", delay.Round(time.Second)),
+		Severity: SeverityWarning,
+		Data: map[string]interface{}{
+			"delay_ms":  delay.Milliseconds(),
+			"resume_at": n.now().Add(delay).UTC(),
+		},
+	})
+}
+
+// LogRateLimitResume sends a rate_limit.resume event.
+func (n *Notifier) LogRateLimitResume() {
+	n.publish(Event{
+		Type:     EventRateLimitResume,
+		Message:  "Resuming after rate limit",
+		Severity: SeverityInfo,
+	})
+}
+
+// LogRateLimitCountdown is a no-op implementation.
+func (n *Notifier) LogRateLimitCountdown(remaining, total time.Duration) {
+}
+
+// LogRateLimitAnnounce is a no-op implementation.
+func (n *Notifier) LogRateLimitAnnounce(remaining, total time.Duration) {
+}
+
+// LogControlEvent is a no-op implementation.
+func (n *Notifier) LogControlEvent(event models.ControlEvent) {
+}
+
+// LogTaskActivity is a no-op implementation.
+func (n *Notifier) LogTaskActivity(activity models.AgentActivity) {
+}
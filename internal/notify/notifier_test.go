@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/config"
+	"github.com/harrison/conductor/internal/executor"
+	"github.com/harrison/conductor/internal/models"
+)
+
+// newTestNotifier returns a notifier with one webhook posting to a recording server.
+func newTestNotifier(t *testing.T, events ...string) (*Notifier, func() []recordedRequest) {
+	t.Helper()
+	server, requests := newRecordingServer(t, http.StatusOK)
+
+	n, err := NewNotifier(config.NotificationsConfig{
+		Webhooks: []config.WebhookConfig{{URL: server.URL, Events: events}},
+	}, 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewNotifier() error = %v", err)
+	}
+	for _, w := range n.Webhooks() {
+		w.errOut = io.Discard
+	}
+	n.Plan = "plan.md"
+	n.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return n, requests
+}
+
+func decodeEvents(t *testing.T, requests []recordedRequest) []Event {
+	t.Helper()
+	events := make([]Event, 0, len(requests))
+	for _, req := range requests {
+		var event Event
+		if err := json.Unmarshal(req.body, &event); err != nil {
+			t.Fatalf("body is not JSON: %v", err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestNotifier_TaskResult(t *testing.T) {
+	n, requests := newTestNotifier(t)
+
+	n.LogTaskResult(models.TaskResult{Task: models.Task{Number: "1", Name: "Setup"}, Status: models.StatusGreen})
+	n.LogTaskResult(models.TaskResult{
+		Task:           models.Task{Number: "2", Name: "API"},
+		Status:         models.StatusRed,
+		ReviewFeedback: "Missing tests",
+	})
+	n.LogTaskResult(models.TaskResult{
+		Task:   models.Task{Number: "3", Name: "Deploy"},
+		Status: models.StatusFailed,
+		Error:  errors.New("agent timed out"),
+	})
+	n.Close()
+
+	events := decodeEvents(t, requests())
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2 (GREEN tasks are not notified)", len(events))
+	}
+
+	red := events[0]
+	if red.Type != EventTaskFailed || red.Task != "2" || red.Status != models.StatusRed || red.Severity != SeverityError {
+		t.Errorf("unexpected RED event: %+v", red)
+	}
+	if red.Message != "Task 2 (API) failed quality control" || red.Data["feedback"] != "Missing tests" {
+		t.Errorf("unexpected RED details: %+v", red)
+	}
+	if red.Plan != "plan.md" || !red.Time.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("event not stamped with plan and time: %+v", red)
+	}
+
+	failed := events[1]
+	if failed.Status != models.StatusFailed || failed.Data["error"] != "agent timed out" {
+		t.Errorf("unexpected FAILED event: %+v", failed)
+	}
+}
+
+func TestNotifier_WaveAndRunEvents(t *testing.T) {
+	n, requests := newTestNotifier(t)
+
+	wave := models.Wave{Name: "Wave 1", TaskNumbers: []string{"1", "2"}}
+	n.LogWaveStart(wave)
+	n.LogWaveComplete(wave, 90*time.Second, []models.TaskResult{
+		{Task: models.Task{Number: "1"}, Status: models.StatusGreen},
+		{Task: models.Task{Number: "2"}, Status: models.StatusRed},
+	})
+	n.LogSummary(models.ExecutionResult{TotalTasks: 2, Completed: 1, Failed: 1})
+	n.Close()
+
+	events := decodeEvents(t, requests())
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	if events[0].Type != EventWaveStart || events[0].Message != "Starting Wave 1 with 2 tasks" {
+		t.Errorf("unexpected wave.start event: %+v", events[0])
+	}
+	complete := events[1]
+	if complete.Type != EventWaveComplete || complete.Severity != SeverityWarning || complete.Message != "Wave 1 completed with 1 failures" {
+		t.Errorf("unexpected wave.complete event: %+v", complete)
+	}
+	if complete.Data["duration_ms"] != float64(90000) {
+		t.Errorf("duration_ms = %v, want 90000", complete.Data["duration_ms"])
+	}
+	summary := events[2]
+	if summary.Type != EventRunComplete || summary.Status != "FAILED" || summary.Severity != SeverityError {
+		t.Errorf("unexpected run.complete event: %+v", summary)
+	}
+}
+
+func TestNotifier_AnomalyBudgetAndRateLimit(t *testing.T) {
+	n, requests := newTestNotifier(t)
+
+	n.LogAnomaly(executor.WaveAnomaly{
+		Type:        "consecutive_failures",
+		Description: "3 consecutive failures",
+		Severity:    "high",
+		TaskNumber:  "4",
+		WaveName:    "Wave 2",
+	})
+	n.LogAnomaly("not an anomaly")
+	n.LogBudgetWarning(82.5)
+	n.LogRateLimitPause(5 * time.Minute)
+	n.LogRateLimitResume()
+	n.Close()
+
+	events := decodeEvents(t, requests())
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+	anomaly := events[0]
+	if anomaly.Type != EventAnomaly || anomaly.Severity != SeverityError || anomaly.Wave != "Wave 2" || anomaly.Task != "4" {
+		t.Errorf("unexpected anomaly event: %+v", anomaly)
+	}
+	if events[1].Type != EventBudgetWarning || events[1].Data["percent_used"] != 82.5 {
+		t.Errorf("unexpected budget event: %+v", events[1])
+	}
+	pause := events[2]
+	if pause.Type != EventRateLimitPause || pause.Message != "Rate limited, pausing for 5m0s" {
+		t.Errorf("unexpected pause event: %+v", pause)
+	}
+	if pause.Data["resume_at"] != "2026-01-02T03:09:05Z" {
+		t.Errorf("resume_at = %v", pause.Data["resume_at"])
+	}
+	if events[3].Type != EventRateLimitResume {
+		t.Errorf("unexpected resume event: %+v", events[3])
+	}
+}
+
+func TestNotifier_FiltersPerWebhook(t *testing.T) {
+	n, requests := newTestNotifier(t, EventTaskFailed, EventRateLimitPause)
+
+	n.LogWaveStart(models.Wave{Name: "Wave 1"})
+	n.LogTaskResult(models.TaskResult{Task: models.Task{Number: "1"}, Status: models.StatusRed})
+	n.LogRateLimitPause(time.Minute)
+	n.LogSummary(models.ExecutionResult{})
+	n.Close()
+
+	events := decodeEvents(t, requests())
+	if len(events) != 2 || events[0].Type != EventTaskFailed || events[1].Type != EventRateLimitPause {
+		t.Errorf("expected task.failed and rate_limit.pause only, got %+v", events)
+	}
+}
+
+func TestNewNotifier_InvalidWebhook(t *testing.T) {
+	_, err := NewNotifier(config.NotificationsConfig{
+		Webhooks: []config.WebhookConfig{
+			{URL: "http://example.com/ok"},
+			{URL: "http://example.com/bad", Events: []string{"nope"}},
+		},
+	}, time.Second)
+	if err == nil {
+		t.Fatal("expected error for unknown event type")
+	}
+}
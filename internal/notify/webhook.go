@@ -0,0 +1,253 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/harrison/conductor/internal/config"
+)
+
+// Request headers set on every delivery.
+const (
+	HeaderEvent     = "X-Conductor-Event"
+	HeaderSignature = "X-Conductor-Signature" // "sha256=<hex HMAC of the body>"
+)
+
+// defaultRetryDelay is the first retry backoff; it doubles on each retry.
+const defaultRetryDelay = time.Second
+
+// webhookQueueSize bounds pending deliveries per webhook.
+const webhookQueueSize = 100
+
+// Webhook delivers events to one HTTP endpoint.
+// Deliveries are queued and sent in order by a background worker, so Send
+// never blocks the run; events are dropped if the queue is full.
+type Webhook struct {
+	name       string
+	url        string
+	events     map[string]bool // nil = all events
+	secret     string
+	template   *template.Template
+	headers    map[string]string
+	maxRetries int
+
+	httpClient *http.Client
+	retryDelay time.Duration
+	errOut     io.Writer
+
+	queue  chan Event
+	done   chan struct{}
+	mu     sync.Mutex // guards closed and sends on queue
+	closed bool
+}
+
+// NewWebhook creates a Webhook from its configuration and starts its worker.
+// httpTimeout bounds each request (typically timeouts.http).
+func NewWebhook(cfg config.WebhookConfig, httpTimeout time.Duration) (*Webhook, error) {
+	name := cfg.Name
+	if name == "" {
+		if parsed, err := url.Parse(cfg.URL); err == nil && parsed.Host != "" {
+			name = parsed.Host
+		} else {
+			name = cfg.URL
+		}
+	}
+
+	if err := validateEventTypes(cfg.Events); err != nil {
+		return nil, fmt.Errorf("webhook %s: %w", name, err)
+	}
+
+	w := &Webhook{
+		name:       name,
+		url:        cfg.URL,
+		secret:     cfg.Secret,
+		headers:    cfg.Headers,
+		maxRetries: cfg.MaxRetries,
+		httpClient: &http.Client{Timeout: httpTimeout},
+		retryDelay: defaultRetryDelay,
+		errOut:     os.Stderr,
+		queue:      make(chan Event, webhookQueueSize),
+		done:       make(chan struct{}),
+	}
+
+	if cfg.SecretEnv != "" {
+		secret := os.Getenv(cfg.SecretEnv)
+		if secret == "" {
+			return nil, fmt.Errorf("webhook %s: environment variable %s is not set", name, cfg.SecretEnv)
+		}
+		w.secret = secret
+	}
+
+	if len(cfg.Events) > 0 {
+		w.events = make(map[string]bool, len(cfg.Events))
+		for _, eventType := range cfg.Events {
+			w.events[eventType] = true
+		}
+	}
+
+	if cfg.Template != "" {
+		tmpl, err := template.New(name).Funcs(template.FuncMap{"json": jsonValue}).Parse(cfg.Template)
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s: invalid template: %w", name, err)
+		}
+		w.template = tmpl
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Name returns the webhook's display name.
+func (w *Webhook) Name() string {
+	return w.name
+}
+
+// Accepts reports whether the webhook subscribes to the event type.
+func (w *Webhook) Accepts(eventType string) bool {
+	return w.events == nil || w.events[eventType]
+}
+
+// Send queues an event for delivery if the webhook subscribes to it.
+// It never blocks; events are dropped when the queue is full or the webhook
+// has been closed.
+func (w *Webhook) Send(event Event) {
+	if !w.Accepts(event.Type) {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	select {
+	case w.queue <- event:
+	default:
+		fmt.Fprintf(w.errOut, "Warning: webhook %s: queue full, dropping %s event\n", w.name, event.Type)
+	}
+}
+
+// Close stops accepting events and waits for queued deliveries to finish.
+// It is safe to call multiple times and concurrently with Send.
+func (w *Webhook) Close() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+	<-w.done
+}
+
+// run delivers queued events in order.
+func (w *Webhook) run() {
+	defer close(w.done)
+	for event := range w.queue {
+		if err := w.Deliver(event); err != nil {
+			fmt.Fprintf(w.errOut, "Warning: webhook %s: %v\n", w.name, err)
+		}
+	}
+}
+
+// Deliver posts one event synchronously, retrying network errors, 429 and
+// 5xx responses with exponential backoff.
+func (w *Webhook) Deliver(event Event) error {
+	body, err := w.Render(event)
+	if err != nil {
+		return err
+	}
+
+	delay := w.retryDelay
+	var lastErr error
+	for attempt := 0; attempt <= w.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		retry, err := w.post(event.Type, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return fmt.Errorf("failed to deliver %s event: %w", event.Type, lastErr)
+}
+
+// Render builds the request body: the template output, or the event as JSON.
+func (w *Webhook) Render(event Event) ([]byte, error) {
+	if w.template == nil {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+		}
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := w.template.Execute(&buf, event); err != nil {
+		return nil, fmt.Errorf("failed to render template for %s event: %w", event.Type, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// post sends one request. It reports whether a failure is worth retrying.
+func (w *Webhook) post(eventType string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "conductor")
+	req.Header.Set(HeaderEvent, eventType)
+	for key, value := range w.headers {
+		req.Header.Set(key, value)
+	}
+	if w.secret != "" {
+		req.Header.Set(HeaderSignature, Sign(w.secret, body))
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+}
+
+// Sign returns the X-Conductor-Signature value for body: "sha256=" followed
+// by the hex HMAC-SHA256 of the body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// jsonValue encodes v as JSON for use inside templates, e.g. {"text": {{json .Message}}}.
+func jsonValue(v interface{}) (string, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(encoded)), nil
+}
@@ -0,0 +1,246 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/config"
+)
+
+// recordedRequest captures one delivery received by a test server.
+type recordedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newRecordingServer returns a server that answers with the given status codes
+// in order (the last one repeats) and records every request.
+func newRecordingServer(t *testing.T, statuses ...int) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []recordedRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, recordedRequest{header: r.Header.Clone(), body: body})
+		status := statuses[len(statuses)-1]
+		if len(requests) <= len(statuses) {
+			status = statuses[len(requests)-1]
+		}
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+func newTestWebhook(t *testing.T, cfg config.WebhookConfig) *Webhook {
+	t.Helper()
+	w, err := NewWebhook(cfg, 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+	w.retryDelay = time.Millisecond
+	w.errOut = io.Discard
+	return w
+}
+
+func TestWebhook_DeliversJSONWithSignature(t *testing.T) {
+	server, requests := newRecordingServer(t, http.StatusOK)
+	w := newTestWebhook(t, config.WebhookConfig{
+		URL:     server.URL,
+		Secret:  "s3cret",
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+
+	w.Send(Event{Type: EventTaskFailed, Message: "Task 3 failed", Severity: SeverityError, Task: "3"})
+	w.Close()
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(got))
+	}
+	req := got[0]
+	if req.header.Get(HeaderEvent) != EventTaskFailed {
+		t.Errorf("%s = %q, want %q", HeaderEvent, req.header.Get(HeaderEvent), EventTaskFailed)
+	}
+	if req.header.Get("Authorization") != "Bearer token" {
+		t.Errorf("custom header not sent: %v", req.header)
+	}
+	if want := Sign("s3cret", req.body); req.header.Get(HeaderSignature) != want {
+		t.Errorf("signature = %q, want %q", req.header.Get(HeaderSignature), want)
+	}
+
+	var event Event
+	if err := json.Unmarshal(req.body, &event); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if event.Type != EventTaskFailed || event.Task != "3" || event.Message != "Task 3 failed" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestWebhook_EventFilter(t *testing.T) {
+	server, requests := newRecordingServer(t, http.StatusOK)
+	w := newTestWebhook(t, config.WebhookConfig{
+		URL:    server.URL,
+		Events: []string{EventTaskFailed, EventRateLimitPause},
+	})
+
+	w.Send(Event{Type: EventWaveStart})
+	w.Send(Event{Type: EventRateLimitPause})
+	w.Send(Event{Type: EventRunComplete})
+	w.Close()
+
+	got := requests()
+	if len(got) != 1 || got[0].header.Get(HeaderEvent) != EventRateLimitPause {
+		t.Errorf("expected only the rate_limit.pause event, got %d requests", len(got))
+	}
+}
+
+func TestWebhook_Template(t *testing.T) {
+	server, requests := newRecordingServer(t, http.StatusOK)
+	w := newTestWebhook(t, config.WebhookConfig{
+		URL:      server.URL,
+		Template: `{"text": {{json .Message}}, "task": "{{.Task}}"}`,
+	})
+
+	w.Send(Event{Type: EventTaskFailed, Message: `QC said "no"`, Task: "7"})
+	w.Close()
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(got))
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(got[0].body, &payload); err != nil {
+		t.Fatalf("templated body is not JSON: %v\n%s", err, got[0].body)
+	}
+	if payload["text"] != `QC said "no"` || payload["task"] != "7" {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+}
+
+func TestWebhook_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxRetries   int
+		wantRequests int
+		wantErr      bool
+	}{
+		{name: "succeeds after server errors", statuses: []int{500, 503, 200}, maxRetries: 3, wantRequests: 3},
+		{name: "retries rate limiting", statuses: []int{429, 200}, maxRetries: 3, wantRequests: 2},
+		{name: "gives up after max retries", statuses: []int{500}, maxRetries: 2, wantRequests: 3, wantErr: true},
+		{name: "does not retry client errors", statuses: []int{400}, maxRetries: 3, wantRequests: 1, wantErr: true},
+		{name: "no retries configured", statuses: []int{500}, maxRetries: 0, wantRequests: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newRecordingServer(t, tt.statuses...)
+			w := newTestWebhook(t, config.WebhookConfig{URL: server.URL, MaxRetries: tt.maxRetries})
+			defer w.Close()
+
+			err := w.Deliver(Event{Type: EventWaveStart})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(requests()); got != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestNewWebhook_Errors(t *testing.T) {
+	t.Setenv("CONDUCTOR_TEST_WEBHOOK_SECRET", "")
+
+	tests := []struct {
+		name    string
+		cfg     config.WebhookConfig
+		wantErr string
+	}{
+		{
+			name:    "unknown event",
+			cfg:     config.WebhookConfig{URL: "http://example.com", Events: []string{"task.done"}},
+			wantErr: `unknown event type "task.done"`,
+		},
+		{
+			name:    "invalid template",
+			cfg:     config.WebhookConfig{URL: "http://example.com", Template: "{{.Message"},
+			wantErr: "invalid template",
+		},
+		{
+			name:    "unset secret env",
+			cfg:     config.WebhookConfig{URL: "http://example.com", SecretEnv: "CONDUCTOR_TEST_WEBHOOK_SECRET"},
+			wantErr: "CONDUCTOR_TEST_WEBHOOK_SECRET is not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWebhook(tt.cfg, time.Second)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewWebhook() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "example.com") {
+				t.Errorf("error should name the webhook: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewWebhook_SecretFromEnv(t *testing.T) {
+	t.Setenv("CONDUCTOR_TEST_WEBHOOK_SECRET", "from-env")
+
+	w := newTestWebhook(t, config.WebhookConfig{
+		Name:      "chat",
+		URL:       "http://example.com",
+		Secret:    "inline",
+		SecretEnv: "CONDUCTOR_TEST_WEBHOOK_SECRET",
+	})
+	defer w.Close()
+
+	if w.secret != "from-env" {
+		t.Errorf("secret = %q, want secret_env to take precedence", w.secret)
+	}
+	if w.Name() != "chat" {
+		t.Errorf("Name() = %q, want chat", w.Name())
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Known HMAC-SHA256 vector (RFC 4231 test case 2)
+	got := Sign("Jefe", []byte("what do ya want for nothing?"))
+	want := "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+}
+
+func TestWebhook_SendAfterCloseIsDropped(t *testing.T) {
+	server, requests := newRecordingServer(t, http.StatusOK)
+	w := newTestWebhook(t, config.WebhookConfig{URL: server.URL})
+
+	w.Send(Event{Type: EventWaveStart})
+	w.Close()
+	w.Send(Event{Type: EventWaveComplete})
+	w.Close()
+
+	if got := requests(); len(got) != 1 {
+		t.Errorf("got %d requests, want only the event sent before Close", len(got))
+	}
+}
@@ -182,6 +182,25 @@ func extractTasksLineByLine(content []byte) ([]models.Task, error) {
 	var taskContent strings.Builder
 	inCodeBlock := false
 
+	// Matrix tasks are expanded into their cells once all tasks are read (v3.6+)
+	matrixAxes := make(map[string][]matrixAxis)
+	cellStates := make(map[string]cellState)
+
+	saveTask := func() {
+		content := taskContent.String()
+		if axes := parseMatrixMarkdown(removeCodeBlocks(content)); len(axes) > 0 {
+			var states map[string]cellState
+			content, states = extractMarkdownCells(currentTask.Number, content)
+			matrixAxes[currentTask.Number] = axes
+			for number, state := range states {
+				cellStates[number] = state
+			}
+		}
+		parseTaskMetadata(currentTask, content)
+		currentTask.Prompt = injectFilesIntoPrompt(content, currentTask.Files)
+		tasks = append(tasks, *currentTask)
+	}
+
 	for i := 0; i < len(lines); i++ {
 		line := lines[i]
 
@@ -211,10 +230,7 @@ func extractTasksLineByLine(content []byte) ([]models.Task, error) {
 		if len(matches) == 3 {
 			// Save previous task if exists
 			if currentTask != nil {
-				content := taskContent.String()
-				parseTaskMetadata(currentTask, content)
-				currentTask.Prompt = injectFilesIntoPrompt(content, currentTask.Files)
-				tasks = append(tasks, *currentTask)
+				saveTask()
 			}
 
 			// Start new task
@@ -231,10 +247,7 @@ func extractTasksLineByLine(content []byte) ([]models.Task, error) {
 			// Stop at next level 2 heading (but not level 3)
 			if strings.HasPrefix(line, "## ") && !strings.HasPrefix(line, "### ") {
 				// This is another section, stop current task
-				content := taskContent.String()
-				parseTaskMetadata(currentTask, content)
-				currentTask.Prompt = injectFilesIntoPrompt(content, currentTask.Files)
-				tasks = append(tasks, *currentTask)
+				saveTask()
 				currentTask = nil
 				taskContent.Reset()
 				continue
@@ -248,13 +261,10 @@ func extractTasksLineByLine(content []byte) ([]models.Task, error) {
 
 	// Don't forget the last task
 	if currentTask != nil {
-		content := taskContent.String()
-		parseTaskMetadata(currentTask, content)
-		currentTask.Prompt = injectFilesIntoPrompt(content, currentTask.Files)
-		tasks = append(tasks, *currentTask)
+		saveTask()
 	}
 
-	return tasks, nil
+	return expandMatrixTasks(tasks, matrixAxes, cellStates)
 }
 
 // extractText extracts plain text from an AST node
@@ -319,6 +329,12 @@ func parseDependenciesFromMarkdown(task *models.Task, depStr string) {
 			continue
 		}
 
+		// Keep matrix placeholders (e.g. "3.{{service}}") for expansion (v3.6+)
+		if strings.Contains(part, "{{") {
+			task.DependsOn = append(task.DependsOn, part)
+			continue
+		}
+
 		// Check for numeric pattern (int, float, alphanumeric)
 		numPat := regexp.MustCompile(`^[\d.]+[a-zA-Z-]*$|^\d+$`)
 		if numPat.MatchString(part) {
@@ -358,7 +374,7 @@ func removeCodeBlocks(content string) string {
 	return result.String()
 }
 
-// parseType extracts the Type field from task content (component or integration)
+// parseType extracts the Type field from task content (component, integration or approval)
 // Returns empty string if Type field not found (backward compatible - defaults to component)
 func parseType(content string) string {
 	// Regex pattern: \*\*Type\*\*:\s*(.+)
@@ -545,6 +561,29 @@ func parseTaskMetadata(task *models.Task, content string) {
 		task.Agent = strings.TrimSpace(matches[1])
 	}
 
+	// Parse **Backend**: (v3.6+)
+	backendRegex := regexp.MustCompile(`\*\*Backend\*\*:\s*(\S+)`)
+	if matches := backendRegex.FindStringSubmatch(contentWithoutCode); len(matches) > 1 {
+		task.Backend = strings.TrimSpace(matches[1])
+	}
+
+	// Parse **Fixes**: (v3.6+)
+	fixesRegex := regexp.MustCompile(`\*\*Fixes\*\*:\s*(.+)`)
+	if matches := fixesRegex.FindStringSubmatch(contentWithoutCode); len(matches) > 1 {
+		for _, part := range strings.Split(matches[1], ",") {
+			part = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part), "Task "))
+			if part != "" {
+				task.Fixes = append(task.Fixes, part)
+			}
+		}
+	}
+
+	// Parse **When**: (v3.6+)
+	whenRegex := regexp.MustCompile(`\*\*When\*\*:\s*(.+)`)
+	if matches := whenRegex.FindStringSubmatch(contentWithoutCode); len(matches) > 1 {
+		task.When = strings.Trim(strings.TrimSpace(matches[1]), "`")
+	}
+
 	// Parse **WorktreeGroup**:
 	worktreeGroupRegex := regexp.MustCompile(`\*\*WorktreeGroup\*\*:\s*(\S+)`)
 	if matches := worktreeGroupRegex.FindStringSubmatch(contentWithoutCode); len(matches) > 1 {
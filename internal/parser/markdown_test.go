@@ -2505,6 +2505,11 @@ func TestParseType_DirectFunction(t *testing.T) {
 			content:      `**Type**: integration`,
 			expectedType: "integration",
 		},
+		{
+			name:         "type approval",
+			content:      `**Type**: approval`,
+			expectedType: "approval",
+		},
 		{
 			name:         "type missing (empty string)",
 			content:      `**File(s)**: test.go`,
@@ -3519,3 +3524,32 @@ func TestMarkdownRuntimeEnforcementFixture(t *testing.T) {
 		t.Logf("task 3: found %d key points", len(task3.KeyPoints))
 	}
 }
+
+func TestParseTaskMetadataFixes(t *testing.T) {
+	content := `**File(s)**: ` + "`internal/api/handler.go`" + `
+**Fixes**: Task 2, 3
+
+Handle the errors the earlier tasks dropped.
+`
+	task := &models.Task{}
+	parseTaskMetadata(task, content)
+
+	if got := strings.Join(task.Fixes, ","); got != "2,3" {
+		t.Errorf("Expected fixes '2,3', got '%s'", got)
+	}
+}
+
+func TestParseTaskMetadataWhen(t *testing.T) {
+	content := `**File(s)**: ` + "`internal/client/client.go`" + `
+**Depends on**: Task 2
+**When**: ` + "`changed(\"db/schema/**\", \"2\")`" + `
+
+Regenerate the client from the new schema.
+`
+	task := &models.Task{}
+	parseTaskMetadata(task, content)
+
+	if want := `changed("db/schema/**", "2")`; task.When != want {
+		t.Errorf("Expected when %q, got %q", want, task.When)
+	}
+}
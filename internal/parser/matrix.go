@@ -0,0 +1,390 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// Matrix tasks (v3.6+) repeat one task for every combination of the values of
+// their matrix variables. The parsers expand a matrix task into one concrete
+// task per combination, a "cell", so validation, wave calculation and
+// execution only ever see ordinary tasks.
+//
+// A cell's number is the matrix task's number followed by its values, e.g.
+// "4.svc-a" or "4.svc-a.linux", and {{variable}} placeholders in its name,
+// prompt, files, test commands, criteria, commit message, when expression and
+// dependencies are replaced by the cell's values. Dependencies on a matrix
+// task are rewritten to its cells: a cell of another matrix task depends on
+// the cells with the same values for the variables both matrices share, and
+// every other task depends on all cells.
+
+var (
+	matrixVariablePattern    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	matrixPlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	matrixUnsafeChars        = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+)
+
+// matrixAxis is one variable of a matrix task and the values it takes.
+type matrixAxis struct {
+	name   string
+	values []string
+}
+
+// matrixCell is one combination of values of a matrix task.
+type matrixCell struct {
+	number string
+	values map[string]string
+}
+
+// cellState is the status the plan file records for one matrix cell.
+type cellState struct {
+	status      string
+	completedAt *time.Time
+}
+
+// matrixCellNumber derives a cell's task number. Values are joined with dots,
+// and characters that do not belong in a task number become dashes, so the
+// value "internal/api" gives "4.internal-api".
+func matrixCellNumber(base string, values []string) string {
+	parts := make([]string, 0, len(values)+1)
+	parts = append(parts, base)
+	for _, value := range values {
+		parts = append(parts, strings.Trim(matrixUnsafeChars.ReplaceAllString(value, "-"), "-"))
+	}
+	return strings.Join(parts, ".")
+}
+
+// matrixCells validates a task's matrix and lists its cells in declaration
+// order, the last variable varying fastest.
+func matrixCells(base string, axes []matrixAxis) ([]matrixCell, error) {
+	seenAxis := make(map[string]bool)
+	for _, axis := range axes {
+		if !matrixVariablePattern.MatchString(axis.name) {
+			return nil, fmt.Errorf("invalid matrix variable %q: use letters, digits and underscores", axis.name)
+		}
+		if seenAxis[axis.name] {
+			return nil, fmt.Errorf("matrix variable %q is declared twice", axis.name)
+		}
+		seenAxis[axis.name] = true
+		if len(axis.values) == 0 {
+			return nil, fmt.Errorf("matrix variable %q has no values", axis.name)
+		}
+		for _, value := range axis.values {
+			if matrixCellNumber("", []string{value}) == "." {
+				return nil, fmt.Errorf("matrix variable %q has value %q, which cannot be part of a task number", axis.name, value)
+			}
+		}
+	}
+
+	cells := []matrixCell{{values: map[string]string{}}}
+	for _, axis := range axes {
+		var next []matrixCell
+		for _, cell := range cells {
+			for _, value := range axis.values {
+				values := make(map[string]string, len(cell.values)+1)
+				for k, v := range cell.values {
+					values[k] = v
+				}
+				values[axis.name] = value
+				next = append(next, matrixCell{values: values})
+			}
+		}
+		cells = next
+	}
+
+	seen := make(map[string]bool)
+	for i := range cells {
+		ordered := make([]string, len(axes))
+		for j, axis := range axes {
+			ordered[j] = cells[i].values[axis.name]
+		}
+		cells[i].number = matrixCellNumber(base, ordered)
+		if seen[cells[i].number] {
+			return nil, fmt.Errorf("matrix values give task number %s twice", cells[i].number)
+		}
+		seen[cells[i].number] = true
+	}
+	return cells, nil
+}
+
+// substituteMatrix replaces the {{variable}} placeholders of a cell's
+// variables. Other {{...}} text, such as template syntax in a prompt, is kept.
+func substituteMatrix(s string, values map[string]string) string {
+	if !strings.Contains(s, "{{") {
+		return s
+	}
+	return matrixPlaceholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := matrixPlaceholderPattern.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+func substituteMatrixAll(list []string, values map[string]string) []string {
+	if list == nil {
+		return nil
+	}
+	out := make([]string, len(list))
+	for i, s := range list {
+		out[i] = substituteMatrix(s, values)
+	}
+	return out
+}
+
+// expandMatrixTasks replaces every matrix task, keyed by number in axes, with
+// its cells and rewrites dependencies on matrix tasks to their cells. states
+// holds the statuses recorded for cells in the plan file, keyed by cell
+// number; a cell without one inherits the matrix task's status.
+func expandMatrixTasks(tasks []models.Task, axes map[string][]matrixAxis, states map[string]cellState) ([]models.Task, error) {
+	if len(axes) == 0 {
+		return tasks, nil
+	}
+
+	cellsOf := make(map[string][]matrixCell)
+	for _, task := range tasks {
+		if taskAxes, ok := axes[task.Number]; ok {
+			cells, err := matrixCells(task.Number, taskAxes)
+			if err != nil {
+				return nil, fmt.Errorf("task %s: %w", task.Number, err)
+			}
+			cellsOf[task.Number] = cells
+		}
+	}
+
+	// rewrite maps dependencies (or fixes) on matrix tasks to their cells.
+	rewrite := func(deps []string, cell *matrixCell) ([]string, error) {
+		if deps == nil {
+			return nil, nil
+		}
+		var out []string
+		for _, dep := range deps {
+			cells, ok := cellsOf[dep]
+			if !ok {
+				out = append(out, dep)
+				continue
+			}
+			if cell == nil || !sharesVariable(cells[0], *cell) {
+				for _, c := range cells {
+					out = append(out, c.number)
+				}
+				continue
+			}
+			var matching []string
+			for _, c := range cells {
+				if sharesValues(c, *cell) {
+					matching = append(matching, c.number)
+				}
+			}
+			if len(matching) == 0 {
+				return nil, fmt.Errorf("no cell of matrix task %s has the values of cell %s for the variables they share", dep, cell.number)
+			}
+			out = append(out, matching...)
+		}
+		return out, nil
+	}
+
+	expanded := make([]models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		cells, ok := cellsOf[task.Number]
+		if !ok {
+			// Without a cell every dependency resolves to all cells
+			task.DependsOn, _ = rewrite(task.DependsOn, nil)
+			task.Fixes, _ = rewrite(task.Fixes, nil)
+			expanded = append(expanded, task)
+			continue
+		}
+		for i := range cells {
+			t, err := expandMatrixCell(task, &cells[i], rewrite, states)
+			if err != nil {
+				return nil, fmt.Errorf("task %s: %w", task.Number, err)
+			}
+			expanded = append(expanded, t)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, task := range expanded {
+		if seen[task.Number] {
+			return nil, fmt.Errorf("duplicate task number %s after expanding matrix tasks", task.Number)
+		}
+		seen[task.Number] = true
+	}
+	return expanded, nil
+}
+
+// expandMatrixCell builds the concrete task for one cell of a matrix task.
+func expandMatrixCell(task models.Task, cell *matrixCell, rewrite func([]string, *matrixCell) ([]string, error), states map[string]cellState) (models.Task, error) {
+	values := cell.values
+	t := task
+	t.Number = cell.number
+	t.Name = substituteMatrix(task.Name, values)
+	t.Prompt = substituteMatrix(task.Prompt, values)
+	t.Files = substituteMatrixAll(task.Files, values)
+	t.TestCommands = substituteMatrixAll(task.TestCommands, values)
+	t.SuccessCriteria = substituteMatrixAll(task.SuccessCriteria, values)
+	t.IntegrationCriteria = substituteMatrixAll(task.IntegrationCriteria, values)
+	t.When = substituteMatrix(task.When, values)
+	var err error
+	if t.DependsOn, err = rewrite(substituteMatrixAll(task.DependsOn, values), cell); err != nil {
+		return models.Task{}, err
+	}
+	if t.Fixes, err = rewrite(substituteMatrixAll(task.Fixes, values), cell); err != nil {
+		return models.Task{}, err
+	}
+
+	if task.StructuredCriteria != nil {
+		t.StructuredCriteria = make([]models.SuccessCriterion, len(task.StructuredCriteria))
+		for i, sc := range task.StructuredCriteria {
+			sc.Criterion = substituteMatrix(sc.Criterion, values)
+			if sc.Verification != nil {
+				v := *sc.Verification
+				v.Command = substituteMatrix(v.Command, values)
+				sc.Verification = &v
+			}
+			t.StructuredCriteria[i] = sc
+		}
+	}
+	if task.CommitSpec != nil {
+		spec := *task.CommitSpec
+		spec.Message = substituteMatrix(spec.Message, values)
+		spec.Body = substituteMatrix(spec.Body, values)
+		spec.Files = substituteMatrixAll(spec.Files, values)
+		t.CommitSpec = &spec
+	}
+
+	if state, ok := states[cell.number]; ok {
+		t.Status = state.status
+		t.CompletedAt = state.completedAt
+	}
+	return t, nil
+}
+
+// sharesVariable reports whether two cells have a variable in common.
+func sharesVariable(a, b matrixCell) bool {
+	for name := range a.values {
+		if _, ok := b.values[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sharesValues reports whether two cells agree on every variable they share.
+// Cells with no variable in common do not match.
+func sharesValues(a, b matrixCell) bool {
+	shared := false
+	for name, value := range a.values {
+		other, ok := b.values[name]
+		if !ok {
+			continue
+		}
+		if other != value {
+			return false
+		}
+		shared = true
+	}
+	return shared
+}
+
+// parseMatrixYAML reads a task's matrix mapping, keeping the variables in
+// declaration order. A variable takes a list of values or a single value.
+func parseMatrixYAML(node *yaml.Node) ([]matrixAxis, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("matrix must map variable names to lists of values")
+	}
+	var axes []matrixAxis
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		axis := matrixAxis{name: node.Content[i].Value}
+		valueNode := node.Content[i+1]
+		switch valueNode.Kind {
+		case yaml.ScalarNode:
+			axis.values = []string{valueNode.Value}
+		case yaml.SequenceNode:
+			for _, item := range valueNode.Content {
+				if item.Kind != yaml.ScalarNode {
+					return nil, fmt.Errorf("matrix variable %q: values must be scalars", axis.name)
+				}
+				axis.values = append(axis.values, item.Value)
+			}
+		default:
+			return nil, fmt.Errorf("matrix variable %q: values must be a list", axis.name)
+		}
+		axes = append(axes, axis)
+	}
+	return axes, nil
+}
+
+// parseMatrixMarkdown reads a **Matrix**: bullet list, one variable per bullet:
+//
+//	**Matrix**:
+//	- service: svc-a, svc-b
+//	- os: linux, darwin
+func parseMatrixMarkdown(content string) []matrixAxis {
+	headingRegex := regexp.MustCompile(`(?m)^\*\*Matrix\*\*:\s*$`)
+	loc := headingRegex.FindStringIndex(content)
+	if loc == nil {
+		return nil
+	}
+
+	bulletRegex := regexp.MustCompile(`^\s*[-*]\s+([^:]+):\s*(.*)$`)
+	var axes []matrixAxis
+	for _, line := range strings.Split(content[loc[1]:], "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			if len(axes) > 0 {
+				break
+			}
+			continue
+		}
+		matches := bulletRegex.FindStringSubmatch(line)
+		if matches == nil || markdownCellLinePattern.MatchString(line) {
+			break
+		}
+		axis := matrixAxis{name: strings.Trim(strings.TrimSpace(matches[1]), "`")}
+		for _, value := range strings.Split(matches[2], ",") {
+			if value = strings.Trim(strings.TrimSpace(value), "`"); value != "" {
+				axis.values = append(axis.values, value)
+			}
+		}
+		axes = append(axes, axis)
+	}
+	return axes
+}
+
+// markdownCellLinePattern matches the checkbox line the updater keeps for a
+// matrix cell inside its matrix task, e.g. "- [x] Task 4.svc-a (status: completed)".
+var (
+	markdownCellLinePattern   = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+Task\s+(\S+)(.*)$`)
+	markdownCellStatusPattern = regexp.MustCompile(`(?i)status\s*:\s*([^)|]+)`)
+)
+
+// extractMarkdownCells removes the cell checkbox lines of matrix task base
+// from its content and returns their statuses keyed by cell number.
+func extractMarkdownCells(base, content string) (string, map[string]cellState) {
+	states := make(map[string]cellState)
+	var kept []string
+	for _, line := range strings.Split(content, "\n") {
+		matches := markdownCellLinePattern.FindStringSubmatch(line)
+		if matches == nil || !strings.HasPrefix(matches[2], base+".") {
+			kept = append(kept, line)
+			continue
+		}
+		status := "pending"
+		if strings.EqualFold(matches[1], "x") {
+			status = "completed"
+		}
+		if sub := markdownCellStatusPattern.FindStringSubmatch(matches[3]); len(sub) > 1 {
+			status = strings.TrimSpace(sub[1])
+		}
+		states[matches[2]] = cellState{status: status}
+	}
+	return strings.Join(kept, "\n"), states
+}
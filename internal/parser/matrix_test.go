@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+func taskByNumber(t *testing.T, tasks []models.Task, number string) models.Task {
+	t.Helper()
+	for _, task := range tasks {
+		if task.Number == number {
+			return task
+		}
+	}
+	t.Fatalf("task %s not found", number)
+	return models.Task{}
+}
+
+func taskNumbers(tasks []models.Task) []string {
+	numbers := make([]string, len(tasks))
+	for i, task := range tasks {
+		numbers[i] = task.Number
+	}
+	return numbers
+}
+
+func TestParseYAMLWithMatrix(t *testing.T) {
+	yamlContent := `
+plan:
+  tasks:
+    - task_number: 1
+      name: "Shared client"
+      description: "Add the shared client"
+    - task_number: 2
+      name: "Migrate {{service}}"
+      depends_on: [1]
+      matrix:
+        service: [svc-a, svc-b]
+      files: ["services/{{service}}/db.go"]
+      description: "Migrate {{service}} to the shared client. Keep {{ .Values }} templates."
+      test_commands: ["go test ./services/{{service}}/..."]
+      success_criteria: ["{{service}} uses the shared client"]
+      when: env("SKIP_{{service}}") == ""
+      cells:
+        svc-a:
+          status: completed
+          completed_date: "2025-11-10"
+    - task_number: 3
+      name: "Test {{service}} on {{os}}"
+      depends_on: [2]
+      matrix:
+        service: [svc-a, svc-b]
+        os: [linux, darwin]
+      description: "Run the {{service}} tests on {{os}}"
+    - task_number: 4
+      name: "Release"
+      depends_on: [3]
+      description: "Release all services"
+`
+
+	plan, err := NewYAMLParser().Parse(strings.NewReader(yamlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+
+	want := []string{"1", "2.svc-a", "2.svc-b", "3.svc-a.linux", "3.svc-a.darwin", "3.svc-b.linux", "3.svc-b.darwin", "4"}
+	if got := taskNumbers(plan.Tasks); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expanded tasks = %v, want %v", got, want)
+	}
+
+	cell := taskByNumber(t, plan.Tasks, "2.svc-b")
+	if cell.Name != "Migrate svc-b" {
+		t.Errorf("Name = %q", cell.Name)
+	}
+	if !reflect.DeepEqual(cell.Files, []string{"services/svc-b/db.go"}) {
+		t.Errorf("Files = %v", cell.Files)
+	}
+	if !reflect.DeepEqual(cell.TestCommands, []string{"go test ./services/svc-b/..."}) {
+		t.Errorf("TestCommands = %v", cell.TestCommands)
+	}
+	if !reflect.DeepEqual(cell.SuccessCriteria, []string{"svc-b uses the shared client"}) {
+		t.Errorf("SuccessCriteria = %v", cell.SuccessCriteria)
+	}
+	if cell.When != `env("SKIP_svc-b") == ""` {
+		t.Errorf("When = %q", cell.When)
+	}
+	if !strings.Contains(cell.Prompt, "Migrate svc-b to the shared client") || !strings.Contains(cell.Prompt, "{{ .Values }}") {
+		t.Errorf("prompt should substitute matrix variables and keep other templates:\n%s", cell.Prompt)
+	}
+	if !reflect.DeepEqual(cell.DependsOn, []string{"1"}) {
+		t.Errorf("DependsOn = %v", cell.DependsOn)
+	}
+
+	if done := taskByNumber(t, plan.Tasks, "2.svc-a"); done.Status != "completed" || done.CompletedAt == nil {
+		t.Errorf("cell status should be read from cells: %q, %v", done.Status, done.CompletedAt)
+	}
+	if cell.Status != "" {
+		t.Errorf("cell without a recorded status should keep the matrix task's status, got %q", cell.Status)
+	}
+
+	if deps := taskByNumber(t, plan.Tasks, "3.svc-b.darwin").DependsOn; !reflect.DeepEqual(deps, []string{"2.svc-b"}) {
+		t.Errorf("cell should depend on the cell with the same service, got %v", deps)
+	}
+	if deps := taskByNumber(t, plan.Tasks, "4").DependsOn; len(deps) != 4 {
+		t.Errorf("task depending on a matrix task should depend on all its cells, got %v", deps)
+	}
+}
+
+func TestParseMarkdownWithMatrix(t *testing.T) {
+	markdown := `# Plan
+
+## Task 1: Shared client
+
+Add the shared client.
+
+## Task 2: Migrate {{pkg}}
+
+**Depends on**: Task 1
+**File(s)**: ` + "`internal/{{pkg}}/db.go`" + `
+**Matrix**:
+- pkg: api, ` + "`worker`" + `
+
+Migrate internal/{{pkg}} to the shared client.
+
+- [x] Task 2.api (status: completed)
+
+## Task 3: Smoke test {{pkg}}
+
+**Depends on**: 2.{{pkg}}
+**Matrix**:
+- pkg: api, worker
+
+Run the {{pkg}} smoke test.
+`
+
+	plan, err := NewMarkdownParser().Parse(strings.NewReader(markdown))
+	if err != nil {
+		t.Fatalf("Failed to parse markdown: %v", err)
+	}
+
+	want := []string{"1", "2.api", "2.worker", "3.api", "3.worker"}
+	if got := taskNumbers(plan.Tasks); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expanded tasks = %v, want %v", got, want)
+	}
+
+	worker := taskByNumber(t, plan.Tasks, "2.worker")
+	if worker.Name != "Migrate worker" || !reflect.DeepEqual(worker.Files, []string{"internal/worker/db.go"}) {
+		t.Errorf("unexpected cell: %q %v", worker.Name, worker.Files)
+	}
+	if worker.Status != "pending" {
+		t.Errorf("cell without a checkbox should be pending, got %q", worker.Status)
+	}
+	if strings.Contains(worker.Prompt, "Task 2.api") {
+		t.Errorf("cell checkbox lines should not be part of the prompt:\n%s", worker.Prompt)
+	}
+	if api := taskByNumber(t, plan.Tasks, "2.api"); api.Status != "completed" {
+		t.Errorf("cell status should be read from its checkbox line, got %q", api.Status)
+	}
+	if deps := taskByNumber(t, plan.Tasks, "3.worker").DependsOn; !reflect.DeepEqual(deps, []string{"2.worker"}) {
+		t.Errorf("DependsOn = %v", deps)
+	}
+}
+
+func TestParseMatrixErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  string
+		extra   string
+		wantErr string
+	}{
+		{"no values", "{service: []}", "", `matrix variable "service" has no values`},
+		{"bad variable", "{my-service: [a]}", "", `invalid matrix variable "my-service"`},
+		{"colliding values", "{pkg: [internal/api, internal-api]}", "", "task number 2.internal-api twice"},
+		{"not a mapping", "[a, b]", "", "matrix must map variable names"},
+		{"collides with a task", "{n: [\"1\"]}", "\n    - task_number: \"2.1\"\n      name: \"Other\"", "duplicate task number 2.1"},
+		{"no common value", "{service: [svc-a, svc-b]}", "\n    - task_number: 3\n      name: \"Deploy\"\n      depends_on: [2]\n      matrix: {service: [svc-c]}", "task 3: no cell of matrix task 2 has the values of cell 3.svc-c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := `
+plan:
+  tasks:
+    - task_number: 2
+      name: "Matrix"
+      matrix: ` + tt.matrix + tt.extra + `
+`
+			_, err := NewYAMLParser().Parse(strings.NewReader(yamlContent))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
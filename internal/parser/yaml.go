@@ -47,6 +47,11 @@ type yamlTask struct {
 	DependsOn           []interface{}        `yaml:"depends_on"` // Accepts int, float, or string
 	EstimatedTime       string               `yaml:"estimated_time"`
 	Agent               string               `yaml:"agent"`
+	Backend             string               `yaml:"backend"`        // Agent backend override (v3.6+)
+	Fixes               []interface{}        `yaml:"fixes"`          // Tasks this task fixes, for QC calibration (v3.6+)
+	When                string               `yaml:"when"`           // Condition evaluated at launch (v3.6+)
+	Matrix              yaml.Node            `yaml:"matrix"`         // Variables the task is repeated for (v3.6+)
+	Cells               map[string]yamlCell  `yaml:"cells"`          // Status of each matrix cell, keyed by its values (v3.6+)
 	WorktreeGroup       string               `yaml:"worktree_group"` // Worktree group for task organization
 	Status              string               `yaml:"status"`
 	CompletedDate       string               `yaml:"completed_date"` // Date format: YYYY-MM-DD
@@ -105,6 +110,13 @@ type yamlTask struct {
 	} `yaml:"commit"`
 }
 
+// yamlCell is the status the updater records for one matrix cell (v3.6+).
+type yamlCell struct {
+	Status        string `yaml:"status"`
+	CompletedDate string `yaml:"completed_date"`
+	CompletedAt   string `yaml:"completed_at"`
+}
+
 // yamlConductorConfig represents the optional conductor configuration section in YAML
 type yamlConductorConfig struct {
 	DefaultAgent   string `yaml:"default_agent"`
@@ -232,6 +244,10 @@ func (p *YAMLParser) Parse(r io.Reader) (*models.Plan, error) {
 		}
 	}
 
+	// Matrix tasks are expanded into their cells once all tasks are converted (v3.6+)
+	matrixAxes := make(map[string][]matrixAxis)
+	cellStates := make(map[string]cellState)
+
 	// Convert YAML tasks to models.Task
 	for i, yt := range yp.Plan.Tasks {
 		taskNum, err := convertToString(yt.TaskNumber)
@@ -245,6 +261,15 @@ func (p *YAMLParser) Parse(r io.Reader) (*models.Plan, error) {
 			return nil, fmt.Errorf("task %s: %w", taskNum, err)
 		}
 
+		var fixes []string
+		for _, f := range yt.Fixes {
+			fixed, err := convertToString(f)
+			if err != nil {
+				return nil, fmt.Errorf("task %s: invalid fixes entry: %w", taskNum, err)
+			}
+			fixes = append(fixes, fixed)
+		}
+
 		// Parse success criteria (supports both []string and []SuccessCriterion)
 		successCriteria, structuredCriteria, err := parseSuccessCriteria(yt.SuccessCriteria, taskNum)
 		if err != nil {
@@ -258,6 +283,9 @@ func (p *YAMLParser) Parse(r io.Reader) (*models.Plan, error) {
 			Files:               yt.Files,
 			DependsOn:           dependsOn,
 			Agent:               yt.Agent,
+			Backend:             yt.Backend,
+			Fixes:               fixes,
+			When:                strings.TrimSpace(yt.When),
 			WorktreeGroup:       yt.WorktreeGroup,
 			Status:              yt.Status,
 			SuccessCriteria:     successCriteria,
@@ -318,9 +346,35 @@ func (p *YAMLParser) Parse(r io.Reader) (*models.Plan, error) {
 		// Build comprehensive prompt from all sections
 		task.Prompt = buildPromptFromYAML(&yt)
 
+		if yt.Matrix.Kind != 0 {
+			axes, err := parseMatrixYAML(&yt.Matrix)
+			if err != nil {
+				return nil, fmt.Errorf("task %s: %w", taskNum, err)
+			}
+			matrixAxes[taskNum] = axes
+			for key, yc := range yt.Cells {
+				state := cellState{status: yc.Status}
+				if yc.CompletedAt != "" {
+					if t, err := parseCompletionTimestamp(yc.CompletedAt); err == nil {
+						state.completedAt = &t
+					}
+				} else if yc.CompletedDate != "" {
+					if t, err := parseCompletionDate(yc.CompletedDate); err == nil {
+						state.completedAt = &t
+					}
+				}
+				cellStates[taskNum+"."+key] = state
+			}
+		}
+
 		plan.Tasks = append(plan.Tasks, task)
 	}
 
+	plan.Tasks, err = expandMatrixTasks(plan.Tasks, matrixAxes, cellStates)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate runtime metadata requirements if strict enforcement is enabled
 	if plan.PlannerCompliance != nil && plan.PlannerCompliance.StrictEnforcement {
 		for _, task := range plan.Tasks {
@@ -287,6 +287,73 @@ plan:
 	}
 }
 
+func TestParseYAMLWithBackend(t *testing.T) {
+	yamlContent := `
+plan:
+  tasks:
+    - task_number: 1
+      name: "Trial Task"
+      depends_on: []
+      estimated_time: "30m"
+      description: "Run on an alternative runtime"
+      agent: "golang-pro"
+      backend: "my-cli"
+`
+
+	parser := NewYAMLParser()
+	plan, err := parser.Parse(strings.NewReader(yamlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+
+	if plan.Tasks[0].Backend != "my-cli" {
+		t.Errorf("Expected backend 'my-cli', got '%s'", plan.Tasks[0].Backend)
+	}
+}
+
+func TestParseYAMLWithFixes(t *testing.T) {
+	yamlContent := `
+plan:
+  tasks:
+    - task_number: 4
+      name: "Fix handler error handling"
+      description: "Handle the errors task 2 dropped"
+      fixes: [2, "3a"]
+`
+
+	parser := NewYAMLParser()
+	plan, err := parser.Parse(strings.NewReader(yamlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+
+	if got := strings.Join(plan.Tasks[0].Fixes, ","); got != "2,3a" {
+		t.Errorf("Expected fixes '2,3a', got '%s'", got)
+	}
+}
+
+func TestParseYAMLWithWhen(t *testing.T) {
+	yamlContent := `
+plan:
+  tasks:
+    - task_number: 3
+      name: "Regenerate client"
+      depends_on: [2]
+      description: "Regenerate the API client"
+      when: changed("db/schema/**", "2") && env("SKIP_CODEGEN") == ""
+`
+
+	parser := NewYAMLParser()
+	plan, err := parser.Parse(strings.NewReader(yamlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+
+	if want := `changed("db/schema/**", "2") && env("SKIP_CODEGEN") == ""`; plan.Tasks[0].When != want {
+		t.Errorf("Expected when %q, got %q", want, plan.Tasks[0].When)
+	}
+}
+
 func TestParseYAMLWithStatus(t *testing.T) {
 	tests := []struct {
 		name           string
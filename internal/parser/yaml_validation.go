@@ -19,10 +19,11 @@ func ValidateTaskType(task *models.Task) error {
 		"regular":     true,
 		"integration": true,
 		"component":   true,
+		"approval":    true,
 	}
 
 	if !validTypes[task.Type] {
-		return fmt.Errorf("invalid task type %q: must be 'regular', 'integration', or 'component'", task.Type)
+		return fmt.Errorf("invalid task type %q: must be 'regular', 'integration', 'component', or 'approval'", task.Type)
 	}
 
 	return nil
@@ -18,6 +18,7 @@ func TestValidateTaskType(t *testing.T) {
 		{"integration type", "integration", false},
 		{"case insensitive regular", "REGULAR", false},
 		{"case insensitive integration", "Integration", false},
+		{"approval type", "Approval", false},
 		{"whitespace trimmed", "  regular  ", false},
 		{"invalid type", "invalid", true},
 		{"unknown type", "custom", true},
@@ -46,6 +47,9 @@ func TestValidateTaskType(t *testing.T) {
 				if tt.taskType == "integration" || tt.taskType == "Integration" || tt.taskType == "INTEGRATION" {
 					expectedNormalized = "integration"
 				}
+				if tt.taskType == "Approval" {
+					expectedNormalized = "approval"
+				}
 				if task.Type != expectedNormalized {
 					t.Errorf("expected normalized type %q, got %q", expectedNormalized, task.Type)
 				}
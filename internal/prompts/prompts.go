@@ -0,0 +1,309 @@
+// Package prompts renders the task, QC and retry prompts conductor sends to
+// agents from named text/template templates (v3.6+).
+//
+// The default templates are embedded from templates/*.tmpl. A project can
+// override any of them by placing a file with the same name in
+// .conductor/templates/. Each template receives the data type documented on
+// its name constant. A single trailing newline in a template file is ignored.
+package prompts
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// DefaultDir is the project directory searched for template overrides.
+const DefaultDir = ".conductor/templates"
+
+// Template names. Overrides are read from <name>.tmpl.
+const (
+	// Claude4Enhancements prepends Claude 4 guidance to a prompt. Data: PromptData.
+	Claude4Enhancements = "claude4_enhancements"
+	// AgentPrompt wraps every task prompt with the JSON response format. Data: PromptData.
+	AgentPrompt = "agent_prompt"
+	// QCPrompt wraps every QC review prompt with the verdict format. Data: PromptData.
+	QCPrompt = "qc_prompt"
+	// QCReview is the review prompt for tasks reviewed without criteria. Data: QCReviewData.
+	QCReview = "qc_review"
+	// QCStructuredReview is the criteria-based review prompt. Data: StructuredReviewData.
+	QCStructuredReview = "qc_structured_review"
+	// RetryLearning adds past failure analysis to a task prompt. Data: RetryLearningData.
+	RetryLearning = "retry_learning"
+	// RetryClassification lists classified test errors for a retry. Data: RetryClassificationData.
+	RetryClassification = "retry_classification"
+)
+
+// PromptData is the data for templates that wrap a single prompt.
+type PromptData struct {
+	Prompt string
+}
+
+// QCReviewData is the data for the qc_review template.
+type QCReviewData struct {
+	Task              models.Task
+	Output            string // Agent output
+	Diff              string // Git diff of the task's changes (may be empty)
+	HistoricalContext string // Past QC results for the task (may be empty)
+	BehaviorContext   string // Behavioral metrics for the session (may be empty)
+}
+
+// Criterion is one numbered success or integration criterion.
+type Criterion struct {
+	Index int
+	Text  string
+}
+
+// StructuredReviewData is the data for the qc_structured_review template.
+// The string sections are pre-rendered and empty when not applicable.
+type StructuredReviewData struct {
+	Task                models.Task
+	Criteria            []Criterion // Success criteria, plus integration criteria for integration tasks
+	IntegrationCriteria []Criterion // Integration criteria with their index in Criteria (integration tasks only)
+	UncoveredKeyPoints  int         // Key points beyond the number of criteria
+	DomainChecks        string      // Review checks inferred from file extensions
+	Output              string      // Agent output, truncated to fit the context window
+	Diff                string      // Git diff of the task's changes (diff review only)
+	TestResults         string
+	CriterionResults    string
+	DetectedErrors      string
+	DocTargets          string
+	PriorArt            string // STOP protocol prior art
+	Architecture        string // Architecture checkpoint assessment
+	CommitVerification  string
+	HistoricalContext   string
+	BehaviorContext     string
+}
+
+// RetryLearningData is the data for the retry_learning template.
+type RetryLearningData struct {
+	Prompt            string // Original task prompt
+	FailedAttempts    int
+	TriedAgents       []string
+	CommonPatterns    []string
+	SuggestedApproach string
+}
+
+// ClassifiedError is one classified test error.
+type ClassifiedError struct {
+	Index      int // 1-based position in the detected errors
+	Category   string
+	Suggestion string
+	Method     string  // "claude" or "regex"
+	Confidence float64 // 0-1, set for Method "claude"
+}
+
+// RetryClassificationData is the data for the retry_classification template.
+type RetryClassificationData struct {
+	Errors []ClassifiedError
+}
+
+//go:embed templates/*.tmpl
+var defaultFS embed.FS
+
+// samples are used to check that overrides execute against their data type.
+var samples = map[string]interface{}{
+	Claude4Enhancements: PromptData{Prompt: "prompt"},
+	AgentPrompt:         PromptData{Prompt: "prompt"},
+	QCPrompt:            PromptData{Prompt: "prompt"},
+	QCReview:            QCReviewData{Task: sampleTask(), Output: "output"},
+	QCStructuredReview: StructuredReviewData{
+		Task:     sampleTask(),
+		Criteria: []Criterion{{Index: 0, Text: "criterion"}},
+		Output:   "output",
+	},
+	RetryLearning:       RetryLearningData{Prompt: "prompt", FailedAttempts: 1, TriedAgents: []string{"agent"}},
+	RetryClassification: RetryClassificationData{Errors: []ClassifiedError{{Index: 1, Category: "ENV_LEVEL", Method: "claude", Confidence: 0.9}}},
+}
+
+func sampleTask() models.Task {
+	return models.Task{
+		Number:          "1",
+		Name:            "Sample",
+		Prompt:          "prompt",
+		Files:           []string{"main.go"},
+		SuccessCriteria: []string{"criterion"},
+		KeyPoints:       []models.KeyPoint{{Point: "point"}},
+		TestCommands:    []string{"go test ./..."},
+	}
+}
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"trim": strings.TrimSpace,
+	"percent": func(v float64) string {
+		return fmt.Sprintf("%.0f%%", v*100)
+	},
+}
+
+// Templates is a set of named prompt templates.
+type Templates struct {
+	set        *template.Template
+	sources    map[string]string // Override text by name
+	Overridden []string          // Names replaced by project files, sorted
+}
+
+// Names returns every template name.
+func Names() []string {
+	names := make([]string, 0, len(samples))
+	for name := range samples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default returns the embedded default templates.
+func Default() *Templates {
+	return defaults
+}
+
+var defaults = mustParseDefaults()
+
+func mustParseDefaults() *Templates {
+	set := template.New("prompts").Funcs(funcs).Option("missingkey=error")
+	for _, name := range Names() {
+		text, err := defaultFS.ReadFile("templates/" + name + ".tmpl")
+		if err != nil {
+			panic(fmt.Sprintf("prompts: missing default template %s: %v", name, err))
+		}
+		if _, err := set.New(name).Parse(trimFinalNewline(string(text))); err != nil {
+			panic(fmt.Sprintf("prompts: invalid default template %s: %v", name, err))
+		}
+	}
+	return &Templates{set: set}
+}
+
+// Load returns the default templates with overrides from dir applied.
+// A missing dir yields the defaults. Files that do not match a template name,
+// fail to parse, or fail to execute against sample data are errors.
+func Load(dir string) (*Templates, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return defaults, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory %s: %w", dir, err)
+	}
+
+	set, err := defaults.set.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy default templates: %w", err)
+	}
+	result := &Templates{set: set, sources: make(map[string]string)}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tmpl" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		if _, known := samples[name]; !known {
+			return nil, fmt.Errorf("unknown prompt template %s (valid: %s)", entry.Name(), strings.Join(Names(), ", "))
+		}
+		text, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", entry.Name(), err)
+		}
+		if _, err := set.New(name).Parse(trimFinalNewline(string(text))); err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", entry.Name(), err)
+		}
+		result.Overridden = append(result.Overridden, name)
+		result.sources[name] = string(text)
+	}
+	sort.Strings(result.Overridden)
+
+	// Templates can include each other, so check all of them once overrides are in place
+	for _, name := range Names() {
+		if _, err := result.execute(name, samples[name]); err != nil {
+			return nil, fmt.Errorf("template %s.tmpl: %w", name, err)
+		}
+	}
+	return result, nil
+}
+
+// Render executes the named template. If an override fails at runtime the
+// embedded default is used instead, so a bad override never drops a prompt.
+func (t *Templates) Render(name string, data interface{}) string {
+	out, err := t.execute(name, data)
+	if err != nil && t != defaults {
+		out, err = defaults.execute(name, data)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("prompts: default template %s failed: %v", name, err))
+	}
+	return out
+}
+
+// Fingerprint hashes the overrides of the given templates, so caches of agent
+// results can tell when a prompt changed. Returns "" when none is overridden.
+func (t *Templates) Fingerprint(names ...string) string {
+	h := sha256.New()
+	overridden := false
+	for _, name := range names {
+		if source, ok := t.sources[name]; ok {
+			overridden = true
+			fmt.Fprintf(h, "%s\x00%s\x00", name, source)
+		}
+	}
+	if !overridden {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (t *Templates) execute(name string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := t.set.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// trimFinalNewline drops the newline most editors add at the end of a file.
+func trimFinalNewline(text string) string {
+	text = strings.TrimSuffix(text, "\n")
+	return strings.TrimSuffix(text, "\r")
+}
+
+var (
+	activeMu sync.RWMutex
+	active   = defaults
+)
+
+// SetActive makes templates the set used by Render.
+// Called once during startup (from cmd) after loading project overrides.
+func SetActive(templates *Templates) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	active = templates
+}
+
+// Active returns the set used by Render.
+func Active() *Templates {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	return active
+}
+
+// Render executes the named template from the active set.
+func Render(name string, data interface{}) string {
+	return Active().Render(name, data)
+}
+
+// EstimateTokens approximates the token count of text at about four
+// characters per token. Good enough for budgeting, not for billing.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
@@ -0,0 +1,149 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+func TestDefaultTemplatesRender(t *testing.T) {
+	for _, name := range Names() {
+		if out := Default().Render(name, samples[name]); strings.TrimSpace(out) == "" {
+			t.Errorf("default template %s rendered empty output", name)
+		}
+	}
+
+	out := Default().Render(AgentPrompt, PromptData{Prompt: "Build the parser"})
+	if !strings.Contains(out, "<context_awareness>") || !strings.Contains(out, "Build the parser\n\n<response_format>") {
+		t.Errorf("agent prompt should wrap the prompt with enhancements and response format:\n%s", out)
+	}
+	if strings.HasSuffix(out, "\n") {
+		t.Error("the trailing newline of a template file should be ignored")
+	}
+}
+
+func TestLoad_MissingDirReturnsDefaults(t *testing.T) {
+	templates, err := Load(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if templates != Default() || len(templates.Overridden) != 0 {
+		t.Error("expected the default templates")
+	}
+}
+
+func TestLoad_OverridesTemplate(t *testing.T) {
+	dir := t.TempDir()
+	override := "House rules: reject TODO comments.\n{{template \"claude4_enhancements\" .}}\n"
+	if err := os.WriteFile(filepath.Join(dir, "qc_prompt.tmpl"), []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(templates.Overridden) != 1 || templates.Overridden[0] != QCPrompt {
+		t.Errorf("Overridden = %v, want [qc_prompt]", templates.Overridden)
+	}
+
+	out := templates.Render(QCPrompt, PromptData{Prompt: "review this"})
+	if !strings.HasPrefix(out, "House rules: reject TODO comments.\n<context_awareness>") || !strings.HasSuffix(out, "review this") {
+		t.Errorf("override not applied:\n%s", out)
+	}
+	if strings.Contains(Default().Render(QCPrompt, PromptData{Prompt: "x"}), "House rules") {
+		t.Error("overrides must not change the default templates")
+	}
+	if got := templates.Render(AgentPrompt, PromptData{Prompt: "x"}); got != Default().Render(AgentPrompt, PromptData{Prompt: "x"}) {
+		t.Error("templates without an override should keep the default text")
+	}
+
+	if Default().Fingerprint(QCPrompt) != "" || templates.Fingerprint(AgentPrompt) != "" {
+		t.Error("fingerprint should be empty without overrides")
+	}
+	if templates.Fingerprint(AgentPrompt, QCPrompt) == "" {
+		t.Error("fingerprint should change when a QC template is overridden")
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		text string
+		want string
+	}{
+		{"unknown name", "qc_reviw.tmpl", "x", "unknown prompt template"},
+		{"parse error", "qc_review.tmpl", "{{if .Task}}", "invalid template"},
+		{"unknown field", "retry_learning.tmpl", "{{.Attempts}}", "retry_learning.tmpl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.text), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_UsesActiveTemplates(t *testing.T) {
+	dir := t.TempDir()
+	text := "{{range .Errors}}{{.Category}}:{{.Suggestion}};{{end}}"
+	if err := os.WriteFile(filepath.Join(dir, "retry_classification.tmpl"), []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	templates, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	SetActive(templates)
+	defer SetActive(Default())
+
+	data := RetryClassificationData{Errors: []ClassifiedError{{Index: 1, Category: "CODE_LEVEL", Suggestion: "fix it"}}}
+	if got := Render(RetryClassification, data); got != "CODE_LEVEL:fix it;" {
+		t.Errorf("Render() = %q", got)
+	}
+}
+
+func TestStructuredReviewTemplate(t *testing.T) {
+	task := models.Task{
+		Name:         "Parser",
+		Prompt:       "Build it",
+		KeyPoints:    []models.KeyPoint{{Point: "Use tokens", Details: "not regex", Reference: "lexer.go"}, {Point: "Handle EOF"}},
+		TestCommands: []string{"go test ./..."},
+	}
+	out := Default().Render(QCStructuredReview, StructuredReviewData{
+		Task:               task,
+		Criteria:           []Criterion{{Index: 0, Text: "Parses input"}},
+		UncoveredKeyPoints: 1,
+		Output:             "done",
+	})
+
+	for _, want := range []string{
+		"<qc_review task=\"Parser\">",
+		"<success_criteria>\n0. [ ] Parses input\n</success_criteria>",
+		"⚠️ 1 key point(s) do not have explicit success criteria above.",
+		"- Use tokens: not regex (ref: lexer.go)\n- Handle EOF\n</key_points>",
+		"<test_commands>\n- `go test ./...`\n</test_commands>",
+		"<agent_output>\ndone\n</agent_output>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("structured review missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<expected_files") || strings.Contains(out, "<integration_criteria>") {
+		t.Errorf("empty sections should be omitted:\n%s", out)
+	}
+}
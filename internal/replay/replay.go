@@ -0,0 +1,199 @@
+// Package replay records agent and Claude CLI invocations to disk and serves
+// them back without calling the CLI (v3.6+). Recordings are keyed by a hash of
+// the prompt, agent and schema, so a replayed run follows the same QC, retry and
+// agent-swap decisions as the recorded one as long as it builds the same prompts.
+package replay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FormatVersion is the version written to every recording file.
+const FormatVersion = 1
+
+// Mode selects whether a Store records or replays invocations.
+type Mode string
+
+const (
+	// ModeRecord saves every invocation to the store directory.
+	ModeRecord Mode = "record"
+	// ModeReplay serves invocations from the store directory instead of running them.
+	ModeReplay Mode = "replay"
+)
+
+// Invocation kinds used when building keys.
+const (
+	KindAgent  = "agent"  // Task execution through an agent backend
+	KindQC     = "qc"     // QC review through an agent backend
+	KindClaude = "claude" // Auxiliary Claude CLI request (selection, swap, assessment)
+)
+
+// ErrNotRecorded is returned in replay mode when no recording matches a key.
+var ErrNotRecorded = errors.New("no recorded invocation")
+
+// Recording is one saved invocation with its raw output.
+type Recording struct {
+	Version       int             `json:"version"`
+	Key           string          `json:"key"`
+	Kind          string          `json:"kind"`
+	Sequence      int             `json:"sequence"`
+	TaskNumber    string          `json:"task_number,omitempty"`
+	Agent         string          `json:"agent,omitempty"`
+	Prompt        string          `json:"prompt"`
+	Schema        string          `json:"schema,omitempty"`
+	Output        string          `json:"output"`
+	ExitCode      int             `json:"exit_code,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	Failed        bool            `json:"failed,omitempty"` // Invocation returned Error instead of a result
+	SessionID     string          `json:"session_id,omitempty"`
+	DurationMs    int64           `json:"duration_ms"`
+	AgentResponse json.RawMessage `json:"agent_response,omitempty"`
+	RecordedAt    time.Time       `json:"recorded_at"`
+}
+
+// Key returns the recording key for an invocation.
+func Key(kind, prompt, agent, schema string) string {
+	h := sha256.New()
+	for _, part := range []string{kind, prompt, agent, schema} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// Store reads and writes recordings in a directory.
+// Identical invocations are numbered in call order, so a prompt that is sent
+// twice (e.g. a retry with unchanged input) replays each recorded output in turn.
+// Store is safe for concurrent use.
+type Store struct {
+	dir  string
+	mode Mode
+
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+// NewStore opens a store in dir. Record mode creates the directory;
+// replay mode requires it to exist.
+func NewStore(dir string, mode Mode) (*Store, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("replay directory cannot be empty")
+	}
+
+	switch mode {
+	case ModeRecord:
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create recording directory: %w", err)
+		}
+	case ModeReplay:
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open replay directory: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("replay path %s is not a directory", dir)
+		}
+	default:
+		return nil, fmt.Errorf("unknown replay mode %q", mode)
+	}
+
+	return &Store{dir: dir, mode: mode, seen: make(map[string]int)}, nil
+}
+
+// Dir returns the store directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Mode returns the store mode.
+func (s *Store) Mode() Mode {
+	return s.mode
+}
+
+// Replaying reports whether invocations should be served from the store.
+func (s *Store) Replaying() bool {
+	return s != nil && s.mode == ModeReplay
+}
+
+// Recording reports whether invocations should be saved to the store.
+func (s *Store) Recording() bool {
+	return s != nil && s.mode == ModeRecord
+}
+
+// Save writes a recording, assigning its sequence number for rec.Key.
+func (s *Store) Save(rec Recording) error {
+	if rec.Key == "" {
+		return fmt.Errorf("recording key cannot be empty")
+	}
+
+	rec.Version = FormatVersion
+	rec.Sequence = s.next(rec.Key)
+	if rec.RecordedAt.IsZero() {
+		rec.RecordedAt = time.Now()
+	}
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+
+	path := s.path(rec.Key, rec.Sequence)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// Load returns the next recording for key.
+// When a key is requested more often than it was recorded, the last recording is repeated.
+func (s *Store) Load(key string) (*Recording, error) {
+	seq := s.next(key)
+
+	for ; seq >= 0; seq-- {
+		data, err := os.ReadFile(s.path(key, seq))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recording %s: %w", key, err)
+		}
+
+		var rec Recording
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("failed to decode recording %s: %w", key, err)
+		}
+		if rec.Version > FormatVersion {
+			return nil, fmt.Errorf("recording %s has unsupported version %d", key, rec.Version)
+		}
+		return &rec, nil
+	}
+
+	return nil, fmt.Errorf("%w for key %s in %s", ErrNotRecorded, key, s.dir)
+}
+
+// next returns the call index for key and advances it.
+func (s *Store) next(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seq := s.seen[key]
+	s.seen[key] = seq + 1
+	return seq
+}
+
+// path returns the file path for a recording.
+func (s *Store) path(key string, seq int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s-%03d.json", key, seq))
+}
@@ -0,0 +1,97 @@
+package replay
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	base := Key(KindAgent, "prompt", "golang-pro", "{}")
+	if base != Key(KindAgent, "prompt", "golang-pro", "{}") {
+		t.Error("Key should be deterministic")
+	}
+	if len(base) != 32 {
+		t.Errorf("Key length = %d, want 32", len(base))
+	}
+
+	variants := []string{
+		Key(KindQC, "prompt", "golang-pro", "{}"),
+		Key(KindAgent, "prompt2", "golang-pro", "{}"),
+		Key(KindAgent, "prompt", "python-pro", "{}"),
+		Key(KindAgent, "prompt", "golang-pro", ""),
+		Key(KindAgent, "promptgolang-pro", "", "{}"),
+	}
+	for i, k := range variants {
+		if k == base {
+			t.Errorf("variant %d should produce a different key", i)
+		}
+	}
+}
+
+func TestStore_RecordAndReplay(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "rec")
+
+	rec, err := NewStore(dir, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewStore(record) returned error: %v", err)
+	}
+	if !rec.Recording() || rec.Replaying() {
+		t.Fatal("record store should report Recording only")
+	}
+
+	key := Key(KindQC, "review", "qc", "")
+	for _, out := range []string{"first", "second"} {
+		if err := rec.Save(Recording{Key: key, Kind: KindQC, Output: out}); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+
+	play, err := NewStore(dir, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewStore(replay) returned error: %v", err)
+	}
+
+	for i, want := range []string{"first", "second", "second"} {
+		got, err := play.Load(key)
+		if err != nil {
+			t.Fatalf("Load #%d returned error: %v", i, err)
+		}
+		if got.Output != want {
+			t.Errorf("Load #%d output = %q, want %q", i, got.Output, want)
+		}
+		if got.Version != FormatVersion {
+			t.Errorf("Load #%d version = %d, want %d", i, got.Version, FormatVersion)
+		}
+	}
+
+	if _, err := play.Load(Key(KindQC, "other", "qc", "")); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("expected ErrNotRecorded, got %v", err)
+	}
+}
+
+func TestNewStore_Errors(t *testing.T) {
+	if _, err := NewStore("", ModeRecord); err == nil {
+		t.Error("expected error for empty directory")
+	}
+	if _, err := NewStore(filepath.Join(t.TempDir(), "missing"), ModeReplay); err == nil {
+		t.Error("expected error for missing replay directory")
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewStore(file, ModeReplay); err == nil {
+		t.Error("expected error when replay path is a file")
+	}
+	if _, err := NewStore(t.TempDir(), Mode("bogus")); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+
+	var nilStore *Store
+	if nilStore.Recording() || nilStore.Replaying() {
+		t.Error("nil store should neither record nor replay")
+	}
+}
@@ -0,0 +1,196 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite groups the tasks of one plan file.
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is one task.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitMessage `xml:"failure,omitempty"`
+	Error     *JUnitMessage `xml:"error,omitempty"`
+	Skipped   *JUnitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitMessage is a failure, error or skipped element.
+type JUnitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// defaultSuiteName is used for tasks without a source plan file.
+const defaultSuiteName = "conductor"
+
+// BuildJUnit maps each task result to a testcase.
+// RED tasks become failures with the QC feedback as message, FAILED tasks
+// become errors and BLOCKED tasks are skipped. The per-attempt history is
+// written to system-out. Tasks are grouped into one suite per plan file.
+func BuildJUnit(result *models.ExecutionResult) *JUnitTestSuites {
+	root := &JUnitTestSuites{Name: defaultSuiteName}
+	if result != nil {
+		root.Time = seconds(result.Duration.Seconds())
+	}
+
+	suiteIndex := make(map[string]int)
+	var suiteSeconds []float64
+	for _, taskResult := range sortedResults(result) {
+		suiteName := defaultSuiteName
+		if taskResult.Task.SourceFile != "" {
+			suiteName = filepath.Base(taskResult.Task.SourceFile)
+		}
+		idx, exists := suiteIndex[suiteName]
+		if !exists {
+			idx = len(root.Suites)
+			suiteIndex[suiteName] = idx
+			root.Suites = append(root.Suites, JUnitTestSuite{Name: suiteName})
+			suiteSeconds = append(suiteSeconds, 0)
+		}
+		suite := &root.Suites[idx]
+
+		testCase := JUnitTestCase{
+			Name:      taskLabel(taskResult.Task),
+			ClassName: suiteName,
+			Time:      seconds(taskResult.Duration.Seconds()),
+			SystemOut: attemptHistory(taskResult),
+		}
+
+		switch taskResult.Status {
+		case models.StatusRed:
+			testCase.Failure = &JUnitMessage{
+				Message: firstLine(failureFeedback(taskResult)),
+				Type:    models.StatusRed,
+				Body:    failureFeedback(taskResult),
+			}
+			suite.Failures++
+		case models.StatusFailed:
+			message := failureFeedback(taskResult)
+			if taskResult.Error != nil {
+				message = taskResult.Error.Error()
+			}
+			testCase.Error = &JUnitMessage{
+				Message: firstLine(message),
+				Type:    models.StatusFailed,
+				Body:    message,
+			}
+			suite.Errors++
+		case models.StatusBlocked:
+			testCase.Skipped = &JUnitMessage{Message: "blocked by a failed dependency"}
+			suite.Skipped++
+		case models.StatusSkipped:
+			testCase.Skipped = &JUnitMessage{Message: taskResult.Output}
+			suite.Skipped++
+		}
+
+		suiteSeconds[idx] += taskResult.Duration.Seconds()
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for i := range root.Suites {
+		suite := &root.Suites[i]
+		suite.Time = seconds(suiteSeconds[i])
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Errors += suite.Errors
+		root.Skipped += suite.Skipped
+	}
+	if root.Time == "" {
+		root.Time = seconds(0)
+	}
+
+	return root
+}
+
+// WriteJUnit writes a JUnit XML report for result.
+func WriteJUnit(w io.Writer, result *models.ExecutionResult) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write junit report: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(BuildJUnit(result)); err != nil {
+		return fmt.Errorf("failed to write junit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// failureFeedback returns the QC feedback explaining why a task did not pass.
+func failureFeedback(result models.TaskResult) string {
+	if result.ReviewFeedback != "" {
+		return result.ReviewFeedback
+	}
+	if attempt, ok := finalAttempt(result); ok && attempt.QCFeedback != "" {
+		return attempt.QCFeedback
+	}
+	if result.Error != nil {
+		return result.Error.Error()
+	}
+	return fmt.Sprintf("task finished with status %s", result.Status)
+}
+
+// attemptHistory renders the per-attempt history for system-out.
+func attemptHistory(result models.TaskResult) string {
+	if len(result.ExecutionHistory) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, attempt := range result.ExecutionHistory {
+		fmt.Fprintf(&b, "Attempt %d (agent: %s): %s in %.1fs\n", attempt.Attempt, attempt.Agent, attempt.Verdict, attempt.Duration.Seconds())
+		if attempt.QCFeedback != "" {
+			fmt.Fprintf(&b, "  QC: %s\n", attempt.QCFeedback)
+		}
+		for _, issue := range attempt.QCIssues {
+			location := ""
+			if issue.Location != "" {
+				location = fmt.Sprintf(" (%s)", issue.Location)
+			}
+			fmt.Fprintf(&b, "  [%s] %s%s\n", issue.Severity, issue.Description, location)
+		}
+	}
+	return b.String()
+}
+
+// firstLine returns the first line of s, for use as a short message attribute.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// seconds formats a duration in seconds the way JUnit consumers expect.
+func seconds(secs float64) string {
+	return fmt.Sprintf("%.3f", secs)
+}
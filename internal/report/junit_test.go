@@ -0,0 +1,86 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestBuildJUnit(t *testing.T) {
+	suites := BuildJUnit(sampleResult())
+
+	if suites.Tests != 4 || suites.Failures != 1 || suites.Errors != 1 || suites.Skipped != 1 {
+		t.Errorf("totals = tests %d failures %d errors %d skipped %d, want 4/1/1/1",
+			suites.Tests, suites.Failures, suites.Errors, suites.Skipped)
+	}
+	if suites.Time != "180.000" {
+		t.Errorf("run time = %s, want 180.000", suites.Time)
+	}
+	if len(suites.Suites) != 2 || suites.Suites[0].Name != "part-1.md" || suites.Suites[1].Name != "part-2.md" {
+		t.Fatalf("expected one suite per plan file, got %+v", suites.Suites)
+	}
+
+	part1 := suites.Suites[0]
+	if part1.Tests != 2 || part1.Time != "120.000" {
+		t.Errorf("part-1 suite = %d tests in %s, want 2 in 120.000", part1.Tests, part1.Time)
+	}
+
+	green := part1.TestCases[0]
+	if green.Name != "Task 1: Setup" || green.Failure != nil || green.Error != nil || green.Skipped != nil {
+		t.Errorf("unexpected passing testcase: %+v", green)
+	}
+
+	red := part1.TestCases[1]
+	if red.Failure == nil {
+		t.Fatalf("RED task should be a failure: %+v", red)
+	}
+	if red.Failure.Message != "Handler ignores errors" || red.Failure.Type != "RED" {
+		t.Errorf("failure = %+v, want QC feedback first line as message", red.Failure)
+	}
+	for _, want := range []string{
+		"Attempt 1 (agent: golang-pro): RED in 40.0s",
+		"QC: Missing tests",
+		"Attempt 2 (agent: golang-pro): RED in 50.0s",
+		"[critical] error from Save is dropped (api/handler.go:42)",
+	} {
+		if !strings.Contains(red.SystemOut, want) {
+			t.Errorf("system-out missing %q:\n%s", want, red.SystemOut)
+		}
+	}
+
+	part2 := suites.Suites[1]
+	failed := part2.TestCases[0]
+	if failed.Error == nil || failed.Error.Message != "agent timed out" {
+		t.Errorf("FAILED task should be an error with the execution error, got %+v", failed)
+	}
+	blocked := part2.TestCases[1]
+	if blocked.Skipped == nil {
+		t.Errorf("BLOCKED task should be skipped, got %+v", blocked)
+	}
+}
+
+func TestWriteJUnitIsValidXML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, sampleResult()); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+
+	var decoded JUnitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("report is not valid XML: %v\n%s", err, buf.String())
+	}
+	if decoded.Tests != 4 || len(decoded.Suites) != 2 {
+		t.Errorf("decoded report = %+v", decoded)
+	}
+	if !strings.Contains(buf.String(), `<failure message="Handler ignores errors" type="RED">`) {
+		t.Errorf("expected failure element in report:\n%s", buf.String())
+	}
+}
+
+func TestBuildJUnitEmptyResult(t *testing.T) {
+	suites := BuildJUnit(nil)
+	if suites.Tests != 0 || len(suites.Suites) != 0 || suites.Time != "0.000" {
+		t.Errorf("unexpected report for nil result: %+v", suites)
+	}
+}
@@ -0,0 +1,133 @@
+// Package report renders run results as CI-friendly reports (v3.6+).
+//
+// JUnit XML maps each task to a testcase so CI systems show task failures
+// like test failures; SARIF maps located QC issues to code scanning results
+// so they appear inline in code review.
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// Supported report formats.
+const (
+	FormatJUnit = "junit"
+	FormatSARIF = "sarif"
+)
+
+// Spec is a requested report: a format and the file to write it to.
+type Spec struct {
+	Format string
+	Path   string
+}
+
+// ParseSpec parses a "format=path" report argument (e.g. "junit=results.xml").
+func ParseSpec(value string) (Spec, error) {
+	format, path, found := strings.Cut(value, "=")
+	format = strings.ToLower(strings.TrimSpace(format))
+	path = strings.TrimSpace(path)
+	if !found || path == "" {
+		return Spec{}, fmt.Errorf("invalid report %q: expected format=path (e.g. junit=results.xml)", value)
+	}
+	switch format {
+	case FormatJUnit, FormatSARIF:
+	default:
+		return Spec{}, fmt.Errorf("invalid report %q: unknown format %q (supported: %s, %s)", value, format, FormatJUnit, FormatSARIF)
+	}
+	return Spec{Format: format, Path: path}, nil
+}
+
+// ParseSpecs parses every report argument, rejecting two reports for the same path.
+func ParseSpecs(values []string) ([]Spec, error) {
+	var specs []Spec
+	paths := make(map[string]bool)
+	for _, value := range values {
+		spec, err := ParseSpec(value)
+		if err != nil {
+			return nil, err
+		}
+		if paths[spec.Path] {
+			return nil, fmt.Errorf("report path %s is used more than once", spec.Path)
+		}
+		paths[spec.Path] = true
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// Write renders the report for spec to w.
+// toolVersion is recorded in the report as the conductor version.
+func Write(w io.Writer, spec Spec, result *models.ExecutionResult, toolVersion string) error {
+	switch spec.Format {
+	case FormatJUnit:
+		return WriteJUnit(w, result)
+	case FormatSARIF:
+		return WriteSARIF(w, result, toolVersion)
+	default:
+		return fmt.Errorf("unknown report format %q", spec.Format)
+	}
+}
+
+// WriteFile renders the report for spec to spec.Path, creating parent directories.
+func WriteFile(spec Spec, result *models.ExecutionResult, toolVersion string) error {
+	var buf bytes.Buffer
+	if err := Write(&buf, spec, result, toolVersion); err != nil {
+		return err
+	}
+	if dir := filepath.Dir(spec.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(spec.Path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s report: %w", spec.Format, err)
+	}
+	return nil
+}
+
+// sortedResults returns task results ordered by task number for stable reports.
+func sortedResults(result *models.ExecutionResult) []models.TaskResult {
+	if result == nil {
+		return nil
+	}
+	results := append([]models.TaskResult(nil), result.TaskResults...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return lessTaskNumber(results[i].Task.Number, results[j].Task.Number)
+	})
+	return results
+}
+
+// lessTaskNumber orders numeric task numbers numerically and the rest lexically.
+func lessTaskNumber(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil && x != y {
+		return x < y
+	}
+	return a < b
+}
+
+// taskLabel returns "Task N: Name" for a task.
+func taskLabel(task models.Task) string {
+	if task.Name == "" {
+		return fmt.Sprintf("Task %s", task.Number)
+	}
+	return fmt.Sprintf("Task %s: %s", task.Number, task.Name)
+}
+
+// finalAttempt returns the last recorded attempt, if any.
+func finalAttempt(result models.TaskResult) (models.ExecutionAttempt, bool) {
+	if len(result.ExecutionHistory) == 0 {
+		return models.ExecutionAttempt{}, false
+	}
+	return result.ExecutionHistory[len(result.ExecutionHistory)-1], true
+}
@@ -0,0 +1,129 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// sampleResult builds an execution result covering every task status.
+func sampleResult() *models.ExecutionResult {
+	results := []models.TaskResult{
+		{
+			Task:     models.Task{Number: "10", Name: "Docs", SourceFile: "plans/part-2.md"},
+			Status:   models.StatusBlocked,
+			Duration: 0,
+		},
+		{
+			Task:           models.Task{Number: "2", Name: "API", Agent: "golang-pro", SourceFile: "plans/part-1.md"},
+			Status:         models.StatusRed,
+			Duration:       90 * time.Second,
+			RetryCount:     1,
+			ReviewFeedback: "Handler ignores errors\nSee issues",
+			ExecutionHistory: []models.ExecutionAttempt{
+				{
+					Attempt: 1, Agent: "golang-pro", Verdict: models.StatusRed, Duration: 40 * time.Second,
+					QCFeedback: "Missing tests",
+					QCIssues:   []models.Issue{{Severity: "warning", Description: "no tests", Location: "api/handler_test.go"}},
+				},
+				{
+					Attempt: 2, Agent: "golang-pro", Verdict: models.StatusRed, Duration: 50 * time.Second,
+					QCFeedback: "Handler ignores errors",
+					QCIssues: []models.Issue{
+						{Severity: "critical", Description: "error from Save is dropped", Location: "api/handler.go:42"},
+						{Severity: "info", Description: "consider a table test", Location: "api/handler_test.go:10:3"},
+						{Severity: "warning", Description: "logging is noisy", Location: "logging"},
+						{Severity: "warning", Description: "general remark"},
+					},
+				},
+			},
+		},
+		{
+			Task:     models.Task{Number: "1", Name: "Setup", SourceFile: "plans/part-1.md"},
+			Status:   models.StatusGreen,
+			Duration: 30 * time.Second,
+			ExecutionHistory: []models.ExecutionAttempt{
+				{Attempt: 1, Agent: "general-purpose", Verdict: models.StatusGreen, Duration: 30 * time.Second},
+			},
+		},
+		{
+			Task:     models.Task{Number: "3", Name: "Deploy", SourceFile: "plans/part-2.md"},
+			Status:   models.StatusFailed,
+			Duration: 5 * time.Second,
+			Error:    errors.New("agent timed out"),
+		},
+	}
+	return models.NewExecutionResult(results, false, 3*time.Minute)
+}
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Spec
+		wantErr string
+	}{
+		{value: "junit=out/results.xml", want: Spec{Format: FormatJUnit, Path: "out/results.xml"}},
+		{value: "SARIF = qc.sarif", want: Spec{Format: FormatSARIF, Path: "qc.sarif"}},
+		{value: "junit", wantErr: "expected format=path"},
+		{value: "junit=", wantErr: "expected format=path"},
+		{value: "html=report.html", wantErr: "unknown format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSpec(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSpec(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSpec(%q) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSpec(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSpecsRejectsDuplicatePaths(t *testing.T) {
+	if _, err := ParseSpecs([]string{"junit=out.xml", "sarif=out.xml"}); err == nil {
+		t.Error("expected error for a path used twice")
+	}
+	specs, err := ParseSpecs([]string{"junit=a.xml", "sarif=b.sarif"})
+	if err != nil || len(specs) != 2 {
+		t.Errorf("ParseSpecs() = %v, %v", specs, err)
+	}
+}
+
+func TestWriteFileCreatesDirectories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "nested", "results.xml")
+
+	if err := WriteFile(Spec{Format: FormatJUnit, Path: path}, sampleResult(), "1.0.0"); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "<?xml") {
+		t.Errorf("expected an XML report, got: %s", content)
+	}
+}
+
+func TestSortedResultsOrdersTaskNumbersNumerically(t *testing.T) {
+	var numbers []string
+	for _, result := range sortedResults(sampleResult()) {
+		numbers = append(numbers, result.Task.Number)
+	}
+	if got := strings.Join(numbers, ","); got != "1,2,3,10" {
+		t.Errorf("task order = %s, want 1,2,3,10", got)
+	}
+}
@@ -0,0 +1,218 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/harrison/conductor/internal/models"
+)
+
+// SARIF 2.1.0 identifiers.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolURI = "https://github.com/blueman82/conductor"
+)
+
+// SARIF rule IDs, one per QC issue severity.
+const (
+	RuleQCCritical = "conductor/qc-critical"
+	RuleQCWarning  = "conductor/qc-warning"
+	RuleQCInfo     = "conductor/qc-info"
+)
+
+// SARIFLog is the root of a SARIF report. Only the subset of the schema
+// conductor produces is modelled.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is one analysis run.
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes conductor and its rules.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the tool component that produced the results.
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes a kind of result.
+type SARIFRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     SARIFMessage       `json:"shortDescription"`
+	DefaultConfiguration SARIFConfiguration `json:"defaultConfiguration"`
+}
+
+// SARIFConfiguration holds a rule's default level.
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a plain text message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is one QC issue.
+type SARIFResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    SARIFMessage           `json:"message"`
+	Locations  []SARIFLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFLocation is either a file location or a logical (component) location.
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// SARIFPhysicalLocation points into a file.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is a file URI relative to the repository root.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is a line (and optional column) within a file.
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// SARIFLogicalLocation names a component that is not a file.
+type SARIFLogicalLocation struct {
+	Name string `json:"name"`
+}
+
+// locationPattern matches "path:line" and "path:line:col".
+var locationPattern = regexp.MustCompile(`^(.+?):(\d+)(?::(\d+))?$`)
+
+// BuildSARIF maps every QC issue with a Location from each task's final
+// attempt to a SARIF result. Issues from earlier attempts are omitted: they
+// were reported to the agent and presumably fixed by the retry.
+func BuildSARIF(result *models.ExecutionResult, toolVersion string) *SARIFLog {
+	run := SARIFRun{
+		Tool: SARIFTool{Driver: SARIFDriver{
+			Name:           "conductor",
+			Version:        toolVersion,
+			InformationURI: sarifToolURI,
+			Rules: []SARIFRule{
+				sarifRule(RuleQCCritical, "QCCriticalIssue", "Critical issue reported by quality control review", "error"),
+				sarifRule(RuleQCWarning, "QCWarning", "Warning reported by quality control review", "warning"),
+				sarifRule(RuleQCInfo, "QCInfo", "Informational finding from quality control review", "note"),
+			},
+		}},
+		Results: []SARIFResult{},
+	}
+
+	for _, taskResult := range sortedResults(result) {
+		attempt, ok := finalAttempt(taskResult)
+		if !ok {
+			continue
+		}
+		for _, issue := range attempt.QCIssues {
+			if strings.TrimSpace(issue.Location) == "" {
+				continue
+			}
+			ruleID, level := sarifSeverity(issue.Severity)
+			run.Results = append(run.Results, SARIFResult{
+				RuleID:    ruleID,
+				Level:     level,
+				Message:   SARIFMessage{Text: fmt.Sprintf("%s: %s", taskLabel(taskResult.Task), issue.Description)},
+				Locations: []SARIFLocation{sarifLocation(issue.Location)},
+				Properties: map[string]interface{}{
+					"task":    taskResult.Task.Number,
+					"status":  taskResult.Status,
+					"verdict": attempt.Verdict,
+					"agent":   attempt.Agent,
+					"attempt": attempt.Attempt,
+				},
+			})
+		}
+	}
+
+	return &SARIFLog{Schema: sarifSchema, Version: sarifVersion, Runs: []SARIFRun{run}}
+}
+
+// WriteSARIF writes a SARIF 2.1.0 report for result.
+func WriteSARIF(w io.Writer, result *models.ExecutionResult, toolVersion string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(BuildSARIF(result, toolVersion)); err != nil {
+		return fmt.Errorf("failed to write sarif report: %w", err)
+	}
+	return nil
+}
+
+// sarifRule creates a rule descriptor.
+func sarifRule(id, name, description, level string) SARIFRule {
+	return SARIFRule{
+		ID:                   id,
+		Name:                 name,
+		ShortDescription:     SARIFMessage{Text: description},
+		DefaultConfiguration: SARIFConfiguration{Level: level},
+	}
+}
+
+// sarifSeverity maps Issue.Severity to a rule ID and SARIF level.
+// Unknown severities are reported as warnings.
+func sarifSeverity(severity string) (string, string) {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "critical", "error", "high":
+		return RuleQCCritical, "error"
+	case "info", "note", "low":
+		return RuleQCInfo, "note"
+	default:
+		return RuleQCWarning, "warning"
+	}
+}
+
+// sarifLocation converts an Issue.Location ("file:line[:col]", "file" or a
+// component name) into a SARIF location.
+func sarifLocation(location string) SARIFLocation {
+	location = strings.TrimSpace(location)
+	if match := locationPattern.FindStringSubmatch(location); match != nil {
+		region := &SARIFRegion{}
+		region.StartLine, _ = strconv.Atoi(match[2])
+		if match[3] != "" {
+			region.StartColumn, _ = strconv.Atoi(match[3])
+		}
+		if region.StartLine < 1 {
+			region = nil
+		}
+		return SARIFLocation{PhysicalLocation: &SARIFPhysicalLocation{
+			ArtifactLocation: SARIFArtifactLocation{URI: filepath.ToSlash(match[1])},
+			Region:           region,
+		}}
+	}
+	if strings.ContainsAny(location, "/\\") || filepath.Ext(location) != "" {
+		return SARIFLocation{PhysicalLocation: &SARIFPhysicalLocation{
+			ArtifactLocation: SARIFArtifactLocation{URI: filepath.ToSlash(location)},
+		}}
+	}
+	return SARIFLocation{LogicalLocations: []SARIFLogicalLocation{{Name: location}}}
+}
@@ -0,0 +1,107 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestBuildSARIF(t *testing.T) {
+	log := BuildSARIF(sampleResult(), "3.6.0")
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: %+v", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "conductor" || run.Tool.Driver.Version != "3.6.0" || len(run.Tool.Driver.Rules) != 3 {
+		t.Errorf("unexpected driver: %+v", run.Tool.Driver)
+	}
+
+	// Only located issues from the final attempt are reported
+	if len(run.Results) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(run.Results), run.Results)
+	}
+
+	critical := run.Results[0]
+	if critical.RuleID != RuleQCCritical || critical.Level != "error" {
+		t.Errorf("critical issue = %s/%s, want %s/error", critical.RuleID, critical.Level, RuleQCCritical)
+	}
+	if critical.Message.Text != "Task 2: API: error from Save is dropped" {
+		t.Errorf("message = %q", critical.Message.Text)
+	}
+	physical := critical.Locations[0].PhysicalLocation
+	if physical == nil || physical.ArtifactLocation.URI != "api/handler.go" || physical.Region == nil || physical.Region.StartLine != 42 {
+		t.Errorf("unexpected location: %+v", critical.Locations[0])
+	}
+	if critical.Properties["task"] != "2" || critical.Properties["attempt"] != 2 {
+		t.Errorf("unexpected properties: %v", critical.Properties)
+	}
+
+	info := run.Results[1]
+	if info.Level != "note" || info.Locations[0].PhysicalLocation.Region.StartColumn != 3 {
+		t.Errorf("unexpected info result: %+v", info)
+	}
+
+	component := run.Results[2]
+	if component.Level != "warning" || component.Locations[0].PhysicalLocation != nil ||
+		len(component.Locations[0].LogicalLocations) != 1 || component.Locations[0].LogicalLocations[0].Name != "logging" {
+		t.Errorf("component location should be logical: %+v", component.Locations[0])
+	}
+}
+
+func TestSARIFLocation(t *testing.T) {
+	tests := []struct {
+		location string
+		uri      string
+		line     int
+		logical  string
+	}{
+		{location: "internal/a.go:12", uri: "internal/a.go", line: 12},
+		{location: "README.md", uri: "README.md"},
+		{location: "cmd/", uri: "cmd/"},
+		{location: "internal/a.go:0", uri: "internal/a.go"},
+		{location: "auth service", logical: "auth service"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			loc := sarifLocation(tt.location)
+			if tt.logical != "" {
+				if len(loc.LogicalLocations) != 1 || loc.LogicalLocations[0].Name != tt.logical {
+					t.Errorf("sarifLocation(%q) = %+v, want logical %q", tt.location, loc, tt.logical)
+				}
+				return
+			}
+			if loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation.URI != tt.uri {
+				t.Fatalf("sarifLocation(%q) = %+v, want uri %q", tt.location, loc, tt.uri)
+			}
+			line := 0
+			if loc.PhysicalLocation.Region != nil {
+				line = loc.PhysicalLocation.Region.StartLine
+			}
+			if line != tt.line {
+				t.Errorf("sarifLocation(%q) line = %d, want %d", tt.location, line, tt.line)
+			}
+		})
+	}
+}
+
+func TestWriteSARIFEmptyResultHasEmptyResults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, nil, "dev"); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("report is not valid JSON: %v", err)
+	}
+	runs := decoded["runs"].([]interface{})
+	results := runs[0].(map[string]interface{})["results"]
+	if list, ok := results.([]interface{}); !ok || len(list) != 0 {
+		t.Errorf("results = %v, want empty array", results)
+	}
+	if decoded["$schema"] == "" {
+		t.Error("expected $schema")
+	}
+}
@@ -116,6 +116,17 @@ func (l *TTSLogger) LogRateLimitCountdown(remaining, total time.Duration) {
 	// No-op: live visual countdown is console-only
 }
 
+// LogControlEvent is a no-op implementation.
+// Run control changes are recorded by the console and file loggers.
+func (l *TTSLogger) LogControlEvent(event models.ControlEvent) {
+	// No-op: operator-initiated changes don't need voice announcements
+}
+
+// LogTaskActivity is a no-op implementation.
+func (l *TTSLogger) LogTaskActivity(activity models.AgentActivity) {
+	// No-op: live agent activity is too frequent to speak
+}
+
 // LogRateLimitAnnounce speaks TTS announcements at the configured interval.
 func (l *TTSLogger) LogRateLimitAnnounce(remaining, total time.Duration) {
 	if l.announcer != nil {
@@ -27,6 +27,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/harrison/conductor/internal/filelock"
+	"github.com/harrison/conductor/internal/models"
 	"github.com/harrison/conductor/internal/parser"
 )
 
@@ -54,6 +55,10 @@ type CommitVerificationData struct {
 
 var (
 	markdownStatusPattern = regexp.MustCompile(`(?i)status\s*:\s*([^)|]+)`)
+	// markdownHeadingPattern matches a "## Task N:" heading, capturing N.
+	markdownHeadingPattern = regexp.MustCompile(`^##\s+Task\s+(\S+?):`)
+	// markdownTaskLinePattern matches a "- [ ] Task" checkbox line of any task.
+	markdownTaskLinePattern = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]\s+Task\s+`)
 
 	// ErrUnsupportedFormat indicates the plan file uses an unsupported format.
 	ErrUnsupportedFormat = errors.New("updater: unsupported plan format")
@@ -198,6 +203,11 @@ func updateMarkdownPlan(content []byte, taskNumber string, status string) ([]byt
 		desiredMark = "x"
 	}
 
+	if !markdownHasCheckbox(lines, re) {
+		// A matrix cell (v3.6+) gets its own checkbox line under its matrix task
+		lines = insertMarkdownCell(lines, taskNumber)
+	}
+
 	for i, line := range lines {
 		if matches := re.FindStringSubmatch(line); matches != nil {
 			prefix := matches[1]
@@ -222,6 +232,57 @@ func updateMarkdownPlan(content []byte, taskNumber string, status string) ([]byt
 	return nil, "", fmt.Errorf("%w: task %s not found in markdown plan", ErrTaskNotFound, taskNumber)
 }
 
+// markdownHasCheckbox reports whether any line matches re, the checkbox
+// pattern of one task.
+func markdownHasCheckbox(lines []string, re *regexp.Regexp) bool {
+	for _, line := range lines {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// insertMarkdownCell adds an unchecked "- [ ] Task <cell>" line at the end of
+// the section of the matrix task the cell belongs to. Lines are returned
+// unchanged when taskNumber is not a cell of a matrix task.
+func insertMarkdownCell(lines []string, taskNumber string) []string {
+	for i, line := range lines {
+		matches := markdownHeadingPattern.FindStringSubmatch(line)
+		if matches == nil || !strings.HasPrefix(taskNumber, matches[1]+".") {
+			continue
+		}
+
+		end := len(lines)
+		isMatrix := false
+		for j := i + 1; j < len(lines); j++ {
+			if strings.HasPrefix(lines[j], "## ") {
+				end = j
+				break
+			}
+			if strings.HasPrefix(strings.TrimSpace(lines[j]), "**Matrix**:") {
+				isMatrix = true
+			}
+		}
+		if !isMatrix {
+			continue
+		}
+		for end > i+1 && strings.TrimSpace(lines[end-1]) == "" {
+			end--
+		}
+
+		cellLine := fmt.Sprintf("- [ ] Task %s", taskNumber)
+		updated := make([]string, 0, len(lines)+2)
+		updated = append(updated, lines[:end]...)
+		if !markdownTaskLinePattern.MatchString(lines[end-1]) {
+			updated = append(updated, "")
+		}
+		updated = append(updated, cellLine)
+		return append(updated, lines[end:]...)
+	}
+	return lines
+}
+
 func updateYAMLPlan(content []byte, taskNumber string, status string, completedAt *time.Time) ([]byte, string, error) {
 	var doc yaml.Node
 
@@ -296,7 +357,17 @@ func findMapValue(mapping *yaml.Node, key string) *yaml.Node {
 	return nil
 }
 
+// findTaskNode returns the mapping of the task with the given number. The
+// number of a matrix cell (v3.6+), such as "4.svc-a", resolves to the cell's
+// entry under the matrix task's cells, which is created on first use.
 func findTaskNode(tasks *yaml.Node, taskNumber string) *yaml.Node {
+	if node := findPlainTaskNode(tasks, taskNumber); node != nil {
+		return node
+	}
+	return findCellNode(tasks, taskNumber)
+}
+
+func findPlainTaskNode(tasks *yaml.Node, taskNumber string) *yaml.Node {
 	for _, item := range tasks.Content {
 		if item.Kind != yaml.MappingNode {
 			continue
@@ -323,6 +394,42 @@ func findTaskNode(tasks *yaml.Node, taskNumber string) *yaml.Node {
 	return nil
 }
 
+// findCellNode returns the cells entry of a matrix cell, keyed by the part of
+// its number after the matrix task's number.
+func findCellNode(tasks *yaml.Node, taskNumber string) *yaml.Node {
+	for _, item := range tasks.Content {
+		if item.Kind != yaml.MappingNode || findMapValue(item, "matrix") == nil {
+			continue
+		}
+		numNode := findMapValue(item, "task_number")
+		if numNode == nil || !strings.HasPrefix(taskNumber, numNode.Value+".") {
+			continue
+		}
+		key := strings.TrimPrefix(taskNumber, numNode.Value+".")
+		if key == "" {
+			continue
+		}
+
+		cells := findMapValue(item, "cells")
+		if cells == nil {
+			cells = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			item.Content = append(item.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "cells"}, cells)
+		} else if cells.Kind != yaml.MappingNode {
+			return nil
+		}
+		if cell := findMapValue(cells, key); cell != nil {
+			return cell
+		}
+		cell := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		cells.Content = append(cells.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Style: yaml.DoubleQuotedStyle, Value: key}, cell)
+		return cell
+	}
+
+	return nil
+}
+
 func setMapScalar(mapping *yaml.Node, key, value string) {
 	for i := 0; i < len(mapping.Content); i += 2 {
 		k := mapping.Content[i]
@@ -825,3 +932,294 @@ func createCommitVerificationValueNode(verif *CommitVerificationData) *yaml.Node
 
 	return verifNode
 }
+
+// InsertTasks adds tasks to a plan file right after the task numbered after
+// (v3.6+), for subtasks proposed while the plan runs. YAML plans get task
+// entries and Markdown plans get "## Task N: Name" sections. Tasks are
+// appended at the end when after cannot be found.
+func InsertTasks(planPath string, after string, tasks []models.Task) error {
+	return modifyPlan(planPath, func(format parser.Format, content []byte) ([]byte, error) {
+		if format == parser.FormatMarkdown {
+			return insertMarkdownTasks(content, after, tasks), nil
+		}
+		return editYAMLTasks(content, func(tasksNode *yaml.Node) error {
+			nodes := make([]*yaml.Node, 0, len(tasks))
+			for _, task := range tasks {
+				nodes = append(nodes, newYAMLTaskNode(task))
+			}
+			idx := yamlTaskIndex(tasksNode, after)
+			if idx < 0 {
+				idx = len(tasksNode.Content) - 1
+			}
+			updated := make([]*yaml.Node, 0, len(tasksNode.Content)+len(nodes))
+			updated = append(updated, tasksNode.Content[:idx+1]...)
+			updated = append(updated, nodes...)
+			tasksNode.Content = append(updated, tasksNode.Content[idx+1:]...)
+			return nil
+		})
+	})
+}
+
+// AddTaskDependencies appends dependencies to a task in a plan file (v3.6+).
+// Dependencies the task already has are not repeated. For a matrix cell the
+// dependencies are added to its matrix task, and so to every cell.
+func AddTaskDependencies(planPath string, taskNumber string, deps []string) error {
+	return modifyPlan(planPath, func(format parser.Format, content []byte) ([]byte, error) {
+		if format == parser.FormatMarkdown {
+			return addMarkdownDependencies(content, taskNumber, deps)
+		}
+		return editYAMLTasks(content, func(tasksNode *yaml.Node) error {
+			idx := yamlTaskIndex(tasksNode, taskNumber)
+			if idx < 0 {
+				return fmt.Errorf("%w: task %s not found in YAML plan", ErrTaskNotFound, taskNumber)
+			}
+			taskNode := tasksNode.Content[idx]
+			depsNode := findMapValue(taskNode, "depends_on")
+			if depsNode == nil || depsNode.Kind != yaml.SequenceNode {
+				removeMapKey(taskNode, "depends_on")
+				depsNode = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+				taskNode.Content = append(taskNode.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "depends_on"}, depsNode)
+			}
+			existing := make(map[string]bool)
+			for _, item := range depsNode.Content {
+				existing[item.Value] = true
+			}
+			for _, dep := range deps {
+				if !existing[dep] {
+					existing[dep] = true
+					depsNode.Content = append(depsNode.Content, quotedScalar(dep))
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// modifyPlan applies edit to a plan file under its file lock and writes the
+// result atomically.
+func modifyPlan(planPath string, edit func(format parser.Format, content []byte) ([]byte, error)) error {
+	format := parser.DetectFormat(planPath)
+	if format == parser.FormatUnknown {
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, filepath.Ext(planPath))
+	}
+
+	lock := filelock.NewFileLock(planPath + ".lock")
+	if err := lock.Lock(); err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	content, err := os.ReadFile(planPath)
+	if err != nil {
+		return err
+	}
+	updated, err := edit(format, content)
+	if err != nil {
+		return err
+	}
+	return filelock.AtomicWrite(planPath, updated)
+}
+
+// editYAMLTasks decodes a YAML plan, lets edit change its tasks sequence and
+// encodes the result.
+func editYAMLTasks(content []byte, edit func(tasksNode *yaml.Node) error) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(content)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPlan, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%w: missing document node", ErrInvalidPlan)
+	}
+	planNode := findMapValue(doc.Content[0], "plan")
+	if planNode == nil {
+		return nil, fmt.Errorf("%w: plan section not found", ErrInvalidPlan)
+	}
+	tasksNode := findMapValue(planNode, "tasks")
+	if tasksNode == nil || tasksNode.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("%w: tasks sequence not found", ErrInvalidPlan)
+	}
+	if err := edit(tasksNode); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML plan: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlTaskIndex returns the position of a task in the tasks sequence, or of
+// the matrix task a matrix cell belongs to. It returns -1 if there is none.
+func yamlTaskIndex(tasksNode *yaml.Node, taskNumber string) int {
+	target := findPlainTaskNode(tasksNode, taskNumber)
+	for i, item := range tasksNode.Content {
+		if target != nil {
+			if item == target {
+				return i
+			}
+			continue
+		}
+		if item.Kind != yaml.MappingNode || findMapValue(item, "matrix") == nil {
+			continue
+		}
+		if numNode := findMapValue(item, "task_number"); numNode != nil && strings.HasPrefix(taskNumber, numNode.Value+".") {
+			return i
+		}
+	}
+	return -1
+}
+
+func quotedScalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Style: yaml.DoubleQuotedStyle, Value: value}
+}
+
+// newYAMLTaskNode builds the plan entry of a task added while the plan runs.
+func newYAMLTaskNode(task models.Task) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	add := func(key string, value *yaml.Node) {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	}
+	list := func(values []string) *yaml.Node {
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+		for _, value := range values {
+			seq.Content = append(seq.Content, quotedScalar(value))
+		}
+		return seq
+	}
+
+	add("task_number", quotedScalar(task.Number))
+	add("name", quotedScalar(task.Name))
+	if len(task.Files) > 0 {
+		add("files", list(task.Files))
+	}
+	add("depends_on", list(task.DependsOn))
+	if task.Agent != "" {
+		add("agent", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: task.Agent})
+	}
+	description := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: task.Prompt}
+	if strings.Contains(task.Prompt, "\n") {
+		description.Style = yaml.LiteralStyle
+	}
+	add("description", description)
+	return node
+}
+
+// markdownSection returns the line range of the "## Task N:" section of a
+// task, or of the matrix task a matrix cell belongs to. start is -1 if there
+// is none.
+func markdownSection(lines []string, taskNumber string) (start, end int) {
+	start = -1
+	for i, line := range lines {
+		matches := markdownHeadingPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		if start >= 0 {
+			return start, i
+		}
+		if matches[1] == taskNumber || strings.HasPrefix(taskNumber, matches[1]+".") {
+			start = i
+		}
+	}
+	return start, len(lines)
+}
+
+func insertMarkdownTasks(content []byte, after string, tasks []models.Task) []byte {
+	lines := strings.Split(string(content), "\n")
+	start, end := markdownSection(lines, after)
+	if start < 0 {
+		end = len(lines)
+	}
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+
+	var added []string
+	for _, task := range tasks {
+		added = append(added, "", fmt.Sprintf("## Task %s: %s", task.Number, task.Name), "")
+		deps := make([]string, len(task.DependsOn))
+		for i, dep := range task.DependsOn {
+			deps[i] = "Task " + dep
+		}
+		added = append(added, "**Depends on**: "+strings.Join(deps, ", "))
+		if task.Agent != "" {
+			added = append(added, "**Agent**: "+task.Agent)
+		}
+		if len(task.Files) > 0 {
+			files := make([]string, len(task.Files))
+			for i, file := range task.Files {
+				files[i] = "`" + file + "`"
+			}
+			added = append(added, "**File(s)**: "+strings.Join(files, ", "))
+		}
+		added = append(added, "", strings.TrimSpace(task.Prompt))
+	}
+	if end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+		added = append(added, "")
+	}
+
+	updated := make([]string, 0, len(lines)+len(added))
+	updated = append(updated, lines[:end]...)
+	updated = append(updated, added...)
+	updated = append(updated, lines[end:]...)
+	return []byte(strings.Join(updated, "\n"))
+}
+
+func addMarkdownDependencies(content []byte, taskNumber string, deps []string) ([]byte, error) {
+	lines := strings.Split(string(content), "\n")
+	start, end := markdownSection(lines, taskNumber)
+	if start < 0 {
+		return nil, fmt.Errorf("%w: task %s not found in markdown plan", ErrTaskNotFound, taskNumber)
+	}
+
+	depsRe := regexp.MustCompile(`^\*\*Depends on\*\*:\s*(.*)$`)
+	for i := start + 1; i < end; i++ {
+		matches := depsRe.FindStringSubmatch(lines[i])
+		if matches == nil {
+			continue
+		}
+		var current []string
+		existing := make(map[string]bool)
+		if value := strings.TrimSpace(matches[1]); value != "" && !strings.Contains(strings.ToLower(value), "none") {
+			for _, part := range strings.Split(value, ",") {
+				part = strings.TrimSpace(part)
+				current = append(current, part)
+				existing[strings.TrimSpace(strings.TrimPrefix(part, "Task "))] = true
+			}
+		}
+		for _, dep := range deps {
+			if !existing[dep] {
+				existing[dep] = true
+				current = append(current, "Task "+dep)
+			}
+		}
+		lines[i] = "**Depends on**: " + strings.Join(current, ", ")
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+
+	line := make([]string, len(deps))
+	for i, dep := range deps {
+		line[i] = "Task " + dep
+	}
+	// Put the line first in the task's metadata block, after the heading
+	pos := start + 1
+	for pos < end && strings.TrimSpace(lines[pos]) == "" {
+		pos++
+	}
+	added := []string{"**Depends on**: " + strings.Join(line, ", ")}
+	if pos == start+1 {
+		added = append([]string{""}, added...)
+	}
+	if pos < end && !strings.HasPrefix(lines[pos], "**") {
+		added = append(added, "")
+	}
+	updated := make([]string, 0, len(lines)+len(added))
+	updated = append(updated, lines[:pos]...)
+	updated = append(updated, added...)
+	updated = append(updated, lines[pos:]...)
+	return []byte(strings.Join(updated, "\n")), nil
+}
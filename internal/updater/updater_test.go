@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/harrison/conductor/internal/filelock"
+	"github.com/harrison/conductor/internal/models"
 	"github.com/harrison/conductor/internal/parser"
 )
 
@@ -175,6 +176,208 @@ func TestUpdateYAMLTaskStatusReopenRemovesCompletedDate(t *testing.T) {
 	}
 }
 
+func TestUpdateYAMLMatrixCellStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "plan.yaml")
+
+	yamlContent := `plan:
+  metadata:
+    feature_name: "Test Plan"
+  tasks:
+    - task_number: 4
+      name: "Migrate {{service}}"
+      description: "Migrate {{service}}"
+      matrix:
+        service: [svc-a, svc-b]
+`
+	writeFile(t, planPath, yamlContent)
+
+	completedAt := time.Date(2025, time.November, 8, 0, 0, 0, 0, time.UTC)
+	if err := UpdateTaskStatus(planPath, "4.svc-a", "completed", &completedAt); err != nil {
+		t.Fatalf("UpdateTaskStatus failed: %v", err)
+	}
+	if err := UpdateTaskStatus(planPath, "4.svc-b", "in-progress", nil); err != nil {
+		t.Fatalf("UpdateTaskStatus failed: %v", err)
+	}
+	if err := UpdateTaskFeedback(planPath, "4.svc-b", &ExecutionAttempt{AttemptNumber: 1, Agent: "golang-pro", Verdict: "RED", Timestamp: completedAt}); err != nil {
+		t.Fatalf("UpdateTaskFeedback failed: %v", err)
+	}
+
+	plan, err := parser.ParseFile(planPath)
+	if err != nil {
+		t.Fatalf("failed to parse updated plan: %v\n%s", err, readFile(t, planPath))
+	}
+	statuses := make(map[string]string)
+	for _, task := range plan.Tasks {
+		statuses[task.Number] = task.Status
+	}
+	if statuses["4.svc-a"] != "completed" || statuses["4.svc-b"] != "in-progress" || len(statuses) != 2 {
+		t.Fatalf("expected per-cell statuses, got %v\n%s", statuses, readFile(t, planPath))
+	}
+
+	content := readFile(t, planPath)
+	if !strings.Contains(content, "completed_date: \"2025-11-08\"") || !strings.Contains(content, "verdict: RED") {
+		t.Fatalf("expected completion date and history under the cells, got:\n%s", content)
+	}
+}
+
+func TestUpdateMarkdownMatrixCellStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "plan.md")
+
+	markdown := `# Plan
+
+## Task 4: Migrate {{pkg}}
+
+**Matrix**:
+- pkg: api, worker
+
+Migrate internal/{{pkg}}.
+
+## Task 5: Release
+
+**Depends on**: Task 4
+`
+	writeFile(t, planPath, markdown)
+
+	if err := UpdateTaskStatus(planPath, "4.worker", "in-progress", nil); err != nil {
+		t.Fatalf("UpdateTaskStatus failed: %v", err)
+	}
+	if err := UpdateTaskStatus(planPath, "4.api", "completed", nil); err != nil {
+		t.Fatalf("UpdateTaskStatus failed: %v", err)
+	}
+	if err := UpdateTaskStatus(planPath, "4.worker", "completed", nil); err != nil {
+		t.Fatalf("UpdateTaskStatus failed: %v", err)
+	}
+
+	content := readFile(t, planPath)
+	if !strings.Contains(content, "- [x] Task 4.worker (status: completed)\n- [x] Task 4.api (status: completed)\n\n## Task 5") {
+		t.Fatalf("expected checkbox lines for the cells in the matrix task, got:\n%s", content)
+	}
+
+	plan, err := parser.ParseFile(planPath)
+	if err != nil {
+		t.Fatalf("failed to parse updated plan: %v", err)
+	}
+	for _, task := range plan.Tasks {
+		if task.Number != "5" && task.Status != "completed" {
+			t.Errorf("task %s status = %q, want completed", task.Number, task.Status)
+		}
+	}
+
+	if err := UpdateTaskStatus(planPath, "5.api", "completed", nil); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound for a task that is not a matrix task, got %v", err)
+	}
+}
+
+func TestInsertTasksYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "plan.yaml")
+
+	yamlContent := `plan:
+  metadata:
+    feature_name: "Test Plan"
+  tasks:
+    - task_number: 1
+      name: "Handlers"
+      description: "Add the handlers"
+    - task_number: 2
+      name: "Docs"
+      depends_on: [1]
+      description: "Document the API"
+`
+	writeFile(t, planPath, yamlContent)
+
+	subtasks := []models.Task{
+		{Number: "3", Name: "Users handler", Prompt: "Add the users handler.\nKeep it small.", Files: []string{"api/users.go"}, DependsOn: []string{"1"}, Agent: "golang-pro"},
+		{Number: "4", Name: "Handler tests", Prompt: "Test the handlers", DependsOn: []string{"1", "3"}},
+	}
+	if err := InsertTasks(planPath, "1", subtasks); err != nil {
+		t.Fatalf("InsertTasks failed: %v", err)
+	}
+	if err := AddTaskDependencies(planPath, "2", []string{"1", "3", "4"}); err != nil {
+		t.Fatalf("AddTaskDependencies failed: %v", err)
+	}
+
+	plan, err := parser.ParseFile(planPath)
+	if err != nil {
+		t.Fatalf("failed to parse updated plan: %v\n%s", err, readFile(t, planPath))
+	}
+	var numbers []string
+	for _, task := range plan.Tasks {
+		numbers = append(numbers, task.Number)
+	}
+	if strings.Join(numbers, ",") != "1,3,4,2" {
+		t.Fatalf("tasks = %v, want the subtasks after task 1\n%s", numbers, readFile(t, planPath))
+	}
+	users := plan.Tasks[1]
+	if users.Name != "Users handler" || users.Agent != "golang-pro" || !strings.Contains(users.Prompt, "Keep it small.") || strings.Join(users.Files, ",") != "api/users.go" {
+		t.Errorf("unexpected inserted task: %+v", users)
+	}
+	if deps := strings.Join(plan.Tasks[3].DependsOn, ","); deps != "1,3,4" {
+		t.Errorf("task 2 depends_on = %s, want 1,3,4", deps)
+	}
+
+	if err := AddTaskDependencies(planPath, "9", []string{"3"}); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestInsertTasksMarkdown(t *testing.T) {
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "plan.md")
+
+	markdown := `# Plan
+
+## Task 1: Handlers
+
+Add the handlers.
+
+## Task 2: Docs
+
+**Depends on**: Task 1
+
+Document the API.
+
+## Task 5: Changelog
+
+**Agent**: technical-writer
+
+Update the changelog.
+`
+	writeFile(t, planPath, markdown)
+
+	subtasks := []models.Task{
+		{Number: "6", Name: "Users handler", Prompt: "Add the users handler.", Files: []string{"api/users.go"}, DependsOn: []string{"1"}, Agent: "golang-pro"},
+	}
+	if err := InsertTasks(planPath, "1", subtasks); err != nil {
+		t.Fatalf("InsertTasks failed: %v", err)
+	}
+	if err := AddTaskDependencies(planPath, "2", []string{"1", "6"}); err != nil {
+		t.Fatalf("AddTaskDependencies failed: %v", err)
+	}
+	if err := AddTaskDependencies(planPath, "5", []string{"6"}); err != nil {
+		t.Fatalf("AddTaskDependencies failed: %v", err)
+	}
+
+	content := readFile(t, planPath)
+	if !strings.Contains(content, "Add the handlers.\n\n## Task 6: Users handler\n\n**Depends on**: Task 1\n**Agent**: golang-pro\n**File(s)**: `api/users.go`\n\nAdd the users handler.\n\n## Task 2: Docs") {
+		t.Fatalf("expected the subtask section after task 1, got:\n%s", content)
+	}
+
+	plan, err := parser.ParseFile(planPath)
+	if err != nil {
+		t.Fatalf("failed to parse updated plan: %v\n%s", err, content)
+	}
+	deps := make(map[string]string)
+	for _, task := range plan.Tasks {
+		deps[task.Number] = strings.Join(task.DependsOn, ",")
+	}
+	if deps["6"] != "1" || deps["2"] != "1,6" || deps["5"] != "6" {
+		t.Errorf("unexpected dependencies %v in:\n%s", deps, content)
+	}
+}
+
 func TestUpdateTaskStatusUnknownTask(t *testing.T) {
 	tmpDir := t.TempDir()
 	planPath := filepath.Join(tmpDir, "plan.yaml")